@@ -52,6 +52,9 @@ const (
 	RPCEndpointFlag = "rpc-endpoint"
 	// NeoFSRPCEndpointFlag is a long flag name for a NeoFS RPC endpoint.
 	NeoFSRPCEndpointFlag = "fs-rpc-endpoint"
+	// EnterWalletFilePasswordPrompt is a prompt used to ask the user for
+	// a whole-file encrypted wallet password.
+	EnterWalletFilePasswordPrompt = "Enter wallet file password > "
 )
 
 // Wallet is a set of flags used for wallet operations.
@@ -419,7 +422,7 @@ func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error
 		pass = &cfg.Password
 	}
 
-	wall, err := wallet.NewWalletFromFile(wPath)
+	wall, err := ReadWalletFile(wPath, pass)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -437,6 +440,24 @@ func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error
 	return acc, wall, err
 }
 
+// ReadWalletFile reads the wallet from the given path. If it's a whole-file
+// encrypted wallet, it's decrypted with the given password (or the one entered
+// interactively if pass is nil).
+func ReadWalletFile(path string, pass *string) (*wallet.Wallet, error) {
+	wall, err := wallet.NewWalletFromFile(path)
+	if !errors.Is(err, wallet.ErrWalletEncrypted) {
+		return wall, err
+	}
+	if pass == nil {
+		rawPass, err := input.ReadPassword(EnterWalletFilePasswordPrompt)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading password: %w", err)
+		}
+		pass = &rawPass
+	}
+	return wallet.NewWalletFromFileWithPassword(path, *pass)
+}
+
 // GetUnlockedAccount returns account from wallet, address and uses pass to unlock specified account if given.
 // If the password is not given, then it is requested from user.
 func GetUnlockedAccount(wall *wallet.Wallet, addr util.Uint160, pass *string) (*wallet.Account, error) {
@@ -484,5 +505,9 @@ func ReadWalletConfig(configPath string) (*config.Wallet, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal wallet config YAML: %w", err)
 	}
+	err = cfg.ResolvePassword()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet password: %w", err)
+	}
 	return cfg, nil
 }
@@ -1,9 +1,12 @@
 package server_test
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testcli"
@@ -156,3 +159,154 @@ func TestDBDumpRestoreIncremental(t *testing.T) {
 	// Restore second 15 blocks from incremental dump.
 	e.Run(t, append(restoreBaseArgs, "--in", incDump, "-n", "--count", "15")...)
 }
+
+func TestDBRestoreFromCheckpoint(t *testing.T) {
+	tmpDir := t.TempDir()
+	chainPath := filepath.Join(tmpDir, "neogotestchain")
+	checkpointsPath := filepath.Join(tmpDir, "checkpoints")
+
+	cfg, err := config.LoadFile(filepath.Join("..", "..", "config", "protocol.unit_testnet.yml"))
+	require.NoError(t, err, "could not load config")
+	cfg.ApplicationConfiguration.DBConfiguration.Type = dbconfig.LevelDB
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = chainPath
+	cfg.ApplicationConfiguration.Ledger.StateCheckpoints = config.StateCheckpoints{
+		Enabled:  true,
+		Interval: 10,
+		Path:     checkpointsPath,
+	}
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+
+	cfgPath := filepath.Join(tmpDir, "protocol.unit_testnet.yml")
+	require.NoError(t, os.WriteFile(cfgPath, out, os.ModePerm))
+
+	e := testcli.NewExecutor(t, false)
+	restoreArgs := []string{"neo-go", "db", "restore", "--unittest",
+		"--config-path", tmpDir, "--in", inDump}
+
+	// Checkpoint state roots can't be verified without StateRootInHeader,
+	// see TestBlockchain_StateCheckpoints for successful restoration.
+	t.Run("no StateRootInHeader", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "StateRootInHeader is off", restoreArgs...)
+		e.RunWithErrorCheckExit(t, "StateRootInHeader is off", append(restoreArgs, "--from-checkpoint", checkpointsPath)...)
+	})
+
+	cfg.ProtocolConfiguration.StateRootInHeader = true
+	out, err = yaml.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cfgPath, out, os.ModePerm))
+	require.NoError(t, os.MkdirAll(checkpointsPath, os.ModePerm))
+	t.Run("no checkpoints", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "no suitable checkpoint", append(restoreArgs, "--from-checkpoint", checkpointsPath)...)
+	})
+}
+
+func TestDBExportDiffs(t *testing.T) {
+	tmpDir := t.TempDir()
+	chainPath := filepath.Join(tmpDir, "neogotestchain")
+	jsonPath := filepath.Join(tmpDir, "diffs.json")
+	csvPath := filepath.Join(tmpDir, "diffs.csv")
+
+	cfg, err := config.LoadFile(filepath.Join("..", "..", "config", "protocol.unit_testnet.yml"))
+	require.NoError(t, err, "could not load config")
+	cfg.ApplicationConfiguration.DBConfiguration.Type = dbconfig.LevelDB
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = chainPath
+	writeConfig := func(t *testing.T, saveDiffs bool) {
+		cfg.ApplicationConfiguration.SaveStorageDiffs = saveDiffs
+		out, err := yaml.Marshal(cfg)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "protocol.unit_testnet.yml"), out, os.ModePerm))
+	}
+	writeConfig(t, true)
+
+	e := testcli.NewExecutor(t, false)
+	e.Run(t, "neo-go", "db", "restore", "--unittest", "--config-path", tmpDir, "--in", inDump)
+
+	baseArgs := []string{"neo-go", "db", "export-diffs", "--unittest", "--config-path", tmpDir}
+	t.Run("bad format", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "unknown format", append(baseArgs, "--format", "parquet")...)
+	})
+	t.Run("too many blocks", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "chain is not that high", append(baseArgs, "--start", "10", "--count", "100")...)
+	})
+
+	e.Run(t, append(baseArgs, "--out", jsonPath, "--start", "1")...)
+	e.Run(t, append(baseArgs, "--out", csvPath, "--start", "1", "--format", "csv")...)
+
+	data, err := os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.NotEmpty(t, lines)
+	var (
+		blocks = make(map[uint32]bool)
+		rec    struct {
+			Block    uint32  `json:"block"`
+			ID       int32   `json:"id"`
+			Key      []byte  `json:"key"`
+			OldValue *[]byte `json:"oldvalue"`
+			NewValue *[]byte `json:"newvalue"`
+		}
+	)
+	for _, l := range lines {
+		require.NoError(t, json.Unmarshal([]byte(l), &rec))
+		require.True(t, rec.Block >= 1)
+		require.True(t, rec.OldValue != nil || rec.NewValue != nil)
+		blocks[rec.Block] = true
+	}
+	require.Equal(t, 50, len(blocks))
+
+	f, err := os.Open(csvPath)
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"block", "contract_id", "op", "key", "old_value", "new_value"}, records[0])
+	require.Equal(t, len(lines), len(records)-1)
+	for _, r := range records[1:] {
+		require.Contains(t, []string{"add", "update", "delete"}, r[2])
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		writeConfig(t, false)
+		e.RunWithErrorCheckExit(t, "storage diffs are not saved", baseArgs...)
+	})
+}
+
+func TestDBStatsCompact(t *testing.T) {
+	tmpDir := t.TempDir()
+	chainPath := filepath.Join(tmpDir, "neogotestchain")
+
+	cfg, err := config.LoadFile(filepath.Join("..", "..", "config", "protocol.unit_testnet.yml"))
+	require.NoError(t, err, "could not load config")
+	writeConfig := func(t *testing.T, dbType string) {
+		cfg.ApplicationConfiguration.DBConfiguration.Type = dbType
+		cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = chainPath
+		out, err := yaml.Marshal(cfg)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "protocol.unit_testnet.yml"), out, os.ModePerm))
+	}
+
+	e := testcli.NewExecutor(t, false)
+	t.Run("compaction not supported", func(t *testing.T) {
+		writeConfig(t, dbconfig.InMemoryDB)
+		e.RunWithErrorCheckExit(t, "compaction is not supported for inmemory DB", "neo-go", "db", "compact", "--unittest", "--config-path", tmpDir)
+	})
+
+	writeConfig(t, dbconfig.LevelDB)
+	e.Run(t, "neo-go", "db", "restore", "--unittest", "--config-path", tmpDir, "--in", inDump)
+
+	checkStats := func(t *testing.T) {
+		e.Out.Reset()
+		e.Run(t, "neo-go", "db", "stats", "--unittest", "--config-path", tmpDir)
+		out := e.Out.String()
+		for _, s := range []string{"DataExecutable (0x01)", "blocks", "transactions", "DataMPT (0x03)", "STStorage (0x70)", "Total", "Contract ID"} {
+			require.Contains(t, out, s)
+		}
+	}
+	checkStats(t)
+
+	e.Out.Reset()
+	e.Run(t, "neo-go", "db", "compact", "--unittest", "--config-path", tmpDir)
+	require.Contains(t, e.Out.String(), "DB is compacted")
+	checkStats(t)
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/urfave/cli/v2"
+)
+
+// executableNames contains names of storage.DataExecutable entry types.
+var executableNames = map[byte]string{
+	storage.ExecBlock:       "blocks",
+	storage.ExecTransaction: "transactions",
+}
+
+func dbStats(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	dbCfg := cfg.ApplicationConfiguration.DBConfiguration
+	dbCfg.LevelDBOptions.ReadOnly = true
+	dbCfg.BoltDBOptions.ReadOnly = true
+	store, err := storage.NewStore(dbCfg)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("could not initialize storage: %w", err), 1)
+	}
+	defer store.Close()
+
+	stats, err := storage.CollectStats(newGraceContext(), store)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to collect DB statistics: %w", err), 1)
+	}
+
+	var total storage.EntriesStats
+	tw := tabwriter.NewWriter(ctx.App.Writer, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "Prefix\tKeys\tSize")
+	for _, p := range slices.Sorted(maps.Keys(stats.Prefixes)) {
+		s := stats.Prefixes[p]
+		total.Keys += s.Keys
+		total.Size += s.Size
+		_, _ = fmt.Fprintf(tw, "%s (0x%02x)\t%d\t%d\n", p, byte(p), s.Keys, s.Size)
+		if p == storage.DataExecutable {
+			for _, typ := range slices.Sorted(maps.Keys(stats.Executables)) {
+				name, ok := executableNames[typ]
+				if !ok {
+					name = fmt.Sprintf("0x%02x", typ)
+				}
+				e := stats.Executables[typ]
+				_, _ = fmt.Fprintf(tw, "  %s\t%d\t%d\n", name, e.Keys, e.Size)
+			}
+		}
+	}
+	_, _ = fmt.Fprintf(tw, "Total\t%d\t%d\n", total.Keys, total.Size)
+	if len(stats.Contracts) != 0 {
+		_, _ = fmt.Fprintln(tw)
+		_, _ = fmt.Fprintln(tw, "Contract ID\tKeys\tSize")
+		// The biggest contracts go first.
+		ids := slices.SortedFunc(maps.Keys(stats.Contracts), func(a, b int32) int {
+			return cmp.Or(cmp.Compare(stats.Contracts[b].Size, stats.Contracts[a].Size), cmp.Compare(a, b))
+		})
+		for _, id := range ids {
+			c := stats.Contracts[id]
+			_, _ = fmt.Fprintf(tw, "%d\t%d\t%d\n", id, c.Keys, c.Size)
+		}
+	}
+	return tw.Flush()
+}
+
+func compactDB(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	dbCfg := cfg.ApplicationConfiguration.DBConfiguration
+	store, err := storage.NewStore(dbCfg)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("could not initialize storage: %w", err), 1)
+	}
+	c, ok := store.(storage.Compactor)
+	if !ok {
+		_ = store.Close()
+		return cli.Exit(fmt.Errorf("compaction is not supported for %s DB", dbCfg.Type), 1)
+	}
+	start := time.Now()
+	err = c.Compact()
+	if err != nil {
+		_ = store.Close()
+		return cli.Exit(fmt.Errorf("failed to compact the DB: %w", err), 1)
+	}
+	err = store.Close()
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to close the DB: %w", err), 1)
+	}
+	_, _ = fmt.Fprintf(ctx.App.Writer, "DB is compacted in %s\n", time.Since(start).Round(time.Millisecond))
+	return nil
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/urfave/cli/v2"
+)
+
+// Storage diff export formats.
+const (
+	diffFormatJSON = "json"
+	diffFormatCSV  = "csv"
+)
+
+// Storage diff CSV operation types.
+const (
+	diffOpAdd    = "add"
+	diffOpUpdate = "update"
+	diffOpDelete = "delete"
+)
+
+// diffRecord is a single storage change exported in JSON lines format.
+type diffRecord struct {
+	Block uint32 `json:"block"`
+	state.StorageDiff
+}
+
+// diffCSVHeader is the header of storage diff CSV export.
+var diffCSVHeader = []string{"block", "contract_id", "op", "key", "old_value", "new_value"}
+
+func exportDiffs(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	format := ctx.String("format")
+	if format != diffFormatJSON && format != diffFormatCSV {
+		return cli.Exit(fmt.Errorf("unknown format %q, %q or %q expected", format, diffFormatJSON, diffFormatCSV), 1)
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	if !cfg.ApplicationConfiguration.SaveStorageDiffs {
+		return cli.Exit("storage diffs are not saved (SaveStorageDiffs setting is disabled)", 1)
+	}
+	log, _, logCloser, err := options.HandleLoggingParams(ctx, cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+	count := uint32(ctx.Uint("count"))
+	start := uint32(ctx.Uint("start"))
+
+	var outStream = os.Stdout
+	if out := ctx.String("out"); out != "" {
+		outStream, err = os.Create(out)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+	}
+	defer outStream.Close()
+
+	chain, prometheus, pprof, err := InitBCWithMetrics(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		pprof.ShutDown()
+		prometheus.ShutDown()
+		chain.Close()
+	}()
+
+	chainCount := chain.BlockHeight() + 1
+	if start+count > chainCount {
+		return cli.Exit(fmt.Errorf("chain is not that high (%d) to export %d blocks starting from %d", chainCount-1, count, start), 1)
+	}
+	if count == 0 {
+		count = chainCount - start
+	}
+
+	var (
+		bw    = bufio.NewWriter(outStream)
+		csvw  *csv.Writer
+		write func(index uint32, d *state.StorageDiff) error
+	)
+	switch format {
+	case diffFormatJSON:
+		enc := json.NewEncoder(bw)
+		write = func(index uint32, d *state.StorageDiff) error {
+			return enc.Encode(diffRecord{Block: index, StorageDiff: *d})
+		}
+	case diffFormatCSV:
+		csvw = csv.NewWriter(bw)
+		if err = csvw.Write(diffCSVHeader); err != nil {
+			return cli.Exit(err, 1)
+		}
+		write = func(index uint32, d *state.StorageDiff) error {
+			op := diffOpUpdate
+			if d.Old == nil {
+				op = diffOpAdd
+			} else if d.New == nil {
+				op = diffOpDelete
+			}
+			return csvw.Write([]string{
+				strconv.FormatUint(uint64(index), 10),
+				strconv.FormatInt(int64(d.ID), 10),
+				op,
+				hex.EncodeToString(d.Key),
+				hex.EncodeToString(d.Old),
+				hex.EncodeToString(d.New),
+			})
+		}
+	}
+	for i := start; i < start+count; i++ {
+		diff, err := chain.GetStorageDiff(i)
+		if err != nil {
+			if errors.Is(err, storage.ErrKeyNotFound) {
+				return cli.Exit(fmt.Errorf("no storage diff for block %d (it was processed with SaveStorageDiffs disabled)", i), 1)
+			}
+			return cli.Exit(fmt.Errorf("failed to get storage diff for block %d: %w", i, err), 1)
+		}
+		for j := range diff {
+			if err = write(i, &diff[j]); err != nil {
+				return cli.Exit(fmt.Errorf("failed to write storage diff for block %d: %w", i, err), 1)
+			}
+		}
+	}
+	if csvw != nil {
+		csvw.Flush()
+		if err = csvw.Error(); err != nil {
+			return cli.Exit(err, 1)
+		}
+	}
+	if err = bw.Flush(); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return nil
+}
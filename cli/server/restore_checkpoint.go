@@ -0,0 +1,104 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/checkpoint"
+	"go.uber.org/zap"
+)
+
+// checkpointItemsBatchSize is the number of checkpoint items applied at once.
+const checkpointItemsBatchSize = 10000
+
+// checkpointedChain is a chaindump.DumperRestorer that stores blocks up to
+// the checkpoint height without execution, restores the state from
+// checkpoints once the next block (having the state root for the checkpoint)
+// is received and then processes the rest of blocks in a regular way.
+type checkpointedChain struct {
+	*core.Blockchain
+
+	log         *zap.Logger
+	restorer    *core.CheckpointRestorer
+	checkpoints []checkpoint.Info
+}
+
+func newCheckpointedChain(chain *core.Blockchain, log *zap.Logger, dir string, maxIndex uint32) (*checkpointedChain, error) {
+	if maxIndex == 0 {
+		return nil, checkpoint.ErrNoCheckpoint
+	}
+	// The block following the checkpoint is needed to verify its state root.
+	chn, err := checkpoint.Chain(dir, chain.GetConfig().Magic, maxIndex-1)
+	if err != nil {
+		return nil, err
+	}
+	last := chn[len(chn)-1]
+	r, err := chain.NewCheckpointRestorer(last.Index, last.Root)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("restoring from state checkpoint",
+		zap.Uint32("index", last.Index),
+		zap.Int("checkpoints", len(chn)))
+	return &checkpointedChain{
+		Blockchain:  chain,
+		log:         log,
+		restorer:    r,
+		checkpoints: chn,
+	}, nil
+}
+
+// AddBlock implements chaindump.DumperRestorer.
+func (c *checkpointedChain) AddBlock(b *block.Block) error {
+	index := c.checkpoints[len(c.checkpoints)-1].Index
+	if b.Index <= index {
+		return c.restorer.AddBlock(b)
+	}
+	if b.Index == index+1 {
+		err := c.restoreState(&b.Header)
+		if err != nil {
+			return err
+		}
+	}
+	return c.Blockchain.AddBlock(b)
+}
+
+func (c *checkpointedChain) restoreState(next *block.Header) error {
+	for _, info := range c.checkpoints {
+		err := c.applyCheckpoint(info)
+		if err != nil {
+			return fmt.Errorf("checkpoint %s: %w", info.Path, err)
+		}
+		c.log.Info("state checkpoint applied", zap.Uint32("index", info.Index))
+	}
+	return c.restorer.Finalize(next)
+}
+
+func (c *checkpointedChain) applyCheckpoint(info checkpoint.Info) error {
+	r, err := checkpoint.Open(info.Path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	items := make([]checkpoint.Item, 0, checkpointItemsBatchSize)
+	for {
+		it, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		items = append(items, it)
+		if len(items) == checkpointItemsBatchSize {
+			if err = c.restorer.AddItems(items); err != nil {
+				return err
+			}
+			items = items[:0]
+		}
+	}
+	return c.restorer.AddItems(items)
+}
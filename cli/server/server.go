@@ -23,6 +23,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network"
+	"github.com/nspcc-dev/neo-go/pkg/services/checkpointuploader"
 	"github.com/nspcc-dev/neo-go/pkg/services/metrics"
 	"github.com/nspcc-dev/neo-go/pkg/services/notary"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
@@ -76,13 +77,35 @@ func NewCommands() []*cli.Command {
 			Aliases: []string{"n"},
 			Usage:   "Use if dump is incremental",
 		},
+		&cli.StringFlag{
+			Name:  "from-checkpoint",
+			Usage: "Local directory with state checkpoints to restore the state from instead of executing blocks up to the latest suitable checkpoint",
+		},
 	)
+	var cfgDiffFlags = slices.Clone(cfgCountOutFlags)
+	cfgDiffFlags = append(cfgDiffFlags, &cli.StringFlag{
+		Name:  "format",
+		Value: diffFormatJSON,
+		Usage: "Output format: '" + diffFormatJSON + "' (JSON lines) or '" + diffFormatCSV + "'",
+	})
 	var cfgHeightFlags = slices.Clone(cfgFlags)
 	cfgHeightFlags = append(cfgHeightFlags, &cli.UintFlag{
 		Name:     "height",
 		Usage:    "Height of the state to reset DB to",
 		Required: true,
 	})
+	var cfgOutFlags = slices.Clone(cfgFlags)
+	cfgOutFlags = append(cfgOutFlags, &cli.StringFlag{
+		Name:    "out",
+		Aliases: []string{"o"},
+		Usage:   "Output file (stdout if not given)",
+	})
+	var cfgInFlags = slices.Clone(cfgFlags)
+	cfgInFlags = append(cfgInFlags, &cli.StringFlag{
+		Name:    "in",
+		Aliases: []string{"i"},
+		Usage:   "Input file (stdin if not given)",
+	})
 	return []*cli.Command{
 		{
 			Name:      "node",
@@ -112,10 +135,53 @@ func NewCommands() []*cli.Command {
 				{
 					Name:      "restore",
 					Usage:     "Restore blocks from the file",
-					UsageText: "neo-go db restore [-i file] [--dump] [-n] [-c count] [--config-path path] [-p/-m/-t] [--config-file file] [--force-timestamp-logs]",
+					UsageText: "neo-go db restore [-i file] [--dump] [-n] [--from-checkpoint dir] [-c count] [--config-path path] [-p/-m/-t] [--config-file file] [--force-timestamp-logs]",
 					Action:    restoreDB,
 					Flags:     cfgCountInFlags,
 				},
+				{
+					Name:      "export-diffs",
+					Usage:     "Export contract storage changes made by blocks (starting with the genesis or specified block)",
+					UsageText: "neo-go db export-diffs [-o file] [-s start] [-c count] [--format json|csv] [--config-path path] [-p/-m/-t] [--config-file file] [--force-timestamp-logs]",
+					Description: `Exports per-block contract storage changes saved by the node with
+   SaveStorageDiffs setting enabled. Every change is a separate record
+   containing block index, contract ID, item key, old and new values.
+
+   'json' format produces JSON lines with base64-encoded keys and values
+   (missing values are null). 'csv' format produces CSV with a header line,
+   hex-encoded keys and values and an operation column ('add', 'update'
+   or 'delete') telling which values are present.
+`,
+					Action: exportDiffs,
+					Flags:  cfgDiffFlags,
+				},
+				{
+					Name:      "stats",
+					Usage:     "Show database usage statistics",
+					UsageText: "neo-go db stats [--config-path path] [-p/-m/-t] [--config-file file]",
+					Description: `Walks through the whole database and shows the number of keys and their
+   size (keys and values in bytes, before any DB-specific compression or
+   overhead) for every key prefix. Blocks and transactions are shown
+   separately, contract storage statistics is also shown per contract ID.
+   The database is opened in read-only mode, but it can't be used by the
+   running node at the same time.
+`,
+					Action: dbStats,
+					Flags:  cfgFlags,
+				},
+				{
+					Name:      "compact",
+					Usage:     "Compact database",
+					UsageText: "neo-go db compact [--config-path path] [-p/-m/-t] [--config-file file]",
+					Description: `Triggers database compaction reclaiming space occupied by removed or
+   overwritten entries. LevelDB is compacted in place, BoltDB is copied into
+   a new file that replaces the original one, so there should be enough
+   free disk space for the compacted copy. Must only be used when the node
+   is stopped.
+`,
+					Action: compactDB,
+					Flags:  cfgFlags,
+				},
 				{
 					Name:      "reset",
 					Usage:     "Reset database to the previous state",
@@ -123,6 +189,26 @@ func NewCommands() []*cli.Command {
 					Action:    resetDB,
 					Flags:     cfgHeightFlags,
 				},
+				{
+					Name:      "signing-export",
+					Usage:     "Export consensus signing protection database",
+					UsageText: "neo-go db signing-export [-o file] [--config-path path] [-p/-m/-t] [--config-file file]",
+					Action:    exportSigningProtection,
+					Flags:     cfgOutFlags,
+				},
+				{
+					Name:      "signing-import",
+					Usage:     "Merge exported data into consensus signing protection database",
+					UsageText: "neo-go db signing-import [-i file] [--config-path path] [-p/-m/-t] [--config-file file]",
+					Description: `Merges signing protection data exported with 'db signing-export' into the
+   database specified in the node configuration (it's created if missing).
+   The latest records for every key are kept, so the resulting database
+   doesn't allow to sign anything that's not allowed by either of the
+   sources. Must only be used when the node is stopped.
+`,
+					Action: importSigningProtection,
+					Flags:  cfgInFlags,
+				},
 			},
 		},
 	}
@@ -290,6 +376,17 @@ func restoreDB(ctx *cli.Context) error {
 		zap.Uint32("skip", skip),
 		zap.Uint32("count", count))
 
+	var restorer chaindump.DumperRestorer = chain
+	if dir := ctx.String("from-checkpoint"); dir != "" {
+		if dumpDir != "" || start != 0 || chain.BlockHeight() != 0 {
+			return cli.Exit("--from-checkpoint can only be used for a full dump restored into an empty DB without --dump", 1)
+		}
+		restorer, err = newCheckpointedChain(chain, log, dir, skip+count-1)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("failed to restore from checkpoint: %w", err), 1)
+		}
+	}
+
 	gctx := newGraceContext()
 	var lastIndex uint32
 	dump := newDump()
@@ -328,7 +425,7 @@ func restoreDB(ctx *cli.Context) error {
 		}
 	}
 
-	err = chaindump.Restore(chain, reader, skip, count, f)
+	err = chaindump.Restore(restorer, reader, skip, count, f)
 	if err != nil {
 		return cli.Exit(fmt.Errorf("wrong dump file or settings mismatch: %w", err), 1)
 	}
@@ -368,6 +465,58 @@ func resetDB(ctx *cli.Context) error {
 	return nil
 }
 
+func exportSigningProtection(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	path := cfg.ApplicationConfiguration.Consensus.SigningProtectionDB
+	if path == "" {
+		return cli.Exit("signing protection DB is not configured", 1)
+	}
+	var outStream = os.Stdout
+	if out := ctx.String("out"); out != "" {
+		outStream, err = os.Create(out)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+	}
+	defer outStream.Close()
+	if err := consensus.ExportSigningProtection(path, outStream); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return nil
+}
+
+func importSigningProtection(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	path := cfg.ApplicationConfiguration.Consensus.SigningProtectionDB
+	if path == "" {
+		return cli.Exit("signing protection DB is not configured", 1)
+	}
+	var inStream = os.Stdin
+	if in := ctx.String("in"); in != "" {
+		inStream, err = os.Open(in)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+	}
+	defer inStream.Close()
+	if err := consensus.ImportSigningProtection(path, inStream); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return nil
+}
+
 // oracleService is an interface representing Oracle service with network.Service
 // capabilities and ability to submit oracle responses.
 type oracleService interface {
@@ -409,6 +558,8 @@ func mkConsensus(config config.Consensus, tpb time.Duration, chain *core.Blockch
 		StopTxFlow:            serv.StopTxFlow,
 		Wallet:                config.UnlockWallet,
 		TimePerBlock:          tpb,
+		SigningProtectionDB:   config.SigningProtectionDB,
+		Standby:               config.Standby,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't initialize Consensus module: %w", err)
@@ -445,6 +596,19 @@ func mkP2PNotary(config config.P2PNotary, chain *core.Blockchain, serv *network.
 	return n, nil
 }
 
+func mkCheckpointUploader(config config.StateCheckpoints, chain *core.Blockchain, serv *network.Server, log *zap.Logger) error {
+	if !config.Enabled || !config.NeoFS.Enabled {
+		return nil
+	}
+	up, err := checkpointuploader.New(chain, config.NeoFS, log)
+	if err != nil {
+		return fmt.Errorf("can't initialize checkpoint uploader: %w", err)
+	}
+	serv.AddService(up)
+	chain.SetCheckpointUploader(up)
+	return nil
+}
+
 func startServer(ctx *cli.Context) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
@@ -504,8 +668,16 @@ func startServer(ctx *cli.Context) error {
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
+	err = mkCheckpointUploader(cfg.ApplicationConfiguration.Ledger.StateCheckpoints, chain, serv, log)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
 	errChan := make(chan error)
 	rpcServer := rpcsrv.New(chain, cfg.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
+	rpcServer.SetConsensusHandler(dbftSrv)
+	if p2pNotary != nil {
+		rpcServer.SetNotaryHandler(p2pNotary)
+	}
 	serv.AddService(rpcServer)
 	setNeoGoVersion(config.Version)
 	serv.Start()
@@ -565,6 +737,10 @@ Main:
 				serv.DelService(rpcServer)
 				rpcServer.Shutdown()
 				rpcServer = rpcsrv.New(chain, cfgnew.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
+				rpcServer.SetConsensusHandler(dbftSrv)
+				if p2pNotary != nil {
+					rpcServer.SetNotaryHandler(p2pNotary)
+				}
 				serv.AddService(rpcServer)
 				if !cfgnew.ApplicationConfiguration.RPC.StartWhenSynchronized || serv.IsInSync() {
 					// Here similar to the initial run (see above for-loop), so async.
@@ -605,6 +781,7 @@ Main:
 				if p2pNotary != nil {
 					serv.DelService(p2pNotary)
 					chain.SetNotary(nil)
+					rpcServer.SetNotaryHandler(nil)
 					p2pNotary.Shutdown()
 				}
 				p2pNotary, err = mkP2PNotary(cfgnew.ApplicationConfiguration.P2PNotary, chain, serv, log)
@@ -612,8 +789,11 @@ Main:
 					log.Error("failed to create notary service", zap.Error(err))
 					break // Keep going.
 				}
-				if p2pNotary != nil && serv.IsInSync() {
-					p2pNotary.Start()
+				if p2pNotary != nil {
+					rpcServer.SetNotaryHandler(p2pNotary)
+					if serv.IsInSync() {
+						p2pNotary.Start()
+					}
 				}
 				serv.DelExtensibleService(sr, stateroot.Category)
 				srMod.SetUpdateValidatorsCallback(nil)
@@ -630,6 +810,7 @@ Main:
 			case sigusr2:
 				if dbftSrv != nil {
 					serv.DelConsensusService(dbftSrv)
+					rpcServer.SetConsensusHandler(nil)
 					dbftSrv.Shutdown()
 				}
 				dbftSrv, err = mkConsensus(cfgnew.ApplicationConfiguration.Consensus, serverConfig.TimePerBlock, chain, serv, log)
@@ -637,6 +818,7 @@ Main:
 					log.Error("failed to create consensus service", zap.Error(err))
 					break // Whatever happens, I'll leave it all to chance.
 				}
+				rpcServer.SetConsensusHandler(dbftSrv)
 				if dbftSrv != nil && serv.IsInSync() {
 					dbftSrv.Start()
 				}
@@ -405,3 +405,51 @@ func TestResetDB(t *testing.T) {
 	err = resetDB(ctx)
 	require.NoError(t, err)
 }
+
+func TestSigningProtectionExportImport(t *testing.T) {
+	d := t.TempDir()
+	cfg, err := config.LoadFile(filepath.Join(serverTestWD, "..", "..", "config", "protocol.privnet.yml"))
+	require.NoError(t, err)
+	newCfg := func(t *testing.T, name string, dbPath string) string {
+		cfg.ApplicationConfiguration.Consensus.SigningProtectionDB = dbPath
+		out, err := yaml.Marshal(cfg)
+		require.NoError(t, err)
+		cfgPath := filepath.Join(d, name)
+		require.NoError(t, os.WriteFile(cfgPath, out, os.ModePerm))
+		return cfgPath
+	}
+	newCtx := func(cfgPath string, flags map[string]string) *cli.Context {
+		set := flag.NewFlagSet("flagSet", flag.ExitOnError)
+		set.String("config-file", cfgPath, "")
+		for k, v := range flags {
+			set.String(k, v, "")
+		}
+		return cli.NewContext(cli.NewApp(), set, nil)
+	}
+	var (
+		pub      = "02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2"
+		srcDB    = filepath.Join(d, "src.json")
+		dstDB    = filepath.Join(d, "dst.json")
+		exported = filepath.Join(d, "exported.json")
+		srcCfg   = newCfg(t, "src.yml", srcDB)
+		dstCfg   = newCfg(t, "dst.yml", dstDB)
+	)
+	require.NoError(t, os.WriteFile(srcDB, []byte(`{"`+pub+`":{"Commit":{"height":10,"view":1,"hash":"0x0000000000000000000000000000000000000000000000000000000000000001"}}}`), 0o600))
+
+	t.Run("not configured", func(t *testing.T) {
+		emptyCfg := newCfg(t, "empty.yml", "")
+		require.Error(t, exportSigningProtection(newCtx(emptyCfg, map[string]string{"out": exported})))
+		require.Error(t, importSigningProtection(newCtx(emptyCfg, map[string]string{"in": exported})))
+	})
+	t.Run("missing input", func(t *testing.T) {
+		require.Error(t, importSigningProtection(newCtx(dstCfg, map[string]string{"in": filepath.Join(d, "missing")})))
+	})
+
+	require.NoError(t, exportSigningProtection(newCtx(srcCfg, map[string]string{"out": exported})))
+	require.NoError(t, importSigningProtection(newCtx(dstCfg, map[string]string{"in": exported})))
+
+	data, err := os.ReadFile(dstDB)
+	require.NoError(t, err)
+	require.Contains(t, string(data), pub)
+	require.Contains(t, string(data), `"height":10`)
+}
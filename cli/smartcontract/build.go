@@ -0,0 +1,475 @@
+package smartcontract
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/rpcbinding"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/tools/go/packages"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultWorkspaceFile is the workspace file used if none is specified.
+	defaultWorkspaceFile = "workspace.yml"
+	// defaultWorkspaceOutput is the default output directory of the workspace.
+	defaultWorkspaceOutput = "build"
+	// buildStateFile is the name of the file in the output directory with
+	// the results of the last build.
+	buildStateFile = "build.json"
+)
+
+var buildCmd = &cli.Command{
+	Name:      "build",
+	Usage:     "Build all smart contracts of the workspace",
+	UsageText: "neo-go contract build [-i workspace.yml] [--force]",
+	Description: `Compiles all contracts listed in the workspace file and emits NEF,
+   manifest, debug information and bindings configuration files for each of
+   them into the output directory. Contracts are compiled in the dependency
+   order, contract hashes are calculated for the workspace sender and Go
+   wrappers are generated with these hashes if requested, so that contracts
+   can call each other via generated wrappers. Contracts are not recompiled
+   if their sources, configuration and dependencies haven't changed since
+   the last build (unless --force is given). Example workspace file:
+
+     sender: NbrUYaZgyhSkNoRo9ugRyEMdUZxrhkNaWB
+     output: build
+     contracts:
+       - name: registry
+         source: registry
+         config: registry/registry.yml
+         wrapper: wrappers/registry/registry.go
+       - name: token
+         source: token
+         config: token/token.yml
+         rpcwrapper: rpc/token/token.go
+         dependsOn: [registry]
+
+   All paths are relative to the workspace file directory. The resulting
+   deployment order and contract hashes are printed and saved to the
+   build.json file in the output directory.
+`,
+	Action: contractBuild,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "in",
+			Aliases: []string{"i"},
+			Value:   defaultWorkspaceFile,
+			Usage:   "Workspace file (*.yml)",
+			Action:  cmdargs.EnsureNotEmpty("in"),
+		},
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "Rebuild all contracts even if they're up to date",
+		},
+	},
+}
+
+type (
+	// workspace is a multi-contract build configuration.
+	workspace struct {
+		// Sender is an address of the deployer used to calculate contract hashes.
+		Sender string `yaml:"sender"`
+		// Output is the directory for the build results.
+		Output    string              `yaml:"output"`
+		Contracts []workspaceContract `yaml:"contracts"`
+	}
+
+	// workspaceContract describes a single contract of the workspace.
+	workspaceContract struct {
+		// Name is a unique contract identifier used in dependencies and
+		// output file names.
+		Name string `yaml:"name"`
+		// Source is a contract package directory or file.
+		Source string `yaml:"source"`
+		// Config is a contract configuration file.
+		Config string `yaml:"config"`
+		// DependsOn is a list of contracts that should be built (and
+		// deployed) before this one.
+		DependsOn []string `yaml:"dependsOn,omitempty"`
+		// Wrapper is an optional output path for the contract Go wrapper
+		// to be used by other contracts.
+		Wrapper string `yaml:"wrapper,omitempty"`
+		// RPCWrapper is an optional output path for the contract Go RPC
+		// wrapper.
+		RPCWrapper string `yaml:"rpcwrapper,omitempty"`
+		// Optimize enables compiler optimization pass.
+		Optimize bool `yaml:"optimize,omitempty"`
+	}
+
+	// buildState contains the results of the workspace build.
+	buildState struct {
+		Sender    util.Uint160    `json:"sender"`
+		Contracts []builtContract `json:"contracts"`
+	}
+
+	// builtContract contains the build results for a single contract.
+	builtContract struct {
+		Name        string       `json:"name"`
+		Hash        util.Uint160 `json:"hash"`
+		NEF         string       `json:"nef"`
+		Manifest    string       `json:"manifest"`
+		Fingerprint string       `json:"fingerprint"`
+	}
+)
+
+func contractBuild(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wsPath := ctx.String("in")
+	ws, err := readWorkspace(wsPath)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	sender, err := address.StringToUint160(ws.Sender)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("invalid workspace sender: %w", err), 1)
+	}
+	order, err := ws.buildOrder()
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	root := filepath.Dir(wsPath)
+	out := filepath.Join(root, ws.Output)
+	if err := os.MkdirAll(out, os.ModePerm); err != nil {
+		return cli.Exit(fmt.Errorf("can't create output directory: %w", err), 1)
+	}
+	var prev = make(map[string]builtContract)
+	if data, err := os.ReadFile(filepath.Join(out, buildStateFile)); err == nil {
+		var st buildState
+		if json.Unmarshal(data, &st) == nil && st.Sender.Equals(sender) {
+			for _, c := range st.Contracts {
+				prev[c.Name] = c
+			}
+		}
+	}
+
+	var (
+		res    = buildState{Sender: sender}
+		hashes = make(map[string]util.Uint160)
+	)
+	for _, c := range order {
+		fp, err := c.fingerprint(root, sender, hashes)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("contract %s: %w", c.Name, err), 1)
+		}
+		var (
+			base     = filepath.Join(out, c.Name)
+			built    = builtContract{Name: c.Name, NEF: c.Name + ".nef", Manifest: c.Name + ".manifest.json", Fingerprint: fp}
+			bindings = base + ".bindings.yml"
+			status   = "up to date"
+		)
+		if ctx.Bool("force") || prev[c.Name].Fingerprint != fp || !filesExist(base+".nef", base+".manifest.json", base+".debug.json", bindings) {
+			err = c.compile(root, base)
+			if err != nil {
+				return cli.Exit(fmt.Errorf("contract %s: %w", c.Name, err), 1)
+			}
+			status = "compiled"
+		}
+		nefFile, _, err := readNEFFile(base + ".nef")
+		if err != nil {
+			return cli.Exit(fmt.Errorf("contract %s: can't read NEF file: %w", c.Name, err), 1)
+		}
+		m, _, err := readManifest(base+".manifest.json", util.Uint160{})
+		if err != nil {
+			return cli.Exit(fmt.Errorf("contract %s: can't read manifest: %w", c.Name, err), 1)
+		}
+		built.Hash = state.CreateContractHash(sender, nefFile.Checksum, m.Name)
+		hashes[c.Name] = built.Hash
+
+		cfg, err := readBindingsConfig(bindings)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("contract %s: %w", c.Name, err), 1)
+		}
+		cfg.Manifest = m
+		cfg.Hash = built.Hash
+		if c.Wrapper != "" {
+			err = generateToFile(filepath.Join(root, c.Wrapper), cfg, binding.Generate)
+			if err != nil {
+				return cli.Exit(fmt.Errorf("contract %s: can't generate wrapper: %w", c.Name, err), 1)
+			}
+		}
+		if c.RPCWrapper != "" {
+			err = generateToFile(filepath.Join(root, c.RPCWrapper), cfg, rpcbinding.Generate)
+			if err != nil {
+				return cli.Exit(fmt.Errorf("contract %s: can't generate RPC wrapper: %w", c.Name, err), 1)
+			}
+		}
+		res.Contracts = append(res.Contracts, built)
+		fmt.Fprintf(ctx.App.Writer, "%s: %s (%s)\n", c.Name, built.Hash.StringLE(), status)
+	}
+
+	data, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	if err := os.WriteFile(filepath.Join(out, buildStateFile), data, os.ModePerm); err != nil {
+		return cli.Exit(fmt.Errorf("can't save build results: %w", err), 1)
+	}
+	return nil
+}
+
+// readWorkspace reads and validates workspace file.
+func readWorkspace(path string) (*workspace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read workspace file: %w", err)
+	}
+	var ws workspace
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&ws); err != nil {
+		return nil, fmt.Errorf("can't parse workspace file: %w", err)
+	}
+	if ws.Sender == "" {
+		return nil, errors.New("workspace sender is not specified")
+	}
+	if ws.Output == "" {
+		ws.Output = defaultWorkspaceOutput
+	}
+	if len(ws.Contracts) == 0 {
+		return nil, errors.New("no contracts in the workspace")
+	}
+	var names = make(map[string]bool, len(ws.Contracts))
+	for i, c := range ws.Contracts {
+		switch {
+		case c.Name == "":
+			return nil, fmt.Errorf("contract #%d has no name", i)
+		case c.Name != filepath.Base(c.Name) || strings.HasPrefix(c.Name, "."):
+			return nil, fmt.Errorf("invalid contract name: %s", c.Name)
+		case names[c.Name]:
+			return nil, fmt.Errorf("duplicate contract name: %s", c.Name)
+		case c.Source == "":
+			return nil, fmt.Errorf("contract %s has no source", c.Name)
+		case c.Config == "":
+			return nil, fmt.Errorf("contract %s has no config", c.Name)
+		}
+		names[c.Name] = true
+	}
+	for _, c := range ws.Contracts {
+		for _, d := range c.DependsOn {
+			if !names[d] {
+				return nil, fmt.Errorf("contract %s depends on unknown contract %s", c.Name, d)
+			}
+		}
+	}
+	return &ws, nil
+}
+
+// buildOrder returns workspace contracts sorted in the dependency order,
+// independent contracts keep the workspace file order.
+func (ws *workspace) buildOrder() ([]workspaceContract, error) {
+	var (
+		byName = make(map[string]workspaceContract, len(ws.Contracts))
+		done   = make(map[string]bool, len(ws.Contracts))
+		path   []string
+		res    = make([]workspaceContract, 0, len(ws.Contracts))
+		visit  func(c workspaceContract) error
+	)
+	for _, c := range ws.Contracts {
+		byName[c.Name] = c
+	}
+	visit = func(c workspaceContract) error {
+		if done[c.Name] {
+			return nil
+		}
+		if i := slices.Index(path, c.Name); i >= 0 {
+			return fmt.Errorf("dependency cycle: %s", strings.Join(append(path[i:], c.Name), " -> "))
+		}
+		path = append(path, c.Name)
+		for _, d := range c.DependsOn {
+			if err := visit(byName[d]); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		done[c.Name] = true
+		res = append(res, c)
+		return nil
+	}
+	for _, c := range ws.Contracts {
+		if err := visit(c); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// compile compiles the contract saving the results to the files with the
+// given base path.
+func (c workspaceContract) compile(root, base string) error {
+	conf, err := ParseContractConfig(filepath.Join(root, c.Config))
+	if err != nil {
+		return err
+	}
+	o := &compiler.Options{
+		Outfile:      base + ".nef",
+		DebugInfo:    base + ".debug.json",
+		ManifestFile: base + ".manifest.json",
+		BindingsFile: base + ".bindings.yml",
+		Optimize:     c.Optimize,
+	}
+	conf.fillOptions(o)
+	_, err = compiler.CompileAndSave(filepath.Join(root, c.Source), o)
+	return err
+}
+
+// fingerprint returns a hash of all contract build inputs: workspace
+// settings, configuration, Go sources of the contract directory and of all
+// non-standard packages it imports (with go.mod files of their modules) and
+// hashes of dependencies. Generated wrappers of the contract itself are not
+// included. File paths are hashed relative to the workspace root (files
+// outside of it are hashed with absolute paths), so the result doesn't depend
+// on the way the workspace file is specified and on its location.
+func (c workspaceContract) fingerprint(root string, sender util.Uint160, hashes map[string]util.Uint160) (string, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	desc, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "%s\n%s\n%s\n", config.Version, sender.StringLE(), desc)
+	for _, d := range c.DependsOn {
+		fmt.Fprintf(h, "%s:%s\n", d, hashes[d].StringLE())
+	}
+	files := []string{filepath.Join(root, c.Config)}
+
+	src := filepath.Join(root, c.Source)
+	fi, err := os.Stat(src)
+	if err != nil {
+		return "", err
+	}
+	if !fi.IsDir() {
+		src = filepath.Dir(src)
+	}
+	var own []string
+	for _, w := range []string{c.Wrapper, c.RPCWrapper} {
+		if w != "" {
+			own = append(own, filepath.Clean(filepath.Join(root, w)))
+		}
+	}
+	var sources = make(map[string]struct{})
+	err = filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		name := d.Name()
+		if filepath.Ext(name) == ".go" || name == "go.mod" || name == "go.sum" {
+			sources[p] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	deps, err := packageFiles(src)
+	if err != nil {
+		return "", fmt.Errorf("can't load contract packages: %w", err)
+	}
+	for _, f := range deps {
+		f, err = filepath.Abs(f)
+		if err != nil {
+			return "", err
+		}
+		sources[f] = struct{}{}
+	}
+	for _, f := range slices.Sorted(maps.Keys(sources)) {
+		if !slices.Contains(own, f) {
+			files = append(files, f)
+		}
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return "", err
+		}
+		name := f
+		if rel, err := filepath.Rel(root, f); err == nil && filepath.IsLocal(rel) {
+			name = rel
+		}
+		fmt.Fprintf(h, "%s %d\n", filepath.ToSlash(name), len(data))
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// packageFiles returns Go files of the package located in the given directory
+// and of all its dependencies except the standard library ones along with
+// go.mod and go.sum files of their modules. Package errors are not checked
+// here, they're reported by the compiler.
+func packageFiles(dir string) ([]string, error) {
+	conf := &packages.Config{
+		Mode: packages.NeedName |
+			packages.NeedFiles |
+			packages.NeedImports |
+			packages.NeedDeps |
+			packages.NeedModule,
+		Dir: dir,
+	}
+	pkgs, err := packages.Load(conf, "pattern="+dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		if p.Module == nil { // Standard library.
+			return
+		}
+		files = append(files, p.GoFiles...)
+		if p.Module.GoMod != "" {
+			files = append(files, p.Module.GoMod)
+			sum := filepath.Join(filepath.Dir(p.Module.GoMod), "go.sum")
+			if _, err := os.Stat(sum); err == nil {
+				files = append(files, sum)
+			}
+		}
+	})
+	return files, nil
+}
+
+// generateToFile generates bindings with the given generator and saves them to
+// the specified file creating parent directories if needed.
+func generateToFile(path string, cfg binding.Config, gen func(binding.Config) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	cfg.Output = &buf
+	if err := gen(cfg); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), os.ModePerm)
+}
+
+// filesExist returns true if all given files exist.
+func filesExist(paths ...string) bool {
+	for _, p := range paths {
+		if _, err := os.Stat(p); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/internal/testcli"
 	"github.com/nspcc-dev/neo-go/internal/versionutil"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -23,6 +24,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
@@ -201,6 +203,16 @@ func ToMap(a []testcontract.MyPair) map[int]string {
 	return neogointernal.CallWithToken(Hash, "toMap", int(contract.All), a).(map[int]string)
 }
 `, string(bs))
+
+	t.Run("gas comments", func(t *testing.T) {
+		e.Run(t, append(cmd, "--gas-comments")...)
+		e.Run(t, "neo-go", "contract", "generate-wrapper",
+			"--config", bindingsPath, "--manifest", manifestPath,
+			"--out", outPath, "--hash", "0x0123456789987654321001234567899876543210")
+		bs, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		require.Regexp(t, "// Blocks invokes `blocks` method of contract.\n// Its static execution cost estimation is [0-9.]+ GAS.\n", string(bs))
+	})
 }
 
 // updateGoMod updates the go.mod file located in the specified directory.
@@ -607,6 +619,23 @@ func TestContractManifestGroups(t *testing.T) {
 	e.Run(t, append(cmd, "--wallet", testcli.TestWalletPath,
 		"--sender", testcli.ValidatorAddr, "--address", testcli.TestWalletAccount)...)
 
+	t.Run("encrypted wallet file", func(t *testing.T) {
+		w, err := wallet.NewWalletFromFile(testcli.TestWalletPath)
+		require.NoError(t, err)
+		require.NoError(t, w.EncryptFile("filepass", wallet.Argon2Params{Time: 1, Memory: 64, Threads: 1}))
+		w.SetPath(filepath.Join(tmpDir, "encrypted.json"))
+		require.NoError(t, w.Save())
+
+		e.In.WriteString("invalid\r")
+		e.RunWithError(t, append(cmd, "--wallet", w.Path(),
+			"--sender", testcli.ValidatorAddr, "--address", testcli.TestWalletAccount)...)
+
+		e.In.WriteString("filepass\r")
+		e.In.WriteString("testpass\r")
+		e.Run(t, append(cmd, "--wallet", w.Path(),
+			"--sender", testcli.ValidatorAddr, "--address", testcli.TestWalletAccount)...)
+	})
+
 	e.In.WriteString(testcli.ValidatorPass + "\r")
 	e.Run(t, "neo-go", "contract", "deploy",
 		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
@@ -1061,6 +1090,304 @@ func TestContractInspect(t *testing.T) {
 		e.Run(t, append(cmd, "--in", nefName)...)
 		require.True(t, strings.Contains(e.Out.String(), "SYSCALL"))
 	})
+	t.Run("gas", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "--gas requires --compile", append(cmd, "--in", nefName, "--gas")...)
+		e.Run(t, append(cmd, "--in", srcPath, "--compile", "--gas")...)
+		e.CheckNextLine(t, `^_initialize: [0-9.]+ GAS \(opcodes: [0-9.]+ GAS\)$`)
+		e.CheckNextLine(t, `^_deploy: [0-9.]+ GAS \(opcodes: [0-9.]+ GAS\)$`)
+		e.CheckNextLine(t, `^\tSystem\.Runtime\.GetCallingScriptHash: 0\.0000048 GAS$`)
+		e.CheckNextLine(t, `^\tSystem\.Storage\.GetContext: 0\.0000048 GAS$`)
+		e.CheckNextLine(t, `^\tSystem\.Storage\.Put: 0\.0098304 GAS$`)
+		e.CheckNextLine(t, `^\tloop at .*main\.go:29: data-dependent bounds$`)
+		require.True(t, strings.Contains(e.Out.String(), "\tcalls with unknown cost\n"))
+	})
+}
+
+func TestContractLint(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	const (
+		srcPath  = "testdata/lint/lint.go"
+		confPath = "testdata/lint/lint.yml"
+	)
+	cmd := []string{"neo-go", "contract", "lint"}
+	t.Run("missing input", func(t *testing.T) {
+		e.RunWithErrorCheck(t, `Required flag "in" not set`, cmd...)
+	})
+	t.Run("bad format", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "unknown output format", append(cmd, "--in", srcPath, "--format", "xml")...)
+	})
+	t.Run("text", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "1 issue(s) found", append(cmd, "--in", srcPath)...)
+		e.CheckNextLine(t, `lint\.go:10:2: .* \(missing-witness\)`)
+		e.CheckEOF(t)
+
+		e.RunWithErrorCheckExit(t, "2 issue(s) found", append(cmd, "--in", srcPath, "--config", confPath)...)
+		e.CheckNextLine(t, `lint\.go:10:2: .* \(missing-witness\)`)
+		e.CheckNextLine(t, `lint\.go:11:2: event 'Put' is not declared .* \(undeclared-event\)`)
+		e.CheckEOF(t)
+	})
+	t.Run("json", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--in", srcPath, "--format", "json")...)
+		var issues []compiler.LintIssue
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), &issues))
+		require.Equal(t, 1, len(issues))
+		require.Equal(t, compiler.LintMissingWitness, issues[0].Rule)
+		require.Equal(t, 10, issues[0].Line)
+	})
+	t.Run("sarif", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--in", srcPath, "--format", "sarif")...)
+		var log map[string]any
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), &log))
+		require.Equal(t, "2.1.0", log["version"])
+		results := log["runs"].([]any)[0].(map[string]any)["results"].([]any)
+		require.Equal(t, 1, len(results))
+		require.Equal(t, compiler.LintMissingWitness, results[0].(map[string]any)["ruleId"])
+	})
+	t.Run("no issues", func(t *testing.T) {
+		e.Run(t, append(cmd, "--in", "../../examples/token")...)
+		e.CheckEOF(t)
+	})
+}
+
+func TestContractDiff(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	tmpDir := t.TempDir()
+
+	var files = make(map[string]string)
+	for _, v := range []string{"v1", "v2"} {
+		nefName := filepath.Join(tmpDir, v+".nef")
+		manifestName := filepath.Join(tmpDir, v+".manifest.json")
+		e.Run(t, "neo-go", "contract", "compile",
+			"--in", filepath.Join("testdata", "diff", v),
+			"--config", filepath.Join("testdata", "diff", v, "store.yml"),
+			"--out", nefName, "--manifest", manifestName)
+		files[v] = nefName + "," + manifestName
+	}
+
+	cmd := []string{"neo-go", "contract", "diff"}
+	t.Run("missing flags", func(t *testing.T) {
+		e.RunWithErrorCheck(t, `Required flags "old, new" not set`, cmd...)
+		e.RunWithErrorCheckExit(t, "both --old-src and --new-src should be specified",
+			append(cmd, "--old", files["v1"], "--new", files["v2"], "--old-src", "testdata/diff/v1")...)
+	})
+	t.Run("invalid files", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "'nef,manifest' expected", append(cmd, "--old", "v1.nef", "--new", files["v2"])...)
+		e.RunWithErrorCheckExit(t, "can't get new contract", append(cmd, "--old", files["v1"], "--new", "v2.nef,v2.manifest.json")...)
+	})
+	t.Run("no changes", func(t *testing.T) {
+		e.Run(t, append(cmd, "--old", files["v1"], "--new", files["v1"],
+			"--old-src", "testdata/diff/v1", "--new-src", "testdata/diff/v1")...)
+		e.CheckNextLine(t, "No changes found")
+		e.CheckEOF(t)
+	})
+	t.Run("breaking", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "5 breaking change(s) found", append(cmd, "--old", files["v1"], "--new", files["v2"],
+			"--old-src", "testdata/diff/v1", "--new-src", "testdata/diff/v2")...)
+		e.CheckNextLine(t, `^\[BREAKING\] method delete\(key ByteArray\) Void removed$`)
+		e.CheckNextLine(t, `^\[BREAKING\] method get/1: return type changed from ByteArray to String$`)
+		e.CheckNextLine(t, `^\[BREAKING\] method get/1 is no longer safe$`)
+		e.CheckNextLine(t, `^\[BREAKING\] method put/2: parameter #1 type changed from ByteArray to String$`)
+		e.CheckNextLine(t, `^\[INFO\] method count\(\) Integer added$`)
+		e.CheckNextLine(t, `^\[BREAKING\] event Put removed$`)
+		e.CheckNextLine(t, `^\[INFO\] event Stored added$`)
+		e.CheckNextLine(t, `^\[WARNING\] storage prefix 0x01 is no longer used$`)
+		e.CheckNextLine(t, `^\[INFO\] storage prefix 0x03 added$`)
+		e.CheckEOF(t)
+	})
+	t.Run("compatible", func(t *testing.T) {
+		nefName := filepath.Join(tmpDir, "unsafe.nef")
+		manifestName := filepath.Join(tmpDir, "unsafe.manifest.json")
+		e.Run(t, "neo-go", "contract", "compile",
+			"--in", filepath.Join("testdata", "diff", "v1"),
+			"--config", filepath.Join("testdata", "diff", "v1", "unsafe.yml"),
+			"--out", nefName, "--manifest", manifestName)
+		e.Run(t, append(cmd, "--old", nefName+","+manifestName, "--new", files["v1"])...)
+		e.CheckNextLine(t, `^\[INFO\] method get/1 became safe$`)
+		e.CheckEOF(t)
+	})
+	t.Run("deployed", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "invalid contract hash", append(cmd, "--old", "bad@http://"+e.RPC.Addresses()[0], "--new", files["v1"])...)
+		e.RunWithErrorCheckExit(t, "breaking change(s) found", append(cmd, "--old", gas.Hash.StringLE()+"@http://"+e.RPC.Addresses()[0], "--new", files["v1"])...)
+		e.CheckNextLine(t, `^\[BREAKING\] contract name changed from 'GasToken' to 'Store'$`)
+	})
+}
+
+func TestContractBuild(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmpDir := t.TempDir()
+
+	interopPath, err := filepath.Abs(filepath.Join("..", "..", "pkg", "interop"))
+	require.NoError(t, err)
+	var files = map[string]string{
+		"go.mod": `module myimport.com/workspace
+
+go 1.23
+
+require github.com/nspcc-dev/neo-go/pkg/interop v0.0.0
+
+replace github.com/nspcc-dev/neo-go/pkg/interop => ` + interopPath + `
+`,
+		"callee/callee.go": `package callee
+
+import "myimport.com/workspace/util"
+
+func Value() int {
+	return 42 * util.One()
+}
+`,
+		"util/util.go": `package util
+
+func One() int {
+	return 1
+}
+`,
+		"callee/callee.yml": `name: Callee
+safemethods: ["value"]
+`,
+		"caller/caller.go": `package caller
+
+import "myimport.com/workspace/wrappers/callee"
+
+func Double() int {
+	return callee.Value() * 2
+}
+`,
+		"caller/caller.yml": `name: Caller
+permissions:
+  - methods: ["value"]
+`,
+		"workspace.yml": `sender: ` + testcli.ValidatorAddr + `
+output: out
+contracts:
+  - name: caller
+    source: caller
+    config: caller/caller.yml
+    rpcwrapper: rpc/caller/caller.go
+    dependsOn: [callee]
+  - name: callee
+    source: callee
+    config: callee/callee.yml
+    wrapper: wrappers/callee/callee.go
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(tmpDir, name)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), os.ModePerm))
+	}
+	wsPath := filepath.Join(tmpDir, "workspace.yml")
+	cmd := []string{"neo-go", "contract", "build", "--in", wsPath}
+
+	t.Run("invalid workspace", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "can't read workspace file", "neo-go", "contract", "build", "--in", filepath.Join(tmpDir, "unknown.yml"))
+
+		badPath := filepath.Join(tmpDir, "bad.yml")
+		for _, tc := range []struct {
+			ws  string
+			err string
+		}{
+			{"sender: " + testcli.ValidatorAddr + "\nunknown: field\n", "can't parse workspace file"},
+			{"contracts: []\n", "workspace sender is not specified"},
+			{"sender: bad\ncontracts: [{name: a, source: a, config: a.yml}]\n", "invalid workspace sender"},
+			{"sender: " + testcli.ValidatorAddr + "\n", "no contracts in the workspace"},
+			{"sender: " + testcli.ValidatorAddr + "\ncontracts: [{name: a, source: a, config: a.yml}, {name: a, source: b, config: b.yml}]\n", "duplicate contract name: a"},
+			{"sender: " + testcli.ValidatorAddr + "\ncontracts: [{name: a, source: a, config: a.yml, dependsOn: [b]}]\n", "contract a depends on unknown contract b"},
+			{"sender: " + testcli.ValidatorAddr + "\ncontracts: [{name: a, source: a, config: a.yml, dependsOn: [b]}, {name: b, source: b, config: b.yml, dependsOn: [a]}]\n", "dependency cycle: a -> b -> a"},
+		} {
+			require.NoError(t, os.WriteFile(badPath, []byte(tc.ws), os.ModePerm))
+			e.RunWithErrorCheckExit(t, tc.err, "neo-go", "contract", "build", "--in", badPath)
+		}
+	})
+
+	e.Run(t, cmd...)
+	calleeLine := e.GetNextLine(t)
+	require.Regexp(t, `^callee: [0-9a-f]{40} \(compiled\)$`, calleeLine)
+	callerLine := e.GetNextLine(t)
+	require.Regexp(t, `^caller: [0-9a-f]{40} \(compiled\)$`, callerLine)
+	e.CheckEOF(t)
+
+	for _, name := range []string{"callee", "caller"} {
+		for _, ext := range []string{".nef", ".manifest.json", ".debug.json", ".bindings.yml"} {
+			require.FileExists(t, filepath.Join(tmpDir, "out", name+ext))
+		}
+	}
+	require.FileExists(t, filepath.Join(tmpDir, "rpc", "caller", "caller.go"))
+
+	calleeHash, err := util.Uint160DecodeStringLE(strings.Fields(calleeLine)[1])
+	require.NoError(t, err)
+	wrapper, err := os.ReadFile(filepath.Join(tmpDir, "wrappers", "callee", "callee.go"))
+	require.NoError(t, err)
+	var hashLit strings.Builder
+	for _, b := range calleeHash.BytesBE() {
+		fmt.Fprintf(&hashLit, "\\x%02x", b)
+	}
+	require.Contains(t, string(wrapper), `const Hash = "`+hashLit.String()+`"`)
+
+	nefBytes, err := os.ReadFile(filepath.Join(tmpDir, "out", "callee.nef"))
+	require.NoError(t, err)
+	nefFile, err := nef.FileFromBytes(nefBytes)
+	require.NoError(t, err)
+	require.Equal(t, state.CreateContractHash(testcli.ValidatorHash, nefFile.Checksum, "Callee"), calleeHash)
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "out", "build.json"))
+	require.NoError(t, err)
+	var res struct {
+		Contracts []struct {
+			Name string
+			Hash util.Uint160
+		}
+	}
+	require.NoError(t, json.Unmarshal(data, &res))
+	require.Equal(t, 2, len(res.Contracts))
+	require.Equal(t, "callee", res.Contracts[0].Name)
+	require.Equal(t, calleeHash, res.Contracts[0].Hash)
+	require.Equal(t, "caller", res.Contracts[1].Name)
+
+	t.Run("up to date", func(t *testing.T) {
+		e.Run(t, cmd...)
+		e.CheckNextLine(t, `^callee: [0-9a-f]{40} \(up to date\)$`)
+		e.CheckNextLine(t, `^caller: [0-9a-f]{40} \(up to date\)$`)
+		e.CheckEOF(t)
+	})
+	t.Run("relative workspace path", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		rel, err := filepath.Rel(wd, wsPath)
+		require.NoError(t, err)
+		e.Run(t, "neo-go", "contract", "build", "--in", rel)
+		e.CheckNextLine(t, `^callee: [0-9a-f]{40} \(up to date\)$`)
+		e.CheckNextLine(t, `^caller: [0-9a-f]{40} \(up to date\)$`)
+		e.CheckEOF(t)
+	})
+	t.Run("moved workspace", func(t *testing.T) {
+		movedDir := t.TempDir()
+		require.NoError(t, os.CopyFS(movedDir, os.DirFS(tmpDir)))
+		e.Run(t, "neo-go", "contract", "build", "--in", filepath.Join(movedDir, "workspace.yml"))
+		e.CheckNextLine(t, `^callee: [0-9a-f]{40} \(up to date\)$`)
+		e.CheckNextLine(t, `^caller: [0-9a-f]{40} \(up to date\)$`)
+		e.CheckEOF(t)
+	})
+	t.Run("dependency changed", func(t *testing.T) {
+		src := strings.Replace(files["callee/callee.go"], "42", "43", 1)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "callee", "callee.go"), []byte(src), os.ModePerm))
+		e.Run(t, cmd...)
+		e.CheckNextLine(t, `^callee: [0-9a-f]{40} \(compiled\)$`)
+		e.CheckNextLine(t, `^caller: [0-9a-f]{40} \(compiled\)$`)
+		e.CheckEOF(t)
+	})
+	t.Run("imported package changed", func(t *testing.T) {
+		src := strings.Replace(files["util/util.go"], "return 1", "one := 1\n\treturn one", 1)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "util", "util.go"), []byte(src), os.ModePerm))
+		e.Run(t, cmd...)
+		e.CheckNextLine(t, `^callee: [0-9a-f]{40} \(compiled\)$`)
+		e.CheckNextLine(t, `^caller: [0-9a-f]{40} \(compiled\)$`)
+		e.CheckEOF(t)
+	})
+	t.Run("force", func(t *testing.T) {
+		e.Run(t, append(cmd, "--force")...)
+		e.CheckNextLine(t, `^callee: [0-9a-f]{40} \(compiled\)$`)
+		e.CheckNextLine(t, `^caller: [0-9a-f]{40} \(compiled\)$`)
+		e.CheckEOF(t)
+	})
 }
 
 func TestCompileExamples(t *testing.T) {
@@ -0,0 +1,402 @@
+package smartcontract
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/urfave/cli/v2"
+)
+
+var diffCmd = &cli.Command{
+	Name:      "diff",
+	Usage:     "Check contract update compatibility",
+	UsageText: "neo-go contract diff --old <nef,manifest|hash@endpoint> --new <nef,manifest> [--old-src path] [--new-src path] [-s timeout]",
+	Description: `Compares the currently deployed (old) contract with the new version to be
+   passed to ContractManagement.update and reports changes that can break
+   existing users of the contract: removed methods and events, changed
+   parameter and return types, methods that are no longer safe, removed groups
+   and supported standards (the new manifest is also checked for compliance
+   with the standards it declares) and contract name change (not allowed by
+   update). Permission changes are reported as warnings.
+
+   The old contract can be specified either as a pair of NEF and manifest
+   files separated by comma or as a contract hash with RPC node address
+   (like 0x1b4357bff5a01bdf2a6581247cf9ed1e24629176@http://localhost:20332).
+
+   If contract sources are provided via --old-src and --new-src flags, then
+   constant storage key prefixes used by both versions are compared and
+   prefixes that are no longer used are reported as warnings (the data stored
+   under them becomes inaccessible).
+
+   The command exits with non-zero code if there are breaking changes.
+`,
+	Action: contractDiff,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "old",
+			Required: true,
+			Usage:    "Deployed contract: 'nef,manifest' files or 'hash@endpoint'",
+			Action:   cmdargs.EnsureNotEmpty("old"),
+		},
+		&cli.StringFlag{
+			Name:     "new",
+			Required: true,
+			Usage:    "New contract version: 'nef,manifest' files",
+			Action:   cmdargs.EnsureNotEmpty("new"),
+		},
+		&cli.StringFlag{
+			Name:  "old-src",
+			Usage: "Source code of the deployed contract (*.go file or directory)",
+		},
+		&cli.StringFlag{
+			Name:  "new-src",
+			Usage: "Source code of the new contract version (*.go file or directory)",
+		},
+		&cli.DurationFlag{
+			Name:    "timeout",
+			Aliases: []string{"s"},
+			Value:   options.DefaultTimeout,
+			Usage:   "Timeout for the RPC request if the old contract is specified by hash",
+		},
+	},
+}
+
+// diffSeverity is the level of contract change.
+type diffSeverity byte
+
+const (
+	diffInfo diffSeverity = iota
+	diffWarning
+	diffBreaking
+)
+
+// String implements the fmt.Stringer interface.
+func (s diffSeverity) String() string {
+	switch s {
+	case diffBreaking:
+		return "BREAKING"
+	case diffWarning:
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
+
+// contractChange is a single difference between contract versions.
+type contractChange struct {
+	severity diffSeverity
+	message  string
+}
+
+// contractDiffer accumulates changes between contract versions.
+type contractDiffer struct {
+	changes []contractChange
+}
+
+func (d *contractDiffer) add(s diffSeverity, format string, args ...any) {
+	d.changes = append(d.changes, contractChange{severity: s, message: fmt.Sprintf(format, args...)})
+}
+
+func contractDiff(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	oldSrc, newSrc := ctx.String("old-src"), ctx.String("new-src")
+	if (len(oldSrc) == 0) != (len(newSrc) == 0) {
+		return cli.Exit(errors.New("both --old-src and --new-src should be specified"), 1)
+	}
+
+	var (
+		oldM *manifest.Manifest
+		err  error
+	)
+	if hashStr, endpoint, ok := strings.Cut(ctx.String("old"), "@"); ok {
+		oldM, err = getDeployedManifest(ctx, hashStr, endpoint)
+	} else {
+		oldM, err = readNEFAndManifest(ctx.String("old"))
+	}
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't get old contract: %w", err), 1)
+	}
+	newM, err := readNEFAndManifest(ctx.String("new"))
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't get new contract: %w", err), 1)
+	}
+
+	d := new(contractDiffer)
+	d.compareManifests(oldM, newM)
+	if len(oldSrc) != 0 {
+		oldPrefixes, err := getStoragePrefixes(oldSrc)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("can't compile old contract: %w", err), 1)
+		}
+		newPrefixes, err := getStoragePrefixes(newSrc)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("can't compile new contract: %w", err), 1)
+		}
+		d.compareStoragePrefixes(oldPrefixes, newPrefixes)
+	}
+
+	var breaking int
+	for _, c := range d.changes {
+		fmt.Fprintf(ctx.App.Writer, "[%s] %s\n", c.severity, c.message)
+		if c.severity == diffBreaking {
+			breaking++
+		}
+	}
+	if len(d.changes) == 0 {
+		fmt.Fprintln(ctx.App.Writer, "No changes found")
+	}
+	if breaking != 0 {
+		return cli.Exit(fmt.Errorf("%d breaking change(s) found", breaking), 1)
+	}
+	return nil
+}
+
+// readNEFAndManifest checks NEF and reads manifest files specified as
+// "nef,manifest".
+func readNEFAndManifest(s string) (*manifest.Manifest, error) {
+	nefFile, manifestFile, ok := strings.Cut(s, ",")
+	if !ok {
+		return nil, fmt.Errorf("'nef,manifest' expected, got %s", s)
+	}
+	if _, _, err := readNEFFile(nefFile); err != nil {
+		return nil, err
+	}
+	m, _, err := readManifest(manifestFile, util.Uint160{})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// getDeployedManifest retrieves the manifest of the deployed contract via RPC.
+func getDeployedManifest(ctx *cli.Context, hashStr, endpoint string) (*manifest.Manifest, error) {
+	h, err := flags.ParseAddress(hashStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract hash: %w", err)
+	}
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+	c, err := rpcclient.New(gctx, endpoint, rpcclient.Options{})
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	cs, err := c.GetContractStateByHash(h)
+	if err != nil {
+		return nil, err
+	}
+	return &cs.Manifest, nil
+}
+
+// getStoragePrefixes compiles the contract and returns storage prefixes used
+// by it.
+func getStoragePrefixes(src string) ([][]byte, error) {
+	_, di, err := compiler.CompileWithOptions(src, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return di.StoragePrefixes, nil
+}
+
+// compareManifests adds all manifest changes.
+func (d *contractDiffer) compareManifests(oldM, newM *manifest.Manifest) {
+	if oldM.Name != newM.Name {
+		d.add(diffBreaking, "contract name changed from '%s' to '%s'", oldM.Name, newM.Name)
+	}
+	d.compareMethods(oldM.ABI.Methods, newM.ABI.Methods)
+	d.compareEvents(oldM.ABI.Events, newM.ABI.Events)
+	d.comparePermissions(oldM.Permissions, newM.Permissions)
+
+	for _, g := range oldM.Groups {
+		if !slices.ContainsFunc(newM.Groups, func(ng manifest.Group) bool { return ng.PublicKey.Equal(g.PublicKey) }) {
+			d.add(diffBreaking, "group %s removed", g.PublicKey.StringCompressed())
+		}
+	}
+	for _, g := range newM.Groups {
+		if !slices.ContainsFunc(oldM.Groups, func(og manifest.Group) bool { return og.PublicKey.Equal(g.PublicKey) }) {
+			d.add(diffInfo, "group %s added", g.PublicKey.StringCompressed())
+		}
+	}
+
+	for _, s := range oldM.SupportedStandards {
+		if !slices.Contains(newM.SupportedStandards, s) {
+			d.add(diffBreaking, "supported standard %s removed", s)
+		}
+	}
+	for _, s := range newM.SupportedStandards {
+		if !slices.Contains(oldM.SupportedStandards, s) {
+			d.add(diffInfo, "supported standard %s added", s)
+		}
+		if err := standard.Check(newM, s); err != nil {
+			d.add(diffBreaking, "new contract: %s", err)
+		}
+	}
+}
+
+func methodSignature(m manifest.Method) string {
+	var params = make([]string, len(m.Parameters))
+	for i, p := range m.Parameters {
+		params[i] = p.Name + " " + p.Type.String()
+	}
+	return fmt.Sprintf("%s(%s) %s", m.Name, strings.Join(params, ", "), m.ReturnType)
+}
+
+func (d *contractDiffer) compareMethods(oldMethods, newMethods []manifest.Method) {
+	findMethod := func(methods []manifest.Method, name string, pcount int) *manifest.Method {
+		for i := range methods {
+			if methods[i].Name == name && len(methods[i].Parameters) == pcount {
+				return &methods[i]
+			}
+		}
+		return nil
+	}
+	for _, om := range oldMethods {
+		nm := findMethod(newMethods, om.Name, len(om.Parameters))
+		if nm == nil {
+			d.add(diffBreaking, "method %s removed", methodSignature(om))
+			continue
+		}
+		for i, op := range om.Parameters {
+			np := nm.Parameters[i]
+			if op.Type != np.Type {
+				d.add(diffBreaking, "method %s/%d: parameter #%d type changed from %s to %s", om.Name, len(om.Parameters), i, op.Type, np.Type)
+			}
+			if op.Name != np.Name {
+				d.add(diffInfo, "method %s/%d: parameter #%d renamed from '%s' to '%s'", om.Name, len(om.Parameters), i, op.Name, np.Name)
+			}
+		}
+		if om.ReturnType != nm.ReturnType {
+			d.add(diffBreaking, "method %s/%d: return type changed from %s to %s", om.Name, len(om.Parameters), om.ReturnType, nm.ReturnType)
+		}
+		if om.Safe && !nm.Safe {
+			d.add(diffBreaking, "method %s/%d is no longer safe", om.Name, len(om.Parameters))
+		} else if !om.Safe && nm.Safe {
+			d.add(diffInfo, "method %s/%d became safe", om.Name, len(om.Parameters))
+		}
+	}
+	for _, nm := range newMethods {
+		if findMethod(oldMethods, nm.Name, len(nm.Parameters)) == nil {
+			d.add(diffInfo, "method %s added", methodSignature(nm))
+		}
+	}
+}
+
+func (d *contractDiffer) compareEvents(oldEvents, newEvents []manifest.Event) {
+	findEvent := func(events []manifest.Event, name string) *manifest.Event {
+		for i := range events {
+			if events[i].Name == name {
+				return &events[i]
+			}
+		}
+		return nil
+	}
+	for _, oe := range oldEvents {
+		ne := findEvent(newEvents, oe.Name)
+		if ne == nil {
+			d.add(diffBreaking, "event %s removed", oe.Name)
+			continue
+		}
+		if len(oe.Parameters) != len(ne.Parameters) {
+			d.add(diffBreaking, "event %s: number of parameters changed from %d to %d", oe.Name, len(oe.Parameters), len(ne.Parameters))
+			continue
+		}
+		for i, op := range oe.Parameters {
+			np := ne.Parameters[i]
+			if op.Type != np.Type {
+				d.add(diffBreaking, "event %s: parameter #%d type changed from %s to %s", oe.Name, i, op.Type, np.Type)
+			}
+			if op.Name != np.Name {
+				d.add(diffInfo, "event %s: parameter #%d renamed from '%s' to '%s'", oe.Name, i, op.Name, np.Name)
+			}
+		}
+	}
+	for _, ne := range newEvents {
+		if findEvent(oldEvents, ne.Name) == nil {
+			d.add(diffInfo, "event %s added", ne.Name)
+		}
+	}
+}
+
+func (d *contractDiffer) comparePermissions(oldPerms, newPerms []manifest.Permission) {
+	toMap := func(perms []manifest.Permission) map[string]string {
+		var res = make(map[string]string, len(perms))
+		for _, p := range perms {
+			c, _ := json.Marshal(p.Contract)
+			m, _ := json.Marshal(p.Methods)
+			res[string(c)] = string(m)
+		}
+		return res
+	}
+	oldMap, newMap := toMap(oldPerms), toMap(newPerms)
+	for _, c := range sortedKeys(oldMap) {
+		nm, ok := newMap[c]
+		if !ok {
+			d.add(diffWarning, "permission for contract %s removed", c)
+		} else if nm != oldMap[c] {
+			d.add(diffWarning, "permission for contract %s changed: methods %s -> %s", c, oldMap[c], nm)
+		}
+	}
+	for _, c := range sortedKeys(newMap) {
+		if _, ok := oldMap[c]; !ok {
+			d.add(diffInfo, "permission for contract %s added: methods %s", c, newMap[c])
+		}
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys = make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// compareStoragePrefixes adds storage prefixes that are no longer used and
+// the new ones. Prefixes are considered to be the same if one of them is
+// a prefix of the other.
+func (d *contractDiffer) compareStoragePrefixes(oldPrefixes, newPrefixes [][]byte) {
+	overlaps := func(prefixes [][]byte, p []byte) bool {
+		return slices.ContainsFunc(prefixes, func(q []byte) bool {
+			return bytes.HasPrefix(p, q) || bytes.HasPrefix(q, p)
+		})
+	}
+	for _, p := range oldPrefixes {
+		if !overlaps(newPrefixes, p) {
+			d.add(diffWarning, "storage prefix %s is no longer used", formatStoragePrefix(p))
+		}
+	}
+	for _, p := range newPrefixes {
+		if !overlaps(oldPrefixes, p) {
+			d.add(diffInfo, "storage prefix %s added", formatStoragePrefix(p))
+		}
+	}
+}
+
+// formatStoragePrefix returns hex representation of the prefix along with the
+// string one if it's printable.
+func formatStoragePrefix(p []byte) string {
+	s := "0x" + hex.EncodeToString(p)
+	if utf8.Valid(p) && !slices.ContainsFunc([]rune(string(p)), func(r rune) bool { return !unicode.IsPrint(r) }) {
+		s += " (" + strconv.Quote(string(p)) + ")"
+	}
+	return s
+}
@@ -3,11 +3,18 @@ package smartcontract
 import (
 	"bytes"
 	"fmt"
+	"go/parser"
+	"go/token"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/rpcbinding"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/urfave/cli/v2"
@@ -22,11 +29,10 @@ var generatorFlags = []cli.Flag{
 	generated by 'contract compile' command with --bindings flag`,
 	},
 	&cli.StringFlag{
-		Name:     "manifest",
-		Aliases:  []string{"m"},
-		Required: true,
-		Usage:    "Read contract manifest (*.manifest.json) file",
-		Action:   cmdargs.EnsureNotEmpty("manifest"),
+		Name:    "manifest",
+		Aliases: []string{"m"},
+		Usage:   "Read contract manifest (*.manifest.json) file",
+		Action:  cmdargs.EnsureNotEmpty("manifest"),
 	},
 	&cli.StringFlag{
 		Name:     "out",
@@ -39,18 +45,41 @@ var generatorFlags = []cli.Flag{
 		Name:  "hash",
 		Usage: "Smart-contract hash. If not passed, the wrapper will be designed for dynamic hash usage",
 	},
+	&cli.StringFlag{
+		Name:    options.RPCEndpointFlag,
+		Aliases: []string{"r"},
+		Usage:   "RPC node address to get the manifest of the deployed contract with the given --hash from",
+		Action:  cmdargs.EnsureNotEmpty(options.RPCEndpointFlag),
+	},
+	&cli.DurationFlag{
+		Name:    "timeout",
+		Aliases: []string{"s"},
+		Value:   options.DefaultTimeout,
+		Usage:   "Timeout for the RPC request",
+	},
 }
 
+// generatorSourceDescription describes contract manifest sources of generators.
+const generatorSourceDescription = `
+   Contract manifest is read either from the file specified by --manifest flag
+   or from the RPC node specified by --rpc-endpoint flag for the contract
+   deployed with the given --hash. In the latter case types of NEP-11 and
+   NEP-24 standard methods are reconstructed (iterators, maps and royalty
+   structures) unless they're specified in the configuration file and
+   parameter names of NEP-17, NEP-26 and NEP-27 methods and events are
+   replaced with the standard ones.
+`
+
 var generateWrapperCmd = &cli.Command{
 	Name:      "generate-wrapper",
 	Usage:     "Generate wrapper to use in other contracts",
-	UsageText: "neo-go contract generate-wrapper --manifest <file.json> --out <file.go> [--hash <hash>] [--config <config>]",
+	UsageText: "neo-go contract generate-wrapper --manifest <file.json>|--rpc-endpoint <node> --out <file.go> [--hash <hash>] [--config <config>]",
 	Description: `Generates a Go wrapper to use it in other smart contracts. If the
    --hash flag is provided, CALLT instruction is used for the target contract
    invocation as an optimization of the wrapper contract code. If omitted, the
    generated wrapper will be designed for dynamic hash usage, allowing
    the hash to be specified at runtime.
-`,
+` + generatorSourceDescription,
 	Action: contractGenerateWrapper,
 	Flags:  generatorFlags,
 }
@@ -58,9 +87,56 @@ var generateWrapperCmd = &cli.Command{
 var generateRPCWrapperCmd = &cli.Command{
 	Name:      "generate-rpcwrapper",
 	Usage:     "Generate RPC wrapper to use for data reads",
-	UsageText: "neo-go contract generate-rpcwrapper --manifest <file.json> --out <file.go> [--hash <hash>] [--config <config>]",
-	Action:    contractGenerateRPCWrapper,
-	Flags:     generatorFlags,
+	UsageText: "neo-go contract generate-rpcwrapper --manifest <file.json>|--rpc-endpoint <node> --out <file> [--hash <hash>] [--config <config>] [--lang go|ts|python]",
+	Description: `Generates RPC client wrapper for the contract. Go wrapper is generated by
+   default, TypeScript (--lang ts) and Python (--lang python) wrappers are also
+   supported. They use the same configuration file, but Go-specific type
+   overrides are ignored for them. TypeScript and Python wrappers have no
+   external dependencies and use node JSON-RPC API directly.
+` + generatorSourceDescription,
+	Action: contractGenerateRPCWrapper,
+	Flags: append(slices.Clone(generatorFlags), &cli.StringFlag{
+		Name:  "lang",
+		Value: "go",
+		Usage: "Wrapper language: go, ts or python",
+	}),
+}
+
+var generateStorageCmd = &cli.Command{
+	Name:      "generate-storage",
+	Usage:     "Generate typed storage accessors for the contract",
+	UsageText: "neo-go contract generate-storage --config <file.yml> --out <file.go> [--package <name>]",
+	Description: `Generates Go file with typed accessors (Get, Put, Delete, Find for maps,
+   Get and Increment for counters, Get, Put and Delete for singletons) for
+   the storage layout declared in the 'storage' section of the contract
+   configuration file. The file is supposed to be a part of the contract
+   package, if --package is not specified, it's taken from other Go files
+   of the output directory (or from the directory name). The same layout is
+   written by the compiler to the manifest 'extra' field and used by
+   generate-rpcwrapper to create StorageReader decoding storage items via
+   getstorage and findstorage RPC calls.
+`,
+	Action: contractGenerateStorage,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "config",
+			Aliases:  []string{"c"},
+			Required: true,
+			Usage:    "Contract configuration file (*.yml) with storage layout",
+			Action:   cmdargs.EnsureNotEmpty("config"),
+		},
+		&cli.StringFlag{
+			Name:     "out",
+			Aliases:  []string{"o"},
+			Required: true,
+			Usage:    "Output of the generated accessors",
+			Action:   cmdargs.EnsureNotEmpty("out"),
+		},
+		&cli.StringFlag{
+			Name:  "package",
+			Usage: "Package name of the generated file",
+		},
+	},
 }
 
 func contractGenerateWrapper(ctx *cli.Context) error {
@@ -68,7 +144,18 @@ func contractGenerateWrapper(ctx *cli.Context) error {
 }
 
 func contractGenerateRPCWrapper(ctx *cli.Context) error {
-	return contractGenerateSomething(ctx, rpcbinding.Generate)
+	var gen func(binding.Config) error
+	switch lang := ctx.String("lang"); lang {
+	case "go":
+		gen = rpcbinding.Generate
+	case "ts":
+		gen = rpcbinding.GenerateTS
+	case "python":
+		gen = rpcbinding.GeneratePython
+	default:
+		return cli.Exit(fmt.Errorf("unknown wrapper language: %s", lang), 1)
+	}
+	return contractGenerateSomething(ctx, gen)
 }
 
 // contractGenerateSomething reads generator parameters and calls the given callback.
@@ -86,27 +173,39 @@ func contractGenerateSomething(ctx *cli.Context, cb func(binding.Config) error)
 			return cli.Exit(fmt.Errorf("invalid contract hash: %w", err), 1)
 		}
 	}
-	m, _, err := readManifest(ctx.String("manifest"), h)
-	if err != nil {
-		return cli.Exit(fmt.Errorf("can't read contract manifest: %w", err), 1)
-	}
-
-	cfg := binding.NewConfig()
-	if cfgPath := ctx.String("config"); cfgPath != "" {
-		bs, err := os.ReadFile(cfgPath)
+	var (
+		m        *manifest.Manifest
+		endpoint = ctx.String(options.RPCEndpointFlag)
+	)
+	switch {
+	case ctx.IsSet("manifest") && len(endpoint) != 0:
+		return cli.Exit("either --manifest or --rpc-endpoint should be provided, not both", 1)
+	case ctx.IsSet("manifest"):
+		m, _, err = readManifest(ctx.String("manifest"), h)
 		if err != nil {
-			return cli.Exit(fmt.Errorf("can't read config file: %w", err), 1)
+			return cli.Exit(fmt.Errorf("can't read contract manifest: %w", err), 1)
 		}
-		decoder := yaml.NewDecoder(bytes.NewReader(bs))
-		decoder.KnownFields(true)
-
-		err = decoder.Decode(&cfg)
+	case len(endpoint) != 0:
+		if h.Equals(util.Uint160{}) {
+			return cli.Exit("--hash is required to get the manifest from RPC node", 1)
+		}
+		m, err = getDeployedManifest(ctx, h.StringLE(), endpoint)
 		if err != nil {
-			return cli.Exit(fmt.Errorf("can't parse config file: %w", err), 1)
+			return cli.Exit(fmt.Errorf("can't get contract manifest: %w", err), 1)
 		}
+	default:
+		return cli.Exit("either --manifest or --rpc-endpoint should be provided", 1)
+	}
+
+	cfg, err := readBindingsConfig(ctx.String("config"))
+	if err != nil {
+		return cli.Exit(err, 1)
 	}
 	cfg.Manifest = m
 	cfg.Hash = h
+	if len(endpoint) != 0 {
+		binding.AddStandardTypes(&cfg)
+	}
 
 	f, err := os.Create(ctx.String("out"))
 	if err != nil {
@@ -122,3 +221,79 @@ func contractGenerateSomething(ctx *cli.Context, cb func(binding.Config) error)
 	}
 	return nil
 }
+
+// readBindingsConfig reads bindings configuration file, default configuration
+// is returned if the path is empty.
+func readBindingsConfig(cfgPath string) (binding.Config, error) {
+	cfg := binding.NewConfig()
+	if cfgPath == "" {
+		return cfg, nil
+	}
+	bs, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return cfg, fmt.Errorf("can't read config file: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(bs))
+	decoder.KnownFields(true)
+
+	err = decoder.Decode(&cfg)
+	if err != nil {
+		return cfg, fmt.Errorf("can't parse config file: %w", err)
+	}
+	return cfg, nil
+}
+
+func contractGenerateStorage(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	conf, err := ParseContractConfig(ctx.String("config"))
+	if err != nil {
+		return err
+	}
+	if len(conf.Storage) == 0 {
+		return cli.Exit("no storage layout in the configuration file", 1)
+	}
+	out := ctx.String("out")
+	pkg := ctx.String("package")
+	if pkg == "" {
+		pkg = guessPackageName(filepath.Dir(out))
+	}
+	var buf bytes.Buffer
+	if err := binding.GenerateStorage(pkg, conf.Storage, &buf); err != nil {
+		return cli.Exit(fmt.Errorf("error during generation: %w", err), 1)
+	}
+	if err := os.WriteFile(out, buf.Bytes(), os.ModePerm); err != nil {
+		return cli.Exit(fmt.Errorf("can't write output file: %w", err), 1)
+	}
+	return nil
+}
+
+// guessPackageName returns package name of Go files from the given directory
+// or the directory name if there are none.
+func guessPackageName(dir string) string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") {
+			continue
+		}
+		af, err := parser.ParseFile(token.NewFileSet(), f, nil, parser.PackageClauseOnly)
+		if err == nil {
+			return af.Name.Name
+		}
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, filepath.Base(abs))
+	if !token.IsIdentifier(name) {
+		name = "contract"
+	}
+	return name
+}
@@ -4,11 +4,18 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
 	"testing"
 
+	registry "github.com/nspcc-dev/neo-go/cli/smartcontract/testdata/rpcbindings/storage/rpc"
 	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -423,7 +430,7 @@ func (c *ContractReader) Get() (*big.Int, error) {
 }
 
 // rewriteExpectedOutputs denotes whether expected output files should be rewritten
-// for TestGenerateRPCBindings and TestAssistedRPCBindings.
+// for TestGenerateRPCBindings, TestAssistedRPCBindings and other generator tests.
 const rewriteExpectedOutputs = false
 
 func TestGenerateRPCBindings(t *testing.T) {
@@ -543,6 +550,9 @@ func TestAssistedRPCBindings(t *testing.T) {
 		checkBinding(filepath.Join("testdata", "rpcbindings", "structs"), "", "", hasDefinedHash, false)
 		checkBinding(filepath.Join("testdata", "rpcbindings", "royalty"), "", "", hasDefinedHash, false)
 	}
+	// Dynamic hash storage bindings are compiled and used by TestStorageBindings.
+	checkBinding(filepath.Join("testdata", "rpcbindings", "storage"), "", "", true, false)
+	checkBinding(filepath.Join("testdata", "rpcbindings", "storage"), "", filepath.Join("testdata", "rpcbindings", "storage", "rpc", "registry.go"), false, false)
 	checkBinding(filepath.Join("testdata", "rpcbindings", "notifications"), "", "", true, false)
 	checkBinding(filepath.Join("testdata", "rpcbindings", "notifications"), "", "", true, false, "_extended")
 	checkBinding(filepath.Join("testdata", "rpcbindings", "notifications"), "", "", true, true, "_guessed")
@@ -555,6 +565,177 @@ func TestAssistedRPCBindings(t *testing.T) {
 	require.False(t, rewriteExpectedOutputs)
 }
 
+// chainStorage implements registry.StorageGetter over the blockchain the same
+// way RPC server does.
+type chainStorage struct {
+	bc *core.Blockchain
+}
+
+func (c chainStorage) GetStorageByHash(hash util.Uint160, key []byte) ([]byte, error) {
+	cs := c.bc.GetContractState(hash)
+	if cs == nil {
+		return nil, neorpc.ErrUnknownContract
+	}
+	si := c.bc.GetStorageItem(cs.ID, key)
+	if si == nil {
+		return nil, neorpc.ErrUnknownStorageItem
+	}
+	return bytes.Clone(si), nil
+}
+
+func (c chainStorage) FindStorageByHash(hash util.Uint160, prefix []byte, _ *int) (result.FindStorage, error) {
+	cs := c.bc.GetContractState(hash)
+	if cs == nil {
+		return result.FindStorage{}, neorpc.ErrUnknownContract
+	}
+	var res result.FindStorage
+	c.bc.SeekStorage(cs.ID, prefix, func(k, v []byte) bool {
+		res.Results = append(res.Results, result.KeyValue{
+			Key:   append(bytes.Clone(prefix), k...),
+			Value: bytes.Clone(v),
+		})
+		return true
+	})
+	return res, nil
+}
+
+// TestStorageBindings checks that values stored by the generated contract
+// storage accessors are read back by the generated StorageReader.
+func TestStorageBindings(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	source := filepath.Join("testdata", "rpcbindings", "storage")
+	ctr := neotest.CompileFile(t, e.CommitteeHash, source, filepath.Join(source, "config.yml"))
+	e.DeployContract(t, ctr, nil)
+	inv := e.CommitteeInvoker(ctr.Hash)
+	reader := registry.NewStorageReader(chainStorage{bc}, ctr.Hash)
+
+	owner, err := reader.Owner()
+	require.NoError(t, err)
+	require.Equal(t, e.CommitteeHash, owner)
+
+	t.Run("missing items", func(t *testing.T) {
+		inv.Invoke(t, 0, "count")
+		lastID, err := reader.LastID()
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(0), lastID)
+
+		inv.Invoke(t, 0, "balance", owner)
+		balance, err := reader.Balances(owner)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(0), balance)
+
+		inv.Invoke(t, "", "name", 1)
+		name, err := reader.Names(big.NewInt(1))
+		require.NoError(t, err)
+		require.Equal(t, "", name)
+
+		tags, err := reader.Tags("alice")
+		require.NoError(t, err)
+		require.Nil(t, tags)
+	})
+
+	inv.Invoke(t, 1, "register", "alice", []any{"a", "b"}, 10)
+	inv.Invoke(t, 2, "register", "bob", []any{}, 5)
+
+	t.Run("stored items", func(t *testing.T) {
+		inv.Invoke(t, 2, "count")
+		lastID, err := reader.LastID()
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(2), lastID)
+
+		inv.Invoke(t, 15, "balance", owner)
+		balance, err := reader.Balances(owner)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(15), balance)
+
+		inv.Invoke(t, "alice", "name", 1)
+		name, err := reader.Names(big.NewInt(1))
+		require.NoError(t, err)
+		require.Equal(t, "alice", name)
+
+		tags, err := reader.Tags("alice")
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, tags)
+		tags, err = reader.Tags("bob")
+		require.NoError(t, err)
+		require.Equal(t, []string{}, tags)
+	})
+	t.Run("find", func(t *testing.T) {
+		names, err := reader.FindNames()
+		require.NoError(t, err)
+		require.Equal(t, []registry.NamesEntry{
+			{Key: big.NewInt(1), Value: "alice"},
+			{Key: big.NewInt(2), Value: "bob"},
+		}, names)
+
+		balances, err := reader.FindBalances()
+		require.NoError(t, err)
+		require.Equal(t, []registry.BalancesEntry{{Key: owner, Value: big.NewInt(15)}}, balances)
+	})
+}
+
+func TestGenerateStorage(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmpDir := t.TempDir()
+	source := filepath.Join("testdata", "rpcbindings", "storage")
+	cmd := []string{"neo-go", "contract", "generate-storage"}
+
+	t.Run("missing flags", func(t *testing.T) {
+		e.RunWithErrorCheck(t, `Required flags "config, out" not set`, cmd...)
+	})
+	t.Run("no storage layout", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "no storage layout in the configuration file", append(cmd,
+			"--config", filepath.Join("testdata", "rpcbindings", "types", "config.yml"),
+			"--out", filepath.Join(tmpDir, "layout.go"))...)
+	})
+	t.Run("invalid layout", func(t *testing.T) {
+		cfgPath := filepath.Join(tmpDir, "bad.yml")
+		require.NoError(t, os.WriteFile(cfgPath, []byte(`name: Bad
+storage:
+  - name: a
+    kind: counter
+    prefix: 1
+  - name: b
+    kind: counter
+    prefix: 1
+`), os.ModePerm))
+		e.RunWithErrorCheckExit(t, "storage items a and b have the same prefix 0x01", append(cmd,
+			"--config", cfgPath, "--out", filepath.Join(tmpDir, "layout.go"))...)
+	})
+	t.Run("package from directory", func(t *testing.T) {
+		dir := filepath.Join(tmpDir, "my-store")
+		require.NoError(t, os.Mkdir(dir, os.ModePerm))
+		outFile := filepath.Join(dir, "layout.go")
+		e.Run(t, append(cmd, "--config", filepath.Join(source, "config.yml"), "--out", outFile)...)
+		data, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "\npackage mystore\n")
+
+		e.Run(t, append(cmd, "--config", filepath.Join(source, "config.yml"), "--out", outFile, "--package", "custom")...)
+		data, err = os.ReadFile(outFile)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "\npackage custom\n")
+	})
+
+	outFile := filepath.Join(tmpDir, "layout.go")
+	require.NoError(t, os.WriteFile(outFile, []byte("package registry\n"), os.ModePerm))
+	e.Run(t, append(cmd, "--config", filepath.Join(source, "config.yml"), "--out", outFile)...)
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	data = bytes.ReplaceAll(data, []byte("\r"), []byte{}) // Windows.
+	expectedFile := filepath.Join(source, "layout.go")
+	if rewriteExpectedOutputs {
+		require.NoError(t, os.WriteFile(expectedFile, data, os.ModePerm))
+	} else {
+		expected, err := os.ReadFile(expectedFile)
+		require.NoError(t, err)
+		expected = bytes.ReplaceAll(expected, []byte("\r"), []byte{}) // Windows.
+		require.Equal(t, string(expected), string(data))
+	}
+	require.False(t, rewriteExpectedOutputs)
+}
+
 func TestGenerate_Errors(t *testing.T) {
 	e := testcli.NewExecutor(t, false)
 	args := []string{"neo-go", "contract", "generate-wrapper"}
@@ -563,7 +744,7 @@ func TestGenerate_Errors(t *testing.T) {
 		e.RunWithErrorCheckExit(t, "invalid contract hash", append(args, "--hash", "xxx", "--manifest", "yyy", "--out", "zzz")...)
 	})
 	t.Run("missing manifest argument", func(t *testing.T) {
-		e.RunWithErrorCheck(t, `Required flag "manifest" not set`, append(args, "--hash", util.Uint160{}.StringLE(), "--out", "zzz")...)
+		e.RunWithErrorCheckExit(t, "either --manifest or --rpc-endpoint should be provided", append(args, "--hash", util.Uint160{}.StringLE(), "--out", "zzz")...)
 	})
 	t.Run("missing manifest file", func(t *testing.T) {
 		e.RunWithErrorCheckExit(t, "can't read contract manifest", append(args, "--manifest", "notexists", "--hash", util.Uint160{}.StringLE(), "--out", "zzz")...)
@@ -696,3 +877,164 @@ func TestGenerateRPCBindings_Errors(t *testing.T) {
 		})
 	})
 }
+
+func TestGenerateRPCBindingsLang(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := testcli.NewExecutor(t, false)
+
+	var checkBinding = func(t *testing.T, args []string, lang string, good string) {
+		outFile := filepath.Join(tmpDir, "out")
+		cmd := append([]string{"", "contract", "generate-rpcwrapper",
+			"--lang", lang,
+			"--out", outFile,
+		}, args...)
+		e.Run(t, cmd...)
+
+		data, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		data = bytes.ReplaceAll(data, []byte("\r"), []byte{}) // Windows.
+		if rewriteExpectedOutputs {
+			require.NoError(t, os.WriteFile(good, data, os.ModePerm))
+		} else {
+			expected, err := os.ReadFile(good)
+			require.NoError(t, err)
+			expected = bytes.ReplaceAll(expected, []byte("\r"), []byte{}) // Windows.
+			require.Equal(t, string(expected), string(data))
+		}
+	}
+
+	for lang, ext := range map[string]string{"ts": "ts", "python": "py"} {
+		t.Run(lang, func(t *testing.T) {
+			checkBinding(t, []string{
+				"--manifest", filepath.Join("testdata", "gas", "gas.manifest.json"),
+				"--hash", "0xd2a4cff31913016155e38e474a2c06d08be276cf",
+			}, lang, filepath.Join("testdata", "gas", "gas."+ext))
+
+			for _, source := range []string{"types", "structs", "notifications"} {
+				t.Run(source, func(t *testing.T) {
+					var (
+						dir        = filepath.Join("testdata", "rpcbindings", source)
+						configFile = filepath.Join(dir, "config.yml")
+						manifestF  = filepath.Join(tmpDir, "manifest.json")
+						bindingF   = filepath.Join(tmpDir, "binding.yml")
+					)
+					if source == "notifications" {
+						configFile = filepath.Join(dir, "config_extended.yml")
+					}
+					e.Run(t, "", "contract", "compile",
+						"--in", dir,
+						"--config", configFile,
+						"--manifest", manifestF,
+						"--bindings", bindingF,
+						"--out", filepath.Join(tmpDir, "out.nef"),
+					)
+					checkBinding(t, []string{
+						"--config", bindingF,
+						"--manifest", manifestF,
+						"--hash", "0x00112233445566778899aabbccddeeff00112233",
+					}, lang, filepath.Join(dir, "rpcbindings."+ext+".out"))
+				})
+			}
+		})
+	}
+
+	t.Run("unknown language", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "unknown wrapper language: rust", "", "contract", "generate-rpcwrapper",
+			"--lang", "rust",
+			"--manifest", filepath.Join("testdata", "gas", "gas.manifest.json"),
+			"--out", filepath.Join(tmpDir, "out"))
+	})
+
+	require.False(t, rewriteExpectedOutputs)
+}
+
+func TestGenerateFromRPC(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := testcli.NewExecutor(t, true)
+	endpoint := "http://" + e.RPC.Addresses()[0]
+	nftHash := testcli.DeployContract(t, e, filepath.Join("..", "..", "examples", "nft-nd"), filepath.Join("..", "..", "examples", "nft-nd", "nft.yml"),
+		testcli.ValidatorWallet, testcli.ValidatorAddr, testcli.ValidatorPass)
+	tokenHash := testcli.DeployContract(t, e, filepath.Join("..", "..", "examples", "token"), filepath.Join("..", "..", "examples", "token", "token.yml"),
+		testcli.ValidatorWallet, testcli.ValidatorAddr, testcli.ValidatorPass)
+	out := filepath.Join(tmpDir, "out")
+
+	t.Run("rpcwrapper", func(t *testing.T) {
+		e.Run(t, "", "contract", "generate-rpcwrapper",
+			"--rpc-endpoint", endpoint,
+			"--hash", "0xd2a4cff31913016155e38e474a2c06d08be276cf",
+			"--out", out)
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+		expected, err := os.ReadFile(filepath.Join("testdata", "gas", "gas.go"))
+		require.NoError(t, err)
+		require.Equal(t, string(bytes.ReplaceAll(expected, []byte("\r"), []byte{})), string(data))
+	})
+	t.Run("standard types", func(t *testing.T) {
+		e.Run(t, "", "contract", "generate-rpcwrapper",
+			"--rpc-endpoint", endpoint,
+			"--hash", nftHash.StringLE(),
+			"--lang", "ts",
+			"--out", out)
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "async tokensOf(holder: string, maxItems: number = 100): Promise<Array<Uint8Array>>")
+		require.Contains(t, string(data), "async tokens(maxItems: number = 100): Promise<Array<Uint8Array>>")
+		require.Contains(t, string(data), "async properties(id: Uint8Array): Promise<Map<string, StackItem>>")
+		require.Contains(t, string(data), "Promise<Array<Nep24RoyaltyRecipient | null>>")
+	})
+	t.Run("wrapper", func(t *testing.T) {
+		e.Run(t, "", "contract", "generate-wrapper",
+			"--rpc-endpoint", endpoint,
+			"--hash", nftHash.StringLE(),
+			"--out", out)
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "package hashynft")
+		require.Contains(t, string(data), "func OwnerOf(token []byte) interop.Hash160 {")
+	})
+	t.Run("standard names", func(t *testing.T) {
+		var hashLit string
+		for _, b := range tokenHash.BytesBE() {
+			hashLit += fmt.Sprintf("\\x%02x", b)
+		}
+		for _, tc := range []struct {
+			args     []string
+			expected string
+		}{
+			{[]string{"generate-rpcwrapper", "--lang", "ts"}, "token.ts"},
+			{[]string{"generate-wrapper"}, "token.go"},
+		} {
+			e.Run(t, append(append([]string{"", "contract"}, tc.args...),
+				"--rpc-endpoint", endpoint,
+				"--hash", tokenHash.StringLE(),
+				"--out", out)...)
+			data, err := os.ReadFile(out)
+			require.NoError(t, err)
+			// Contract hash depends on the compiler version.
+			data = bytes.ReplaceAll(data, []byte(tokenHash.StringLE()), []byte("<hash>"))
+			data = bytes.ReplaceAll(data, []byte(hashLit), []byte("<hash>"))
+			expectedFile := filepath.Join("testdata", "deployed", tc.expected)
+			if rewriteExpectedOutputs {
+				require.NoError(t, os.WriteFile(expectedFile, data, os.ModePerm))
+			} else {
+				expected, err := os.ReadFile(expectedFile)
+				require.NoError(t, err)
+				expected = bytes.ReplaceAll(expected, []byte("\r"), []byte{}) // Windows.
+				require.Equal(t, string(expected), string(data))
+			}
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "either --manifest or --rpc-endpoint should be provided, not both", "", "contract", "generate-wrapper",
+			"--rpc-endpoint", endpoint,
+			"--manifest", filepath.Join("testdata", "gas", "gas.manifest.json"),
+			"--out", out)
+		e.RunWithErrorCheckExit(t, "--hash is required to get the manifest from RPC node", "", "contract", "generate-wrapper",
+			"--rpc-endpoint", endpoint,
+			"--out", out)
+		e.RunWithErrorCheckExit(t, "can't get contract manifest", "", "contract", "generate-wrapper",
+			"--rpc-endpoint", endpoint,
+			"--hash", util.Uint160{1, 2, 3}.StringLE(),
+			"--out", out)
+	})
+}
@@ -0,0 +1,226 @@
+package smartcontract
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/urfave/cli/v2"
+)
+
+// Lint output formats.
+const (
+	lintFormatText  = "text"
+	lintFormatJSON  = "json"
+	lintFormatSARIF = "sarif"
+)
+
+var lintCmd = &cli.Command{
+	Name:      "lint",
+	Usage:     "Check smart contract code for common security issues",
+	UsageText: "neo-go contract lint -i path [-c yaml] [--format text|json|sarif]",
+	Description: `Compiles given smart contract and checks it for common NEO-specific
+   pitfalls:
+     missing-witness   public method writes to the storage without
+                       runtime.CheckWitness (or calling script hash) check
+     unchecked-call    contract.Call result is ignored
+     reentrancy        storage is written after an external contract call
+     unbounded-find    loop over storage.Find results can't be stopped
+                       before the iterator is exhausted
+     deploy-update     _deploy doesn't check isUpdate parameter
+     undeclared-event  notification is not declared in the configuration
+                       (checked only if configuration file is provided)
+     safe-write        method marked as safe writes to the storage
+   Issues can be suppressed with '//nolint' or '//nolint:rule1,rule2'
+   comment placed on the same line or in the function documentation (the
+   latter suppresses issues for the whole function). The command exits with
+   non-zero code if any issue is found.
+`,
+	Action: contractLint,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "in",
+			Aliases:  []string{"i"},
+			Required: true,
+			Usage:    "Input file for the smart contract to be checked (*.go file or directory)",
+			Action:   cmdargs.EnsureNotEmpty("in"),
+		},
+		&cli.StringFlag{
+			Name:    "config",
+			Aliases: []string{"c"},
+			Usage:   "Configuration input file (*.yml)",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Value: lintFormatText,
+			Usage: "Output format: text, json or sarif",
+		},
+	},
+}
+
+type (
+	// sarifLog is a minimal SARIF 2.1.0 log representation.
+	sarifLog struct {
+		Version string     `json:"version"`
+		Schema  string     `json:"$schema"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           sarifRegion           `json:"region"`
+	}
+
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn"`
+	}
+)
+
+func contractLint(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	format := ctx.String("format")
+	if format != lintFormatText && format != lintFormatJSON && format != lintFormatSARIF {
+		return cli.Exit(fmt.Errorf("unknown output format: %s", format), 1)
+	}
+	o := &compiler.Options{NoEventsCheck: true}
+	if confFile := ctx.String("config"); len(confFile) != 0 {
+		conf, err := ParseContractConfig(confFile)
+		if err != nil {
+			return err
+		}
+		o.NoEventsCheck = false
+		o.ContractEvents = conf.Events
+		o.SafeMethods = conf.SafeMethods
+		o.Overloads = conf.Overloads
+	}
+	issues, err := compiler.Lint(ctx.String("in"), nil, o)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to compile: %w", err), 1)
+	}
+	if wd, err := os.Getwd(); err == nil {
+		for i := range issues {
+			if rel, err := filepath.Rel(wd, issues[i].File); err == nil {
+				issues[i].File = filepath.ToSlash(rel)
+			}
+		}
+	}
+
+	switch format {
+	case lintFormatText:
+		for _, issue := range issues {
+			fmt.Fprintln(ctx.App.Writer, issue)
+		}
+	case lintFormatJSON:
+		if issues == nil {
+			issues = []compiler.LintIssue{}
+		}
+		err = writeJSONIndent(ctx, issues)
+	case lintFormatSARIF:
+		err = writeJSONIndent(ctx, toSARIF(issues))
+	}
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	if len(issues) != 0 {
+		return cli.Exit(fmt.Errorf("%d issue(s) found", len(issues)), 1)
+	}
+	return nil
+}
+
+func writeJSONIndent(ctx *cli.Context, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(ctx.App.Writer, string(data))
+	return err
+}
+
+// toSARIF converts lint issues to SARIF log.
+func toSARIF(issues []compiler.LintIssue) sarifLog {
+	var rules []sarifRule
+	for _, id := range slices.Sorted(maps.Keys(compiler.LintRules)) {
+		rules = append(rules, sarifRule{
+			ID:               id,
+			ShortDescription: sarifMessage{Text: compiler.LintRules[id]},
+		})
+	}
+	results := make([]sarifResult, 0, len(issues))
+	for _, issue := range issues {
+		results = append(results, sarifResult{
+			RuleID:  issue.Rule,
+			Level:   "warning",
+			Message: sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: issue.File},
+					Region: sarifRegion{
+						StartLine:   issue.Line,
+						StartColumn: issue.Column,
+					},
+				},
+			}},
+		})
+	}
+	return sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs: []sarifRun{{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:           "neo-go",
+					InformationURI: "https://github.com/nspcc-dev/neo-go",
+					Rules:          rules,
+				},
+			},
+			Results: results,
+		}},
+	}
+}
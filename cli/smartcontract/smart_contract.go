@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
@@ -151,7 +153,7 @@ func NewCommands() []*cli.Command {
 			{
 				Name:      "compile",
 				Usage:     "Compile a smart contract to a .nef file",
-				UsageText: "neo-go contract compile -i path [-o nef] [-v] [-d] [-m manifest] [-c yaml] [--bindings file] [--no-standards] [--no-events] [--no-permissions] [--guess-eventtypes]",
+				UsageText: "neo-go contract compile -i path [-o nef] [-v] [-d] [-m manifest] [-c yaml] [--bindings file] [--no-standards] [--no-events] [--no-permissions] [--guess-eventtypes] [--optimize] [--gas-comments]",
 				Description: `Compiles given smart contract to a .nef file and emits other associated
    information (manifest, bindings configuration, debug information files) if
    asked to. If none of --out, --manifest, --config, --bindings flags are specified,
@@ -210,10 +212,18 @@ func NewCommands() []*cli.Command {
 						Name:  "guess-eventtypes",
 						Usage: "Guess event types for smart-contract bindings configuration from the code usages",
 					},
+					&cli.BoolFlag{
+						Name:  "optimize",
+						Usage: "Remove unreachable code and redundant instructions from the resulting script",
+					},
 					&cli.StringFlag{
 						Name:  "bindings",
 						Usage: "Output file for smart-contract bindings configuration",
 					},
+					&cli.BoolFlag{
+						Name:  "gas-comments",
+						Usage: "Add static method execution cost estimations to smart-contract bindings configuration",
+					},
 				},
 			},
 			{
@@ -231,6 +241,10 @@ func NewCommands() []*cli.Command {
 			},
 			generateWrapperCmd,
 			generateRPCWrapperCmd,
+			generateStorageCmd,
+			lintCmd,
+			diffCmd,
+			buildCmd,
 			{
 				Name:      "invokefunction",
 				Usage:     "Invoke deployed contract on the blockchain",
@@ -300,14 +314,25 @@ func NewCommands() []*cli.Command {
 			{
 				Name:      "inspect",
 				Usage:     "Creates a user readable dump of the program instructions",
-				UsageText: "neo-go contract inspect -i file [-c]",
-				Action:    inspect,
+				UsageText: "neo-go contract inspect -i file [-c] [--gas]",
+				Description: `Prints the program instructions. If --gas flag is given, static execution
+   cost estimations of exported methods are printed instead. Every loop body
+   is counted once (even for loops with constant bounds), so the estimation
+   is a lower bound for methods with loops, recursion or calls to other
+   contracts, these are reported as well. Estimations assume the default execution fee factor and
+   require Go source code to be compiled (--compile flag).
+`,
+				Action: inspect,
 				Flags: []cli.Flag{
 					&cli.BoolFlag{
 						Name:    "compile",
 						Aliases: []string{"c"},
 						Usage:   "Compile input file (it should be go code then)",
 					},
+					&cli.BoolFlag{
+						Name:  "gas",
+						Usage: "Print static execution cost estimations of contract methods",
+					},
 					&cli.StringFlag{
 						Name:     "in",
 						Aliases:  []string{"i"},
@@ -486,6 +511,8 @@ func contractCompile(ctx *cli.Context) error {
 		NoPermissionsCheck: ctx.Bool("no-permissions"),
 
 		GuessEventTypes: ctx.Bool("guess-eventtypes"),
+		Optimize:        ctx.Bool("optimize"),
+		GasComments:     ctx.Bool("gas-comments"),
 	}
 
 	if len(confFile) != 0 {
@@ -493,17 +520,7 @@ func contractCompile(ctx *cli.Context) error {
 		if err != nil {
 			return err
 		}
-		o.Name = conf.Name
-		o.SourceURL = conf.SourceURL
-		o.ContractEvents = conf.Events
-		o.DeclaredNamedTypes = conf.NamedTypes
-		o.ContractSupportedStandards = conf.SupportedStandards
-		o.Permissions = make([]manifest.Permission, len(conf.Permissions))
-		for i := range conf.Permissions {
-			o.Permissions[i] = manifest.Permission(conf.Permissions[i])
-		}
-		o.SafeMethods = conf.SafeMethods
-		o.Overloads = conf.Overloads
+		conf.fillOptions(o)
 	}
 
 	result, err := compiler.CompileAndSave(src, o)
@@ -732,6 +749,7 @@ type ProjectConfig struct {
 	Permissions        []permission
 	Overloads          map[string]string               `yaml:"overloads,omitempty"`
 	NamedTypes         map[string]binding.ExtendedType `yaml:"namedtypes,omitempty"`
+	Storage            []binding.StorageItem           `yaml:"storage,omitempty"`
 }
 
 func inspect(ctx *cli.Context) error {
@@ -744,6 +762,17 @@ func inspect(ctx *cli.Context) error {
 		b   []byte
 		err error
 	)
+	if ctx.Bool("gas") {
+		if !compile {
+			return cli.Exit("--gas requires --compile", 1)
+		}
+		_, di, err := compiler.CompileWithOptions(in, nil, nil)
+		if err != nil {
+			return cli.Exit(fmt.Errorf("failed to compile: %w", err), 1)
+		}
+		printGasEstimations(ctx.App.Writer, di)
+		return nil
+	}
 	if compile {
 		b, err = compiler.Compile(in, nil)
 		if err != nil {
@@ -767,6 +796,31 @@ func inspect(ctx *cli.Context) error {
 	return nil
 }
 
+func printGasEstimations(w io.Writer, di *compiler.DebugInfo) {
+	for _, m := range di.Methods {
+		if m.Gas == nil {
+			continue
+		}
+		fmt.Fprintf(w, "%s: %s GAS (opcodes: %s GAS)\n", m.Name.Name, fixedn.Fixed8(m.Gas.Total), fixedn.Fixed8(m.Gas.Opcodes))
+		for _, c := range m.Gas.Calls {
+			fmt.Fprintf(w, "\t%s: %s GAS\n", c.Name, fixedn.Fixed8(c.Price))
+		}
+		for _, l := range m.Gas.Loops {
+			bounds := "constant"
+			if !l.Bounded {
+				bounds = "data-dependent"
+			}
+			fmt.Fprintf(w, "\tloop at %s:%d: %s bounds\n", di.Documents[l.Document], l.Line, bounds)
+		}
+		if m.Gas.Recursive {
+			fmt.Fprintln(w, "\trecursive calls")
+		}
+		if m.Gas.Dynamic {
+			fmt.Fprintln(w, "\tcalls with unknown cost")
+		}
+	}
+}
+
 // contractDeploy deploys contract.
 func contractDeploy(ctx *cli.Context) error {
 	nefFile, f, err := readNEFFile(ctx.String("in"))
@@ -819,6 +873,22 @@ func contractDeploy(ctx *cli.Context) error {
 	return nil
 }
 
+// fillOptions sets manifest-related compiler options from the configuration.
+func (conf ProjectConfig) fillOptions(o *compiler.Options) {
+	o.Name = conf.Name
+	o.SourceURL = conf.SourceURL
+	o.ContractEvents = conf.Events
+	o.DeclaredNamedTypes = conf.NamedTypes
+	o.ContractSupportedStandards = conf.SupportedStandards
+	o.Permissions = make([]manifest.Permission, len(conf.Permissions))
+	for i := range conf.Permissions {
+		o.Permissions[i] = manifest.Permission(conf.Permissions[i])
+	}
+	o.SafeMethods = conf.SafeMethods
+	o.Overloads = conf.Overloads
+	o.StorageLayout = conf.Storage
+}
+
 // ParseContractConfig reads contract configuration file (.yaml) and returns unmarshalled ProjectConfig.
 func ParseContractConfig(confFile string) (ProjectConfig, error) {
 	conf := ProjectConfig{}
@@ -0,0 +1,43 @@
+// Code generated by neo-go contract generate-wrapper --manifest <file.json> --out <file.go> [--hash <hash>] [--config <config>]; DO NOT EDIT.
+
+// Package awesomeneotoken contains wrappers for Awesome NEO Token contract.
+package awesomeneotoken
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+)
+
+// Hash contains contract hash in big-endian form.
+const Hash = "<hash>"
+
+// BalanceOf invokes `balanceOf` method of contract.
+func BalanceOf(account interop.Hash160) int {
+	return neogointernal.CallWithToken(Hash, "balanceOf", int(contract.ReadOnly), account).(int)
+}
+
+// Decimals invokes `decimals` method of contract.
+func Decimals() int {
+	return neogointernal.CallWithToken(Hash, "decimals", int(contract.ReadOnly)).(int)
+}
+
+// Mint invokes `mint` method of contract.
+func Mint(to interop.Hash160) bool {
+	return neogointernal.CallWithToken(Hash, "mint", int(contract.All), to).(bool)
+}
+
+// Symbol invokes `symbol` method of contract.
+func Symbol() string {
+	return neogointernal.CallWithToken(Hash, "symbol", int(contract.ReadOnly)).(string)
+}
+
+// TotalSupply invokes `totalSupply` method of contract.
+func TotalSupply() int {
+	return neogointernal.CallWithToken(Hash, "totalSupply", int(contract.ReadOnly)).(int)
+}
+
+// Transfer invokes `transfer` method of contract.
+func Transfer(from interop.Hash160, to interop.Hash160, amount int, data any) bool {
+	return neogointernal.CallWithToken(Hash, "transfer", int(contract.All), from, to, amount, data).(bool)
+}
@@ -0,0 +1,30 @@
+package store
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+const (
+	prefixValue = 0x01
+	prefixOwner = 0x02
+)
+
+// Get returns the value stored by the key.
+func Get(key []byte) []byte {
+	return storage.Get(storage.GetReadOnlyContext(), append([]byte{prefixValue}, key...)).([]byte)
+}
+
+// Put stores the value by the key.
+func Put(key, value []byte) {
+	if !runtime.CheckWitness(storage.Get(storage.GetReadOnlyContext(), []byte{prefixOwner}).([]byte)) {
+		panic("not an owner")
+	}
+	storage.Put(storage.GetContext(), append([]byte{prefixValue}, key...), value)
+	runtime.Notify("Put", key)
+}
+
+// Delete removes the value stored by the key.
+func Delete(key []byte) {
+	storage.Delete(storage.GetContext(), append([]byte{prefixValue}, key...))
+}
@@ -0,0 +1,30 @@
+package store
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+const (
+	prefixValue = 0x03
+	prefixOwner = 0x02
+)
+
+// Get returns the value stored by the key.
+func Get(key []byte) string {
+	return storage.Get(storage.GetReadOnlyContext(), append([]byte{prefixValue}, key...)).(string)
+}
+
+// Put stores the value by the key.
+func Put(key []byte, value string) {
+	if !runtime.CheckWitness(storage.Get(storage.GetReadOnlyContext(), []byte{prefixOwner}).([]byte)) {
+		panic("not an owner")
+	}
+	storage.Put(storage.GetContext(), append([]byte{prefixValue}, key...), value)
+	runtime.Notify("Stored", key)
+}
+
+// Count returns the number of stored values.
+func Count() int {
+	return 0
+}
@@ -0,0 +1,22 @@
+package lint
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+// Put stores the value without any checks.
+func Put(key, value []byte) {
+	storage.Put(storage.GetContext(), key, value)
+	runtime.Notify("Put", key)
+}
+
+// Get returns the stored value.
+func Get(key []byte) any {
+	return storage.Get(storage.GetReadOnlyContext(), key)
+}
+
+// Delete removes the value, it's intended to be used by anyone.
+func Delete(key []byte) {
+	storage.Delete(storage.GetContext(), key) //nolint:missing-witness
+}
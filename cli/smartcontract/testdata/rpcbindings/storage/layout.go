@@ -0,0 +1,134 @@
+// Code generated by neo-go contract generate-storage --config <file.yml> --out <file.go>; DO NOT EDIT.
+
+package registry
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/convert"
+	"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/std"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+// Storage prefixes declared in the contract storage layout.
+const (
+	PrefixBalances = 0x01
+	PrefixNames    = 0x02
+	PrefixTags     = 0x03
+	PrefixLastID   = 0x04
+	PrefixOwner    = 0x05
+)
+
+// GetBalances returns Balances value for the given key, zero value is
+// returned if there is no such key.
+func GetBalances(ctx storage.Context, key interop.Hash160) int {
+	v := storage.Get(ctx, append([]byte{PrefixBalances}, key...))
+	if v == nil {
+		return 0
+	}
+	return v.(int)
+}
+
+// PutBalances stores Balances value for the given key.
+func PutBalances(ctx storage.Context, key interop.Hash160, value int) {
+	storage.Put(ctx, append([]byte{PrefixBalances}, key...), value)
+}
+
+// DeleteBalances removes Balances value for the given key.
+func DeleteBalances(ctx storage.Context, key interop.Hash160) {
+	storage.Delete(ctx, append([]byte{PrefixBalances}, key...))
+}
+
+// FindBalances returns iterator over all Balances items, keys are returned
+// without prefix.
+func FindBalances(ctx storage.Context) iterator.Iterator {
+	return storage.Find(ctx, []byte{PrefixBalances}, storage.RemovePrefix)
+}
+
+// GetNames returns Names value for the given key, zero value is
+// returned if there is no such key.
+func GetNames(ctx storage.Context, key int) string {
+	v := storage.Get(ctx, append([]byte{PrefixNames}, convert.ToBytes(key)...))
+	if v == nil {
+		return ""
+	}
+	return v.(string)
+}
+
+// PutNames stores Names value for the given key.
+func PutNames(ctx storage.Context, key int, value string) {
+	storage.Put(ctx, append([]byte{PrefixNames}, convert.ToBytes(key)...), value)
+}
+
+// DeleteNames removes Names value for the given key.
+func DeleteNames(ctx storage.Context, key int) {
+	storage.Delete(ctx, append([]byte{PrefixNames}, convert.ToBytes(key)...))
+}
+
+// FindNames returns iterator over all Names items, keys are returned
+// without prefix.
+func FindNames(ctx storage.Context) iterator.Iterator {
+	return storage.Find(ctx, []byte{PrefixNames}, storage.RemovePrefix)
+}
+
+// GetTags returns Tags value for the given key, zero value is
+// returned if there is no such key.
+func GetTags(ctx storage.Context, key string) []any {
+	v := storage.Get(ctx, append([]byte{PrefixTags}, []byte(key)...))
+	if v == nil {
+		return nil
+	}
+	return std.Deserialize(v.([]byte)).([]any)
+}
+
+// PutTags stores Tags value for the given key.
+func PutTags(ctx storage.Context, key string, value []any) {
+	storage.Put(ctx, append([]byte{PrefixTags}, []byte(key)...), std.Serialize(value))
+}
+
+// DeleteTags removes Tags value for the given key.
+func DeleteTags(ctx storage.Context, key string) {
+	storage.Delete(ctx, append([]byte{PrefixTags}, []byte(key)...))
+}
+
+// FindTags returns iterator over all Tags items, keys are returned
+// without prefix, values are deserialized.
+func FindTags(ctx storage.Context) iterator.Iterator {
+	return storage.Find(ctx, []byte{PrefixTags}, storage.RemovePrefix|storage.DeserializeValues)
+}
+
+// GetLastID returns current LastID counter value.
+func GetLastID(ctx storage.Context) int {
+	v := storage.Get(ctx, []byte{PrefixLastID})
+	if v == nil {
+		return 0
+	}
+	return v.(int)
+}
+
+// IncrementLastID increments LastID counter and returns its new value.
+func IncrementLastID(ctx storage.Context) int {
+	v := GetLastID(ctx) + 1
+	storage.Put(ctx, []byte{PrefixLastID}, v)
+	return v
+}
+
+// GetOwner returns Owner value, zero value is returned if it's not
+// stored.
+func GetOwner(ctx storage.Context) interop.Hash160 {
+	v := storage.Get(ctx, []byte{PrefixOwner})
+	if v == nil {
+		return nil
+	}
+	return v.(interop.Hash160)
+}
+
+// PutOwner stores Owner value.
+func PutOwner(ctx storage.Context, value interop.Hash160) {
+	storage.Put(ctx, []byte{PrefixOwner}, value)
+}
+
+// DeleteOwner removes Owner value.
+func DeleteOwner(ctx storage.Context) {
+	storage.Delete(ctx, []byte{PrefixOwner})
+}
@@ -0,0 +1,727 @@
+// Code generated by neo-go contract generate-rpcwrapper --manifest <file.json> --out <file.go> [--hash <hash>] [--config <config>]; DO NOT EDIT.
+
+// Package registry contains RPC wrappers for Registry contract.
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"math/big"
+	"unicode/utf8"
+)
+
+// Invoker is used by ContractReader to call various safe methods.
+type Invoker interface {
+	Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error)
+}
+
+// Actor is used by Contract to call state-changing methods.
+type Actor interface {
+	Invoker
+
+	MakeCall(contract util.Uint160, method string, params ...any) (*transaction.Transaction, error)
+	MakeRun(script []byte) (*transaction.Transaction, error)
+	MakeUnsignedCall(contract util.Uint160, method string, attrs []transaction.Attribute, params ...any) (*transaction.Transaction, error)
+	MakeUnsignedRun(script []byte, attrs []transaction.Attribute) (*transaction.Transaction, error)
+	SendCall(contract util.Uint160, method string, params ...any) (util.Uint256, uint32, error)
+	SendRun(script []byte) (util.Uint256, uint32, error)
+}
+
+// ContractReader implements safe contract methods.
+type ContractReader struct {
+	invoker Invoker
+	hash    util.Uint160
+}
+
+// Contract implements all contract methods.
+type Contract struct {
+	ContractReader
+	actor Actor
+	hash  util.Uint160
+}
+
+// NewReader creates an instance of ContractReader using provided contract hash and the given Invoker.
+func NewReader(invoker Invoker, hash util.Uint160) *ContractReader {
+	return &ContractReader{invoker, hash}
+}
+
+// New creates an instance of Contract using provided contract hash and the given Actor.
+func New(actor Actor, hash util.Uint160) *Contract {
+	return &Contract{ContractReader{actor, hash}, actor, hash}
+}
+
+// Balance invokes `balance` method of contract.
+func (c *ContractReader) Balance(acc util.Uint160) (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "balance", acc))
+}
+
+// Count invokes `count` method of contract.
+func (c *ContractReader) Count() (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "count"))
+}
+
+// Name invokes `name` method of contract.
+func (c *ContractReader) Name(id *big.Int) (string, error) {
+	return unwrap.UTF8String(c.invoker.Call(c.hash, "name", id))
+}
+
+// DeleteBalances creates a transaction invoking `deleteBalances` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) DeleteBalances(ctx any, key util.Uint160) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "deleteBalances", ctx, key)
+}
+
+// DeleteBalancesTransaction creates a transaction invoking `deleteBalances` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) DeleteBalancesTransaction(ctx any, key util.Uint160) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "deleteBalances", ctx, key)
+}
+
+// DeleteBalancesUnsigned creates a transaction invoking `deleteBalances` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) DeleteBalancesUnsigned(ctx any, key util.Uint160) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "deleteBalances", nil, ctx, key)
+}
+
+// DeleteNames creates a transaction invoking `deleteNames` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) DeleteNames(ctx any, key *big.Int) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "deleteNames", ctx, key)
+}
+
+// DeleteNamesTransaction creates a transaction invoking `deleteNames` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) DeleteNamesTransaction(ctx any, key *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "deleteNames", ctx, key)
+}
+
+// DeleteNamesUnsigned creates a transaction invoking `deleteNames` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) DeleteNamesUnsigned(ctx any, key *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "deleteNames", nil, ctx, key)
+}
+
+// DeleteOwner creates a transaction invoking `deleteOwner` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) DeleteOwner(ctx any) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "deleteOwner", ctx)
+}
+
+// DeleteOwnerTransaction creates a transaction invoking `deleteOwner` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) DeleteOwnerTransaction(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "deleteOwner", ctx)
+}
+
+// DeleteOwnerUnsigned creates a transaction invoking `deleteOwner` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) DeleteOwnerUnsigned(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "deleteOwner", nil, ctx)
+}
+
+// DeleteTags creates a transaction invoking `deleteTags` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) DeleteTags(ctx any, key string) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "deleteTags", ctx, key)
+}
+
+// DeleteTagsTransaction creates a transaction invoking `deleteTags` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) DeleteTagsTransaction(ctx any, key string) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "deleteTags", ctx, key)
+}
+
+// DeleteTagsUnsigned creates a transaction invoking `deleteTags` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) DeleteTagsUnsigned(ctx any, key string) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "deleteTags", nil, ctx, key)
+}
+
+// FindBalances creates a transaction invoking `findBalances` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) FindBalances(ctx any) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "findBalances", ctx)
+}
+
+// FindBalancesTransaction creates a transaction invoking `findBalances` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) FindBalancesTransaction(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "findBalances", ctx)
+}
+
+// FindBalancesUnsigned creates a transaction invoking `findBalances` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) FindBalancesUnsigned(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "findBalances", nil, ctx)
+}
+
+// FindNames creates a transaction invoking `findNames` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) FindNames(ctx any) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "findNames", ctx)
+}
+
+// FindNamesTransaction creates a transaction invoking `findNames` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) FindNamesTransaction(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "findNames", ctx)
+}
+
+// FindNamesUnsigned creates a transaction invoking `findNames` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) FindNamesUnsigned(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "findNames", nil, ctx)
+}
+
+// FindTags creates a transaction invoking `findTags` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) FindTags(ctx any) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "findTags", ctx)
+}
+
+// FindTagsTransaction creates a transaction invoking `findTags` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) FindTagsTransaction(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "findTags", ctx)
+}
+
+// FindTagsUnsigned creates a transaction invoking `findTags` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) FindTagsUnsigned(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "findTags", nil, ctx)
+}
+
+// GetBalances creates a transaction invoking `getBalances` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) GetBalances(ctx any, key util.Uint160) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "getBalances", ctx, key)
+}
+
+// GetBalancesTransaction creates a transaction invoking `getBalances` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) GetBalancesTransaction(ctx any, key util.Uint160) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "getBalances", ctx, key)
+}
+
+// GetBalancesUnsigned creates a transaction invoking `getBalances` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) GetBalancesUnsigned(ctx any, key util.Uint160) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "getBalances", nil, ctx, key)
+}
+
+// GetLastID creates a transaction invoking `getLastID` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) GetLastID(ctx any) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "getLastID", ctx)
+}
+
+// GetLastIDTransaction creates a transaction invoking `getLastID` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) GetLastIDTransaction(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "getLastID", ctx)
+}
+
+// GetLastIDUnsigned creates a transaction invoking `getLastID` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) GetLastIDUnsigned(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "getLastID", nil, ctx)
+}
+
+// GetNames creates a transaction invoking `getNames` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) GetNames(ctx any, key *big.Int) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "getNames", ctx, key)
+}
+
+// GetNamesTransaction creates a transaction invoking `getNames` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) GetNamesTransaction(ctx any, key *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "getNames", ctx, key)
+}
+
+// GetNamesUnsigned creates a transaction invoking `getNames` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) GetNamesUnsigned(ctx any, key *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "getNames", nil, ctx, key)
+}
+
+// GetOwner creates a transaction invoking `getOwner` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) GetOwner(ctx any) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "getOwner", ctx)
+}
+
+// GetOwnerTransaction creates a transaction invoking `getOwner` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) GetOwnerTransaction(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "getOwner", ctx)
+}
+
+// GetOwnerUnsigned creates a transaction invoking `getOwner` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) GetOwnerUnsigned(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "getOwner", nil, ctx)
+}
+
+// GetTags creates a transaction invoking `getTags` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) GetTags(ctx any, key string) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "getTags", ctx, key)
+}
+
+// GetTagsTransaction creates a transaction invoking `getTags` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) GetTagsTransaction(ctx any, key string) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "getTags", ctx, key)
+}
+
+// GetTagsUnsigned creates a transaction invoking `getTags` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) GetTagsUnsigned(ctx any, key string) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "getTags", nil, ctx, key)
+}
+
+// IncrementLastID creates a transaction invoking `incrementLastID` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) IncrementLastID(ctx any) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "incrementLastID", ctx)
+}
+
+// IncrementLastIDTransaction creates a transaction invoking `incrementLastID` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) IncrementLastIDTransaction(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "incrementLastID", ctx)
+}
+
+// IncrementLastIDUnsigned creates a transaction invoking `incrementLastID` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) IncrementLastIDUnsigned(ctx any) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "incrementLastID", nil, ctx)
+}
+
+// PutBalances creates a transaction invoking `putBalances` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) PutBalances(ctx any, key util.Uint160, value *big.Int) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "putBalances", ctx, key, value)
+}
+
+// PutBalancesTransaction creates a transaction invoking `putBalances` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) PutBalancesTransaction(ctx any, key util.Uint160, value *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "putBalances", ctx, key, value)
+}
+
+// PutBalancesUnsigned creates a transaction invoking `putBalances` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) PutBalancesUnsigned(ctx any, key util.Uint160, value *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "putBalances", nil, ctx, key, value)
+}
+
+// PutNames creates a transaction invoking `putNames` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) PutNames(ctx any, key *big.Int, value string) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "putNames", ctx, key, value)
+}
+
+// PutNamesTransaction creates a transaction invoking `putNames` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) PutNamesTransaction(ctx any, key *big.Int, value string) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "putNames", ctx, key, value)
+}
+
+// PutNamesUnsigned creates a transaction invoking `putNames` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) PutNamesUnsigned(ctx any, key *big.Int, value string) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "putNames", nil, ctx, key, value)
+}
+
+// PutOwner creates a transaction invoking `putOwner` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) PutOwner(ctx any, value util.Uint160) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "putOwner", ctx, value)
+}
+
+// PutOwnerTransaction creates a transaction invoking `putOwner` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) PutOwnerTransaction(ctx any, value util.Uint160) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "putOwner", ctx, value)
+}
+
+// PutOwnerUnsigned creates a transaction invoking `putOwner` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) PutOwnerUnsigned(ctx any, value util.Uint160) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "putOwner", nil, ctx, value)
+}
+
+// PutTags creates a transaction invoking `putTags` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) PutTags(ctx any, key string, value []any) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "putTags", ctx, key, value)
+}
+
+// PutTagsTransaction creates a transaction invoking `putTags` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) PutTagsTransaction(ctx any, key string, value []any) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "putTags", ctx, key, value)
+}
+
+// PutTagsUnsigned creates a transaction invoking `putTags` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) PutTagsUnsigned(ctx any, key string, value []any) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "putTags", nil, ctx, key, value)
+}
+
+// Register creates a transaction invoking `register` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) Register(name string, tags []any, amount *big.Int) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "register", name, tags, amount)
+}
+
+// RegisterTransaction creates a transaction invoking `register` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) RegisterTransaction(name string, tags []any, amount *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "register", name, tags, amount)
+}
+
+// RegisterUnsigned creates a transaction invoking `register` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) RegisterUnsigned(name string, tags []any, amount *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "register", nil, name, tags, amount)
+}
+
+// StorageGetter is used by StorageReader to get contract storage items.
+type StorageGetter interface {
+	GetStorageByHash(hash util.Uint160, key []byte) ([]byte, error)
+	FindStorageByHash(contractHash util.Uint160, prefix []byte, start *int) (result.FindStorage, error)
+}
+
+// StorageReader implements typed access to the contract storage items
+// declared in the contract storage layout.
+type StorageReader struct {
+	getter StorageGetter
+	hash   util.Uint160
+}
+
+// BalancesEntry is a key-value pair of Balances storage map.
+type BalancesEntry struct {
+	Key   util.Uint160
+	Value *big.Int
+}
+
+// NamesEntry is a key-value pair of Names storage map.
+type NamesEntry struct {
+	Key   *big.Int
+	Value string
+}
+
+// TagsEntry is a key-value pair of Tags storage map.
+type TagsEntry struct {
+	Key   string
+	Value []string
+}
+
+// NewStorageReader creates an instance of StorageReader using provided contract hash and the given StorageGetter.
+func NewStorageReader(getter StorageGetter, hash util.Uint160) *StorageReader {
+	return &StorageReader{getter, hash}
+}
+
+// Balances returns Balances storage map value for the given key, zero value
+// is returned if there is no such key.
+func (s *StorageReader) Balances(key util.Uint160) (*big.Int, error) {
+	b, err := s.getter.GetStorageByHash(s.hash, append([]byte{0x01}, key.BytesBE()...))
+	if err != nil {
+		if errors.Is(err, neorpc.ErrUnknownStorageItem) {
+			return big.NewInt(0), nil
+		}
+		return nil, err
+	}
+	return storageToBalances(b)
+}
+
+// FindBalances returns all Balances storage map items.
+func (s *StorageReader) FindBalances() ([]BalancesEntry, error) {
+	var (
+		res   []BalancesEntry
+		start int
+	)
+	for {
+		page, err := s.getter.FindStorageByHash(s.hash, []byte{0x01}, &start)
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range page.Results {
+			k, err := util.Uint160DecodeBytesBE(kv.Key[1:])
+			if err != nil {
+				return nil, fmt.Errorf("key %x: %w", kv.Key, err)
+			}
+			v, err := storageToBalances(kv.Value)
+			if err != nil {
+				return nil, fmt.Errorf("value of %x: %w", kv.Key, err)
+			}
+			res = append(res, BalancesEntry{Key: k, Value: v})
+		}
+		if !page.Truncated {
+			return res, nil
+		}
+		start = page.Next
+	}
+}
+
+// storageToBalances decodes Balances storage value.
+func storageToBalances(b []byte) (*big.Int, error) {
+	return stackitem.NewByteArray(b).TryInteger()
+}
+
+// Names returns Names storage map value for the given key, zero value
+// is returned if there is no such key.
+func (s *StorageReader) Names(key *big.Int) (string, error) {
+	b, err := s.getter.GetStorageByHash(s.hash, append([]byte{0x02}, bigint.ToBytes(key)...))
+	if err != nil {
+		if errors.Is(err, neorpc.ErrUnknownStorageItem) {
+			return "", nil
+		}
+		return "", err
+	}
+	return storageToNames(b)
+}
+
+// FindNames returns all Names storage map items.
+func (s *StorageReader) FindNames() ([]NamesEntry, error) {
+	var (
+		res   []NamesEntry
+		start int
+	)
+	for {
+		page, err := s.getter.FindStorageByHash(s.hash, []byte{0x02}, &start)
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range page.Results {
+			k, err := bigint.FromBytes(kv.Key[1:]), error(nil)
+			if err != nil {
+				return nil, fmt.Errorf("key %x: %w", kv.Key, err)
+			}
+			v, err := storageToNames(kv.Value)
+			if err != nil {
+				return nil, fmt.Errorf("value of %x: %w", kv.Key, err)
+			}
+			res = append(res, NamesEntry{Key: k, Value: v})
+		}
+		if !page.Truncated {
+			return res, nil
+		}
+		start = page.Next
+	}
+}
+
+// storageToNames decodes Names storage value.
+func storageToNames(b []byte) (string, error) {
+	return func(item stackitem.Item) (string, error) {
+		b, err := item.TryBytes()
+		if err != nil {
+			return "", err
+		}
+		if !utf8.Valid(b) {
+			return "", errors.New("not a UTF-8 string")
+		}
+		return string(b), nil
+	}(stackitem.NewByteArray(b))
+}
+
+// Tags returns Tags storage map value for the given key, zero value
+// is returned if there is no such key.
+func (s *StorageReader) Tags(key string) ([]string, error) {
+	b, err := s.getter.GetStorageByHash(s.hash, append([]byte{0x03}, []byte(key)...))
+	if err != nil {
+		if errors.Is(err, neorpc.ErrUnknownStorageItem) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return storageToTags(b)
+}
+
+// FindTags returns all Tags storage map items.
+func (s *StorageReader) FindTags() ([]TagsEntry, error) {
+	var (
+		res   []TagsEntry
+		start int
+	)
+	for {
+		page, err := s.getter.FindStorageByHash(s.hash, []byte{0x03}, &start)
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range page.Results {
+			k, err := string(kv.Key[1:]), error(nil)
+			if err != nil {
+				return nil, fmt.Errorf("key %x: %w", kv.Key, err)
+			}
+			v, err := storageToTags(kv.Value)
+			if err != nil {
+				return nil, fmt.Errorf("value of %x: %w", kv.Key, err)
+			}
+			res = append(res, TagsEntry{Key: k, Value: v})
+		}
+		if !page.Truncated {
+			return res, nil
+		}
+		start = page.Next
+	}
+}
+
+// storageToTags decodes Tags storage value.
+func storageToTags(b []byte) ([]string, error) {
+	item, err := stackitem.Deserialize(b)
+	if err != nil {
+		return nil, err
+	}
+	return func(item stackitem.Item) ([]string, error) {
+		arr, ok := item.Value().([]stackitem.Item)
+		if !ok {
+			return nil, errors.New("not an array")
+		}
+		res := make([]string, len(arr))
+		for i := range res {
+			res[i], err = func(item stackitem.Item) (string, error) {
+				b, err := item.TryBytes()
+				if err != nil {
+					return "", err
+				}
+				if !utf8.Valid(b) {
+					return "", errors.New("not a UTF-8 string")
+				}
+				return string(b), nil
+			}(arr[i])
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+		return res, nil
+	}(item)
+}
+
+// LastID returns LastID storage counter value, zero value is returned
+// if it's not stored.
+func (s *StorageReader) LastID() (*big.Int, error) {
+	b, err := s.getter.GetStorageByHash(s.hash, []byte{0x04})
+	if err != nil {
+		if errors.Is(err, neorpc.ErrUnknownStorageItem) {
+			return big.NewInt(0), nil
+		}
+		return nil, err
+	}
+	return storageToLastID(b)
+}
+
+// storageToLastID decodes LastID storage value.
+func storageToLastID(b []byte) (*big.Int, error) {
+	return stackitem.NewByteArray(b).TryInteger()
+}
+
+// Owner returns Owner storage singleton value, zero value is returned
+// if it's not stored.
+func (s *StorageReader) Owner() (util.Uint160, error) {
+	b, err := s.getter.GetStorageByHash(s.hash, []byte{0x05})
+	if err != nil {
+		if errors.Is(err, neorpc.ErrUnknownStorageItem) {
+			return util.Uint160{}, nil
+		}
+		return util.Uint160{}, err
+	}
+	return storageToOwner(b)
+}
+
+// storageToOwner decodes Owner storage value.
+func storageToOwner(b []byte) (util.Uint160, error) {
+	return func(item stackitem.Item) (util.Uint160, error) {
+		b, err := item.TryBytes()
+		if err != nil {
+			return util.Uint160{}, err
+		}
+		u, err := util.Uint160DecodeBytesBE(b)
+		if err != nil {
+			return util.Uint160{}, err
+		}
+		return u, nil
+	}(stackitem.NewByteArray(b))
+}
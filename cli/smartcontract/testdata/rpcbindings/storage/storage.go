@@ -0,0 +1,43 @@
+package registry
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+func _deploy(_ any, isUpdate bool) {
+	if isUpdate {
+		return
+	}
+	PutOwner(storage.GetContext(), runtime.GetScriptContainer().Sender)
+}
+
+// Register stores a new name for the owner and returns its ID.
+func Register(name string, tags []any, amount int) int {
+	ctx := storage.GetContext()
+	owner := GetOwner(ctx)
+	if !runtime.CheckWitness(owner) {
+		panic("not an owner")
+	}
+	id := IncrementLastID(ctx)
+	PutNames(ctx, id, name)
+	PutTags(ctx, name, tags)
+	PutBalances(ctx, owner, GetBalances(ctx, owner)+amount)
+	return id
+}
+
+// Balance returns the balance of the account.
+func Balance(acc interop.Hash160) int {
+	return GetBalances(storage.GetReadOnlyContext(), acc)
+}
+
+// Name returns the name registered with the given ID.
+func Name(id int) string {
+	return GetNames(storage.GetReadOnlyContext(), id)
+}
+
+// Count returns the number of registered names.
+func Count() int {
+	return GetLastID(storage.GetReadOnlyContext())
+}
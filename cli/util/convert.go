@@ -63,6 +63,14 @@ func NewCommands() []*cli.Command {
 		txctx.AwaitFlag,
 	}, options.RPC...)
 	txCancelFlags = append(txCancelFlags, options.Wallet...)
+	notaryFinalizeFlags := append([]cli.Flag{
+		&flags.AddressFlag{
+			Name:    "address",
+			Aliases: []string{"a"},
+			Usage:   "Notary node address to sign the transaction with",
+		},
+	}, options.RPC...)
+	notaryFinalizeFlags = append(notaryFinalizeFlags, options.Wallet...)
 	uploadBinFlags := append([]cli.Flag{
 		&cli.StringFlag{
 			Name:   "block-attribute",
@@ -150,6 +158,38 @@ func NewCommands() []*cli.Command {
 					Action: cancelTx,
 					Flags:  txCancelFlags,
 				},
+				{
+					Name:  "notary",
+					Usage: "Inspect and finalize notary requests",
+					Subcommands: []*cli.Command{
+						{
+							Name:      "status",
+							Usage:     "Show the state of notary request",
+							UsageText: "status -r <endpoint> <main-txid>",
+							Description: `Shows the state of notary requests with the given main transaction hash:
+   fallback transactions from the RPC node's notary request pool and (if the
+   node runs Notary service) collected and missing main transaction signatures
+   along with the reason why the main transaction is not sent yet.
+`,
+							Action: notaryStatus,
+							Flags:  options.RPC,
+						},
+						{
+							Name:      "finalize",
+							Usage:     "Finalize notary request manually",
+							UsageText: "finalize -r <endpoint> --wallet <wallet> [--address <account>] [--wallet-config <path>] <main-txid>",
+							Description: `Adds Notary contract witness to the completed main transaction (or to the
+   fallback transaction if its NotValidBefore height is reached) and sends it to
+   the RPC node. The main transaction is taken from the Notary service of the
+   RPC node, the account used must belong to one of the designated notary
+   nodes. This command is intended for debugging, Notary service does the same
+   automatically.
+`,
+							Action: notaryFinalize,
+							Flags:  notaryFinalizeFlags,
+						},
+					},
+				},
 				{
 					Name:      "txdump",
 					Usage:     "Dump transaction stored in file",
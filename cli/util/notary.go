@@ -0,0 +1,153 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/urfave/cli/v2"
+)
+
+func getNotaryMainHash(ctx *cli.Context) (util.Uint256, error) {
+	args := ctx.Args().Slice()
+	if len(args) == 0 {
+		return util.Uint256{}, cli.Exit("main transaction hash is missing", 1)
+	} else if len(args) > 1 {
+		return util.Uint256{}, cli.Exit("only one main transaction hash is accepted", 1)
+	}
+	h, err := util.Uint256DecodeStringLE(strings.TrimPrefix(args[0], "0x"))
+	if err != nil {
+		return util.Uint256{}, cli.Exit(fmt.Sprintf("invalid main transaction hash: %s", args[0]), 1)
+	}
+	return h, nil
+}
+
+func notaryStatus(ctx *cli.Context) error {
+	mainHash, err := getNotaryMainHash(ctx)
+	if err != nil {
+		return err
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+	st, err := c.GetNotaryRequestStatus(mainHash)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to get notary request status: %w", err), 1)
+	}
+	dumpNotaryRequestStatus(ctx, st)
+	return nil
+}
+
+func dumpNotaryRequestStatus(ctx *cli.Context, st *result.NotaryRequestStatus) {
+	w := ctx.App.Writer
+	fmt.Fprintf(w, "Main transaction:\t%s\n", st.Hash.StringLE())
+	fmt.Fprintf(w, "Height:\t\t\t%d\n", st.Height)
+	fmt.Fprintf(w, "Tracked:\t\t%t\n", st.Tracked)
+	if st.Tracked {
+		fmt.Fprintf(w, "NotValidBefore:\t\t%d\n", st.NotValidBefore)
+		fmt.Fprintf(w, "Completed:\t\t%t\n", st.Completed)
+		fmt.Fprintf(w, "Sent:\t\t\t%t\n", st.Sent)
+	}
+	if st.Reason != "" {
+		fmt.Fprintf(w, "Reason:\t\t\t%s\n", st.Reason)
+	}
+	if len(st.Witnesses) != 0 {
+		fmt.Fprintln(w, "Witnesses:")
+		for _, ws := range st.Witnesses {
+			fmt.Fprintf(w, "\t%s (%s): %d signature(s) left\n", address.Uint160ToString(ws.Account), ws.Type, ws.SigsLeft)
+			dumpKeys(ctx, "signed", ws.Signed)
+			dumpKeys(ctx, "missing", ws.Missing)
+		}
+	}
+	fmt.Fprintln(w, "Fallbacks:")
+	for _, fb := range st.Fallbacks {
+		fmt.Fprintf(w, "\t%s: NotValidBefore %d, ValidUntilBlock %d\n", fb.Hash.StringLE(), fb.NotValidBefore, fb.ValidUntilBlock)
+	}
+}
+
+func dumpKeys(ctx *cli.Context, name string, pubs keys.PublicKeys) {
+	for _, pub := range pubs {
+		fmt.Fprintf(ctx.App.Writer, "\t\t%s: %s\n", name, pub.StringCompressed())
+	}
+}
+
+func notaryFinalize(ctx *cli.Context) error {
+	mainHash, err := getNotaryMainHash(ctx)
+	if err != nil {
+		return err
+	}
+
+	acc, w, err := options.GetAccFromContext(ctx)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to get notary node account: %w", err), 1)
+	}
+	defer w.Close()
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+	st, err := c.GetNotaryRequestStatus(mainHash)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to get notary request status: %w", err), 1)
+	}
+	if st.Sent {
+		return cli.Exit("main transaction is already sent", 1)
+	}
+
+	var tx *transaction.Transaction
+	if st.Tracked && st.Completed && st.Height < st.NotValidBefore {
+		tx = st.Main
+	} else {
+		for _, fb := range st.Fallbacks {
+			if fb.NotValidBefore <= st.Height {
+				tx, err = c.GetRawNotaryTransaction(fb.Hash)
+				if err != nil {
+					return cli.Exit(fmt.Errorf("failed to get fallback transaction %s: %w", fb.Hash.StringLE(), err), 1)
+				}
+				break
+			}
+		}
+	}
+	if tx == nil {
+		return cli.Exit(fmt.Errorf("notary request can't be finalized yet: %s", st.Reason), 1)
+	}
+
+	v, err := c.GetVersion()
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to get network magic: %w", err), 1)
+	}
+	var signed bool
+	for i, signer := range tx.Signers {
+		if signer.Account == nativehashes.Notary {
+			tx.Scripts[i] = transaction.Witness{
+				InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, acc.SignHashable(v.Protocol.Network, tx)...),
+				VerificationScript: []byte{},
+			}
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return cli.Exit(fmt.Errorf("transaction %s doesn't have Notary contract signer", tx.Hash().StringLE()), 1)
+	}
+	h, err := c.SendRawTransaction(tx)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to send transaction %s: %w", tx.Hash().StringLE(), err), 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, h.StringLE())
+	return nil
+}
@@ -80,6 +80,12 @@ func uploadState(ctx *cli.Context) error {
 	}
 	stateModule := chain.GetStateModule()
 	currentHeight := int(stateModule.CurrentLocalHeight())
+	signed := !chain.GetConfig().StateRootInHeader
+	if signed {
+		// State roots must be signed by state validators to be verified
+		// without StateRootInHeader, so only validated ones are uploaded.
+		currentHeight = int(stateModule.CurrentValidatedHeight())
+	}
 	currentStateIndex := currentHeight / syncInterval
 	if currentStateIndex < stateObjCount {
 		log.Info("no new states to upload",
@@ -101,6 +107,9 @@ func uploadState(ctx *cli.Context) error {
 		if err != nil {
 			return cli.Exit(fmt.Sprintf("failed to get state root for height %d: %v", height, err), 1)
 		}
+		if signed && len(stateRoot.Witness) == 0 {
+			return cli.Exit(fmt.Sprintf("state root for height %d is not signed", height), 1)
+		}
 		h, err := chain.GetHeader(chain.GetHeaderHash(height))
 		if err != nil {
 			return cli.Exit(fmt.Sprintf("failed to get header %d: %v", height, err), 1)
@@ -127,10 +136,17 @@ func uploadState(ctx *cli.Context) error {
 			}
 			start := time.Now()
 			wrt := gio.NewBinWriterFromIO(writer)
-			wrt.WriteB(byte(0))
+			if signed {
+				wrt.WriteB(byte(1))
+			} else {
+				wrt.WriteB(byte(0))
+			}
 			wrt.WriteU32LE(uint32(chain.GetConfig().Magic))
 			wrt.WriteU32LE(height)
 			wrt.WriteBytes(stateRoot.Root[:])
+			if signed {
+				stateRoot.EncodeBinary(wrt)
+			}
 			err = traverseMPT(stateRoot.Root, stateModule, wrt)
 			if err != nil {
 				_ = writer.Close()
@@ -140,6 +140,27 @@ func TestUtilCancelTx(t *testing.T) {
 	}, time.Second*2, time.Millisecond*50)
 }
 
+func TestUtilNotary(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	rpc := "http://" + e.RPC.Addresses()[0]
+	unknown := util.Uint256{1, 2, 3}.StringLE()
+
+	t.Run("status", func(t *testing.T) {
+		args := []string{"neo-go", "util", "notary", "status", "-r", rpc}
+		e.RunWithErrorCheckExit(t, "main transaction hash is missing", args...)
+		e.RunWithErrorCheckExit(t, "only one main transaction hash is accepted", append(args, unknown, unknown)...)
+		e.RunWithErrorCheckExit(t, "invalid main transaction hash: notahash", append(args, "notahash")...)
+		e.RunWithErrorCheckExit(t, "failed to get notary request status: Unknown transaction", append(args, unknown)...)
+	})
+	t.Run("finalize", func(t *testing.T) {
+		args := []string{"neo-go", "util", "notary", "finalize", "-r", rpc,
+			"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr}
+		e.RunWithErrorCheckExit(t, "main transaction hash is missing", args...)
+		e.In.WriteString("one\r")
+		e.RunWithErrorCheckExit(t, "failed to get notary request status: Unknown transaction", append(args, unknown)...)
+	})
+}
+
 func TestAwaitUtilCancelTx(t *testing.T) {
 	e := testcli.NewExecutor(t, true)
 
@@ -0,0 +1,416 @@
+package wallet
+
+import (
+	"crypto/elliptic"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/txctx"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	gio "github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli/v2"
+)
+
+// migrationNFT is a single NEP-11 token to be moved during migration.
+type migrationNFT struct {
+	Asset     util.Uint160
+	Symbol    string
+	Divisible bool
+	ID        []byte
+	Amount    *big.Int
+}
+
+// migrationPlan contains everything that can be moved from one account to
+// another along with the list of things that can't be moved automatically.
+type migrationPlan struct {
+	From util.Uint160
+	To   util.Uint160
+
+	NEP17 []result.NEP17Balance
+	NEP11 []migrationNFT
+	NEO   *big.Int
+
+	// Vote is the key the new account should vote for, nil if no vote is to be
+	// re-cast.
+	Vote *keys.PublicKey
+	// OldCandidate and NewCandidate are set when candidate registration should
+	// be moved from one key to another.
+	OldCandidate *keys.PublicKey
+	NewCandidate *keys.PublicKey
+
+	// NotMoved contains human-readable descriptions of things that should be
+	// handled manually.
+	NotMoved []string
+}
+
+// errNothingToMigrate is returned when there is nothing to move from the source
+// account.
+var errNothingToMigrate = errors.New("nothing to migrate")
+
+func newMigrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "migrate",
+		Usage:     "Move all assets, votes and candidate registration to another account",
+		UsageText: "neo-go wallet migrate -w wallet [--wallet-config path] --rpc-endpoint <node> [--timeout <time>] --from <addr> --to <addr> [-g gas] [-e sysgas] [--out file] [--force] [--await]",
+		Description: `Moves everything that can be moved from one account to another in a single
+   transaction, it's mostly useful for key rotation when the old key may be
+   compromised. NEP-17 and NEP-11 balances are enumerated via getnep17balances
+   and getnep11balances RPC calls, NEO is transferred (which claims unspent
+   GAS) and then all of the remaining GAS is transferred (fees are paid from
+   the old account). If the old account has voted, the vote is re-cast from
+   the new account. If the old account's key is a registered candidate, it's
+   unregistered and the new account's key is registered instead (this requires
+   the new account to be present in the wallet, registration price is paid from
+   the old account's GAS). Everything that can't be moved automatically (like
+   contract-specific permissions) is reported.
+`,
+		Action: migrateAccount,
+		Flags: append([]cli.Flag{
+			walletPathFlag,
+			walletConfigFlag,
+			txctx.GasFlag,
+			txctx.SysGasFlag,
+			txctx.OutFlag,
+			txctx.ForceFlag,
+			txctx.AwaitFlag,
+			&flags.AddressFlag{
+				Name:     "from",
+				Required: true,
+				Usage:    "Address to migrate from",
+			},
+			&flags.AddressFlag{
+				Name:     "to",
+				Required: true,
+				Usage:    "Address to migrate to",
+			},
+		}, options.RPC...),
+	}
+}
+
+func migrateAccount(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wall, pass, err := readWallet(ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+
+	from := ctx.Generic("from").(*flags.Address).Uint160()
+	to := ctx.Generic("to").(*flags.Address).Uint160()
+	if from.Equals(to) {
+		return cli.Exit("source and destination accounts are the same", 1)
+	}
+	acc, err := options.GetUnlockedAccount(wall, from, pass)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, err := options.GetRPCClient(gctx, ctx)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	toAcc := wall.GetAccount(to)
+	plan, err := newMigrationPlan(c, invoker.New(c, nil), acc, toAcc, to)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	signers := []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: from,
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: acc,
+	}}
+	if plan.Vote != nil || plan.NewCandidate != nil {
+		toAcc, err = options.GetUnlockedAccount(wall, to, pass)
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+		signers = append(signers, actor.SignerAccount{
+			Signer: transaction.Signer{
+				Account: to,
+				Scopes:  transaction.CalledByEntry,
+			},
+			Account: toAcc,
+		})
+	}
+	act, err := actor.New(c, signers)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("failed to create Actor: %w", err), 1)
+	}
+
+	printMigrationPlan(ctx.App.Writer, plan)
+	tx, err := makeMigrationTx(act, plan)
+	if err != nil {
+		return cli.Exit(fmt.Errorf("can't make transaction: %w", err), 1)
+	}
+	return txctx.SignAndSend(ctx, act, acc, tx)
+}
+
+// newMigrationPlan collects balances and voting data for the source account.
+// toAcc is the destination account from the wallet, it can be nil if it's not
+// there.
+func newMigrationPlan(c balanceGetter, inv neo.Invoker, fromAcc *wallet.Account, toAcc *wallet.Account, to util.Uint160) (*migrationPlan, error) {
+	var (
+		from = fromAcc.ScriptHash()
+		plan = &migrationPlan{
+			From: from,
+			To:   to,
+		}
+		hasGAS bool
+	)
+
+	b17, err := c.GetNEP17Balances(from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NEP-17 balances: %w", err)
+	}
+	for _, b := range b17.Balances {
+		amount, ok := new(big.Int).SetString(b.Amount, 10)
+		if !ok {
+			plan.NotMoved = append(plan.NotMoved, fmt.Sprintf("%s (%s): invalid balance %q", b.Symbol, b.Asset.StringLE(), b.Amount))
+			continue
+		}
+		if amount.Sign() <= 0 {
+			continue
+		}
+		switch {
+		case b.Asset.Equals(gas.Hash):
+			hasGAS = true
+		case b.Asset.Equals(neo.Hash):
+			plan.NEO = amount
+		default:
+			plan.NEP17 = append(plan.NEP17, b)
+		}
+	}
+
+	b11, err := c.GetNEP11Balances(from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NEP-11 balances: %w", err)
+	}
+	for _, b := range b11.Balances {
+		for _, t := range b.Tokens {
+			id, err := hex.DecodeString(t.ID)
+			if err != nil {
+				plan.NotMoved = append(plan.NotMoved, fmt.Sprintf("%s (%s) token %s: invalid token ID", b.Symbol, b.Asset.StringLE(), t.ID))
+				continue
+			}
+			amount, ok := new(big.Int).SetString(t.Amount, 10)
+			if !ok || amount.Sign() <= 0 {
+				continue
+			}
+			plan.NEP11 = append(plan.NEP11, migrationNFT{
+				Asset:     b.Asset,
+				Symbol:    b.Symbol,
+				Divisible: b.Decimals != 0,
+				ID:        id,
+				Amount:    amount,
+			})
+		}
+	}
+
+	nr := neo.NewReader(inv)
+	if plan.NEO != nil {
+		st, err := nr.GetAccountState(from)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get NEO account state: %w", err)
+		}
+		if st != nil && st.VoteTo != nil {
+			plan.Vote = st.VoteTo
+		}
+	}
+
+	if pb, ok := vm.ParseSignatureContract(fromAcc.Contract.Script); ok {
+		pub, err := keys.NewPublicKeyFromBytes(pb, elliptic.P256())
+		if err != nil {
+			return nil, fmt.Errorf("invalid source account key: %w", err)
+		}
+		votes, err := unwrap.BigInt(inv.Call(neo.Hash, "getCandidateVote", pub))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check candidate registration: %w", err)
+		}
+		if votes.Sign() >= 0 {
+			var (
+				selfVote = plan.Vote != nil && plan.Vote.Equal(pub)
+				toPub    []byte
+			)
+			if toAcc != nil {
+				toPub, ok = vm.ParseSignatureContract(toAcc.Contract.Script)
+			}
+			if toPub != nil && ok {
+				plan.OldCandidate = pub
+				plan.NewCandidate, err = keys.NewPublicKeyFromBytes(toPub, elliptic.P256())
+				if err != nil {
+					return nil, fmt.Errorf("invalid destination account key: %w", err)
+				}
+				if selfVote {
+					plan.Vote = plan.NewCandidate
+				}
+			} else {
+				plan.NotMoved = append(plan.NotMoved, fmt.Sprintf("candidate registration of %s: destination account is not a simple signature account in the wallet", pub.StringCompressed()))
+			}
+			if selfVote {
+				votes.Sub(votes, plan.NEO) // Own votes are handled separately.
+			}
+			if votes.Sign() > 0 {
+				plan.NotMoved = append(plan.NotMoved, fmt.Sprintf("votes cast for candidate %s by other accounts", pub.StringCompressed()))
+			}
+		}
+	}
+	if plan.Vote != nil && toAcc == nil {
+		plan.NotMoved = append(plan.NotMoved, fmt.Sprintf("vote for %s: destination account is not in the wallet", plan.Vote.StringCompressed()))
+		plan.Vote = nil
+	}
+
+	if !hasGAS && plan.NEO == nil && len(plan.NEP17) == 0 && len(plan.NEP11) == 0 && plan.OldCandidate == nil {
+		return nil, errNothingToMigrate
+	}
+	plan.NotMoved = append(plan.NotMoved, "contract-specific permissions (like ContractManagement update/destroy rights or role designations) must be transferred by contract owners")
+	return plan, nil
+}
+
+// balanceGetter is the subset of RPC client methods used to build migration plan.
+type balanceGetter interface {
+	GetNEP11Balances(address util.Uint160) (*result.NEP11Balances, error)
+	GetNEP17Balances(address util.Uint160) (*result.NEP17Balances, error)
+}
+
+// migrationScript creates a script moving everything from the plan. Test
+// script can be produced for fee estimation, it doesn't register the new
+// candidate (registration price is usually higher than what RPC servers allow
+// to spend in test invocations) and doesn't vote for it.
+func migrationScript(plan *migrationPlan, test bool) ([]byte, error) {
+	var w = gio.NewBufBinWriter()
+
+	for _, t := range plan.NEP11 {
+		if t.Divisible {
+			emit.AppCall(w.BinWriter, t.Asset, "transfer", callflag.All, plan.From, plan.To, t.Amount, t.ID, nil)
+		} else {
+			emit.AppCall(w.BinWriter, t.Asset, "transfer", callflag.All, plan.To, t.ID, nil)
+		}
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	}
+	for _, b := range plan.NEP17 {
+		amount, _ := new(big.Int).SetString(b.Amount, 10)
+		emit.AppCall(w.BinWriter, b.Asset, "transfer", callflag.All, plan.From, plan.To, amount, nil)
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	}
+	if plan.NEO != nil {
+		// This transfer also claims unspent GAS.
+		emit.AppCall(w.BinWriter, neo.Hash, "transfer", callflag.All, plan.From, plan.To, plan.NEO, nil)
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	}
+	if plan.OldCandidate != nil {
+		emit.AppCall(w.BinWriter, neo.Hash, "unregisterCandidate", callflag.All, plan.OldCandidate.Bytes())
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+		// It's an unregister call intentionally for test script, see
+		// neo.Contract.RegisterCandidateUnsigned.
+		var method = "registerCandidate"
+		if test {
+			method = "unregisterCandidate"
+		}
+		emit.AppCall(w.BinWriter, neo.Hash, method, callflag.All, plan.NewCandidate.Bytes())
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	}
+	if plan.Vote != nil {
+		var voteTo any = plan.Vote.Bytes()
+		if test && plan.NewCandidate != nil && plan.Vote.Equal(plan.NewCandidate) {
+			voteTo = nil // Not registered in test script, so unvote (same price).
+		}
+		emit.AppCall(w.BinWriter, neo.Hash, "vote", callflag.All, plan.To, voteTo)
+		emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	}
+	// GAS goes last, the amount is the balance at the moment of execution
+	// which excludes transaction fees and includes claimed GAS.
+	emit.Opcodes(w.BinWriter, opcode.PUSHNULL)
+	emit.AppCall(w.BinWriter, gas.Hash, "balanceOf", callflag.ReadStates, plan.From)
+	emit.Bytes(w.BinWriter, plan.To.BytesBE())
+	emit.Bytes(w.BinWriter, plan.From.BytesBE())
+	emit.Int(w.BinWriter, 4)
+	emit.Opcodes(w.BinWriter, opcode.PACK)
+	emit.AppCallNoArgs(w.BinWriter, gas.Hash, "transfer", callflag.All)
+	emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	return w.Bytes(), nil
+}
+
+func makeMigrationTx(act *actor.Actor, plan *migrationPlan) (*transaction.Transaction, error) {
+	script, err := migrationScript(plan, false)
+	if err != nil {
+		return nil, err
+	}
+	if plan.NewCandidate == nil {
+		return act.MakeUnsignedRun(script, nil)
+	}
+	testScript, err := migrationScript(plan, true)
+	if err != nil {
+		return nil, err
+	}
+	r, err := act.Run(testScript)
+	if err != nil {
+		return nil, err
+	}
+	if err = actor.DefaultCheckerModifier(r, nil); err != nil {
+		return nil, err
+	}
+	regPrice, err := neo.NewReader(act).GetRegisterPrice()
+	if err != nil {
+		return nil, err
+	}
+	return act.MakeUnsignedUncheckedRun(script, r.GasConsumed+regPrice, nil)
+}
+
+func printMigrationPlan(w io.Writer, plan *migrationPlan) {
+	fmt.Fprintf(w, "Migrating %s to %s\n", address.Uint160ToString(plan.From), address.Uint160ToString(plan.To))
+	for _, t := range plan.NEP11 {
+		fmt.Fprintf(w, "NEP-11 %s (%s): %x", t.Symbol, t.Asset.StringLE(), t.ID)
+		if t.Divisible {
+			fmt.Fprintf(w, " (%s)", t.Amount)
+		}
+		fmt.Fprintln(w)
+	}
+	for _, b := range plan.NEP17 {
+		fmt.Fprintf(w, "NEP-17 %s (%s): %s\n", b.Symbol, b.Asset.StringLE(), decimalAmount(b.Amount, b.Decimals))
+	}
+	if plan.NEO != nil {
+		fmt.Fprintf(w, "NEO: %s\n", plan.NEO)
+	}
+	fmt.Fprintln(w, "GAS: all remaining")
+	if plan.OldCandidate != nil {
+		fmt.Fprintf(w, "Candidate: %s -> %s\n", plan.OldCandidate.StringCompressed(), plan.NewCandidate.StringCompressed())
+	}
+	if plan.Vote != nil {
+		fmt.Fprintf(w, "Vote: %s\n", plan.Vote.StringCompressed())
+	}
+	for _, s := range plan.NotMoved {
+		fmt.Fprintf(w, "Not moved: %s\n", s)
+	}
+}
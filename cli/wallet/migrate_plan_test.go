@@ -0,0 +1,209 @@
+package wallet
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+type testMigrationChain struct {
+	nep11 result.NEP11Balances
+	nep17 result.NEP17Balances
+
+	// accountState is returned for getAccountState, candidateVotes for
+	// getCandidateVote.
+	accountState   stackitem.Item
+	candidateVotes int64
+}
+
+func (c *testMigrationChain) GetNEP11Balances(util.Uint160) (*result.NEP11Balances, error) {
+	return &c.nep11, nil
+}
+
+func (c *testMigrationChain) GetNEP17Balances(util.Uint160) (*result.NEP17Balances, error) {
+	return &c.nep17, nil
+}
+
+func (c *testMigrationChain) Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error) {
+	if !contract.Equals(neo.Hash) {
+		return nil, errors.New("unexpected contract")
+	}
+	var item stackitem.Item
+	switch operation {
+	case "getAccountState":
+		item = c.accountState
+	case "getCandidateVote":
+		item = stackitem.Make(c.candidateVotes)
+	default:
+		return nil, errors.New("unexpected method")
+	}
+	return &result.Invoke{
+		State: vmstate.Halt.String(),
+		Stack: []stackitem.Item{item},
+	}, nil
+}
+
+func (c *testMigrationChain) CallAndExpandIterator(util.Uint160, string, int, ...any) (*result.Invoke, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *testMigrationChain) TerminateSession(uuid.UUID) error {
+	return errors.New("not implemented")
+}
+
+func (c *testMigrationChain) TraverseIterator(uuid.UUID, *result.Iterator, int) ([]stackitem.Item, error) {
+	return nil, errors.New("not implemented")
+}
+
+func newTestMigrationAccount(t *testing.T) (*wallet.Account, *keys.PublicKey) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	return wallet.NewAccountFromPrivateKey(priv), priv.PublicKey()
+}
+
+func TestMigrationPlan(t *testing.T) {
+	var (
+		fromAcc, fromPub = newTestMigrationAccount(t)
+		toAcc, toPub     = newTestMigrationAccount(t)
+		from             = fromAcc.ScriptHash()
+		to               = toAcc.ScriptHash()
+		token            = util.Uint160{1, 2, 3}
+		nft              = util.Uint160{4, 5, 6}
+		dnft             = util.Uint160{7, 8, 9}
+	)
+	c := &testMigrationChain{
+		nep17: result.NEP17Balances{Balances: []result.NEP17Balance{
+			{Asset: gas.Hash, Amount: "100500", Symbol: "GAS"},
+			{Asset: neo.Hash, Amount: "10", Symbol: "NEO"},
+			{Asset: token, Amount: "42", Symbol: "TKN", Decimals: 2},
+			{Asset: util.Uint160{0xff}, Amount: "0", Symbol: "ZERO"},
+		}},
+		nep11: result.NEP11Balances{Balances: []result.NEP11AssetBalance{
+			{Asset: nft, Symbol: "NFT", Tokens: []result.NEP11TokenBalance{
+				{ID: "0102", Amount: "1"},
+			}},
+			{Asset: dnft, Symbol: "DNFT", Decimals: 2, Tokens: []result.NEP11TokenBalance{
+				{ID: "03", Amount: "50"},
+			}},
+		}},
+		// The account votes for its own key.
+		accountState: stackitem.NewStruct([]stackitem.Item{
+			stackitem.Make(10),
+			stackitem.Make(1),
+			stackitem.Make(fromPub.Bytes()),
+			stackitem.Make(0),
+		}),
+		candidateVotes: 15,
+	}
+
+	t.Run("nothing", func(t *testing.T) {
+		empty := &testMigrationChain{candidateVotes: -1}
+		_, err := newMigrationPlan(empty, empty, fromAcc, toAcc, to)
+		require.ErrorIs(t, err, errNothingToMigrate)
+	})
+	t.Run("destination not in wallet", func(t *testing.T) {
+		plan, err := newMigrationPlan(c, c, fromAcc, nil, to)
+		require.NoError(t, err)
+		require.Nil(t, plan.Vote)
+		require.Nil(t, plan.OldCandidate)
+		require.Nil(t, plan.NewCandidate)
+		require.Len(t, plan.NotMoved, 4) // Registration, other votes, own vote, permissions.
+	})
+	t.Run("full", func(t *testing.T) {
+		plan, err := newMigrationPlan(c, c, fromAcc, toAcc, to)
+		require.NoError(t, err)
+		require.Equal(t, from, plan.From)
+		require.Equal(t, to, plan.To)
+		require.Equal(t, []result.NEP17Balance{c.nep17.Balances[2]}, plan.NEP17)
+		require.Equal(t, []migrationNFT{
+			{Asset: nft, Symbol: "NFT", ID: []byte{1, 2}, Amount: big.NewInt(1)},
+			{Asset: dnft, Symbol: "DNFT", Divisible: true, ID: []byte{3}, Amount: big.NewInt(50)},
+		}, plan.NEP11)
+		require.Equal(t, big.NewInt(10), plan.NEO)
+		require.Equal(t, fromPub, plan.OldCandidate)
+		require.Equal(t, toPub, plan.NewCandidate)
+		require.Equal(t, toPub, plan.Vote) // Self-vote is moved to the new key.
+		require.Len(t, plan.NotMoved, 2)   // Votes of other accounts and permissions.
+
+		expected := func(test bool) []byte {
+			var (
+				w          = io.NewBufBinWriter()
+				reg        = "registerCandidate"
+				voteTo any = toPub.Bytes()
+			)
+			if test {
+				reg = "unregisterCandidate"
+				voteTo = nil
+			}
+			emit.AppCall(w.BinWriter, nft, "transfer", callflag.All, to, []byte{1, 2}, nil)
+			emit.Opcodes(w.BinWriter, opcode.ASSERT)
+			emit.AppCall(w.BinWriter, dnft, "transfer", callflag.All, from, to, 50, []byte{3}, nil)
+			emit.Opcodes(w.BinWriter, opcode.ASSERT)
+			emit.AppCall(w.BinWriter, token, "transfer", callflag.All, from, to, 42, nil)
+			emit.Opcodes(w.BinWriter, opcode.ASSERT)
+			emit.AppCall(w.BinWriter, neo.Hash, "transfer", callflag.All, from, to, 10, nil)
+			emit.Opcodes(w.BinWriter, opcode.ASSERT)
+			emit.AppCall(w.BinWriter, neo.Hash, "unregisterCandidate", callflag.All, fromPub.Bytes())
+			emit.Opcodes(w.BinWriter, opcode.ASSERT)
+			emit.AppCall(w.BinWriter, neo.Hash, reg, callflag.All, toPub.Bytes())
+			emit.Opcodes(w.BinWriter, opcode.ASSERT)
+			emit.AppCall(w.BinWriter, neo.Hash, "vote", callflag.All, to, voteTo)
+			emit.Opcodes(w.BinWriter, opcode.ASSERT)
+			emit.Opcodes(w.BinWriter, opcode.PUSHNULL)
+			emit.AppCall(w.BinWriter, gas.Hash, "balanceOf", callflag.ReadStates, from)
+			emit.Bytes(w.BinWriter, to.BytesBE())
+			emit.Bytes(w.BinWriter, from.BytesBE())
+			emit.Int(w.BinWriter, 4)
+			emit.Opcodes(w.BinWriter, opcode.PACK)
+			emit.AppCallNoArgs(w.BinWriter, gas.Hash, "transfer", callflag.All)
+			emit.Opcodes(w.BinWriter, opcode.ASSERT)
+			require.NoError(t, w.Err)
+			return w.Bytes()
+		}
+		for _, test := range []bool{false, true} {
+			script, err := migrationScript(plan, test)
+			require.NoError(t, err)
+			require.Equal(t, expected(test), script, "test: %t", test)
+		}
+	})
+	t.Run("vote for other candidate", func(t *testing.T) {
+		_, otherPub := newTestMigrationAccount(t)
+		c := *c
+		c.accountState = stackitem.NewStruct([]stackitem.Item{
+			stackitem.Make(10),
+			stackitem.Make(1),
+			stackitem.Make(otherPub.Bytes()),
+			stackitem.Make(0),
+		})
+		c.candidateVotes = -1 // Not a candidate.
+		plan, err := newMigrationPlan(&c, &c, fromAcc, toAcc, to)
+		require.NoError(t, err)
+		require.Nil(t, plan.OldCandidate)
+		require.Nil(t, plan.NewCandidate)
+		require.Equal(t, otherPub, plan.Vote)
+		require.Len(t, plan.NotMoved, 1)
+
+		for _, test := range []bool{false, true} {
+			script, err := migrationScript(plan, test)
+			require.NoError(t, err)
+			w := io.NewBufBinWriter()
+			emit.AppCall(w.BinWriter, neo.Hash, "vote", callflag.All, to, otherPub.Bytes())
+			require.Contains(t, string(script), string(w.Bytes()), "test: %t", test)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package wallet_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletMigrate(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+
+	validatorAddress := testcli.ValidatorPriv.Address()
+	validatorHex := testcli.ValidatorPriv.PublicKey().StringCompressed()
+	to, err := address.StringToUint160(testcli.TestWalletAccount)
+	require.NoError(t, err)
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "multitransfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet,
+		"--from", testcli.ValidatorAddr,
+		"--force",
+		"NEO:"+validatorAddress+":10",
+		"GAS:"+validatorAddress+":10000")
+	e.CheckTxPersisted(t)
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "candidate", "register",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet,
+		"--address", validatorAddress,
+		"--force")
+	e.CheckTxPersisted(t)
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "candidate", "vote",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet,
+		"--address", validatorAddress,
+		"--candidate", validatorHex,
+		"--force")
+	e.CheckTxPersisted(t)
+
+	t.Run("same address", func(t *testing.T) {
+		e.RunWithErrorCheckExit(t, "source and destination accounts are the same", "neo-go", "wallet", "migrate",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			"--wallet", testcli.ValidatorWallet,
+			"--from", validatorAddress,
+			"--to", validatorAddress)
+	})
+
+	t.Run("destination in wallet", func(t *testing.T) {
+		tmp := t.TempDir()
+		walletPath := filepath.Join(tmp, "wallet.json")
+		txPath := filepath.Join(tmp, "migrate.json")
+		data, err := os.ReadFile(testcli.ValidatorWallet)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(walletPath, data, 0o644))
+
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		newPub := priv.PublicKey()
+		newHash := priv.GetScriptHash()
+		e.In.WriteString("new\r")
+		e.In.WriteString("one\r")
+		e.In.WriteString("one\r")
+		e.Run(t, "neo-go", "wallet", "import", "--wallet", walletPath, "--wif", priv.WIF())
+
+		e.In.WriteString("one\r")
+		e.In.WriteString("one\r")
+		e.Run(t, "neo-go", "wallet", "migrate",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			"--wallet", walletPath,
+			"--from", validatorAddress,
+			"--to", priv.Address(),
+			"--out", txPath)
+		e.CheckNextLine(t, "^Migrating "+validatorAddress+" to "+priv.Address()+"$")
+		e.CheckNextLine(t, "^NEO: 10$")
+		e.CheckNextLine(t, "^GAS: all remaining$")
+		e.CheckNextLine(t, "^Candidate: "+validatorHex+" -> "+newPub.StringCompressed()+"$")
+		e.CheckNextLine(t, "^Vote: "+newPub.StringCompressed()+"$")
+		e.CheckNextLine(t, "^Not moved: contract-specific permissions")
+
+		data, err = os.ReadFile(txPath)
+		require.NoError(t, err)
+		pc := new(context.ParameterContext)
+		require.NoError(t, json.Unmarshal(data, pc))
+		tx, ok := pc.Verifiable.(*transaction.Transaction)
+		require.True(t, ok)
+		require.Equal(t, []transaction.Signer{
+			{Account: testcli.ValidatorPriv.GetScriptHash(), Scopes: transaction.CalledByEntry},
+			{Account: newHash, Scopes: transaction.CalledByEntry},
+		}, tx.Signers)
+		for _, c := range []struct {
+			method string
+			params []any
+		}{
+			{"transfer", []any{testcli.ValidatorPriv.GetScriptHash(), newHash, 10, nil}},
+			{"unregisterCandidate", []any{testcli.ValidatorPriv.PublicKey().Bytes()}},
+			{"registerCandidate", []any{newPub.Bytes()}},
+			{"vote", []any{newHash, newPub.Bytes()}},
+		} {
+			w := io.NewBufBinWriter()
+			emit.AppCall(w.BinWriter, neo.Hash, c.method, callflag.All, c.params...)
+			require.NoError(t, w.Err)
+			require.Contains(t, string(tx.Script), string(w.Bytes()), c.method)
+		}
+	})
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "migrate",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet,
+		"--from", validatorAddress,
+		"--to", testcli.TestWalletAccount,
+		"--force")
+	e.CheckNextLine(t, "^Migrating "+validatorAddress+" to "+testcli.TestWalletAccount+"$")
+	e.CheckNextLine(t, "^NEO: 10$")
+	e.CheckNextLine(t, "^GAS: all remaining$")
+	e.CheckNextLine(t, "^Not moved: candidate registration of "+validatorHex)
+	e.CheckNextLine(t, "^Not moved: vote for "+validatorHex)
+	e.CheckNextLine(t, "^Not moved: contract-specific permissions")
+	e.CheckTxPersisted(t)
+
+	b, _ := e.Chain.GetGoverningTokenBalance(testcli.ValidatorPriv.GetScriptHash())
+	require.Equal(t, 0, b.Sign())
+	// Committee member gets some GAS on every block, but that's less than 1 GAS.
+	require.Equal(t, -1, e.Chain.GetUtilityTokenBalance(testcli.ValidatorPriv.GetScriptHash()).Cmp(big.NewInt(1_0000_0000)))
+	b, _ = e.Chain.GetGoverningTokenBalance(to)
+	require.Equal(t, big.NewInt(10), b)
+	require.Equal(t, 1, e.Chain.GetUtilityTokenBalance(to).Cmp(big.NewInt(1000_0000_0000)))
+}
@@ -161,9 +161,19 @@ func NewCommands() []*cli.Command {
 			},
 			{
 				Name:      "convert",
-				Usage:     "Convert addresses from existing Neo Legacy NEP6-wallet to Neo N3 format",
-				UsageText: "neo-go wallet convert -w legacywallet [--wallet-config path] -o n3wallet",
-				Action:    convertWallet,
+				Usage:     "Convert addresses from existing Neo Legacy NEP6-wallet to Neo N3 format or encrypt/decrypt N3 wallet file",
+				UsageText: "neo-go wallet convert -w wallet [--wallet-config path] [--encrypt | --decrypt] -o n3wallet",
+				Description: `Converts Neo Legacy wallet to Neo N3 format by default. If --encrypt
+   flag is given, the input is expected to be a Neo N3 wallet and it's
+   converted into a whole-file encrypted one (Argon2id key derivation and
+   XChaCha20-Poly1305 encryption), which hides labels, addresses, contracts
+   and tokens in addition to NEP-2 encrypted keys. Such wallets can be used
+   by any other wallet command (wallet file password is requested when
+   needed), the password from wallet config is used for the file if
+   --wallet-config is given. --decrypt does the reverse, producing a regular
+   NEP-6 wallet from a whole-file encrypted one.
+`,
+				Action: convertWallet,
 				Flags: []cli.Flag{
 					walletPathFlag,
 					walletConfigFlag,
@@ -174,6 +184,14 @@ func NewCommands() []*cli.Command {
 						Usage:    "Where to write converted wallet",
 						Action:   cmdargs.EnsureNotEmpty("out"),
 					},
+					&cli.BoolFlag{
+						Name:  "encrypt",
+						Usage: "Encrypt the whole N3 wallet file",
+					},
+					&cli.BoolFlag{
+						Name:  "decrypt",
+						Usage: "Decrypt the whole-file encrypted N3 wallet",
+					},
 				},
 			},
 			{
@@ -305,6 +323,7 @@ func NewCommands() []*cli.Command {
 					},
 				}, options.RPC...),
 			},
+			newMigrateCommand(),
 			{
 				Name:      "remove",
 				Usage:     "Remove an account from the wallet",
@@ -440,6 +459,13 @@ func convertWallet(ctx *cli.Context) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
 	}
+	encrypt, decrypt := ctx.Bool("encrypt"), ctx.Bool("decrypt")
+	if encrypt && decrypt {
+		return cli.Exit("--encrypt and --decrypt flags are mutually exclusive", 1)
+	}
+	if encrypt || decrypt {
+		return convertWalletEncryption(ctx, encrypt)
+	}
 	wall, pass, err := newWalletV2FromFile(ctx.String("wallet"), ctx.String("wallet-config"))
 	if err != nil {
 		return cli.Exit(err, 1)
@@ -473,6 +499,40 @@ func convertWallet(ctx *cli.Context) error {
 	return nil
 }
 
+func convertWalletEncryption(ctx *cli.Context, encrypt bool) error {
+	wall, pass, err := openWallet(ctx, true)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer wall.Close()
+
+	if encrypt {
+		if wall.IsFileEncrypted() {
+			return cli.Exit("wallet file is already encrypted", 1)
+		}
+		if pass == nil {
+			phrase, err := readNewPassword()
+			if err != nil {
+				return cli.Exit(err, 1)
+			}
+			pass = &phrase
+		}
+		if err := wall.EncryptFile(*pass, wallet.DefaultArgon2Params()); err != nil {
+			return cli.Exit(err, 1)
+		}
+	} else {
+		if !wall.IsFileEncrypted() {
+			return cli.Exit("wallet file is not encrypted", 1)
+		}
+		wall.DecryptFile()
+	}
+	wall.SetPath(ctx.String("out"))
+	if err := wall.Save(); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return nil
+}
+
 func addAccount(ctx *cli.Context) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
@@ -970,7 +1030,7 @@ func openWallet(ctx *cli.Context, canUseWalletConfig bool) (*wallet.Wallet, *str
 	if path == "-" {
 		return nil, nil, errNoStdin
 	}
-	w, err := wallet.NewWalletFromFile(path)
+	w, err := options.ReadWalletFile(path, pass)
 	if err != nil {
 		return nil, nil, cli.Exit(fmt.Errorf("failed to read wallet: %w", err), 1)
 	}
@@ -989,7 +1049,7 @@ func readWallet(ctx *cli.Context) (*wallet.Wallet, *string, error) {
 		}
 		return w, nil, nil
 	}
-	w, err := wallet.NewWalletFromFile(path)
+	w, err := options.ReadWalletFile(path, pass)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1149,6 +1149,68 @@ func TestWalletConvert(t *testing.T) {
 	}
 }
 
+func TestWalletConvertEncrypt(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := testcli.NewExecutor(t, false)
+
+	encPath := filepath.Join(tmpDir, "encrypted.json")
+	plainPath := filepath.Join(tmpDir, "plain.json")
+
+	e.RunWithErrorCheckExit(t, "mutually exclusive", "neo-go", "wallet", "convert",
+		"--wallet", testcli.ValidatorWallet, "--out", encPath, "--encrypt", "--decrypt")
+	e.RunWithErrorCheckExit(t, "wallet file is not encrypted", "neo-go", "wallet", "convert",
+		"--wallet", testcli.ValidatorWallet, "--out", plainPath, "--decrypt")
+
+	t.Run("password mismatch", func(t *testing.T) {
+		e.In.WriteString("filepass\r")
+		e.In.WriteString("other\r")
+		e.RunWithError(t, "neo-go", "wallet", "convert",
+			"--wallet", testcli.ValidatorWallet, "--out", encPath, "--encrypt")
+	})
+
+	e.In.WriteString("filepass\r")
+	e.In.WriteString("filepass\r")
+	e.Run(t, "neo-go", "wallet", "convert",
+		"--wallet", testcli.ValidatorWallet, "--out", encPath, "--encrypt")
+
+	raw, err := os.ReadFile(encPath)
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), testcli.ValidatorAddr)
+	_, err = wallet.NewWalletFromFile(encPath)
+	require.ErrorIs(t, err, wallet.ErrWalletEncrypted)
+
+	t.Run("use encrypted", func(t *testing.T) {
+		e.In.WriteString("invalid\r")
+		e.RunWithError(t, "neo-go", "wallet", "dump-keys", "--wallet", encPath, "-a", testcli.ValidatorAddr)
+
+		e.In.WriteString("filepass\r")
+		e.Run(t, "neo-go", "wallet", "dump-keys", "--wallet", encPath, "-a", testcli.ValidatorAddr)
+		e.CheckNextLine(t, testcli.ValidatorAddr)
+	})
+
+	t.Run("wallet config", func(t *testing.T) {
+		configPath := filepath.Join(tmpDir, "config.yaml")
+		data, err := yaml.Marshal(config.Wallet{
+			Path:     encPath,
+			Password: "filepass",
+		})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(configPath, data, 0644))
+		e.Run(t, "neo-go", "wallet", "dump-keys", "--wallet-config", configPath, "-a", testcli.ValidatorAddr)
+		e.CheckNextLine(t, testcli.ValidatorAddr)
+	})
+
+	e.In.WriteString("filepass\r")
+	e.Run(t, "neo-go", "wallet", "convert",
+		"--wallet", encPath, "--out", plainPath, "--decrypt")
+
+	actual, err := wallet.NewWalletFromFile(plainPath)
+	require.NoError(t, err)
+	expected, err := wallet.NewWalletFromFile(testcli.ValidatorWallet)
+	require.NoError(t, err)
+	require.Equal(t, expected.Accounts, actual.Accounts)
+}
+
 func deployNNSContract(t *testing.T, e *testcli.Executor) util.Uint160 {
 	return testcli.DeployContract(t, e, "../../examples/nft-nd-nns/", "../../examples/nft-nd-nns/nns.yml", testcli.ValidatorWallet, testcli.ValidatorAddr, testcli.ValidatorPass)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -428,6 +429,21 @@ func (s *FakeStateSync) AddMPTNodes(nodes [][]byte) error {
 	panic("TODO")
 }
 
+// AddContractStorageItems implements the StateSync interface.
+func (s *FakeStateSync) AddContractStorageItems(kvs []storage.KeyValue) error {
+	panic("TODO")
+}
+
+// FinalizeContractStorage implements the StateSync interface.
+func (s *FakeStateSync) FinalizeContractStorage(sr *state.MPTRoot) error {
+	panic("TODO")
+}
+
+// GetStateSyncPoint implements the StateSync interface.
+func (s *FakeStateSync) GetStateSyncPoint() (uint32, util.Uint256) {
+	panic("TODO")
+}
+
 // BlockHeight implements the StateSync interface.
 func (s *FakeStateSync) BlockHeight() uint32 {
 	return 0
@@ -81,6 +81,8 @@ type codegen struct {
 	// containing info about mapping from opcode's offset
 	// to a text span in the source file.
 	sequencePoints map[string][]DebugSeqPoint
+	// loops is a mapping from the method name to the loops found in it.
+	loops map[string][]GasLoop
 
 	// initEndOffset specifies the end of the initialization method.
 	initEndOffset int
@@ -117,11 +119,17 @@ type codegen struct {
 	// invokedContracts contains invoked methods of other contracts.
 	invokedContracts map[util.Uint160][]string
 
+	// storagePrefixes contains constant storage key prefixes.
+	storagePrefixes map[string]bool
+
 	// Label table for recording jump destinations.
 	l []int
 
 	// Tokens for CALLT instruction
 	callTokens []nef.MethodToken
+
+	// instances contains generic function instances to be converted.
+	instances []*funcScope
 }
 
 type labelOffsetType byte
@@ -163,8 +171,9 @@ const (
 	varArgument
 )
 
-// ErrUnsupportedTypeAssertion is returned when type assertion statement is not supported by the compiler.
-var ErrUnsupportedTypeAssertion = errors.New("type assertion with two return values is not supported")
+// ErrUnsupportedTypeAssertion is returned when type assertion or type switch
+// uses a type that can't be checked for in runtime.
+var ErrUnsupportedTypeAssertion = errors.New("unsupported type assertion")
 
 // newLabel creates a new label to jump to.
 func (c *codegen) newLabel() (l uint16) {
@@ -473,8 +482,18 @@ func (c *codegen) convertFuncDecl(file ast.Node, decl *ast.FuncDecl, pkg *types.
 			f = c.newFunc(decl)
 		}
 	}
+	return c.convertFuncScope(file, f, pkg, isLambda)
+}
 
+// convertFuncScope emits the code of the function represented by f.
+func (c *codegen) convertFuncScope(file ast.Node, f *funcScope, pkg *types.Package, isLambda bool) *funcScope {
+	var (
+		decl     = f.decl
+		isInit   = isInitFunc(decl)
+		isDeploy = isDeployFunc(decl)
+	)
 	f.rng.Start = uint16(c.prog.Len())
+	f.emitted = true
 	c.scope = f
 	ast.Inspect(decl, c.scope.analyzeVoidCalls) // @OPTIMIZE
 
@@ -580,13 +599,6 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 	//     x = 2
 	// )
 	case *ast.GenDecl:
-		// Filter out generics usage.
-		err := c.checkGenericsGenDecl(n, c.currPkg.PkgPath)
-		if err != nil {
-			c.prog.Err = err
-			return nil // Program is invalid.
-		}
-
 		if n.Tok == token.VAR || n.Tok == token.CONST {
 			c.saveSequencePoint(n)
 		}
@@ -608,14 +620,6 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		for _, spec := range n.Specs {
 			switch t := spec.(type) {
 			case *ast.ValueSpec:
-				// Filter out type assertion with two return values: var i, ok = v.(int)
-				if len(t.Names) == 2 && len(t.Values) == 1 && n.Tok == token.VAR {
-					err := checkTypeAssertWithOK(t.Values[0])
-					if err != nil {
-						c.prog.Err = err
-						return nil
-					}
-				}
 				multiRet := n.Tok == token.VAR && len(t.Values) != 0 && len(t.Names) != len(t.Values)
 				for _, id := range t.Names {
 					if id.Name != "_" {
@@ -648,7 +652,8 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 					}
 					var hasCall bool
 					if i == 0 || !multiRet {
-						hasCall = containsCall(t.Values[i])
+						// Multiple values (if any) are pushed at once.
+						hasCall = multiRet || containsCall(t.Values[i])
 					}
 					if hasCall {
 						ast.Walk(c, t.Values[i])
@@ -662,14 +667,6 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		return nil
 
 	case *ast.AssignStmt:
-		// Filter out type assertion with two return values: i, ok = v.(int)
-		if len(n.Lhs) == 2 && len(n.Rhs) == 1 && (n.Tok == token.DEFINE || n.Tok == token.ASSIGN) {
-			err := checkTypeAssertWithOK(n.Rhs[0])
-			if err != nil {
-				c.prog.Err = err
-				return nil
-			}
-		}
 		multiRet := len(n.Rhs) != len(n.Lhs)
 		c.saveSequencePoint(n)
 		// Assign operations are grouped https://github.com/golang/go/blob/master/src/go/types/stmt.go#L160
@@ -886,6 +883,88 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 
 		return nil
 
+	case *ast.TypeSwitchStmt:
+		c.scope.vars.newScope()
+		defer c.scope.vars.dropScope()
+
+		if n.Init != nil {
+			ast.Walk(c, n.Init)
+		}
+		var (
+			name   string
+			assert *ast.TypeAssertExpr
+		)
+		switch t := n.Assign.(type) {
+		case *ast.AssignStmt: // switch v := x.(type)
+			name = t.Lhs[0].(*ast.Ident).Name
+			assert = t.Rhs[0].(*ast.TypeAssertExpr)
+		case *ast.ExprStmt: // switch x.(type)
+			assert = t.X.(*ast.TypeAssertExpr)
+		}
+		c.saveSequencePoint(n.Assign)
+		ast.Walk(c, assert.X)
+		switchEnd, label := c.generateLabel(labelEnd)
+
+		lastSwitch := c.currentSwitch
+		c.currentSwitch = label
+		c.pushStackLabel(label, 1)
+
+		// Check all the cases first, default clause (if any) is taken only if
+		// nothing else matches irrespective of its position.
+		lDefault := switchEnd
+		startLabels := make([]uint16, len(n.Body.List))
+		for i := range n.Body.List {
+			startLabels[i] = c.newLabel()
+			cc := n.Body.List[i].(*ast.CaseClause)
+			if len(cc.List) == 0 {
+				lDefault = startLabels[i]
+			}
+			for _, e := range cc.List {
+				emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+				if c.typeAndValueOf(e).IsNil() {
+					emit.Opcodes(c.prog.BinWriter, opcode.ISNULL)
+				} else {
+					c.emitIsType(c.typeOf(e))
+				}
+				emit.Jmp(c.prog.BinWriter, opcode.JMPIFL, startLabels[i])
+			}
+		}
+		emit.Jmp(c.prog.BinWriter, opcode.JMPL, lDefault)
+
+		for i := range n.Body.List {
+			cc := n.Body.List[i].(*ast.CaseClause)
+
+			c.scope.vars.newScope()
+
+			c.setLabel(startLabels[i])
+			if name != "" && name != "_" {
+				// Variable has the type of the case if there is exactly one,
+				// and the type of the switch expression otherwise.
+				typExpr := assert.X
+				emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+				if len(cc.List) == 1 && !c.typeAndValueOf(cc.List[0]).IsNil() {
+					typExpr = cc.List[0]
+					c.emitTypeConvert(c.typeOf(typExpr))
+				}
+				c.scope.newLocal(name)
+				c.registerDebugVariable(name, typExpr)
+				c.emitStoreVar("", name)
+			}
+			for _, stmt := range cc.Body {
+				ast.Walk(c, stmt)
+			}
+			emit.Jmp(c.prog.BinWriter, opcode.JMPL, switchEnd)
+
+			c.scope.vars.dropScope()
+		}
+
+		c.setLabel(switchEnd)
+		c.dropStackLabel()
+
+		c.currentSwitch = lastSwitch
+
+		return nil
+
 	case *ast.FuncLit:
 		var found bool
 		var l uint16
@@ -983,9 +1062,12 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			isLiteral bool
 		)
 
-		switch fun := n.Fun.(type) {
+		switch fun := c.skipTypeArgs(n.Fun).(type) {
 		case *ast.Ident:
 			f, ok = c.getFuncFromIdent(fun)
+			if ok && isGenericFuncDecl(f.decl) {
+				f = c.getFuncInstance(f, c.getIdentName(f.pkg.Path(), f.name), c.instanceOf(fun))
+			}
 			isBuiltin = isGoBuiltin(fun.Name)
 			if !ok && !isBuiltin {
 				name = fun.Name
@@ -1002,6 +1084,13 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			name, isMethod := c.getFuncNameFromSelector(fun)
 
 			f, ok = c.funcs[name]
+			if ok && isGenericFuncDecl(f.decl) {
+				if isMethod {
+					f = c.getFuncInstance(f, name, c.receiverTypeArgs(fun.X))
+				} else {
+					f = c.getFuncInstance(f, name, c.instanceOf(fun.Sel))
+				}
+			}
 			if ok {
 				f.selector = fun.X
 				isBuiltin = isPotentialCustomBuiltin(f, n)
@@ -1250,6 +1339,7 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 	case *ast.ForStmt:
 		c.scope.vars.newScope()
 		defer c.scope.vars.dropScope()
+		c.saveLoop(n)
 
 		fstart, label := c.generateLabel(labelStart)
 		fend := c.newNamedLabel(labelEnd, label)
@@ -1295,6 +1385,7 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 	case *ast.RangeStmt:
 		c.scope.vars.newScope()
 		defer c.scope.vars.dropScope()
+		c.saveLoop(n)
 
 		start, label := c.generateLabel(labelStart)
 		end := c.newNamedLabel(labelEnd, label)
@@ -1404,11 +1495,16 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 	// which is not the assertion type.
 	case *ast.TypeAssertExpr:
 		ast.Walk(c, n.X)
+		goTyp := c.typeOf(n.Type)
+		// Type assertion with two return values: v, ok := x.(T).
+		if _, ok := c.typeOf(n).(*types.Tuple); ok {
+			c.emitTypeAssertWithOK(goTyp)
+			return nil
+		}
 		if c.isCallExprSyscall(n.X) {
 			return nil
 		}
 
-		goTyp := c.typeOf(n.Type)
 		if canConvert(goTyp.String()) {
 			typ := toNeoType(goTyp)
 			c.emitConvert(typ)
@@ -1418,14 +1514,6 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 	return c
 }
 
-func checkTypeAssertWithOK(n ast.Node) error {
-	if t, ok := n.(*ast.TypeAssertExpr); ok &&
-		t.Type != nil { // not a type switch
-		return ErrUnsupportedTypeAssertion
-	}
-	return nil
-}
-
 // packVarArgs packs variadic arguments into an array
 // and returns the amount of arguments packed.
 func (c *codegen) packVarArgs(n *ast.CallExpr, typ *types.Signature) int {
@@ -1441,7 +1529,7 @@ func (c *codegen) isCallExprSyscall(e ast.Expr) bool {
 	if !ok {
 		return false
 	}
-	sel, ok := ce.Fun.(*ast.SelectorExpr)
+	sel, ok := c.skipTypeArgs(ce.Fun).(*ast.SelectorExpr)
 	if !ok {
 		return false
 	}
@@ -2011,6 +2099,113 @@ func (c *codegen) emitConvert(typ stackitem.Type) {
 	}
 }
 
+// emitTypeConvert converts the top stack item to the stack item type
+// corresponding to the given Go type if needed.
+func (c *codegen) emitTypeConvert(typ types.Type) {
+	if t := toNeoType(typ); t != stackitem.AnyT && canConvert(typ.String()) {
+		c.emitConvert(t)
+	}
+}
+
+// emitTypeAssertWithOK emits code for the type assertion with two return
+// values. It consumes the top stack item and pushes a boolean result and the
+// value converted to the given type (or the type default value if it can't
+// be asserted) on top of it.
+func (c *codegen) emitTypeAssertWithOK(typ types.Type) {
+	lFail := c.newLabel()
+	lEnd := c.newLabel()
+
+	emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+	c.emitIsType(typ)
+	emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+	emit.Jmp(c.prog.BinWriter, opcode.JMPIFNOTL, lFail)
+	emit.Opcodes(c.prog.BinWriter, opcode.SWAP)
+	c.emitTypeConvert(typ)
+	emit.Jmp(c.prog.BinWriter, opcode.JMPL, lEnd)
+
+	c.setLabel(lFail)
+	emit.Opcodes(c.prog.BinWriter, opcode.NIP)
+	c.emitDefault(typ)
+	c.setLabel(lEnd)
+}
+
+// emitIsType replaces the top stack item with a boolean showing whether it
+// can be asserted to the given Go type. Basic types, slices, maps and
+// functions are checked against the corresponding stack item type, structures
+// are also checked for the number of fields, interfaces match any non-nil
+// value.
+func (c *codegen) emitIsType(typ types.Type) {
+	switch strings.TrimPrefix(typ.String(), "*") {
+	case interopPrefix + "/iterator.Iterator", interopPrefix + "/storage.Context":
+		emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.InteropT)})
+		return
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Interface:
+		emit.Opcodes(c.prog.BinWriter, opcode.ISNULL, opcode.NOT)
+		return
+	case *types.Basic:
+		info := t.Info()
+		switch {
+		case info&types.IsInteger != 0:
+			emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.IntegerT)})
+			return
+		case info&types.IsBoolean != 0:
+			emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.BooleanT)})
+			return
+		case info&types.IsString != 0:
+			emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.ByteArrayT)})
+			return
+		}
+	case *types.Slice:
+		if isByte(t.Elem()) {
+			// Byte slices can be represented by both ByteString and Buffer.
+			emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+			emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.ByteArrayT)})
+			emit.Opcodes(c.prog.BinWriter, opcode.SWAP)
+			emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.BufferT)})
+			emit.Opcodes(c.prog.BinWriter, opcode.BOOLOR)
+		} else {
+			emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.ArrayT)})
+		}
+		return
+	case *types.Map:
+		emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.MapT)})
+		return
+	case *types.Signature:
+		emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.PointerT)})
+		return
+	}
+	if strct, ok := c.getStruct(typ); ok {
+		if isInteropPath(strings.TrimPrefix(typ.String(), "*")) {
+			// Native contracts return interop structures as arrays.
+			emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.ArrayT)})
+			return
+		}
+		lFail := c.newLabel()
+		lEnd := c.newLabel()
+		emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+		emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.StructT)})
+		if _, ok := typ.Underlying().(*types.Pointer); ok {
+			// Pointers to structures are arrays, see convertStruct.
+			emit.Opcodes(c.prog.BinWriter, opcode.OVER)
+			emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.ArrayT)})
+			emit.Opcodes(c.prog.BinWriter, opcode.BOOLOR)
+		}
+		emit.Jmp(c.prog.BinWriter, opcode.JMPIFNOTL, lFail)
+		emit.Opcodes(c.prog.BinWriter, opcode.SIZE)
+		emit.Int(c.prog.BinWriter, int64(strct.NumFields()))
+		emit.Opcodes(c.prog.BinWriter, opcode.NUMEQUAL)
+		emit.Jmp(c.prog.BinWriter, opcode.JMPL, lEnd)
+		c.setLabel(lFail)
+		emit.Opcodes(c.prog.BinWriter, opcode.DROP)
+		emit.Bool(c.prog.BinWriter, false)
+		c.setLabel(lEnd)
+		return
+	}
+	c.prog.Err = fmt.Errorf("%w: %s", ErrUnsupportedTypeAssertion, typ)
+}
+
 func (c *codegen) convertByteArray(elems []ast.Expr) {
 	buf := make([]byte, len(elems))
 	varIndices := []int{}
@@ -2201,6 +2396,11 @@ func (c *codegen) getFuncFromIdent(fun *ast.Ident) (*funcScope, bool) {
 func (c *codegen) getFuncNameFromSelector(e *ast.SelectorExpr) (string, bool) {
 	if c.typeInfo.Selections[e] != nil {
 		typ := c.typeInfo.Types[e.X].Type.String()
+		if named, ok := derefType(c.typeInfo.Types[e.X].Type).(*types.Named); ok && named.TypeArgs().Len() != 0 {
+			// Methods of generic types are named after the generic type,
+			// instances are resolved separately.
+			typ = named.Obj().Pkg().Path() + "." + named.Obj().Name()
+		}
 		name := c.getIdentName(typ, e.Sel.Name)
 		if name[0] == '*' {
 			name = name[1:]
@@ -2219,6 +2419,10 @@ func (c *codegen) newLambda(u uint16, lit *ast.FuncLit) {
 		Type: lit.Type,
 		Body: lit.Body,
 	}, u)
+	if c.scope != nil {
+		// Lambdas declared in generic functions use the same type arguments.
+		f.typeMap = c.scope.typeMap
+	}
 	c.lambda[c.getFuncNameFromDecl("", f.decl)] = f
 }
 
@@ -2270,13 +2474,15 @@ func (c *codegen) compile(info *buildInfo, pkg *packages.Package) error {
 					pkgPath = pkg.Path()
 				}
 				name := c.getFuncNameFromDecl(pkgPath, n)
-				if !isInitFunc(n) && !isDeployFunc(n) && funUsage.funcUsed(name) &&
+				// Generic functions are converted per instance, see convertFuncInstances.
+				if !isInitFunc(n) && !isDeployFunc(n) && !isGenericFuncDecl(n) && funUsage.funcUsed(name) &&
 					(!isInteropPath(pkg.Path()) && !canInline(pkg.Path(), n.Name.Name, false)) {
 					c.convertFuncDecl(f, n, pkg)
 				}
 			}
 		}
 	})
+	c.convertFuncInstances()
 
 	return c.prog.Err
 }
@@ -2301,7 +2507,9 @@ func newCodegen(info *buildInfo, pkg *packages.Package) *codegen {
 
 		emittedEvents:    make(map[string][]EmittedEventInfo),
 		invokedContracts: make(map[util.Uint160][]string),
+		storagePrefixes:  make(map[string]bool),
 		sequencePoints:   make(map[string][]DebugSeqPoint),
+		loops:            make(map[string][]GasLoop),
 	}
 }
 
@@ -2399,6 +2607,10 @@ func (c *codegen) writeJumps(b []byte) ([]byte, error) {
 		}
 	}
 
+	if c.optimizeEnabled() {
+		nopOffsets = c.optimize(b, nopOffsets)
+	}
+
 	if c.deployEndOffset >= 0 {
 		_, end := correctRange(uint16(c.initEndOffset+1), uint16(c.deployEndOffset), nopOffsets)
 		c.deployEndOffset = int(end)
@@ -2426,7 +2638,8 @@ loop:
 		case ind < int(start):
 			newStart--
 			newEnd--
-		case ind >= int(start):
+		case ind >= int(start) && newEnd > newStart:
+			// Empty range of functions without code can't be shrunk.
 			newEnd--
 		}
 	}
@@ -62,6 +62,11 @@ type Options struct {
 	// occurrence of event call.
 	GuessEventTypes bool
 
+	// Optimize enables additional optimization pass over the resulting script
+	// that removes unreachable code, redundant instructions and local
+	// variables and folds constant integer expressions.
+	Optimize bool
+
 	// Name is a contract's name to be written to manifest.
 	Name string
 
@@ -88,8 +93,17 @@ type Options struct {
 	// Permissions is a list of permissions for every contract method.
 	Permissions []manifest.Permission
 
+	// StorageLayout is a declared contract storage layout to be written to
+	// manifest `extra` field.
+	StorageLayout []binding.StorageItem
+
 	// BindingsFile contains configuration for smart-contract bindings generator.
 	BindingsFile string
+
+	// GasComments specifies if static execution cost estimations of contract
+	// methods need to be added to the bindings configuration, they're
+	// mentioned in the generated bindings doc comments then.
+	GasComments bool
 }
 
 // HybridEvent represents the description of event emitted by the contract squashed
@@ -210,7 +224,7 @@ func getBuildInfo(name string, src any) (*buildInfo, error) {
 		if singleFile && filepath.Dir(filename) == filepath.Dir(absName) && filename != absName {
 			return nil, nil
 		}
-		const mode = parser.AllErrors
+		const mode = parser.AllErrors | parser.ParseComments
 		return parser.ParseFile(fset, filename, src, mode)
 	}
 	prog, err := packages.Load(conf, names...)
@@ -336,6 +350,15 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 			if m.ReturnTypeExtended != nil {
 				cfg.Types[m.Name.Name] = *m.ReturnTypeExtended
 			}
+			if o.GasComments && m.Gas != nil {
+				if cfg.Gas == nil {
+					cfg.Gas = make(map[string]binding.MethodGas)
+				}
+				cfg.Gas[m.Name.Name] = binding.MethodGas{
+					Total:     m.Gas.Total,
+					Unbounded: !m.Gas.IsUpperBound(),
+				}
+			}
 		}
 		if len(di.NamedTypes) > 0 {
 			cfg.NamedTypes = di.NamedTypes
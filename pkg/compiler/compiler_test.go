@@ -14,6 +14,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/interop/native/neo"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -169,6 +170,129 @@ func TestSafeMethodWarnings(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestGasEstimate(t *testing.T) {
+	src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+			"github.com/nspcc-dev/neo-go/pkg/interop/native/neo"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func Simple() int { return 1 }
+		func Get(k []byte) any { return storage.Get(storage.GetContext(), k) }
+		func Balance(h interop.Hash160) int { return neo.BalanceOf(h) }
+		func Sum(xs []int) int {
+			s := 0
+			for _, x := range xs {
+				s += x
+			}
+			for i := 0; i < 10; i++ {
+				s += double(i)
+			}
+			return s
+		}
+		func double(i int) int { return i * 2 }
+		func Const() int {
+			s := 0
+			for i := 0; i < 3; i++ {
+				s += i
+			}
+			return s
+		}
+		func Changed() int {
+			s := 0
+			for i := 0; i != 10; i += 3 {
+				s++
+			}
+			for i := 0; i < 10; i++ {
+				i *= 2
+			}
+			return s
+		}
+		func Fact(n int) int {
+			if n == 0 {
+				return 1
+			}
+			return n * Fact(n-1)
+		}
+		func Call(h interop.Hash160) any { return contract.Call(h, "method", contract.All) }`
+
+	_, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+	estimates := make(map[string]*compiler.GasEstimate)
+	for _, m := range di.Methods {
+		if m.ID == "double" {
+			require.Nil(t, m.Gas)
+			continue
+		}
+		require.NotNil(t, m.Gas, m.ID)
+		require.LessOrEqual(t, m.Gas.Opcodes, m.Gas.Total)
+		estimates[m.ID] = m.Gas
+	}
+
+	// PUSH1 + RET.
+	require.Equal(t, &compiler.GasEstimate{Opcodes: 30, Total: 30}, estimates["Simple"])
+	require.True(t, estimates["Simple"].IsUpperBound())
+
+	get := estimates["Get"]
+	require.Equal(t, []compiler.GasCall{
+		{Name: "System.Storage.Get", Price: 1 << 15 * 30},
+		{Name: "System.Storage.GetContext", Price: 1 << 4 * 30},
+	}, get.Calls)
+	require.Equal(t, get.Opcodes+(1<<15+1<<4)*30, get.Total)
+	require.True(t, get.IsUpperBound())
+
+	require.Equal(t, []compiler.GasCall{{Name: "NeoToken.balanceOf", Price: 1 << 15 * 30}}, estimates["Balance"].Calls)
+
+	sum := estimates["Sum"]
+	require.Equal(t, 2, len(sum.Loops))
+	require.False(t, sum.Loops[0].Bounded)
+	require.True(t, sum.Loops[1].Bounded)
+	require.Equal(t, sum.Loops[0].Line+3, sum.Loops[1].Line)
+	require.Greater(t, sum.Opcodes, estimates["Simple"].Opcodes)
+	require.False(t, sum.IsUpperBound())
+
+	// Loop bodies are counted once irrespective of bounds.
+	cnst := estimates["Const"]
+	require.Equal(t, 1, len(cnst.Loops))
+	require.True(t, cnst.Loops[0].Bounded)
+	require.False(t, cnst.IsUpperBound())
+
+	changed := estimates["Changed"]
+	require.Equal(t, 2, len(changed.Loops))
+	require.False(t, changed.Loops[0].Bounded) // Not equal condition.
+	require.False(t, changed.Loops[1].Bounded) // Counter is changed in the body.
+
+	require.True(t, estimates["Fact"].Recursive)
+	require.False(t, estimates["Fact"].IsUpperBound())
+
+	require.True(t, estimates["Call"].Dynamic)
+	require.False(t, estimates["Call"].IsUpperBound())
+}
+
+func TestStorageLayoutManifest(t *testing.T) {
+	src := `package storagelayout
+		func Main() int { return 1 }`
+
+	_, di, err := compiler.CompileWithOptions("storageLayout.go", strings.NewReader(src),
+		&compiler.Options{Name: "storageLayout"})
+	require.NoError(t, err)
+
+	layout := []binding.StorageItem{
+		{Name: "balances", Kind: binding.StorageMap, Prefix: 1, Key: smartcontract.Hash160Type, Value: &binding.ExtendedType{Base: smartcontract.IntegerType}},
+		{Name: "lastID", Kind: binding.StorageCounter, Prefix: 2},
+	}
+	m, err := compiler.CreateManifest(di, &compiler.Options{Name: "storageLayout", StorageLayout: layout})
+	require.NoError(t, err)
+	actual, err := binding.StorageFromManifest(m)
+	require.NoError(t, err)
+	require.Equal(t, layout, actual)
+
+	layout[1].Prefix = 1
+	_, err = compiler.CreateManifest(di, &compiler.Options{Name: "storageLayout", StorageLayout: layout})
+	require.ErrorContains(t, err, "bad storage layout")
+}
+
 func TestEventWarnings(t *testing.T) {
 	src := `package payable
 		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
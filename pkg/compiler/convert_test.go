@@ -10,6 +10,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 )
 
@@ -137,9 +138,7 @@ func TestTypeAssertionWithOK(t *testing.T) {
 					var _, ok = u.(int)	//	*ast.GenDecl
 					return ok
 				}`
-		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.Error(t, err)
-		require.ErrorIs(t, err, compiler.ErrUnsupportedTypeAssertion)
+		eval(t, src, true)
 	})
 	t.Run("inside assignment statement", func(t *testing.T) {
 		src := `package foo
@@ -148,24 +147,133 @@ func TestTypeAssertionWithOK(t *testing.T) {
 					var u any
 					u = a
 					var ok bool
-					_, ok = u.(int)	// *ast.AssignStmt
+					_, ok = u.(string)	// *ast.AssignStmt
 					return ok
 				}`
-		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.Error(t, err)
-		require.ErrorIs(t, err, compiler.ErrUnsupportedTypeAssertion)
+		eval(t, src, false)
 	})
 	t.Run("inside definition statement", func(t *testing.T) {
 		src := `package foo
+				func Main() int {
+					var u any = 1
+					v, ok := u.(int)	// *ast.AssignStmt
+					if !ok {
+						return -1
+					}
+					return v + 1
+				}`
+		eval(t, src, big.NewInt(2))
+	})
+	t.Run("failed assertion gives default value", func(t *testing.T) {
+		src := `package foo
+				func Main() int {
+					var u any = "str"
+					v, ok := u.(int)
+					if ok {
+						return -1
+					}
+					return v
+				}`
+		eval(t, src, big.NewInt(0))
+	})
+
+	checkOK := func(t *testing.T, decl, typ string, expected bool) {
+		src := `package foo
+				type pair struct { a, b int }
+				type triple struct { a, b, c int }
 				func Main() bool {
-					a := 1
-					var u any
-					u = a
-					_, ok := u.(int)	// *ast.AssignStmt
+					` + decl + `
+					_, ok := u.(` + typ + `)
 					return ok
 				}`
+		eval(t, src, expected)
+	}
+	testCases := []struct {
+		decl     string
+		typ      string
+		expected bool
+	}{
+		{"var u any = true", "bool", true},
+		{"var u any = 1", "bool", false},
+		{`var u any = "s"`, "string", true},
+		{`var u any = "s"`, "[]byte", true},
+		{`var u any = []byte{1}`, "[]byte", true},
+		{`var u any = []byte{1}`, "[]int", false},
+		{`var u any = []int{1}`, "[]int", true},
+		{`var u any = map[int]int{}`, "map[int]int", true},
+		{`var u any = map[int]int{}`, "[]int", false},
+		{`var u any = pair{}`, "pair", true},
+		{`var u any = &pair{}`, "*pair", true},
+		{`var u any = pair{}`, "triple", false},
+		{`var u any = []int{1, 2}`, "pair", false},
+		{`var u any = 1`, "any", true},
+		{`var u any`, "any", false},
+		{`var u any = func() {}`, "func()", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.decl+" "+tc.typ, func(t *testing.T) {
+			checkOK(t, tc.decl, tc.typ, tc.expected)
+		})
+	}
+}
+
+func TestTypeSwitch(t *testing.T) {
+	src := `package foo
+		type pair struct { a, b int }
+		func check(u any) int {
+			switch v := u.(type) {
+			case int:
+				return v + 1
+			default:
+				return -1
+			case string, []byte:
+				return 10
+			case pair:
+				return v.a + v.b
+			case nil:
+				return 0
+			case bool:
+				if v {
+					break
+				}
+				return 20
+			}
+			return 30
+		}
+		func Main() []int {
+			return []int{check(41), check("s"), check([]byte{1}), check(pair{a: 2, b: 3}),
+				check(nil), check(false), check(true), check(map[int]int{})}
+		}`
+	eval(t, src, []stackitem.Item{
+		stackitem.Make(42), stackitem.Make(10), stackitem.Make(10), stackitem.Make(5),
+		stackitem.Make(0), stackitem.Make(20), stackitem.Make(30), stackitem.Make(-1),
+	})
+
+	t.Run("without variable", func(t *testing.T) {
+		src := `package foo
+			func Main() int {
+				var u any = "s"
+				switch u.(type) {
+				case int:
+					return 1
+				case string:
+					return 2
+				}
+				return 3
+			}`
+		eval(t, src, big.NewInt(2))
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		src := `package foo
+			func Main() int {
+				var u any = 1
+				switch u.(type) {
+				case chan int:
+					return 1
+				}
+				return 3
+			}`
 		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.Error(t, err)
 		require.ErrorIs(t, err, compiler.ErrUnsupportedTypeAssertion)
 	})
 }
@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -39,6 +40,10 @@ type DebugInfo struct {
 	EmittedEvents map[string][]EmittedEventInfo `json:"-"`
 	// InvokedContracts contains foreign contract invocations.
 	InvokedContracts map[util.Uint160][]string `json:"-"`
+	// StoragePrefixes contains sorted constant prefixes (or whole keys) of
+	// storage keys used by the contract in storage.Put, storage.Get,
+	// storage.Delete and storage.Find calls.
+	StoragePrefixes [][]byte `json:"-"`
 	// StaticVariables contains a list of static variable names and types.
 	StaticVariables []string `json:"static-variables"`
 }
@@ -70,6 +75,9 @@ type MethodDebugInfo struct {
 	Variables    []string                `json:"variables"`
 	// SeqPoints is a map between source lines and byte-code instruction offsets.
 	SeqPoints []DebugSeqPoint `json:"sequence-points"`
+	// Gas is a static execution cost estimation, it's only set for exported
+	// methods.
+	Gas *GasEstimate `json:"gas,omitempty"`
 }
 
 // DebugMethodName is a combination of a namespace and name.
@@ -205,7 +213,13 @@ func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 
 	var fnames = make([]string, 0, len(c.funcs))
 	for name, scope := range c.funcs {
-		if scope.rng.Start == scope.rng.End {
+		skip := scope.rng.Start == scope.rng.End
+		if c.optimizeEnabled() {
+			// Optimized function can be reduced to a single instruction,
+			// so its range can't be used to detect functions without code.
+			skip = !scope.emitted
+		}
+		if skip {
 			continue
 		}
 		fnames = append(fnames, name)
@@ -218,6 +232,11 @@ func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 	}
 	d.EmittedEvents = c.emittedEvents
 	d.InvokedContracts = c.invokedContracts
+	for p := range c.storagePrefixes {
+		d.StoragePrefixes = append(d.StoragePrefixes, []byte(p))
+	}
+	slices.SortFunc(d.StoragePrefixes, bytes.Compare)
+	c.estimateGas(d, contract)
 	return d
 }
 
@@ -231,6 +250,10 @@ func (c *codegen) registerDebugVariable(name string, expr ast.Expr) {
 }
 
 func (c *codegen) methodInfoFromScope(name string, scope *funcScope, exts map[string]binding.ExtendedType) *MethodDebugInfo {
+	// Type parameters of generic function instances are resolved via scope.
+	defer func(s *funcScope) { c.scope = s }(c.scope)
+	c.scope = scope
+
 	ps := scope.decl.Type.Params
 	params := make([]DebugParam, 0, ps.NumFields())
 	for i := range ps.List {
@@ -245,8 +268,13 @@ func (c *codegen) methodInfoFromScope(name string, scope *funcScope, exts map[st
 			})
 		}
 	}
-	ss := strings.Split(name, ".")
-	name = ss[len(ss)-1]
+	if scope.typeMap != nil {
+		// Type arguments can contain dots, but the instance name is known.
+		name = scope.name
+	} else {
+		ss := strings.Split(name, ".")
+		name = ss[len(ss)-1]
+	}
 	r, n := utf8.DecodeRuneInString(name)
 	st, vt, rt, et := c.scAndVMReturnTypeFromScope(scope, exts)
 
@@ -618,6 +646,16 @@ func (di *DebugInfo) ConvertToManifest(o *Options) (*manifest.Manifest, error) {
 		result.ABI.Events = make([]manifest.Event, 0)
 	}
 	result.Permissions = o.Permissions
+	if len(o.StorageLayout) != 0 {
+		if err := binding.ValidateStorage(o.StorageLayout); err != nil {
+			return nil, fmt.Errorf("bad storage layout: %w", err)
+		}
+		extra, err := binding.StorageToExtra(o.StorageLayout)
+		if err != nil {
+			return nil, err
+		}
+		result.Extra = extra
+	}
 	for name, emitName := range o.Overloads {
 		m := result.ABI.GetMethod(name, -1)
 		if m == nil {
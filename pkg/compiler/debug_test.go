@@ -329,6 +329,47 @@ func TestSequencePoints(t *testing.T) {
 	require.Equal(t, 6, ps[1].StartLine)
 }
 
+func TestDebugInfoNoCodeFunctions(t *testing.T) {
+	// The first instruction is removed from the resulting script here,
+	// functions without code must not get a range with it.
+	src := `package foo
+	import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	func Simple() int { return 1 }
+	func Get(k []byte) any { return storage.Get(storage.GetContext(), k) }`
+
+	_, d, err := CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(d.Methods))
+	for _, m := range d.Methods {
+		require.LessOrEqual(t, m.Range.Start, m.Range.End, m.ID)
+	}
+}
+
+func TestStoragePrefixes(t *testing.T) {
+	src := `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	)
+	const (
+		prefixBalance = 0x01
+		prefixToken   = "t"
+	)
+	func Main(h interop.Hash160, id []byte) {
+		ctx := storage.GetContext()
+		storage.Put(ctx, "totalSupply", 1)
+		storage.Put(ctx, append([]byte{prefixBalance}, h...), 1)
+		storage.Get(ctx, prefixToken+string(id))
+		storage.Delete(ctx, []byte{0x02, id[0], 0x03})
+		storage.Find(ctx, append([]byte{0x04, 0x05}, 0x06), storage.KeysOnly)
+		storage.Get(ctx, id)
+	}`
+
+	_, d, err := CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x01}, {0x02}, {0x04, 0x05, 0x06}, []byte("t"), []byte("totalSupply")}, d.StoragePrefixes)
+}
+
 func TestDebugInfo_MarshalJSON(t *testing.T) {
 	d := &DebugInfo{
 		Documents: []string{"/path/to/file"},
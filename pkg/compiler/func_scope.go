@@ -29,6 +29,9 @@ type funcScope struct {
 
 	// Range of opcodes corresponding to the function.
 	rng DebugRange
+	// emitted is set when the function code is emitted (unused and inlined
+	// functions are not).
+	emitted bool
 	// Variables together with it's type in neo-vm.
 	variables []string
 
@@ -46,6 +49,10 @@ type funcScope struct {
 	// return value to the stack size.
 	voidCalls map[*ast.CallExpr]bool
 
+	// typeMap maps type parameters to type arguments for generic function
+	// instances, it's nil for regular functions.
+	typeMap map[*types.TypeParam]types.Type
+
 	// Local variable counter.
 	i int
 }
@@ -82,28 +89,23 @@ func (c *codegen) newFuncScope(decl *ast.FuncDecl, label uint16) *funcScope {
 func (c *codegen) getFuncNameFromDecl(pkgPath string, decl *ast.FuncDecl) string {
 	name := decl.Name.Name
 	if decl.Recv != nil {
-		switch t := decl.Recv.List[0].Type.(type) {
-		case *ast.Ident:
-			name = t.Name + "." + name
-		case *ast.StarExpr:
-			switch t.X.(type) {
-			case *ast.Ident:
-				name = t.X.(*ast.Ident).Name + "." + name
-			case *ast.IndexExpr:
-				// Generic func declaration receiver: func (x *Pointer[T]) Load() *T
-				name = t.X.(*ast.IndexExpr).X.(*ast.Ident).Name + "." + name
-			default:
-				panic(fmt.Errorf("unexpected function `%s` receiver type: %T", name, t.X))
-			}
+		typ := decl.Recv.List[0].Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		switch t := typ.(type) {
 		case *ast.IndexExpr:
-			switch t.X.(type) {
-			case *ast.Ident:
-				// Generic func declaration receiver: func (x Pointer[T]) Load() *T
-				name = t.X.(*ast.Ident).Name + "." + name
-			default:
-				panic(fmt.Errorf("unexpected function `%s` receiver type: %T", name, t.X))
-			}
+			// Generic type receiver: func (x *Pointer[T]) Load() *T
+			typ = t.X
+		case *ast.IndexListExpr:
+			// Generic type receiver: func (x Pair[K, V]) Key() K
+			typ = t.X
+		}
+		id, ok := typ.(*ast.Ident)
+		if !ok {
+			panic(fmt.Errorf("unexpected function `%s` receiver type: %T", name, typ))
 		}
+		name = id.Name + "." + name
 	}
 	return c.getIdentName(pkgPath, name)
 }
@@ -0,0 +1,405 @@
+package compiler
+
+import (
+	"encoding/binary"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/syscalls"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// GasEstimate is a static execution cost estimation of a contract method. All
+// prices are in datoshi and assume the default execution fee factor. Every loop
+// body is counted once (irrespective of loop bounds), so the estimation is a
+// lower bound for methods with loops, recursion or calls to other contracts.
+type GasEstimate struct {
+	// Opcodes is the cost of instructions along the most expensive execution
+	// path of the method (including internal function calls).
+	Opcodes int64 `json:"opcodes"`
+	// Total is the cost of the same path including interop and native calls.
+	Total int64 `json:"total"`
+	// Calls is a sorted list of interop functions and native contract methods
+	// that can be called by the method.
+	Calls []GasCall `json:"calls,omitempty"`
+	// Loops is a list of loops of the method and functions called by it.
+	Loops []GasLoop `json:"loops,omitempty"`
+	// Recursive is set if the method can call itself (directly or not).
+	Recursive bool `json:"recursive,omitempty"`
+	// Dynamic is set if the method calls other contracts or functions that
+	// can't be determined statically.
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+// GasCall is an interop function or native contract method call.
+type GasCall struct {
+	// Name is either the interop name (like System.Storage.Get) or
+	// native contract name and method (like NeoToken.balanceOf).
+	Name string `json:"name"`
+	// Price is the price of a single call.
+	Price int64 `json:"price"`
+}
+
+// GasLoop is a loop found in the contract code.
+type GasLoop struct {
+	// Document is an index of the source file in DebugInfo documents.
+	Document int `json:"document"`
+	Line     int `json:"line"`
+	// Bounded is set for loops with the number of iterations known at
+	// compile time. It's informational only, loop bodies are counted once
+	// anyway.
+	Bounded bool `json:"bounded"`
+}
+
+// IsUpperBound returns true if the actual method execution cost can't exceed
+// the estimation. It's only the case for methods without loops, recursion and
+// calls to other contracts.
+func (g *GasEstimate) IsUpperBound() bool {
+	return !g.Recursive && !g.Dynamic && len(g.Loops) == 0
+}
+
+var (
+	nativePricesOnce sync.Once
+	nativePrices     map[nef.MethodToken]GasCall
+)
+
+// getNativePrice returns the name and price of the native contract method
+// called via the given token, false is returned for non-native contracts.
+func getNativePrice(tok nef.MethodToken) (GasCall, bool) {
+	nativePricesOnce.Do(func() {
+		var (
+			cs = native.NewContracts(config.ProtocolConfiguration{P2PSigExtensions: true})
+			hf = config.Hardforks[len(config.Hardforks)-1]
+		)
+		nativePrices = make(map[nef.MethodToken]GasCall)
+		for _, ctr := range cs.Contracts {
+			md := ctr.Metadata()
+			for _, m := range md.HFSpecificContractMD(&hf).Methods {
+				key := nef.MethodToken{Hash: md.Hash, Method: m.MD.Name, ParamCount: uint16(len(m.MD.Parameters))}
+				nativePrices[key] = GasCall{
+					Name:  md.Name + "." + m.MD.Name,
+					Price: m.CPUFee * interop.DefaultBaseExecFee,
+				}
+			}
+		}
+	})
+	gc, ok := nativePrices[nef.MethodToken{Hash: tok.Hash, Method: tok.Method, ParamCount: tok.ParamCount}]
+	return gc, ok
+}
+
+// gasFunc is an intermediate estimation of a single function.
+type gasFunc struct {
+	opcodes   int64
+	total     int64
+	calls     map[string]int64
+	loops     []GasLoop
+	recursive bool
+	dynamic   bool
+}
+
+type gasEstimator struct {
+	script  []byte
+	tokens  []nef.MethodToken
+	ranges  map[int]DebugRange
+	loops   map[int][]GasLoop
+	funcs   map[int]*gasFunc
+	pending map[int]bool
+}
+
+// estimateGas fills gas estimations for all exported methods in the debug info.
+func (c *codegen) estimateGas(d *DebugInfo, script []byte) {
+	e := &gasEstimator{
+		script:  script,
+		tokens:  c.callTokens,
+		ranges:  make(map[int]DebugRange, len(d.Methods)),
+		loops:   make(map[int][]GasLoop, len(d.Methods)),
+		funcs:   make(map[int]*gasFunc, len(d.Methods)),
+		pending: make(map[int]bool),
+	}
+	for _, m := range d.Methods {
+		name := m.ID
+		if name == manifest.MethodInit {
+			name = "init"
+		}
+		e.ranges[int(m.Range.Start)] = m.Range
+		e.loops[int(m.Range.Start)] = c.loops[name]
+	}
+	for i := range d.Methods {
+		m := &d.Methods[i]
+		if !m.IsExported {
+			continue
+		}
+		f := e.function(int(m.Range.Start))
+		est := &GasEstimate{
+			Opcodes:   f.opcodes,
+			Total:     f.total,
+			Loops:     f.loops,
+			Recursive: f.recursive,
+			Dynamic:   f.dynamic,
+		}
+		for name, price := range f.calls {
+			est.Calls = append(est.Calls, GasCall{Name: name, Price: price})
+		}
+		slices.SortFunc(est.Calls, func(a, b GasCall) int { return strings.Compare(a.Name, b.Name) })
+		m.Gas = est
+	}
+}
+
+// function returns the estimation of the function starting at the given
+// offset.
+func (e *gasEstimator) function(start int) *gasFunc {
+	if f, ok := e.funcs[start]; ok {
+		return f
+	}
+	rng, ok := e.ranges[start]
+	if !ok {
+		// Lambdas and other unknown code.
+		return &gasFunc{dynamic: true}
+	}
+	if e.pending[start] {
+		return &gasFunc{recursive: true}
+	}
+	e.pending[start] = true
+	defer delete(e.pending, start)
+
+	var (
+		f = &gasFunc{
+			calls: make(map[string]int64),
+			loops: slices.Clone(e.loops[start]),
+		}
+		// Instruction offsets and the cost of the most expensive path
+		// starting at each of them.
+		offsets []int
+		succs   = make(map[int][]int)
+		cost    = make(map[int][2]int64) // opcodes and total.
+		ctx     = vm.NewContext(e.script)
+	)
+	ctx.Jump(start)
+	for ctx.NextIP() <= int(rng.End) && ctx.NextIP() < len(e.script) {
+		op, param, err := ctx.Next()
+		if err != nil {
+			break
+		}
+		var (
+			ip     = ctx.IP()
+			next   = ctx.NextIP()
+			opCost = fee.Opcode(interop.DefaultBaseExecFee, op)
+			extra  int64
+		)
+		offsets = append(offsets, ip)
+		switch op {
+		case opcode.JMP, opcode.JMPL, opcode.ENDTRY, opcode.ENDTRYL:
+			succs[ip] = []int{jumpTarget(ip, param)}
+		case opcode.JMPIF, opcode.JMPIFL, opcode.JMPIFNOT, opcode.JMPIFNOTL,
+			opcode.JMPEQ, opcode.JMPEQL, opcode.JMPNE, opcode.JMPNEL,
+			opcode.JMPGT, opcode.JMPGTL, opcode.JMPGE, opcode.JMPGEL,
+			opcode.JMPLT, opcode.JMPLTL, opcode.JMPLE, opcode.JMPLEL:
+			succs[ip] = []int{next, jumpTarget(ip, param)}
+		case opcode.TRY, opcode.TRYL:
+			succs[ip] = []int{next}
+			half := len(param) / 2
+			for _, p := range [][]byte{param[:half], param[half:]} {
+				if t := jumpTarget(ip, p); t != ip {
+					succs[ip] = append(succs[ip], t)
+				}
+			}
+		case opcode.RET, opcode.THROW, opcode.ABORT, opcode.ABORTMSG, opcode.ENDFINALLY:
+		case opcode.CALL, opcode.CALLL:
+			callee := e.function(jumpTarget(ip, param))
+			f.merge(callee)
+			opCost += callee.opcodes
+			extra = callee.total - callee.opcodes
+			succs[ip] = []int{next}
+		case opcode.CALLA:
+			f.dynamic = true
+			succs[ip] = []int{next}
+		case opcode.CALLT:
+			if tok := int(binary.LittleEndian.Uint16(param)); tok < len(e.tokens) {
+				if gc, ok := getNativePrice(e.tokens[tok]); ok {
+					f.calls[gc.Name] = gc.Price
+					extra = gc.Price
+				} else {
+					f.dynamic = true
+				}
+			}
+			succs[ip] = []int{next}
+		case opcode.SYSCALL:
+			id := binary.LittleEndian.Uint32(param)
+			if name, err := interopnames.FromID(id); err == nil {
+				price, _ := syscalls.GetPrice(id)
+				extra = price * interop.DefaultBaseExecFee
+				f.calls[name] = extra
+				if name == interopnames.SystemContractCall {
+					f.dynamic = true
+				}
+			}
+			succs[ip] = []int{next}
+		default:
+			succs[ip] = []int{next}
+		}
+		cost[ip] = [2]int64{opCost, opCost + extra}
+	}
+
+	// Back jumps (loops) are ignored, so every loop body is counted once and
+	// the most expensive path can be found going backwards.
+	for i := len(offsets) - 1; i >= 0; i-- {
+		var (
+			ip   = offsets[i]
+			best [2]int64
+		)
+		for _, s := range succs[ip] {
+			if s <= ip {
+				continue
+			}
+			if c, ok := cost[s]; ok && c[1] > best[1] {
+				best = c
+			}
+		}
+		c := cost[ip]
+		cost[ip] = [2]int64{c[0] + best[0], c[1] + best[1]}
+	}
+	if len(offsets) != 0 {
+		f.opcodes, f.total = cost[start][0], cost[start][1]
+	}
+	e.funcs[start] = f
+	return f
+}
+
+// merge adds calls, loops and flags of the called function to f.
+func (f *gasFunc) merge(callee *gasFunc) {
+	for name, price := range callee.calls {
+		f.calls[name] = price
+	}
+	for _, l := range callee.loops {
+		if !slices.Contains(f.loops, l) {
+			f.loops = append(f.loops, l)
+		}
+	}
+	f.recursive = f.recursive || callee.recursive
+	f.dynamic = f.dynamic || callee.dynamic
+}
+
+// jumpTarget returns the absolute offset of the jump with the given parameter.
+func jumpTarget(ip int, param []byte) int {
+	if len(param) == 1 {
+		return ip + int(int8(param[0]))
+	}
+	return ip + int(int32(binary.LittleEndian.Uint32(param)))
+}
+
+// saveLoop remembers the loop for gas estimation.
+func (c *codegen) saveLoop(n ast.Node) {
+	name := "init"
+	if c.scope != nil {
+		name = c.scope.name
+	}
+	pos := c.buildInfo.config.Fset.Position(n.Pos())
+	c.loops[name] = append(c.loops[name], GasLoop{
+		Document: c.docIndex[pos.Filename],
+		Line:     pos.Line,
+		Bounded:  c.isBoundedLoop(n),
+	})
+}
+
+// isBoundedLoop checks whether the number of loop iterations is known at
+// compile time: that's the case for ranges over arrays, constants and literals
+// and for loops with a constant-initialized counter compared with a constant
+// (using <, <=, > or >=) that is not changed in the loop body.
+func (c *codegen) isBoundedLoop(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.RangeStmt:
+		if _, ok := n.X.(*ast.CompositeLit); ok || c.typeAndValueOf(n.X).Value != nil {
+			return true
+		}
+		typ := c.typeOf(n.X)
+		if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		_, ok := typ.Underlying().(*types.Array)
+		return ok
+	case *ast.ForStmt:
+		init, ok := n.Init.(*ast.AssignStmt)
+		if !ok || len(init.Lhs) != 1 || len(init.Rhs) != 1 || c.typeAndValueOf(init.Rhs[0]).Value == nil {
+			return false
+		}
+		counter, ok := init.Lhs[0].(*ast.Ident)
+		if !ok || !c.isCounterUpdate(n.Post, counter.Name) {
+			return false
+		}
+		cond, ok := n.Cond.(*ast.BinaryExpr)
+		if !ok {
+			return false
+		}
+		switch cond.Op {
+		case token.LSS, token.LEQ, token.GTR, token.GEQ:
+		default:
+			return false
+		}
+		x, xok := cond.X.(*ast.Ident)
+		y, yok := cond.Y.(*ast.Ident)
+		if !(xok && x.Name == counter.Name && c.typeAndValueOf(cond.Y).Value != nil ||
+			yok && y.Name == counter.Name && c.typeAndValueOf(cond.X).Value != nil) {
+			return false
+		}
+		return !isChangedIn(n.Body, counter.Name)
+	default:
+		return false
+	}
+}
+
+// isCounterUpdate checks whether the statement increments or decrements the
+// named variable by a constant.
+func (c *codegen) isCounterUpdate(stmt ast.Stmt, name string) bool {
+	switch s := stmt.(type) {
+	case *ast.IncDecStmt:
+		id, ok := s.X.(*ast.Ident)
+		return ok && id.Name == name
+	case *ast.AssignStmt:
+		if s.Tok != token.ADD_ASSIGN && s.Tok != token.SUB_ASSIGN || len(s.Lhs) != 1 {
+			return false
+		}
+		id, ok := s.Lhs[0].(*ast.Ident)
+		return ok && id.Name == name && c.typeAndValueOf(s.Rhs[0]).Value != nil
+	default:
+		return false
+	}
+}
+
+// isChangedIn checks whether the named variable can be changed by the given
+// statement, i.e. it's assigned, incremented, decremented or its address is
+// taken.
+func isChangedIn(stmt ast.Stmt, name string) bool {
+	var (
+		changed bool
+		isName  = func(e ast.Expr) bool {
+			id, ok := e.(*ast.Ident)
+			return ok && id.Name == name
+		}
+	)
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			changed = changed || slices.ContainsFunc(n.Lhs, isName)
+		case *ast.IncDecStmt:
+			changed = changed || isName(n.X)
+		case *ast.UnaryExpr:
+			changed = changed || n.Op == token.AND && isName(n.X)
+		case *ast.RangeStmt:
+			changed = changed || n.Key != nil && isName(n.Key) || n.Value != nil && isName(n.Value)
+		}
+		return !changed
+	})
+	return changed
+}
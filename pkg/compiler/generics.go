@@ -0,0 +1,279 @@
+package compiler
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// isGenericFuncDecl checks whether the function has type parameters or is a
+// method of a generic type. Such functions are not compiled directly, every
+// instantiation is compiled separately instead.
+func isGenericFuncDecl(decl *ast.FuncDecl) bool {
+	if decl.Type.TypeParams != nil {
+		return true
+	}
+	if decl.Recv == nil {
+		return false
+	}
+	typ := decl.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch typ.(type) {
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		return true
+	}
+	return false
+}
+
+// skipTypeArgs returns the function expression without explicit type
+// arguments, i.e. `f` for `f[int]` and `pkg.f` for `pkg.f[int, string]`.
+// Other expressions (including index expressions like `fs[0]`) are returned
+// as is.
+func (c *codegen) skipTypeArgs(e ast.Expr) ast.Expr {
+	switch t := e.(type) {
+	case *ast.IndexExpr:
+		if c.typeAndValueOf(t.Index).IsType() {
+			return t.X
+		}
+	case *ast.IndexListExpr:
+		return t.X
+	}
+	return e
+}
+
+// derefType returns the pointer base type for pointers and t itself for other
+// types.
+func derefType(t types.Type) types.Type {
+	t = types.Unalias(t)
+	if ptr, ok := t.(*types.Pointer); ok {
+		return types.Unalias(ptr.Elem())
+	}
+	return t
+}
+
+// substType replaces type parameters in t with the type arguments of the
+// generic function instance being compiled.
+func (c *codegen) substType(t types.Type) types.Type {
+	if t == nil || c.scope == nil || len(c.scope.typeMap) == 0 {
+		return t
+	}
+	return substType(t, c.scope.typeMap)
+}
+
+// substType replaces type parameters in t according to m.
+func substType(t types.Type, m map[*types.TypeParam]types.Type) types.Type {
+	switch t := t.(type) {
+	case *types.TypeParam:
+		if r, ok := m[t]; ok {
+			return r
+		}
+	case *types.Alias:
+		return substType(types.Unalias(t), m)
+	case *types.Pointer:
+		if e := substType(t.Elem(), m); e != t.Elem() {
+			return types.NewPointer(e)
+		}
+	case *types.Slice:
+		if e := substType(t.Elem(), m); e != t.Elem() {
+			return types.NewSlice(e)
+		}
+	case *types.Array:
+		if e := substType(t.Elem(), m); e != t.Elem() {
+			return types.NewArray(e, t.Len())
+		}
+	case *types.Map:
+		k, v := substType(t.Key(), m), substType(t.Elem(), m)
+		if k != t.Key() || v != t.Elem() {
+			return types.NewMap(k, v)
+		}
+	case *types.Chan:
+		if e := substType(t.Elem(), m); e != t.Elem() {
+			return types.NewChan(t.Dir(), e)
+		}
+	case *types.Tuple:
+		if vars, ok := substVars(t, m); ok {
+			return types.NewTuple(vars...)
+		}
+	case *types.Signature:
+		params, okP := substVars(t.Params(), m)
+		results, okR := substVars(t.Results(), m)
+		if okP || okR {
+			return types.NewSignatureType(nil, nil, nil, types.NewTuple(params...), types.NewTuple(results...), t.Variadic())
+		}
+	case *types.Struct:
+		var (
+			changed bool
+			fields  = make([]*types.Var, t.NumFields())
+			tags    = make([]string, t.NumFields())
+		)
+		for i := range fields {
+			f := t.Field(i)
+			typ := substType(f.Type(), m)
+			changed = changed || typ != f.Type()
+			fields[i] = types.NewField(f.Pos(), f.Pkg(), f.Name(), typ, f.Embedded())
+			tags[i] = t.Tag(i)
+		}
+		if changed {
+			return types.NewStruct(fields, tags)
+		}
+	case *types.Named:
+		targs := t.TypeArgs()
+		if targs.Len() == 0 {
+			return t
+		}
+		var (
+			changed bool
+			args    = make([]types.Type, targs.Len())
+		)
+		for i := range args {
+			args[i] = substType(targs.At(i), m)
+			changed = changed || args[i] != targs.At(i)
+		}
+		if changed {
+			inst, err := types.Instantiate(nil, t.Origin(), args, false)
+			if err == nil {
+				return inst
+			}
+		}
+	}
+	return t
+}
+
+// substVars replaces type parameters in the types of tuple variables. It
+// returns false if there is nothing to replace.
+func substVars(t *types.Tuple, m map[*types.TypeParam]types.Type) ([]*types.Var, bool) {
+	var (
+		changed bool
+		vars    = make([]*types.Var, t.Len())
+	)
+	for i := range vars {
+		v := t.At(i)
+		typ := substType(v.Type(), m)
+		changed = changed || typ != v.Type()
+		vars[i] = types.NewParam(v.Pos(), v.Pkg(), v.Name(), typ)
+	}
+	return vars, changed
+}
+
+// instanceOf returns the type arguments generic function referenced by the
+// given identifier is instantiated with.
+func (c *codegen) instanceOf(id *ast.Ident) []types.Type {
+	var (
+		inst types.Instance
+		ok   bool
+	)
+	for i := len(c.pkgInfoInline) - 1; i >= 0 && !ok; i-- {
+		inst, ok = c.pkgInfoInline[i].TypesInfo.Instances[id]
+	}
+	if !ok {
+		inst, ok = c.typeInfo.Instances[id]
+	}
+	for _, p := range c.packageCache {
+		if ok {
+			break
+		}
+		inst, ok = p.TypesInfo.Instances[id]
+	}
+	if !ok {
+		return nil
+	}
+	targs := make([]types.Type, inst.TypeArgs.Len())
+	for i := range targs {
+		targs[i] = c.substType(inst.TypeArgs.At(i))
+	}
+	return targs
+}
+
+// receiverTypeArgs returns the type arguments of the generic method receiver
+// expression type.
+func (c *codegen) receiverTypeArgs(recv ast.Expr) []types.Type {
+	named, ok := derefType(c.typeOf(recv)).(*types.Named)
+	if !ok {
+		return nil
+	}
+	targs := make([]types.Type, named.TypeArgs().Len())
+	for i := range targs {
+		targs[i] = named.TypeArgs().At(i)
+	}
+	return targs
+}
+
+// getFuncInstance returns the scope of the generic function f instantiated
+// with the given type arguments. New instances are scheduled for conversion
+// after all other functions.
+func (c *codegen) getFuncInstance(f *funcScope, key string, targs []types.Type) *funcScope {
+	var (
+		argNames = make([]string, len(targs))
+		argKeys  = make([]string, len(targs))
+		qual     = func(p *types.Package) string {
+			if p == f.pkg {
+				return ""
+			}
+			return p.Name()
+		}
+	)
+	for i := range targs {
+		argNames[i] = types.TypeString(targs[i], qual)
+		argKeys[i] = types.TypeString(targs[i], nil)
+	}
+	var (
+		name     = f.name + "[" + strings.Join(argNames, ",") + "]"
+		suffix   = "[" + strings.Join(argKeys, ",") + "]"
+		instance = key + suffix
+	)
+	if f.decl.Recv != nil {
+		// Method of a generic type: pkg.List.Push -> pkg.List[int].Push.
+		dot := strings.LastIndexByte(key, '.')
+		recv := key[:dot]
+		recvName := recv[strings.LastIndexByte(recv, '.')+1:]
+		name = recvName + "[" + strings.Join(argNames, ",") + "]." + f.name
+		instance = recv + suffix + key[dot:]
+	}
+	if inst, ok := c.funcs[instance]; ok {
+		return inst
+	}
+
+	typeMap := make(map[*types.TypeParam]types.Type, len(targs))
+	if sig, ok := c.packageCache[f.pkg.Path()].TypesInfo.Defs[f.decl.Name].Type().(*types.Signature); ok {
+		tparams := sig.TypeParams()
+		if f.decl.Recv != nil {
+			tparams = sig.RecvTypeParams()
+		}
+		for i := range min(tparams.Len(), len(targs)) {
+			typeMap[tparams.At(i)] = targs[i]
+		}
+	}
+	inst := &funcScope{
+		name:      name,
+		decl:      f.decl,
+		pkg:       f.pkg,
+		file:      f.file,
+		label:     c.newLabel(),
+		vars:      newVarScope(),
+		voidCalls: map[*ast.CallExpr]bool{},
+		variables: []string{},
+		typeMap:   typeMap,
+		i:         -1,
+	}
+	c.funcs[instance] = inst
+	c.instances = append(c.instances, inst)
+	return inst
+}
+
+// convertFuncInstances converts all scheduled generic function instances
+// (including the ones requested during conversion).
+func (c *codegen) convertFuncInstances() {
+	for len(c.instances) != 0 && c.prog.Err == nil {
+		f := c.instances[0]
+		c.instances = c.instances[1:]
+
+		pkg := c.packageCache[f.pkg.Path()]
+		c.typeInfo = pkg.TypesInfo
+		c.currPkg = pkg
+		c.fillImportMap(f.file, pkg)
+		c.setLabel(f.label)
+		c.convertFuncScope(f.file, f, f.pkg, false)
+	}
+}
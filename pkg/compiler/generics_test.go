@@ -1,91 +1,180 @@
 package compiler_test
 
 import (
+	"math/big"
 	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 )
 
-func TestGenericMethodReceiver(t *testing.T) {
-	t.Run("star expression", func(t *testing.T) {
+func TestGenericFunc(t *testing.T) {
+	t.Run("inferred", func(t *testing.T) {
 		src := `
-		package receiver
-		type Pointer[T any] struct {
-			value T
+		package sum
+		func sum[V int | int64](vals []V) V {
+			var s V
+			for i := range vals {
+				s += vals[i]
+			}
+			return s
 		}
-		func (x *Pointer[T]) Load() *T {
-			return &x.value
+		func Main() int {
+			return sum([]int{1, 2, 3})
+		}`
+		eval(t, src, big.NewInt(6))
+	})
+	t.Run("explicit", func(t *testing.T) {
+		src := `
+		package sum
+		func zero[T any]() T {
+			var x T
+			return x
 		}
-`
-		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.ErrorIs(t, err, compiler.ErrGenericsUnsuppored)
+		func Main() bool {
+			return zero[int]() == 0 && zero[string]() == "" && !zero[bool]()
+		}`
+		eval(t, src, true)
 	})
-	t.Run("ident expression", func(t *testing.T) {
+	t.Run("string concatenation", func(t *testing.T) {
 		src := `
-		package receiver
-		type Pointer[T any] struct {
-			value T
+		package sum
+		func join[T ~int | ~string](a, b T) T {
+			return a + b
 		}
-		func (x Pointer[T]) Load() *T {
-			return &x.value
+		func Main() string {
+			if join(1, 2) != 3 {
+				panic("bad sum")
+			}
+			return join("ab", "cd")
+		}`
+		eval(t, src, []byte("abcd"))
+	})
+	t.Run("multiple type parameters", func(t *testing.T) {
+		src := `
+		package sum
+		func mapVals[T, R any](vals []T, f func(T) R) []R {
+			res := make([]R, len(vals))
+			for i := range vals {
+				res[i] = f(vals[i])
+			}
+			return res
 		}
-`
-		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.ErrorIs(t, err, compiler.ErrGenericsUnsuppored)
+		func Main() []string {
+			return mapVals([]int{1, 2}, func(i int) string {
+				if i == 1 {
+					return "one"
+				}
+				return "two"
+			})
+		}`
+		eval(t, src, []stackitem.Item{
+			stackitem.NewByteArray([]byte("one")),
+			stackitem.NewByteArray([]byte("two")),
+		})
 	})
+	t.Run("recursive", func(t *testing.T) {
+		src := `
+		package sum
+		func count[T any](vals []T, i int) int {
+			if i == len(vals) {
+				return 0
+			}
+			return 1 + count(vals, i+1)
+		}
+		func Main() int {
+			return count([]string{"a", "b"}, 0) + count([]int{1, 2, 3}, 0)
+		}`
+		eval(t, src, big.NewInt(5))
+	})
+}
+
+func TestGenericType(t *testing.T) {
+	src := `
+		package sum
+		type List[T any] struct {
+			vals []T
+		}
+		func (l *List[T]) Push(v T) {
+			l.vals = append(l.vals, v)
+		}
+		func (l List[T]) Len() int {
+			return len(l.vals)
+		}
+		type Pair[K comparable, V any] struct {
+			Key K
+			Val V
+		}
+		func (p Pair[K, V]) Value() V {
+			return p.Val
+		}
+		func Main() int {
+			l := &List[int]{}
+			l.Push(1)
+			l.Push(2)
+			s := List[string]{}
+			s.Push("a")
+			p := Pair[string, int]{Key: "k", Val: 3}
+			return l.Len() + s.Len() + p.Value()
+		}`
+	eval(t, src, big.NewInt(6))
+}
+
+func TestGenericDebugInfo(t *testing.T) {
+	src := `
+		package sum
+		type List[T any] struct {
+			vals []T
+		}
+		func (l *List[T]) Push(v T) {
+			l.vals = append(l.vals, v)
+		}
+		func mapVals[T any](v T) T {
+			return v
+		}
+		func Main() int {
+			l := &List[int]{}
+			l.Push(1)
+			return mapVals(1)
+		}`
+	_, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+
+	methods := make(map[string]compiler.MethodDebugInfo)
+	for _, m := range di.Methods {
+		methods[m.ID] = m
+	}
+	require.Contains(t, methods, "mapVals[int]")
+	require.Contains(t, methods, "List[int].Push")
+	require.Equal(t, "Integer", methods["mapVals[int]"].ReturnType)
+	require.Equal(t, "Integer", methods["mapVals[int]"].Parameters[0].Type)
+	require.NotContains(t, methods, "mapVals")
 }
 
-func TestGenericFuncArgument(t *testing.T) {
+func TestGenericExportedFunc(t *testing.T) {
 	src := `
 		package sum
-		func SumInts[V int64 | int32 | int16](vals []V) V { // doesn't make sense with NeoVM, but still it's a valid go code.
+		func Sum[V int | int64](vals []V) V {
 			var s V
 			for i := range vals {
 				s += vals[i]
 			}
 			return s
-		}
-`
+		}`
 	_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
 	require.ErrorIs(t, err, compiler.ErrGenericsUnsuppored)
 }
 
-func TestGenericTypeDecl(t *testing.T) {
-	t.Run("global scope", func(t *testing.T) {
-		src := `
-		package sum
-		type List[T any] struct {
-			next *List[T]
-			val  T
-		}
-
-		func Main() any {
-			l := List[int]{}
-			return l
-		}
-`
-		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.ErrorIs(t, err, compiler.ErrGenericsUnsuppored)
-	})
-	t.Run("local scope", func(t *testing.T) {
-		src := `
+func TestGenericImported(t *testing.T) {
+	src := `
 		package sum
-		func Main() any {
-			type (
-				SomeGoodType int
-			
-				List[T any] struct {
-					next *List[T]
-					val  T
-				}
-			)
-			l := List[int]{}
-			return l
-		}
-`
-		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.ErrorIs(t, err, compiler.ErrGenericsUnsuppored)
-	})
+		import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/generic"
+		func Main() int {
+			s := &generic.Stack[string]{}
+			s.Push(generic.Max("a", "b"))
+			return s.Len() + generic.Max(2, 5) + generic.Max[int](1, 0)
+		}`
+	eval(t, src, big.NewInt(7))
 }
@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"go/ast"
 	"go/constant"
+	"go/token"
 	"go/types"
+	"maps"
+	"math/big"
 	"slices"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -115,6 +119,18 @@ func (c *codegen) inlineCall(f *funcScope, n *ast.CallExpr) {
 		c.emitStoreVar("", name)
 	}
 
+	// Type parameters of the inlined generic function instance are
+	// substituted in its body along with the ones of the caller.
+	if len(f.typeMap) != 0 {
+		oldTypeMap := c.scope.typeMap
+		c.scope.typeMap = maps.Clone(oldTypeMap)
+		if c.scope.typeMap == nil {
+			c.scope.typeMap = make(map[*types.TypeParam]types.Type, len(f.typeMap))
+		}
+		maps.Copy(c.scope.typeMap, f.typeMap)
+		defer func() { c.scope.typeMap = oldTypeMap }()
+	}
+
 	c.pkgInfoInline = append(c.pkgInfoInline, pkg)
 	oldMap := c.importMap
 	oldDefers := c.scope.deferStack
@@ -147,6 +163,13 @@ func (c *codegen) processStdlibCall(f *funcScope, args []ast.Expr, hasEllipsis b
 	if f.pkg.Path() == interopPrefix+"/contract" && f.name == "Call" {
 		c.processContractCall(f, args)
 	}
+
+	if f.pkg.Path() == interopPrefix+"/storage" && len(args) > 1 &&
+		(f.name == "Put" || f.name == "Get" || f.name == "Delete" || f.name == "Find") {
+		if prefix, _ := c.storageKeyPrefix(args[1]); len(prefix) != 0 {
+			c.storagePrefixes[string(prefix)] = true
+		}
+	}
 	return eventParams
 }
 
@@ -288,6 +311,79 @@ func (c *codegen) appendInvokedContract(u util.Uint160, method string, flag uint
 	}
 }
 
+// storageKeyPrefix returns the constant prefix of the storage key and
+// whether it's the whole key.
+func (c *codegen) storageKeyPrefix(expr ast.Expr) ([]byte, bool) {
+	tv := c.typeAndValueOf(expr)
+	if tv.Value != nil {
+		switch tv.Value.Kind() {
+		case constant.String:
+			return []byte(constant.StringVal(tv.Value)), true
+		case constant.Int:
+			if i, ok := constant.Int64Val(tv.Value); ok {
+				return bigint.ToBytes(big.NewInt(i)), true
+			}
+		}
+		return nil, false
+	}
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return c.storageKeyPrefix(e.X)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return nil, false
+		}
+		prefix, exact := c.storageKeyPrefix(e.X)
+		if !exact {
+			return prefix, false
+		}
+		suffix, exact := c.storageKeyPrefix(e.Y)
+		return append(prefix, suffix...), exact
+	case *ast.CompositeLit:
+		if !isByteSlice(c.typeOf(e)) {
+			return nil, false
+		}
+		var prefix []byte
+		for _, elt := range e.Elts {
+			if _, ok := elt.(*ast.KeyValueExpr); ok {
+				return prefix, false
+			}
+			v := c.typeAndValueOf(elt).Value
+			if v == nil || v.Kind() != constant.Int {
+				return prefix, false
+			}
+			b, _ := constant.Int64Val(v)
+			prefix = append(prefix, byte(b))
+		}
+		return prefix, true
+	case *ast.CallExpr:
+		if c.typeAndValueOf(e.Fun).IsType() && len(e.Args) == 1 {
+			return c.storageKeyPrefix(e.Args[0])
+		}
+		if !c.typeAndValueOf(e.Fun).IsBuiltin() || len(e.Args) == 0 {
+			return nil, false
+		}
+		if id, ok := e.Fun.(*ast.Ident); !ok || id.Name != "append" {
+			return nil, false
+		}
+		prefix, exact := c.storageKeyPrefix(e.Args[0])
+		for i := 1; exact && i < len(e.Args); i++ {
+			var suffix []byte
+			if e.Ellipsis.IsValid() {
+				suffix, exact = c.storageKeyPrefix(e.Args[i])
+			} else if v := c.typeAndValueOf(e.Args[i]).Value; v != nil && v.Kind() == constant.Int {
+				b, _ := constant.Int64Val(v)
+				suffix = []byte{byte(b)}
+			} else {
+				exact = false
+			}
+			prefix = append(prefix, suffix...)
+		}
+		return prefix, exact
+	}
+	return nil, false
+}
+
 // hasCalls returns true if expression contains any calls.
 // We uses this as a rough heuristic to determine if expression calculation
 // has any side-effects.
@@ -297,12 +393,12 @@ func (c *codegen) hasCalls(expr ast.Expr) bool {
 		ce, ok := n.(*ast.CallExpr)
 		if !has && ok {
 			isFunc := true
-			fun, ok := ce.Fun.(*ast.Ident)
+			fun, ok := c.skipTypeArgs(ce.Fun).(*ast.Ident)
 			if ok {
 				_, isFunc = c.getFuncFromIdent(fun)
 			} else {
 				var sel *ast.SelectorExpr
-				sel, ok = ce.Fun.(*ast.SelectorExpr)
+				sel, ok = c.skipTypeArgs(ce.Fun).(*ast.SelectorExpr)
 				if ok {
 					name, _ := c.getFuncNameFromSelector(sel)
 					_, isFunc = c.funcs[name]
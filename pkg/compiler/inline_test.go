@@ -454,3 +454,16 @@ func TestInlineForeignType(t *testing.T) {
 		}`
 	eval(t, src, big.NewInt(29))
 }
+
+func TestInlineGeneric(t *testing.T) {
+	src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/inline"
+
+		func Main() string {
+			if inline.Join(1, 2) != 3 {
+				panic("bad sum")
+			}
+			return inline.Join("ab", "cd")
+		}`
+	eval(t, src, []byte("abcd"))
+}
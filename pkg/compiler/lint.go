@@ -0,0 +1,507 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"io"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// Lint rule identifiers.
+const (
+	// LintMissingWitness is reported for public methods writing to the storage
+	// without prior runtime.CheckWitness (or calling script hash) check.
+	LintMissingWitness = "missing-witness"
+	// LintUncheckedCall is reported for contract.Call invocations with
+	// the result ignored.
+	LintUncheckedCall = "unchecked-call"
+	// LintReentrancy is reported for storage writes performed after
+	// an external contract call.
+	LintReentrancy = "reentrancy"
+	// LintUnboundedFind is reported for loops over storage.Find results that
+	// can't be terminated before the iterator is exhausted.
+	LintUnboundedFind = "unbounded-find"
+	// LintDeployUpdate is reported for _deploy methods ignoring isUpdate
+	// parameter.
+	LintDeployUpdate = "deploy-update"
+	// LintUndeclaredEvent is reported for notifications missing from the
+	// contract configuration.
+	LintUndeclaredEvent = "undeclared-event"
+	// LintSafeWrite is reported for safe methods writing to the storage.
+	LintSafeWrite = "safe-write"
+)
+
+// LintRules contains descriptions of all lint rules.
+var LintRules = map[string]string{
+	LintMissingWitness:  "Public method writes to the storage without checking witness first",
+	LintUncheckedCall:   "Result of contract.Call is not checked",
+	LintReentrancy:      "Storage is written after an external contract call",
+	LintUnboundedFind:   "Iteration over storage.Find results is not bounded",
+	LintDeployUpdate:    "_deploy method doesn't check isUpdate parameter",
+	LintUndeclaredEvent: "Emitted notification is not declared in the contract configuration",
+	LintSafeWrite:       "Method marked as safe writes to the storage",
+}
+
+// LintIssue is a potential problem found in the contract code.
+type LintIssue struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+// String implements the fmt.Stringer interface.
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s (%s)", i.File, i.Line, i.Column, i.Message, i.Rule)
+}
+
+type (
+	// linter checks the contract code for common security issues.
+	linter struct {
+		fset    *token.FileSet
+		options *Options
+		mainPkg *packages.Package
+		// pkgs maps functions to the packages they are declared in.
+		pkgs map[*types.Func]*packages.Package
+		// decls contains declarations of all non-interop functions.
+		decls map[*types.Func]*ast.FuncDecl
+		// effects contains the list of side effects of every function
+		// in the order of execution.
+		effects map[*types.Func][]effect
+		// nolint contains suppressed rules for file lines.
+		nolint map[string]map[int][]string
+		issues []LintIssue
+	}
+
+	// effect is a function side effect relevant for the linter.
+	effect struct {
+		kind effectKind
+		pos  token.Pos
+		// name is the name of the interop function causing the effect.
+		name string
+	}
+
+	effectKind byte
+)
+
+const (
+	effectWitness effectKind = iota
+	effectWrite
+	effectCall
+)
+
+// Lint compiles the contract and checks it for common NEO-specific security
+// issues. name and r are treated the same way as in CompileWithOptions. Contract configuration (safe methods,
+// overloads and events) is taken from the provided options. Notifications are
+// checked only if NoEventsCheck option is not set.
+func Lint(name string, r io.Reader, o *Options) ([]LintIssue, error) {
+	if o == nil {
+		o = &Options{}
+	}
+	ctx, err := getBuildInfo(name, r)
+	if err != nil {
+		return nil, err
+	}
+	ctx.options = o
+	_, di, err := codeGen(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &linter{
+		fset:    ctx.config.Fset,
+		options: o,
+		mainPkg: ctx.program[0],
+		pkgs:    make(map[*types.Func]*packages.Package),
+		decls:   make(map[*types.Func]*ast.FuncDecl),
+		effects: make(map[*types.Func][]effect),
+		nolint:  make(map[string]map[int][]string),
+	}
+	packages.Visit(ctx.program, nil, func(p *packages.Package) {
+		if isInteropPath(p.PkgPath) {
+			return
+		}
+		for _, f := range p.Syntax {
+			for _, d := range f.Decls {
+				if fd, ok := d.(*ast.FuncDecl); ok && fd.Body != nil {
+					if fn, ok := p.TypesInfo.Defs[fd.Name].(*types.Func); ok {
+						l.decls[fn] = fd
+						l.pkgs[fn] = p
+					}
+				}
+			}
+		}
+	})
+	for _, f := range l.mainPkg.Syntax {
+		l.collectNolint(f)
+	}
+
+	l.checkMethods(di)
+	for _, f := range l.mainPkg.Syntax {
+		l.checkFile(f)
+	}
+	slices.SortFunc(l.issues, func(a, b LintIssue) int {
+		if c := strings.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+	return l.issues, nil
+}
+
+// collectNolint collects `//nolint` and `//nolint:rule1,rule2` comments from
+// the file. The comment suppresses issues on the same line, if it's a part of
+// the function documentation, then it suppresses issues for the whole
+// function.
+func (l *linter) collectNolint(f *ast.File) {
+	add := func(start, end token.Pos, text string) {
+		text = strings.TrimSpace(strings.TrimPrefix(text, "//"))
+		if text != "nolint" && !strings.HasPrefix(text, "nolint:") {
+			return
+		}
+		var rules = []string{""}
+		if r, ok := strings.CutPrefix(text, "nolint:"); ok {
+			if fs := strings.Fields(r); len(fs) != 0 {
+				rules = strings.Split(fs[0], ",")
+			}
+		}
+		s, e := l.fset.Position(start), l.fset.Position(end)
+		lines := l.nolint[s.Filename]
+		if lines == nil {
+			lines = make(map[int][]string)
+			l.nolint[s.Filename] = lines
+		}
+		for line := s.Line; line <= e.Line; line++ {
+			lines[line] = append(lines[line], rules...)
+		}
+	}
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			add(c.Pos(), c.Pos(), c.Text)
+		}
+	}
+	for _, d := range f.Decls {
+		if fd, ok := d.(*ast.FuncDecl); ok && fd.Doc != nil {
+			for _, c := range fd.Doc.List {
+				add(fd.Pos(), fd.End(), c.Text)
+			}
+		}
+	}
+}
+
+// report adds a new issue unless it's suppressed.
+func (l *linter) report(rule string, pos token.Pos, format string, args ...any) {
+	p := l.fset.Position(pos)
+	for _, r := range l.nolint[p.Filename][p.Line] {
+		if r == "" || r == rule {
+			return
+		}
+	}
+	l.issues = append(l.issues, LintIssue{
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+		File:    p.Filename,
+		Line:    p.Line,
+		Column:  p.Column,
+	})
+}
+
+// checkMethods checks side effects of the contract methods.
+func (l *linter) checkMethods(di *DebugInfo) {
+	var methods = make(map[string]*ast.FuncDecl)
+	for fn, d := range l.decls {
+		if l.pkgs[fn] == l.mainPkg && d.Recv == nil {
+			methods[d.Name.Name] = d
+		}
+	}
+	if d, ok := methods["_deploy"]; ok {
+		l.checkDeploy(d)
+	}
+	for _, m := range di.Methods {
+		// _deploy and _initialize are executed by the system only.
+		if !m.IsExported || !m.IsFunction || m.Name.Namespace != di.MainPkg || strings.HasPrefix(m.ID, "_") {
+			continue
+		}
+		d, ok := methods[m.ID]
+		if !ok {
+			continue
+		}
+		var (
+			effects = l.getEffects(l.mainPkg.TypesInfo.Defs[d.Name].(*types.Func))
+			name    = m.Name.Name
+			safe    = slices.Contains(l.options.SafeMethods, name)
+		)
+		if emitName, ok := l.options.Overloads[name]; ok {
+			safe = safe || slices.Contains(l.options.SafeMethods, emitName)
+		}
+
+		var witness, called bool
+		var callName string
+		for _, e := range effects {
+			switch e.kind {
+			case effectWitness:
+				witness = true
+			case effectCall:
+				if !called {
+					called, callName = true, e.name
+				}
+			case effectWrite:
+				if !witness {
+					l.report(LintMissingWitness, e.pos, "method %s writes to the storage via %s without checking witness", m.ID, e.name)
+					witness = true // Report once.
+				}
+				if called {
+					l.report(LintReentrancy, e.pos, "method %s writes to the storage via %s after calling %s", m.ID, e.name, callName)
+					called = false // Report once per call.
+				}
+				if safe {
+					l.report(LintSafeWrite, e.pos, "safe method %s writes to the storage via %s", m.ID, e.name)
+					safe = false // Report once.
+				}
+			}
+		}
+	}
+}
+
+// checkDeploy checks that _deploy method uses isUpdate parameter.
+func (l *linter) checkDeploy(d *ast.FuncDecl) {
+	var params []*ast.Ident
+	for _, f := range d.Type.Params.List {
+		params = append(params, f.Names...)
+	}
+	if len(params) != 2 {
+		return
+	}
+	isUpdate := l.mainPkg.TypesInfo.Defs[params[1]]
+	var used bool
+	if isUpdate != nil {
+		ast.Inspect(d.Body, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && l.mainPkg.TypesInfo.Uses[id] == isUpdate {
+				used = true
+			}
+			return !used
+		})
+	}
+	if !used {
+		l.report(LintDeployUpdate, d.Name.Pos(), "_deploy doesn't check isUpdate parameter, initialization code is executed on every update")
+	}
+}
+
+// getEffects returns the list of side effects of the function including
+// the ones of the functions it calls.
+func (l *linter) getEffects(fn *types.Func) []effect {
+	if es, ok := l.effects[fn]; ok {
+		return es
+	}
+	d, ok := l.decls[fn]
+	if !ok {
+		return nil
+	}
+	l.effects[fn] = nil // Recursive calls don't add anything new.
+
+	var (
+		info = l.pkgs[fn].TypesInfo
+		es   []effect
+		walk func(n ast.Node) bool
+	)
+	walk = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Lambdas are executed at an unknown point.
+			return false
+		case *ast.CallExpr:
+			// Arguments are evaluated before the call itself.
+			ast.Inspect(n.Fun, walk)
+			for _, arg := range n.Args {
+				ast.Inspect(arg, walk)
+			}
+			callee := typeutil.StaticCallee(info, n)
+			if callee == nil {
+				return false
+			}
+			callee = callee.Origin()
+			if callee.Pkg() == nil {
+				return false
+			}
+			if isInteropPath(callee.Pkg().Path()) {
+				if kind, ok := interopEffect(callee); ok {
+					es = append(es, effect{kind: kind, pos: n.Pos(), name: callee.Pkg().Name() + "." + callee.Name()})
+				}
+				return false
+			}
+			for _, e := range l.getEffects(callee) {
+				es = append(es, effect{kind: e.kind, pos: n.Pos(), name: e.name})
+			}
+			return false
+		}
+		return true
+	}
+	ast.Inspect(d.Body, walk)
+	l.effects[fn] = es
+	return es
+}
+
+// interopEffect returns the kind of side effect of the interop function call.
+func interopEffect(fn *types.Func) (effectKind, bool) {
+	path := strings.TrimPrefix(fn.Pkg().Path(), interopPrefix)
+	switch {
+	case path == "/runtime" && (fn.Name() == "CheckWitness" || fn.Name() == "GetCallingScriptHash"):
+		return effectWitness, true
+	case path == "/storage" && (fn.Name() == "Put" || fn.Name() == "Delete"):
+		return effectWrite, true
+	case path == "/contract" && fn.Name() == "Call",
+		strings.HasPrefix(path, "/native/") && fn.Name() == "Transfer":
+		return effectCall, true
+	}
+	return 0, false
+}
+
+// isInteropCall checks whether the expression is a call of the given interop
+// function.
+func (l *linter) isInteropCall(n ast.Expr, pkg, name string) bool {
+	call, ok := n.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn := typeutil.StaticCallee(l.mainPkg.TypesInfo, call)
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == interopPrefix+"/"+pkg && fn.Name() == name
+}
+
+// checkFile checks the main package file for local issues.
+func (l *linter) checkFile(f *ast.File) {
+	var events map[string]bool
+	if !l.options.NoEventsCheck {
+		events = make(map[string]bool, len(l.options.ContractEvents))
+		for _, e := range l.options.ContractEvents {
+			events[e.Name] = true
+		}
+	}
+	info := l.mainPkg.TypesInfo
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			if l.isInteropCall(n.X, "contract", "Call") {
+				l.report(LintUncheckedCall, n.Pos(), "result of contract.Call is not checked")
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i := range n.Rhs {
+					if id, ok := n.Lhs[i].(*ast.Ident); ok && id.Name == "_" && l.isInteropCall(n.Rhs[i], "contract", "Call") {
+						l.report(LintUncheckedCall, n.Rhs[i].Pos(), "result of contract.Call is not checked")
+					}
+				}
+			}
+		case *ast.CallExpr:
+			if events != nil && l.isInteropCall(n, "runtime", "Notify") && len(n.Args) > 0 {
+				if tv := info.Types[n.Args[0]]; tv.Value != nil && tv.Value.Kind() == constant.String {
+					if name := constant.StringVal(tv.Value); !events[name] {
+						l.report(LintUndeclaredEvent, n.Pos(), "event '%s' is not declared in the contract configuration", name)
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if n.Body != nil {
+				l.checkFindLoops(n.Body)
+			}
+		}
+		return true
+	})
+}
+
+// checkFindLoops reports loops over storage.Find iterators that can only be
+// terminated by iterator exhaustion.
+func (l *linter) checkFindLoops(body *ast.BlockStmt) {
+	info := l.mainPkg.TypesInfo
+	found := make(map[types.Object]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i := range n.Rhs {
+					if id, ok := n.Lhs[i].(*ast.Ident); ok && l.isInteropCall(n.Rhs[i], "storage", "Find") {
+						found[info.ObjectOf(id)] = true
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for i := range n.Values {
+					if l.isInteropCall(n.Values[i], "storage", "Find") {
+						found[info.ObjectOf(n.Names[i])] = true
+					}
+				}
+			}
+		case *ast.ForStmt:
+			if !l.isInteropCall(n.Cond, "iterator", "Next") {
+				break
+			}
+			arg := n.Cond.(*ast.CallExpr).Args[0]
+			id, ok := arg.(*ast.Ident)
+			if !ok || !found[info.ObjectOf(id)] || canLeaveLoop(n.Body) {
+				break
+			}
+			l.report(LintUnboundedFind, n.Pos(), "loop over storage.Find results is not bounded")
+		}
+		return true
+	})
+}
+
+// canLeaveLoop checks whether there is a break or return statement in
+// the loop body.
+func canLeaveLoop(body *ast.BlockStmt) bool {
+	var (
+		res   bool
+		depth int // Nested break targets.
+		walk  func(n ast.Node) bool
+	)
+	walk = func(n ast.Node) bool {
+		if res {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			res = true
+		case *ast.BranchStmt:
+			// Labeled breaks and gotos are assumed to leave the loop.
+			res = n.Tok == token.GOTO || n.Tok == token.BREAK && (depth == 0 || n.Label != nil)
+		case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			depth++
+			for _, c := range childNodes(n) {
+				ast.Inspect(c, walk)
+			}
+			depth--
+			return false
+		}
+		return true
+	}
+	ast.Inspect(body, walk)
+	return res
+}
+
+// childNodes returns direct children of the node.
+func childNodes(n ast.Node) []ast.Node {
+	var res []ast.Node
+	ast.Inspect(n, func(c ast.Node) bool {
+		if c == n {
+			return true
+		}
+		if c != nil {
+			res = append(res, c)
+		}
+		return false
+	})
+	return res
+}
@@ -0,0 +1,238 @@
+package compiler_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/stretchr/testify/require"
+)
+
+func lint(t *testing.T, src string, o *compiler.Options) []compiler.LintIssue {
+	issues, err := compiler.Lint("foo.go", strings.NewReader(src), o)
+	require.NoError(t, err)
+	return issues
+}
+
+// requireIssues checks that issues of the specified rule are reported at the
+// given lines.
+func requireIssues(t *testing.T, issues []compiler.LintIssue, rule string, lines ...int) {
+	var actual []int
+	for _, i := range issues {
+		if i.Rule == rule {
+			actual = append(actual, i.Line)
+		}
+	}
+	require.Equal(t, lines, actual, issues)
+}
+
+func TestLintWitness(t *testing.T) {
+	src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func put(key string) {
+			storage.Put(storage.GetContext(), key, 1)
+		}
+		func checkOwner(owner interop.Hash160) {
+			if !runtime.CheckWitness(owner) {
+				panic("not owner")
+			}
+		}
+		func Unprotected(key string) {
+			storage.Put(storage.GetContext(), key, 1)
+		}
+		func UnprotectedNested(key string) {
+			put(key)
+		}
+		func CheckedAfter(owner interop.Hash160, key string) {
+			put(key)
+			checkOwner(owner)
+		}
+		func Protected(owner interop.Hash160, key string) {
+			checkOwner(owner)
+			put(key)
+		}
+		func OnNEP17Payment(from interop.Hash160, amount int, data any) {
+			if !runtime.GetCallingScriptHash().Equals(from) {
+				panic("bad caller")
+			}
+			put("paid")
+		}
+		func Suppressed(key string) {
+			storage.Delete(storage.GetContext(), key) //nolint:missing-witness
+		}
+		//nolint
+		func SuppressedAll(key string) {
+			put(key)
+		}
+		func Read(key string) any {
+			return storage.Get(storage.GetContext(), key)
+		}
+		func _deploy(data any, isUpdate bool) {
+			if !isUpdate {
+				put("init")
+			}
+		}`
+	requireIssues(t, lint(t, src, nil), compiler.LintMissingWitness, 16, 19, 22)
+}
+
+func TestLintUncheckedCall(t *testing.T) {
+	src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		)
+		func Main(h interop.Hash160) bool {
+			contract.Call(h, "a", contract.All)
+			_ = contract.Call(h, "b", contract.All)
+			contract.Call(h, "c", contract.All) //nolint:unchecked-call
+			return contract.Call(h, "d", contract.All).(bool)
+		}`
+	requireIssues(t, lint(t, src, nil), compiler.LintUncheckedCall, 7, 8)
+}
+
+func TestLintReentrancy(t *testing.T) {
+	src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+			"github.com/nspcc-dev/neo-go/pkg/interop/native/gas"
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func Withdraw(h interop.Hash160) {
+			if !runtime.CheckWitness(h) {
+				panic("bad witness")
+			}
+			ctx := storage.GetContext()
+			gas.Transfer(runtime.GetExecutingScriptHash(), h, 1, nil)
+			storage.Delete(ctx, h)
+		}
+		func Safe(h interop.Hash160) bool {
+			if !runtime.CheckWitness(h) {
+				panic("bad witness")
+			}
+			ctx := storage.GetContext()
+			storage.Delete(ctx, h)
+			return contract.Call(h, "onWithdraw", contract.All).(bool)
+		}
+		func Args(h interop.Hash160) {
+			if !runtime.CheckWitness(h) {
+				panic("bad witness")
+			}
+			storage.Put(storage.GetContext(), h, contract.Call(h, "get", contract.ReadOnly))
+		}`
+	requireIssues(t, lint(t, src, nil), compiler.LintReentrancy, 15, 29)
+}
+
+func TestLintUnboundedFind(t *testing.T) {
+	src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func All() int {
+			var n int
+			it := storage.Find(storage.GetReadOnlyContext(), "k", storage.KeysOnly)
+			for iterator.Next(it) {
+				for i := 0; i < 2; i++ {
+					if i == 1 {
+						break
+					}
+				}
+				n++
+			}
+			return n
+		}
+		func Bounded() int {
+			var n int
+			var it = storage.Find(storage.GetReadOnlyContext(), "k", storage.KeysOnly)
+			for iterator.Next(it) {
+				n++
+				if n == 10 {
+					break
+				}
+			}
+			return n
+		}
+		func First() any {
+			it := storage.Find(storage.GetReadOnlyContext(), "k", storage.ValuesOnly)
+			for iterator.Next(it) {
+				return iterator.Value(it)
+			}
+			return nil
+		}`
+	requireIssues(t, lint(t, src, nil), compiler.LintUnboundedFind, 9)
+}
+
+func TestLintDeployUpdate(t *testing.T) {
+	t.Run("unused", func(t *testing.T) {
+		src := `package foo
+			import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+			func _deploy(_ any, isUpdate bool) {
+				storage.Put(storage.GetContext(), "k", 1)
+			}`
+		requireIssues(t, lint(t, src, nil), compiler.LintDeployUpdate, 3)
+	})
+	t.Run("blank", func(t *testing.T) {
+		src := `package foo
+			func _deploy(_ any, _ bool) {}`
+		requireIssues(t, lint(t, src, nil), compiler.LintDeployUpdate, 2)
+	})
+	t.Run("checked", func(t *testing.T) {
+		src := `package foo
+			import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+			func _deploy(_ any, isUpdate bool) {
+				if isUpdate {
+					return
+				}
+				storage.Put(storage.GetContext(), "k", 1)
+			}`
+		requireIssues(t, lint(t, src, nil), compiler.LintDeployUpdate)
+	})
+}
+
+func TestLintEvents(t *testing.T) {
+	src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		const transfer = "Transfer"
+		func Main(name string) {
+			runtime.Notify(transfer, 1)
+			runtime.Notify("Unknown", 2)
+			runtime.Notify(name, 3)
+		}`
+	o := &compiler.Options{ContractEvents: []compiler.HybridEvent{{Name: "Transfer"}}}
+	requireIssues(t, lint(t, src, o), compiler.LintUndeclaredEvent, 6)
+	requireIssues(t, lint(t, src, &compiler.Options{NoEventsCheck: true}), compiler.LintUndeclaredEvent)
+}
+
+func TestLintSafeWrite(t *testing.T) {
+	src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func Get() any {
+			if !runtime.CheckWitness(nil) {
+				return nil
+			}
+			ctx := storage.GetContext()
+			storage.Put(ctx, "cnt", 1)
+			return storage.Get(ctx, "k")
+		}
+		func GetOverloaded(a int) any {
+			if !runtime.CheckWitness(nil) {
+				return nil
+			}
+			storage.Delete(storage.GetContext(), "k")
+			return nil
+		}`
+	o := &compiler.Options{
+		SafeMethods: []string{"get"},
+		Overloads:   map[string]string{"getOverloaded": "get"},
+	}
+	requireIssues(t, lint(t, src, o), compiler.LintSafeWrite, 11, 18)
+}
@@ -0,0 +1,504 @@
+package compiler
+
+import (
+	"encoding/binary"
+	"math/big"
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+type (
+	// optimizer performs dead code elimination and peephole optimizations
+	// over the program with resolved jump offsets. Instructions are never
+	// moved, removed bytes are replaced with NOPs to be dropped by removeNOPs
+	// which also fixes jump offsets, method ranges and sequence points.
+	optimizer struct {
+		b       []byte
+		instrs  []instr
+		index   map[int]int  // Instruction offset -> index in instrs.
+		removed []bool       // Bytes to be removed.
+		targets map[int]bool // Jump and call targets.
+	}
+
+	// instr is a single program instruction.
+	instr struct {
+		ip   int
+		op   opcode.Opcode
+		size int
+		live bool
+	}
+)
+
+// optimizeEnabled returns true if the optimization pass is requested.
+func (c *codegen) optimizeEnabled() bool {
+	return c.buildInfo != nil && c.buildInfo.options != nil && c.buildInfo.options.Optimize
+}
+
+// optimize removes unreachable code, redundant instructions and local slots
+// from the program and returns the updated list of offsets to be removed.
+func (c *codegen) optimize(b []byte, nopOffsets []int) []int {
+	o := newOptimizer(b, nopOffsets)
+
+	var roots []int
+	if c.initEndOffset >= 0 {
+		roots = append(roots, 0)
+	}
+	for ip, info := range c.reverseOffsetMap {
+		if info.name == "_deploy" {
+			roots = append(roots, ip)
+		}
+	}
+	for _, f := range c.funcs {
+		if f.emitted && f.pkg == c.mainPkg.Types &&
+			f.decl.Recv == nil && f.decl.Name.IsExported() {
+			roots = append(roots, int(f.rng.Start))
+		}
+	}
+	slices.Sort(roots)
+
+	reachable, funcs := o.reachable(roots)
+	for i := range o.instrs {
+		if o.instrs[i].live && !reachable[i] {
+			o.remove(i)
+		}
+	}
+	// Drop unreachable functions from debug info together with their
+	// sequence points.
+	for name, f := range c.funcs {
+		if f.emitted && !o.hasLive(int(f.rng.Start), int(f.rng.End)) {
+			delete(c.funcs, name)
+		}
+	}
+	for changed := true; changed; {
+		changed = o.peephole()
+		for _, start := range funcs {
+			changed = o.optimizeLocals(start) || changed
+		}
+	}
+	// Sequence points of removed instructions would otherwise be shifted
+	// to the next remaining one which can belong to another function.
+	for name, points := range c.sequencePoints {
+		c.sequencePoints[name] = slices.DeleteFunc(points, func(p DebugSeqPoint) bool {
+			return p.Opcode < len(o.removed) && o.removed[p.Opcode]
+		})
+	}
+
+	nopOffsets = nopOffsets[:0]
+	for i := range o.removed {
+		if o.removed[i] {
+			nopOffsets = append(nopOffsets, i)
+		}
+	}
+	return nopOffsets
+}
+
+func newOptimizer(b []byte, nopOffsets []int) *optimizer {
+	o := &optimizer{
+		b:       b,
+		index:   make(map[int]int),
+		removed: make([]bool, len(b)),
+		targets: make(map[int]bool),
+	}
+	for _, off := range nopOffsets {
+		o.removed[off] = true
+	}
+	ctx := vm.NewContext(b)
+	for op, _, err := ctx.Next(); err == nil && ctx.IP() < len(b); op, _, err = ctx.Next() {
+		o.index[ctx.IP()] = len(o.instrs)
+		o.instrs = append(o.instrs, instr{
+			ip:   ctx.IP(),
+			op:   op,
+			size: ctx.NextIP() - ctx.IP(),
+			live: !o.removed[ctx.IP()],
+		})
+	}
+	return o
+}
+
+// jumpTargets returns offsets the instruction at the given index can pass
+// control to (except the next instruction).
+func (o *optimizer) jumpTargets(i int) []int {
+	var (
+		in    = o.instrs[i]
+		param = o.b[in.ip+1 : in.ip+in.size]
+	)
+	switch in.op {
+	case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.JMPEQ, opcode.JMPNE,
+		opcode.JMPGT, opcode.JMPGE, opcode.JMPLT, opcode.JMPLE,
+		opcode.CALL, opcode.ENDTRY:
+		return []int{in.ip + int(int8(param[0]))}
+	case opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.JMPEQL, opcode.JMPNEL,
+		opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLTL, opcode.JMPLEL,
+		opcode.CALLL, opcode.PUSHA, opcode.ENDTRYL:
+		return []int{in.ip + int(int32(binary.LittleEndian.Uint32(param)))}
+	case opcode.TRY, opcode.TRYL:
+		var res []int
+		for _, off := range tryOffsets(in.op, param) {
+			if off != 0 {
+				res = append(res, in.ip+off)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+func tryOffsets(op opcode.Opcode, param []byte) []int {
+	if op == opcode.TRY {
+		return []int{int(int8(param[0])), int(int8(param[1]))}
+	}
+	return []int{int(int32(binary.LittleEndian.Uint32(param))), int(int32(binary.LittleEndian.Uint32(param[4:])))}
+}
+
+// hasNext returns true if control can be passed to the next instruction.
+func hasNext(op opcode.Opcode) bool {
+	switch op {
+	case opcode.JMP, opcode.JMPL, opcode.ENDTRY, opcode.ENDTRYL, opcode.ENDFINALLY,
+		opcode.RET, opcode.THROW, opcode.ABORT, opcode.ABORTMSG:
+		return false
+	}
+	return true
+}
+
+// reachable returns the set of instructions reachable from the given roots
+// and the list of function entry points (roots, call and PUSHA targets).
+// It also fills the list of jump targets.
+func (o *optimizer) reachable(roots []int) ([]bool, []int) {
+	var (
+		res   = make([]bool, len(o.instrs))
+		funcs = slices.Clone(roots)
+		queue = slices.Clone(roots)
+	)
+	for _, r := range roots {
+		o.targets[r] = true
+	}
+	for len(queue) != 0 {
+		ip := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		i, ok := o.index[ip]
+		if !ok || res[i] {
+			continue
+		}
+		res[i] = true
+		in := o.instrs[i]
+		for _, t := range o.jumpTargets(i) {
+			o.targets[t] = true
+			if (in.op == opcode.CALL || in.op == opcode.CALLL || in.op == opcode.PUSHA) && !slices.Contains(funcs, t) {
+				funcs = append(funcs, t)
+			}
+			queue = append(queue, t)
+		}
+		if hasNext(in.op) {
+			queue = append(queue, ip+in.size)
+		}
+	}
+	return res, funcs
+}
+
+// hasLive checks whether there are live instructions in the given range.
+func (o *optimizer) hasLive(start, end int) bool {
+	for ip := start; ip <= end && ip < len(o.b); ip++ {
+		if i, ok := o.index[ip]; ok && o.instrs[i].live {
+			return true
+		}
+	}
+	return false
+}
+
+// remove removes the instruction at the given index.
+func (o *optimizer) remove(i int) {
+	o.truncate(i, 0)
+	o.instrs[i].live = false
+}
+
+// truncate removes all instruction bytes after the first n ones.
+func (o *optimizer) truncate(i int, n int) {
+	in := &o.instrs[i]
+	for ip := in.ip + n; ip < in.ip+in.size; ip++ {
+		o.b[ip] = byte(opcode.NOP)
+		o.removed[ip] = true
+	}
+	in.size = n
+}
+
+// replace replaces the instruction at the given index with the given code
+// which must fit into it.
+func (o *optimizer) replace(i int, code []byte) {
+	in := &o.instrs[i]
+	copy(o.b[in.ip:], code)
+	in.op = opcode.Opcode(code[0])
+	o.truncate(i, len(code))
+}
+
+// next returns the index of the next live instruction or -1.
+func (o *optimizer) next(i int) int {
+	for i++; i < len(o.instrs); i++ {
+		if o.instrs[i].live {
+			return i
+		}
+	}
+	return -1
+}
+
+// isTarget checks whether control can be passed to the live instruction at
+// the given index via some jump (including jumps to the preceding removed
+// instructions).
+func (o *optimizer) isTarget(i int) bool {
+	if o.targets[o.instrs[i].ip] {
+		return true
+	}
+	for i--; i >= 0 && !o.instrs[i].live; i-- {
+		if o.targets[o.instrs[i].ip] {
+			return true
+		}
+	}
+	return false
+}
+
+// peephole removes redundant instruction sequences. It returns true if
+// anything was changed.
+func (o *optimizer) peephole() bool {
+	var changed bool
+	for i := range o.instrs {
+		if !o.instrs[i].live {
+			continue
+		}
+		j := o.next(i)
+		if j < 0 || o.isTarget(j) {
+			continue
+		}
+		op, next := o.instrs[i].op, o.instrs[j].op
+		// Values that are pushed without side-effects and dropped immediately.
+		if next == opcode.DROP && (op == opcode.DUP || isPush(op) || isLoad(op)) {
+			o.remove(i)
+			o.remove(j)
+			changed = true
+			continue
+		}
+		if o.foldConstants(i, j) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// foldConstants replaces PUSHINT+unary operation or PUSHINT+PUSHINT+binary
+// operation sequence starting at i (j is the next instruction) with the
+// result.
+func (o *optimizer) foldConstants(i, j int) bool {
+	a, ok := o.intValue(i)
+	if !ok {
+		return false
+	}
+	var (
+		res  = new(big.Int)
+		last = j
+	)
+	switch o.instrs[j].op {
+	case opcode.INC:
+		res.Add(a, big.NewInt(1))
+	case opcode.DEC:
+		res.Sub(a, big.NewInt(1))
+	case opcode.NEGATE:
+		res.Neg(a)
+	default:
+		b, ok := o.intValue(j)
+		if !ok {
+			return false
+		}
+		last = o.next(j)
+		if last < 0 || o.isTarget(last) {
+			return false
+		}
+		switch o.instrs[last].op {
+		case opcode.ADD:
+			res.Add(a, b)
+		case opcode.SUB:
+			res.Sub(a, b)
+		case opcode.MUL:
+			res.Mul(a, b)
+		case opcode.DIV, opcode.MOD:
+			if b.Sign() == 0 {
+				return false
+			}
+			if o.instrs[last].op == opcode.DIV {
+				res.Quo(a, b)
+			} else {
+				res.Rem(a, b)
+			}
+		case opcode.AND:
+			res.And(a, b)
+		case opcode.OR:
+			res.Or(a, b)
+		case opcode.XOR:
+			res.Xor(a, b)
+		default:
+			return false
+		}
+	}
+	if stackitem.CheckIntegerSize(res) != nil {
+		return false
+	}
+	w := io.NewBufBinWriter()
+	if res.IsInt64() {
+		emit.Int(w.BinWriter, res.Int64())
+	} else {
+		emit.BigInt(w.BinWriter, res)
+	}
+	var (
+		code = w.Bytes()
+		in   = &o.instrs[i]
+		end  = o.instrs[last].ip + o.instrs[last].size
+	)
+	// The result can be bigger than the first instruction, but not bigger
+	// than the whole sequence.
+	if len(code) > end-in.ip {
+		return false
+	}
+	for k := i + 1; k <= last; k++ {
+		o.instrs[k].live = false
+	}
+	copy(o.b[in.ip:], code)
+	for ip := in.ip; ip < end; ip++ {
+		o.removed[ip] = ip >= in.ip+len(code)
+		if o.removed[ip] {
+			o.b[ip] = byte(opcode.NOP)
+		}
+	}
+	in.op = opcode.Opcode(code[0])
+	in.size = len(code)
+	return true
+}
+
+// intValue returns the integer pushed by the instruction at the given index.
+func (o *optimizer) intValue(i int) (*big.Int, bool) {
+	in := o.instrs[i]
+	switch {
+	case in.op == opcode.PUSHM1:
+		return big.NewInt(-1), true
+	case opcode.PUSH0 <= in.op && in.op <= opcode.PUSH16:
+		return big.NewInt(int64(in.op - opcode.PUSH0)), true
+	case opcode.PUSHINT8 <= in.op && in.op <= opcode.PUSHINT256:
+		return bigint.FromBytes(o.b[in.ip+1 : in.ip+in.size]), true
+	}
+	return nil, false
+}
+
+func isPush(op opcode.Opcode) bool {
+	return opcode.PUSHINT8 <= op && op <= opcode.PUSH16
+}
+
+func isLoad(op opcode.Opcode) bool {
+	return opcode.LDSFLD0 <= op && op <= opcode.LDSFLD ||
+		opcode.LDLOC0 <= op && op <= opcode.LDLOC ||
+		opcode.LDARG0 <= op && op <= opcode.LDARG
+}
+
+// localIndex returns the local slot index accessed by LDLOC*/STLOC*
+// instruction at the given index, or -1 for other instructions.
+func (o *optimizer) localIndex(i int) (int, bool) {
+	in := o.instrs[i]
+	switch {
+	case opcode.LDLOC0 <= in.op && in.op < opcode.LDLOC:
+		return int(in.op - opcode.LDLOC0), true
+	case in.op == opcode.LDLOC:
+		return int(o.b[in.ip+1]), true
+	case opcode.STLOC0 <= in.op && in.op < opcode.STLOC:
+		return int(in.op - opcode.STLOC0), false
+	case in.op == opcode.STLOC:
+		return int(o.b[in.ip+1]), false
+	}
+	return -1, false
+}
+
+// optimizeLocals removes local variables that are never loaded or are
+// loaded only once right after being stored in the function starting at the
+// given offset. The number of local slots is reduced accordingly. It returns
+// true if anything was changed.
+func (o *optimizer) optimizeLocals(start int) bool {
+	first, ok := o.index[start]
+	if !ok || !o.instrs[first].live || o.instrs[first].op != opcode.INITSLOT {
+		return false
+	}
+	var (
+		loads, stores = make(map[int][]int), make(map[int][]int)
+		seen          = make(map[int]bool)
+		queue         = []int{first}
+	)
+	for len(queue) != 0 {
+		i := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if i < 0 || seen[i] {
+			continue
+		}
+		seen[i] = true
+		in := o.instrs[i]
+		if !in.live {
+			queue = append(queue, o.next(i))
+			continue
+		}
+		if in.op == opcode.INITSLOT && i != first {
+			return false // Unexpected layout, leave it as is.
+		}
+		if idx, isLoad := o.localIndex(i); idx >= 0 {
+			if isLoad {
+				loads[idx] = append(loads[idx], i)
+			} else {
+				stores[idx] = append(stores[idx], i)
+			}
+		}
+		if in.op != opcode.CALL && in.op != opcode.CALLL && in.op != opcode.PUSHA {
+			for _, t := range o.jumpTargets(i) {
+				if k, ok := o.index[t]; ok {
+					queue = append(queue, k)
+				}
+			}
+		}
+		if hasNext(in.op) {
+			queue = append(queue, o.next(i))
+		}
+	}
+
+	var changed bool
+	for idx, st := range stores {
+		ld := loads[idx]
+		switch {
+		case len(ld) == 0:
+			for _, i := range st {
+				o.replace(i, []byte{byte(opcode.DROP)})
+			}
+			delete(stores, idx)
+			changed = true
+		case len(st) == 1 && len(ld) == 1 && o.next(st[0]) == ld[0] && !o.isTarget(ld[0]):
+			o.remove(st[0])
+			o.remove(ld[0])
+			delete(stores, idx)
+			delete(loads, idx)
+			changed = true
+		}
+	}
+
+	count := 0
+	for idx := range loads {
+		count = max(count, idx+1)
+	}
+	for idx := range stores {
+		count = max(count, idx+1)
+	}
+	in := o.instrs[first]
+	switch locals, args := int(o.b[in.ip+1]), o.b[in.ip+2]; {
+	case count == 0 && args == 0:
+		o.remove(first)
+		changed = true
+	case count < locals:
+		o.b[in.ip+1] = byte(count)
+		changed = true
+	}
+	return changed
+}
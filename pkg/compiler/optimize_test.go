@@ -0,0 +1,245 @@
+package compiler_test
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+var optimizeTestCases = []struct {
+	name   string
+	src    string
+	result any
+}{
+	{"constants", `package foo
+		func Main() int {
+			x := 5
+			y := x * 3
+			return y + 1
+		}`, big.NewInt(16)},
+	{"locals", `package foo
+		func Main() int {
+			x := 1
+			y := x + 2
+			unused := y * 3
+			_ = unused
+			return y
+		}`, big.NewInt(3)},
+	{"loop", `package foo
+		func Main() int {
+			sum := 0
+			for i := 0; i < 10; i++ {
+				if i%2 == 0 {
+					continue
+				}
+				sum += i
+			}
+			return sum
+		}`, big.NewInt(25)},
+	{"switch", `package foo
+		func f(x int) int {
+			switch x {
+			case 1:
+				return 10
+			case 2:
+				return 20
+			default:
+				return 30
+			}
+		}
+		func Main() int {
+			return f(1) + f(2) + f(3)
+		}`, big.NewInt(60)},
+	{"unused function", `package foo
+		func used() int { return 42 }
+		func unused() int { return used() + 1 }
+		var callback = unused
+		func Main() int {
+			return used()
+		}`, big.NewInt(42)},
+	{"defer", `package foo
+		var res int
+		func f() {
+			defer func() {
+				if r := recover(); r != nil {
+					res = 7
+				}
+			}()
+			panic("x")
+		}
+		func Main() int {
+			f()
+			return res
+		}`, big.NewInt(7)},
+	{"structs and lambdas", `package foo
+		type pair struct { a, b int }
+		func apply(p *pair, f func(int) int) {
+			p.a = f(p.a)
+		}
+		func Main() int {
+			p := &pair{a: 1, b: 2}
+			apply(p, func(x int) int { return x * 10 })
+			return p.a + p.b
+		}`, big.NewInt(12)},
+}
+
+// compileAndRun compiles the given source and runs its Main method returning
+// the result, the resulting script and GAS consumed.
+func compileAndRun(t testing.TB, src string, optimize bool) (stackitem.Item, []byte, int64) {
+	b, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), &compiler.Options{Optimize: optimize})
+	require.NoError(t, err)
+
+	v := vm.New()
+	v.GasLimit = -1
+	v.SyscallHandler = newStoragePlugin().syscallHandler
+	v.LoadScriptWithFlags(b.Script, callflag.All)
+	var mainOffset, initOffset = -1, -1
+	for _, m := range di.Methods {
+		switch m.ID {
+		case testMainIdent:
+			mainOffset = int(m.Range.Start)
+		case manifest.MethodInit:
+			initOffset = int(m.Range.Start)
+		}
+	}
+	require.True(t, mainOffset >= 0)
+	v.Context().Jump(mainOffset)
+	if initOffset >= 0 {
+		v.Call(initOffset)
+	}
+	require.NoError(t, v.Run())
+	require.Equal(t, 1, v.Estack().Len())
+	return v.Estack().Pop().Item(), b.Script, v.GasConsumed()
+}
+
+func TestOptimize(t *testing.T) {
+	for _, tc := range optimizeTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, plain, plainGas := compileAndRun(t, tc.src, false)
+			require.Equal(t, stackitem.Make(tc.result), res)
+
+			res, opt, optGas := compileAndRun(t, tc.src, true)
+			require.Equal(t, stackitem.Make(tc.result), res)
+			require.LessOrEqual(t, len(opt), len(plain))
+			require.LessOrEqual(t, optGas, plainGas)
+		})
+	}
+
+	t.Run("constant folding", func(t *testing.T) {
+		_, script, _ := compileAndRun(t, optimizeTestCases[0].src, true)
+		require.Equal(t, []byte{byte(opcode.PUSHINT8), 16, byte(opcode.RET)}, script)
+	})
+	t.Run("unused function", func(t *testing.T) {
+		src := `package foo
+			func unused() int { return 1 }
+			func Main() int {
+				return 2
+			}`
+		_, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), &compiler.Options{Optimize: true})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(di.Methods))
+		require.Equal(t, testMainIdent, di.Methods[0].ID)
+		require.Equal(t, compiler.DebugRange{Start: 0, End: 1}, di.Methods[0].Range)
+	})
+	t.Run("single instruction method", func(t *testing.T) {
+		src := `package foo
+			func Main() {}`
+		_, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), &compiler.Options{Optimize: true})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(di.Methods))
+		require.Equal(t, compiler.DebugRange{Start: 0, End: 0}, di.Methods[0].Range)
+	})
+}
+
+func TestOptimizeExamples(t *testing.T) {
+	infos, err := os.ReadDir(examplePath)
+	require.NoError(t, err)
+	for _, info := range infos {
+		if !info.IsDir() || info.Name() == "zkp" {
+			continue
+		}
+		t.Run(info.Name(), func(t *testing.T) {
+			path := filepath.Join(examplePath, info.Name())
+			plain, plainDI, err := compiler.CompileWithOptions(path, nil, nil)
+			require.NoError(t, err)
+			opt, optDI, err := compiler.CompileWithOptions(path, nil, &compiler.Options{Optimize: true})
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(opt.Script), len(plain.Script))
+
+			exported := func(di *compiler.DebugInfo) []string {
+				var res []string
+				for _, m := range di.Methods {
+					if m.IsExported && m.IsFunction && m.Name.Namespace == di.MainPkg {
+						res = append(res, m.ID)
+					}
+				}
+				return res
+			}
+			require.Equal(t, exported(plainDI), exported(optDI))
+			// Sequence points are stored per method name, so methods with
+			// the same name from different packages share them.
+			inMethod := func(off int) bool {
+				for _, m := range optDI.Methods {
+					if int(m.Range.Start) <= off && off <= int(m.Range.End) {
+						return true
+					}
+				}
+				return false
+			}
+			for _, m := range optDI.Methods {
+				require.LessOrEqual(t, m.Range.Start, m.Range.End, m.ID)
+				require.Less(t, int(m.Range.End), len(opt.Script), m.ID)
+				for _, p := range m.SeqPoints {
+					require.True(t, inMethod(p.Opcode), m.ID)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOptimize(b *testing.B) {
+	for _, tc := range optimizeTestCases {
+		for _, optimize := range []bool{false, true} {
+			b.Run(fmt.Sprintf("%s/optimize=%t", tc.name, optimize), func(b *testing.B) {
+				var (
+					script []byte
+					gas    int64
+				)
+				for range b.N {
+					_, script, gas = compileAndRun(b, tc.src, optimize)
+				}
+				b.ReportMetric(float64(len(script)), "bytes")
+				b.ReportMetric(float64(gas), "gas")
+			})
+		}
+	}
+	infos, err := os.ReadDir(examplePath)
+	require.NoError(b, err)
+	for _, info := range infos {
+		if !info.IsDir() || info.Name() == "zkp" {
+			continue
+		}
+		for _, optimize := range []bool{false, true} {
+			b.Run(fmt.Sprintf("examples/%s/optimize=%t", info.Name(), optimize), func(b *testing.B) {
+				var size int
+				for range b.N {
+					f, _, err := compiler.CompileWithOptions(filepath.Join(examplePath, info.Name()), nil, &compiler.Options{Optimize: optimize})
+					require.NoError(b, err)
+					size = len(f.Script)
+				}
+				b.ReportMetric(float64(size), "bytes")
+			})
+		}
+	}
+}
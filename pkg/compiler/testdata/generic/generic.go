@@ -0,0 +1,24 @@
+package generic
+
+// Stack is a simple generic stack.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds v to the stack.
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+// Len returns the number of elements in the stack.
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// Max returns the maximum of two values.
+func Max[T int | string](a, b T) T {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -73,3 +73,8 @@ func AppendInsideInline(val []byte) []byte {
 func ForeignTypeInsideInline() int {
 	return a.GetA()
 }
+
+// Join concatenates strings or sums numbers.
+func Join[T int | string](a, b T) T {
+	return a + b
+}
@@ -29,6 +29,7 @@ type ApplicationConfiguration struct {
 	P2PNotary         P2PNotary           `yaml:"P2PNotary"`
 	StateRoot         StateRoot           `yaml:"StateRoot"`
 	NeoFSBlockFetcher NeoFSBlockFetcher   `yaml:"NeoFSBlockFetcher"`
+	NeoFSStateFetcher NeoFSStateFetcher   `yaml:"NeoFSStateFetcher"`
 }
 
 // EqualsButServices returns true when the o is the same as a except for services
@@ -149,6 +150,15 @@ func (a *ApplicationConfiguration) Validate() error {
 	if err := a.NeoFSBlockFetcher.Validate(); err != nil {
 		return fmt.Errorf("invalid NeoFSBlockFetcher config: %w", err)
 	}
+	if err := a.NeoFSStateFetcher.Validate(); err != nil {
+		return fmt.Errorf("invalid NeoFSStateFetcher config: %w", err)
+	}
+	if err := a.StateCheckpoints.Validate(); err != nil {
+		return fmt.Errorf("invalid StateCheckpoints config: %w", err)
+	}
+	if err := a.Consensus.Validate(); err != nil {
+		return fmt.Errorf("invalid Consensus config: %w", err)
+	}
 	if err := a.RPC.Validate(); err != nil {
 		return fmt.Errorf("invalid RPC config: %w", err)
 	}
@@ -216,6 +216,156 @@ func TestApplicationConfiguration_Validate(t *testing.T) {
 			shouldFail: true,
 			errMsg:     "BQueueSize (5) is lower than OIDBatchSize (10)",
 		},
+		{
+			cfg: ApplicationConfiguration{
+				NeoFSStateFetcher: NeoFSStateFetcher{
+					InternalService: InternalService{Enabled: true},
+					ContainerID:     validContainerID,
+					Addresses:       []string{"127.0.0.1"},
+				},
+			},
+			shouldFail: false,
+		},
+		{
+			cfg: ApplicationConfiguration{
+				NeoFSStateFetcher: NeoFSStateFetcher{
+					InternalService: InternalService{Enabled: true},
+					Path:            "./states",
+				},
+			},
+			shouldFail: false,
+		},
+		{
+			cfg: ApplicationConfiguration{
+				NeoFSStateFetcher: NeoFSStateFetcher{
+					InternalService: InternalService{Enabled: true},
+					ContainerID:     invalidContainerID,
+					Addresses:       []string{"127.0.0.1"},
+				},
+			},
+			shouldFail: true,
+			errMsg:     "invalid NeoFSStateFetcher config: invalid container ID",
+		},
+		{
+			cfg: ApplicationConfiguration{
+				NeoFSStateFetcher: NeoFSStateFetcher{
+					InternalService: InternalService{Enabled: true},
+					ContainerID:     validContainerID,
+				},
+			},
+			shouldFail: true,
+			errMsg:     "invalid NeoFSStateFetcher config: addresses are not set",
+		},
+		{
+			cfg: ApplicationConfiguration{
+				NeoFSStateFetcher: NeoFSStateFetcher{
+					InternalService:        InternalService{Enabled: true},
+					Path:                   "./states",
+					DownloaderWorkersCount: -1,
+				},
+			},
+			shouldFail: true,
+			errMsg:     "invalid NeoFSStateFetcher config: negative DownloaderWorkersCount: -1",
+		},
+		{
+			cfg: ApplicationConfiguration{
+				Ledger: Ledger{
+					StateCheckpoints: StateCheckpoints{Enabled: true, Path: "./checkpoints"},
+				},
+			},
+			shouldFail: false,
+		},
+		{
+			cfg: ApplicationConfiguration{
+				Ledger: Ledger{
+					StateCheckpoints: StateCheckpoints{Enabled: true},
+				},
+			},
+			shouldFail: true,
+			errMsg:     "invalid StateCheckpoints config: path is not set",
+		},
+		{
+			cfg: ApplicationConfiguration{
+				Ledger: Ledger{
+					StateCheckpoints: StateCheckpoints{
+						Enabled: true,
+						Path:    "./checkpoints",
+						NeoFS: NeoFSCheckpoints{
+							InternalService: InternalService{
+								Enabled:      true,
+								UnlockWallet: Wallet{Path: "./wallet.json"},
+							},
+							ContainerID: validContainerID,
+							Addresses:   []string{"127.0.0.1"},
+						},
+					},
+				},
+			},
+			shouldFail: false,
+		},
+		{
+			cfg: ApplicationConfiguration{
+				Ledger: Ledger{
+					StateCheckpoints: StateCheckpoints{
+						NeoFS: NeoFSCheckpoints{
+							InternalService: InternalService{Enabled: true},
+						},
+					},
+				},
+			},
+			shouldFail: true,
+			errMsg:     "invalid StateCheckpoints config: NeoFS uploading is enabled, but checkpoints are not",
+		},
+		{
+			cfg: ApplicationConfiguration{
+				Ledger: Ledger{
+					StateCheckpoints: StateCheckpoints{
+						Enabled: true,
+						Path:    "./checkpoints",
+						NeoFS: NeoFSCheckpoints{
+							InternalService: InternalService{Enabled: true},
+							ContainerID:     invalidContainerID,
+							Addresses:       []string{"127.0.0.1"},
+						},
+					},
+				},
+			},
+			shouldFail: true,
+			errMsg:     "invalid StateCheckpoints config: invalid NeoFS config: invalid container ID",
+		},
+		{
+			cfg: ApplicationConfiguration{
+				Ledger: Ledger{
+					StateCheckpoints: StateCheckpoints{
+						Enabled: true,
+						Path:    "./checkpoints",
+						NeoFS: NeoFSCheckpoints{
+							InternalService: InternalService{Enabled: true},
+							ContainerID:     validContainerID,
+						},
+					},
+				},
+			},
+			shouldFail: true,
+			errMsg:     "invalid StateCheckpoints config: invalid NeoFS config: addresses are not set",
+		},
+		{
+			cfg: ApplicationConfiguration{
+				Ledger: Ledger{
+					StateCheckpoints: StateCheckpoints{
+						Enabled: true,
+						Path:    "./checkpoints",
+						NeoFS: NeoFSCheckpoints{
+							InternalService: InternalService{Enabled: true},
+							ContainerID:     validContainerID,
+							Addresses:       []string{"127.0.0.1"},
+						},
+					},
+				},
+			},
+			shouldFail: true,
+			errMsg:     "invalid StateCheckpoints config: invalid NeoFS config: wallet is not set",
+		},
 		{
 			cfg: ApplicationConfiguration{
 				Logger: Logger{
@@ -225,6 +375,43 @@ func TestApplicationConfiguration_Validate(t *testing.T) {
 			shouldFail: true,
 			errMsg:     "invalid logger config: invalid LogEncoding: unknown",
 		},
+		{
+			cfg: ApplicationConfiguration{
+				Consensus: Consensus{
+					InternalService:     InternalService{Enabled: true},
+					SigningProtectionDB: "./protection.json",
+					Standby: ConsensusStandby{
+						Enabled:   true,
+						LeaseFile: "./lease",
+					},
+				},
+			},
+			shouldFail: false,
+		},
+		{
+			cfg: ApplicationConfiguration{
+				Consensus: Consensus{
+					InternalService:     InternalService{Enabled: true},
+					SigningProtectionDB: "./protection.json",
+					Standby:             ConsensusStandby{Enabled: true},
+				},
+			},
+			shouldFail: true,
+			errMsg:     "invalid Consensus config: standby mode requires LeaseFile",
+		},
+		{
+			cfg: ApplicationConfiguration{
+				Consensus: Consensus{
+					InternalService: InternalService{Enabled: true},
+					Standby: ConsensusStandby{
+						Enabled:   true,
+						LeaseFile: "./lease",
+					},
+				},
+			},
+			shouldFail: true,
+			errMsg:     "standby mode requires SigningProtectionDB",
+		},
 	}
 
 	for _, c := range cases {
@@ -121,6 +121,10 @@ func LoadFile(configPath string, relativePath ...string) (Config, error) {
 	if err != nil {
 		return Config{}, err
 	}
+	err = resolvePasswords(&config.ApplicationConfiguration)
+	if err != nil {
+		return Config{}, err
+	}
 
 	return config, nil
 }
@@ -161,3 +165,28 @@ func updateRelativePaths(relativePath string, config *Config) {
 	updatePath(&config.ApplicationConfiguration.Oracle.UnlockWallet.Path)
 	updatePath(&config.ApplicationConfiguration.StateRoot.UnlockWallet.Path)
 }
+
+// resolvePasswords fetches wallet passwords for enabled services using
+// PasswordCommand if it's set.
+func resolvePasswords(a *ApplicationConfiguration) error {
+	for _, s := range []struct {
+		name    string
+		enabled bool
+		wallet  *Wallet
+	}{
+		{"Consensus", a.Consensus.Enabled, &a.Consensus.UnlockWallet},
+		{"P2PNotary", a.P2PNotary.Enabled, &a.P2PNotary.UnlockWallet},
+		{"Oracle", a.Oracle.Enabled, &a.Oracle.UnlockWallet},
+		{"StateRoot", a.StateRoot.Enabled, &a.StateRoot.UnlockWallet},
+		{"NeoFSBlockFetcher", a.NeoFSBlockFetcher.Enabled, &a.NeoFSBlockFetcher.UnlockWallet},
+		{"NeoFSStateFetcher", a.NeoFSStateFetcher.Enabled, &a.NeoFSStateFetcher.UnlockWallet},
+	} {
+		if !s.enabled {
+			continue
+		}
+		if err := s.wallet.ResolvePassword(); err != nil {
+			return fmt.Errorf("%s wallet: %w", s.name, err)
+		}
+	}
+	return nil
+}
@@ -1,4 +1,57 @@
 package config
 
+import (
+	"errors"
+	"time"
+)
+
 // Consensus contains consensus service configuration.
-type Consensus InternalService
+type Consensus struct {
+	InternalService `yaml:",inline"`
+	// SigningProtectionDB is a path to the local database of signed consensus
+	// messages used to prevent double signing. Optional unless Standby is
+	// enabled.
+	SigningProtectionDB string `yaml:"SigningProtectionDB"`
+	// Standby is a hot-standby mode configuration.
+	Standby ConsensusStandby `yaml:"Standby"`
+}
+
+// ConsensusStandby contains hot-standby mode configuration for consensus
+// nodes. Nodes sharing the same validator key and the same lease file only
+// sign consensus messages while holding the lease.
+type ConsensusStandby struct {
+	Enabled bool `yaml:"Enabled"`
+	// LeaseFile is a path to the lease file shared between nodes.
+	LeaseFile string `yaml:"LeaseFile"`
+	// LeaseTTL is the lease validity period, the holder renews it every
+	// LeaseTTL/3. Three TimePerBlock intervals are used if not set.
+	LeaseTTL time.Duration `yaml:"LeaseTTL"`
+	// MaxClockSkew is the maximum clock difference between nodes, the lease
+	// is only taken over after it's expired by more than that. One second is
+	// used if not set.
+	MaxClockSkew time.Duration `yaml:"MaxClockSkew"`
+	// NodeID is an identifier of the node stored in the lease file, host
+	// name and process ID are used if not set.
+	NodeID string `yaml:"NodeID"`
+}
+
+// Validate checks Consensus for internal consistency. It returns an error if
+// the configuration is invalid.
+func (c *Consensus) Validate() error {
+	if !c.Enabled || !c.Standby.Enabled {
+		return nil
+	}
+	if c.Standby.LeaseFile == "" {
+		return errors.New("standby mode requires LeaseFile")
+	}
+	if c.Standby.LeaseTTL < 0 {
+		return errors.New("negative LeaseTTL")
+	}
+	if c.Standby.MaxClockSkew < 0 {
+		return errors.New("negative MaxClockSkew")
+	}
+	if c.SigningProtectionDB == "" {
+		return errors.New("standby mode requires SigningProtectionDB")
+	}
+	return nil
+}
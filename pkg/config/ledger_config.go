@@ -1,13 +1,22 @@
 package config
 
+import "time"
+
 // Ledger contains core node-specific settings that are not
 // a part of the ProtocolConfiguration (which is common for every node on the
 // network).
 type Ledger struct {
+	// DBStatsInterval sets the interval of DB statistics collection for
+	// Prometheus metrics, statistics are not collected if it's zero.
+	DBStatsInterval time.Duration `yaml:"DBStatsInterval"`
 	// GarbageCollectionPeriod sets the number of blocks to wait before
 	// starting the next MPT garbage collection cycle when RemoveUntraceableBlocks
 	// option is used.
 	GarbageCollectionPeriod uint32 `yaml:"GarbageCollectionPeriod"`
+	// GarbageCollectionChunk sets the maximum number of DB entries checked
+	// by a single incremental garbage collection step (performed after every
+	// persist) when RemoveUntraceableBlocks option is used.
+	GarbageCollectionChunk uint32 `yaml:"GarbageCollectionChunk"`
 	// KeepOnlyLatestState specifies if MPT should only store the latest state.
 	// If true, DB size will be smaller, but older roots won't be accessible.
 	// This value should remain the same for the same database.
@@ -24,6 +33,10 @@ type Ledger struct {
 	SkipBlockVerification bool `yaml:"SkipBlockVerification"`
 	// SaveInvocations enables smart contract invocation data saving.
 	SaveInvocations bool `yaml:"SaveInvocations"`
+	// SaveStorageDiffs enables per-block contract storage changes saving.
+	SaveStorageDiffs bool `yaml:"SaveStorageDiffs"`
+	// StateCheckpoints contains periodic state checkpoints configuration.
+	StateCheckpoints StateCheckpoints `yaml:"StateCheckpoints"`
 }
 
 // Blockchain is a set of settings for core.Blockchain to use, it includes protocol
@@ -15,6 +15,13 @@ type OracleConfiguration struct {
 	RequestTimeout        time.Duration      `yaml:"RequestTimeout"`
 	ResponseTimeout       time.Duration      `yaml:"ResponseTimeout"`
 	UnlockWallet          Wallet             `yaml:"UnlockWallet"`
+	// ExtendedFilters enables JMESPath, XPath and CSV response filters that
+	// are selected by the filter prefix. All oracle nodes of the network
+	// must have the same setting.
+	ExtendedFilters bool `yaml:"ExtendedFilters"`
+	// Schemes contains optional URL scheme handler configurations and
+	// policy overrides for the default ones, indexed by scheme name.
+	Schemes map[string]OracleSchemeConfiguration `yaml:"Schemes"`
 }
 
 // NeoFSConfiguration is a config for the NeoFS service.
@@ -22,3 +29,20 @@ type NeoFSConfiguration struct {
 	Nodes   []string      `yaml:"Nodes"`
 	Timeout time.Duration `yaml:"Timeout"`
 }
+
+// OracleSchemeConfiguration is a config for the oracle URL scheme handler.
+type OracleSchemeConfiguration struct {
+	// Enabled enables optional schemes, https and neofs are always enabled.
+	Enabled bool `yaml:"Enabled"`
+	// Endpoint is the service URL used by the handler (IPFS gateway or
+	// NeoGo RPC node).
+	Endpoint string `yaml:"Endpoint"`
+	// Timeout is the request timeout, RequestTimeout is used by default.
+	Timeout time.Duration `yaml:"Timeout"`
+	// MaxResponseSize limits the size of the fetched data, it can't exceed
+	// the maximum oracle result size which is used by default.
+	MaxResponseSize int `yaml:"MaxResponseSize"`
+	// AllowedHosts is a list of host names allowed to be requested,
+	// "*.example.com" matches any subdomain. Empty list allows any host.
+	AllowedHosts []string `yaml:"AllowedHosts"`
+}
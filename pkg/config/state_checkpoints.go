@@ -0,0 +1,72 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+)
+
+// StateCheckpoints contains configuration for periodic state checkpoints.
+type StateCheckpoints struct {
+	// Enabled turns on checkpoints writing.
+	Enabled bool `yaml:"Enabled"`
+	// Interval is the number of blocks between two adjacent checkpoints.
+	Interval uint32 `yaml:"Interval"`
+	// Path is a local directory to store checkpoint files in.
+	Path string `yaml:"Path"`
+	// NeoFS contains settings for checkpoints uploading to NeoFS.
+	NeoFS NeoFSCheckpoints `yaml:"NeoFS"`
+}
+
+// NeoFSCheckpoints contains configuration for state checkpoints uploading
+// to NeoFS.
+type NeoFSCheckpoints struct {
+	InternalService     `yaml:",inline"`
+	Timeout             time.Duration `yaml:"Timeout"`
+	ContainerID         string        `yaml:"ContainerID"`
+	Addresses           []string      `yaml:"Addresses"`
+	CheckpointAttribute string        `yaml:"CheckpointAttribute"`
+}
+
+// Validate checks StateCheckpoints for internal consistency.
+func (cfg *StateCheckpoints) Validate() error {
+	if !cfg.Enabled {
+		if cfg.NeoFS.Enabled {
+			return errors.New("NeoFS uploading is enabled, but checkpoints are not")
+		}
+		return nil
+	}
+	if cfg.Path == "" {
+		return errors.New("path is not set")
+	}
+	if err := cfg.NeoFS.Validate(); err != nil {
+		return fmt.Errorf("invalid NeoFS config: %w", err)
+	}
+	return nil
+}
+
+// Validate checks NeoFSCheckpoints for internal consistency and ensures
+// that all required fields are properly set. It returns an error if the
+// configuration is invalid or if the ContainerID cannot be properly decoded.
+func (cfg *NeoFSCheckpoints) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.ContainerID == "" {
+		return errors.New("container ID is not set")
+	}
+	var containerID cid.ID
+	err := containerID.DecodeString(cfg.ContainerID)
+	if err != nil {
+		return fmt.Errorf("invalid container ID: %w", err)
+	}
+	if len(cfg.Addresses) == 0 {
+		return errors.New("addresses are not set")
+	}
+	if cfg.UnlockWallet.Path == "" {
+		return errors.New("wallet is not set")
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+)
+
+// NeoFSStateFetcher represents the configuration for the NeoFS StateFetcher
+// service.
+type NeoFSStateFetcher struct {
+	InternalService        `yaml:",inline"`
+	Timeout                time.Duration `yaml:"Timeout"`
+	ContainerID            string        `yaml:"ContainerID"`
+	Addresses              []string      `yaml:"Addresses"`
+	StateAttribute         string        `yaml:"StateAttribute"`
+	DownloaderWorkersCount int           `yaml:"DownloaderWorkersCount"`
+	RangeSize              uint64        `yaml:"RangeSize"`
+	KeyValueBatchSize      int           `yaml:"KeyValueBatchSize"`
+	// Path is a local directory with state snapshot files. If set, snapshots
+	// are read from it instead of NeoFS.
+	Path string `yaml:"Path"`
+}
+
+// Validate checks NeoFSStateFetcher for internal consistency and ensures
+// that all required fields are properly set. It returns an error if the
+// configuration is invalid or if the ContainerID cannot be properly decoded.
+func (cfg *NeoFSStateFetcher) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.DownloaderWorkersCount < 0 {
+		return fmt.Errorf("negative DownloaderWorkersCount: %d", cfg.DownloaderWorkersCount)
+	}
+	if cfg.KeyValueBatchSize < 0 {
+		return fmt.Errorf("negative KeyValueBatchSize: %d", cfg.KeyValueBatchSize)
+	}
+	if cfg.Path != "" {
+		return nil
+	}
+	if cfg.ContainerID == "" {
+		return errors.New("container ID is not set")
+	}
+	var containerID cid.ID
+	err := containerID.DecodeString(cfg.ContainerID)
+	if err != nil {
+		return fmt.Errorf("invalid container ID: %w", err)
+	}
+	if len(cfg.Addresses) == 0 {
+		return errors.New("addresses are not set")
+	}
+	return nil
+}
@@ -1,7 +1,52 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/kballard/go-shellquote"
+)
+
 // Wallet is a wallet info.
 type Wallet struct {
 	Path     string `yaml:"Path"`
 	Password string `yaml:"Password"`
+	// PasswordCommand is a command that is executed to get the password
+	// instead of storing it in plaintext in the Password field. The first
+	// line of its output is used as a password, so it can be a password
+	// manager like `pass show neo/wallet` or OS keyring tool like
+	// `secret-tool lookup service neo-go` or
+	// `security find-generic-password -s neo-go -w`. The command is not
+	// passed to the shell, it's split into arguments using shell quoting rules.
+	PasswordCommand string `yaml:"PasswordCommand"`
+}
+
+// ResolvePassword executes PasswordCommand (if any) and sets Password to its
+// output. It's an error to have both Password and PasswordCommand set.
+func (w *Wallet) ResolvePassword() error {
+	if w.PasswordCommand == "" {
+		return nil
+	}
+	if w.Password != "" {
+		return errors.New("both Password and PasswordCommand are set")
+	}
+	args, err := shellquote.Split(w.PasswordCommand)
+	if err != nil {
+		return fmt.Errorf("invalid PasswordCommand: %w", err)
+	}
+	if len(args) == 0 {
+		return errors.New("empty PasswordCommand")
+	}
+	out, err := exec.Command(args[0], args[1:]...).Output()
+	if err != nil {
+		return fmt.Errorf("PasswordCommand failed: %w", err)
+	}
+	line, _, _ := strings.Cut(string(out), "\n")
+	w.Password = strings.TrimSuffix(line, "\r")
+	if w.Password == "" {
+		return errors.New("PasswordCommand returned no password")
+	}
+	return nil
 }
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletResolvePassword(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no echo binary on Windows")
+	}
+	t.Run("no command", func(t *testing.T) {
+		w := Wallet{Password: "pass"}
+		require.NoError(t, w.ResolvePassword())
+		require.Equal(t, "pass", w.Password)
+	})
+	t.Run("both", func(t *testing.T) {
+		w := Wallet{Password: "pass", PasswordCommand: "echo one"}
+		require.Error(t, w.ResolvePassword())
+	})
+	t.Run("good", func(t *testing.T) {
+		w := Wallet{PasswordCommand: `echo "one two"`}
+		require.NoError(t, w.ResolvePassword())
+		require.Equal(t, "one two", w.Password)
+	})
+	t.Run("empty output", func(t *testing.T) {
+		w := Wallet{PasswordCommand: "echo"}
+		require.Error(t, w.ResolvePassword())
+	})
+	t.Run("bad quoting", func(t *testing.T) {
+		w := Wallet{PasswordCommand: `echo "one`}
+		require.Error(t, w.ResolvePassword())
+	})
+	t.Run("failing command", func(t *testing.T) {
+		w := Wallet{PasswordCommand: "false"}
+		require.Error(t, w.ResolvePassword())
+	})
+}
@@ -18,6 +18,9 @@ type neoBlock struct {
 
 	network   netmode.Magic
 	signature []byte
+	// signGuard is an optional callback checking whether the block can be
+	// signed with the given key.
+	signGuard func(*keys.PrivateKey, *neoBlock) error
 }
 
 var _ dbft.Block[util.Uint256] = (*neoBlock)(nil)
@@ -25,6 +28,11 @@ var _ dbft.Block[util.Uint256] = (*neoBlock)(nil)
 // Sign implements the block.Block interface.
 func (n *neoBlock) Sign(key dbft.PrivateKey) error {
 	k := key.(*keys.PrivateKey)
+	if n.signGuard != nil {
+		if err := n.signGuard(k, n); err != nil {
+			return err
+		}
+	}
 	sig := k.SignHashable(uint32(n.network), &n.Block)
 	n.signature = sig
 	return nil
@@ -3,6 +3,7 @@ package consensus
 import (
 	"errors"
 	"fmt"
+	"os"
 	"slices"
 	"sync/atomic"
 	"time"
@@ -35,6 +36,10 @@ const cacheMaxCapacity = 100
 // defaultTimePerBlock is a period between blocks which is used in Neo.
 const defaultTimePerBlock = 15 * time.Second
 
+// defaultMaxClockSkew is the default maximum clock difference between nodes
+// sharing the standby lease.
+const defaultMaxClockSkew = time.Second
+
 // Number of nanoseconds in millisecond.
 const nsInMs = 1000000
 
@@ -78,6 +83,9 @@ type Service interface {
 	OnPayload(p *npayload.Extensible) error
 	// OnTransaction is a callback to notify the Service about a newly received transaction.
 	OnTransaction(tx *transaction.Transaction)
+	// GetState returns the current dBFT state along with the timeline of
+	// the latest rounds.
+	GetState() State
 }
 
 type service struct {
@@ -107,6 +115,15 @@ type service struct {
 	// before the block is accepted. So, in case of change view, it will contain
 	// an updated value.
 	lastTimestamp uint64
+	// timeline tracks dBFT rounds for monitoring purposes.
+	timeline timeline
+	// protection is a signing protection DB, nil if not used.
+	protection *signingProtection
+	// lease is a standby mode lease, nil if standby mode is disabled.
+	lease *lease
+	// leaseHeld is the lease state after the latest renewal attempt, it's
+	// used for logging only.
+	leaseHeld bool
 }
 
 // Config is a configuration for consensus services.
@@ -133,8 +150,20 @@ type Config struct {
 	// Wallet is a local-node wallet configuration. If the path is empty, then
 	// no wallet will be initialized and the service will be in watch-only mode.
 	Wallet config.Wallet
+	// SigningProtectionDB is a path to the signing protection database that
+	// prevents signing conflicting consensus messages and blocks even after
+	// restarts. It's not used if empty.
+	SigningProtectionDB string
+	// Standby is a hot-standby mode configuration. In this mode the service
+	// only signs anything while holding the lease shared with other nodes
+	// using the same key, it works in watch-only mode otherwise.
+	Standby config.ConsensusStandby
 }
 
+// errLeaseNotHeld is returned when signing is refused because this node
+// doesn't hold the standby lease.
+var errLeaseNotHeld = errors.New("standby lease is not held")
+
 // NewService returns a new consensus.Service instance.
 func NewService(cfg Config) (Service, error) {
 	if cfg.TimePerBlock <= 0 {
@@ -161,7 +190,7 @@ func NewService(cfg Config) (Service, error) {
 	var err error
 
 	if len(cfg.Wallet.Path) > 0 {
-		if srv.wallet, err = wallet.NewWalletFromFile(cfg.Wallet.Path); err != nil {
+		if srv.wallet, err = wallet.NewWalletFromFileWithPassword(cfg.Wallet.Path, cfg.Wallet.Password); err != nil {
 			return nil, err
 		}
 
@@ -174,6 +203,33 @@ func NewService(cfg Config) (Service, error) {
 		}
 	}
 
+	if len(cfg.SigningProtectionDB) > 0 {
+		if srv.protection, err = openSigningProtection(cfg.SigningProtectionDB); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Standby.Enabled {
+		if srv.protection == nil {
+			return nil, errors.New("standby mode requires signing protection DB")
+		}
+		var (
+			ttl   = cfg.Standby.LeaseTTL
+			skew  = cfg.Standby.MaxClockSkew
+			owner = cfg.Standby.NodeID
+		)
+		if ttl <= 0 {
+			ttl = 3 * cfg.TimePerBlock
+		}
+		if skew <= 0 {
+			skew = defaultMaxClockSkew
+		}
+		if len(owner) == 0 {
+			host, _ := os.Hostname()
+			owner = fmt.Sprintf("%s:%d", host, os.Getpid())
+		}
+		srv.lease = newLease(cfg.Standby.LeaseFile, owner, ttl, skew)
+	}
+
 	srv.dbft, err = dbft.New[util.Uint256](
 		dbft.WithTimer[util.Uint256](timer.New()),
 		dbft.WithLogger[util.Uint256](srv.log),
@@ -308,7 +364,11 @@ func (s *service) Start() {
 		s.log.Info("starting consensus service")
 		b, _ := s.Chain.GetBlock(s.Chain.CurrentBlockHash()) // Can't fail, we have some current block!
 		s.lastTimestamp = b.Timestamp
+		if s.lease != nil {
+			s.renewLease()
+		}
 		s.dbft.Start(s.lastTimestamp * nsInMs)
+		s.updateRound()
 		go s.eventLoop()
 	}
 }
@@ -319,6 +379,11 @@ func (s *service) Shutdown() {
 		s.log.Info("stopping consensus service")
 		close(s.quit)
 		<-s.finished
+		if s.lease != nil {
+			if err := s.lease.release(); err != nil {
+				s.log.Warn("can't release standby lease", zap.Error(err))
+			}
+		}
 		if s.wallet != nil {
 			s.wallet.Close()
 		}
@@ -335,18 +400,27 @@ func (s *service) eventLoop() {
 	if b.Timestamp >= s.lastTimestamp {
 		s.handleChainBlock(b)
 	}
+	var leaseTick <-chan time.Time
+	if s.lease != nil {
+		t := time.NewTicker(s.lease.ttl / 3)
+		defer t.Stop()
+		leaseTick = t.C
+	}
 events:
 	for {
 		select {
 		case <-s.quit:
 			s.Chain.UnsubscribeFromBlocks(s.blockEvents)
 			break events
+		case <-leaseTick:
+			s.renewLease()
 		case <-s.dbft.Timer.C():
 			h, v := s.dbft.Timer.Height(), s.dbft.Timer.View()
 			s.log.Debug("timer fired",
 				zap.Uint32("height", h),
 				zap.Uint("view", uint(v)))
 			s.dbft.OnTimeout(h, v)
+			s.updateRound()
 		case msg := <-s.messages:
 			fields := []zap.Field{
 				zap.Uint8("from", msg.message.ValidatorIndex),
@@ -372,9 +446,12 @@ events:
 			}
 
 			s.log.Debug("received message", fields...)
+			s.timeline.onPayload(&msg)
 			s.dbft.OnReceive(&msg)
+			s.updateRound()
 		case tx := <-s.transactions:
 			s.dbft.OnTransaction(tx)
+			s.updateRound()
 		case b := <-s.blockEvents:
 			s.handleChainBlock(b)
 		}
@@ -417,7 +494,69 @@ func (s *service) handleChainBlock(b *coreb.Block) {
 			zap.Uint32("chain index", s.Chain.BlockHeight()))
 		s.postBlock(b)
 		s.dbft.Reset(b.Timestamp * nsInMs)
+		s.updateRound()
+	}
+}
+
+// updateRound starts a new round in the timeline if dBFT has moved to the
+// next height or view.
+func (s *service) updateRound() {
+	s.timeline.update(s.dbft.BlockIndex, s.dbft.ViewNumber, s.dbft.PrimaryIndex, s.dbft.MyIndex, s.dbft.Validators)
+}
+
+// renewLease acquires or renews the standby lease.
+func (s *service) renewLease() {
+	held, err := s.lease.tryAcquire()
+	if err != nil {
+		s.log.Warn("can't acquire standby lease", zap.Error(err))
 	}
+	if held != s.leaseHeld {
+		if held {
+			s.log.Info("standby lease acquired, signing is enabled from the next round")
+		} else {
+			s.log.Warn("standby lease is held by another node, signing is disabled")
+		}
+	}
+	s.leaseHeld = held
+}
+
+// allowSigning checks whether the data of the given kind (consensus message
+// type or block) can be signed by the given key at the given height and view
+// wrt standby lease and signing protection DB.
+func (s *service) allowSigning(pub *keys.PublicKey, kind string, height uint32, view byte, h util.Uint256) error {
+	if s.lease != nil {
+		if !s.lease.valid() {
+			return errLeaseNotHeld
+		}
+		if err := s.lease.check(kind, height, view); err != nil {
+			return err
+		}
+	}
+	if s.protection != nil {
+		if err := s.protection.allow(pub, kind, height, view, h); err != nil {
+			return err
+		}
+	}
+	if s.lease != nil && isHashBound(kind) {
+		return s.lease.recordSigned(height, view, h)
+	}
+	return nil
+}
+
+// allowBlockSigning is a signing guard for the block proposed in the current
+// view.
+func (s *service) allowBlockSigning(key *keys.PrivateKey, b *neoBlock) error {
+	return s.allowSigning(key.PublicKey(), blockKind, b.Index(), s.dbft.ViewNumber, b.Hash())
+}
+
+// allowPayloadSigning checks whether the payload can be signed and sent.
+func (s *service) allowPayloadSigning(pub *keys.PublicKey, p dbft.ConsensusPayload[util.Uint256]) error {
+	return s.allowSigning(pub, p.Type().String(), p.Height(), p.ViewNumber(), p.Hash())
+}
+
+// GetState implements the Service interface.
+func (s *service) GetState() State {
+	return s.timeline.state()
 }
 
 func (s *service) validatePayload(p *Payload) bool {
@@ -432,7 +571,7 @@ func (s *service) validatePayload(p *Payload) bool {
 }
 
 func (s *service) getKeyPair(pubs []dbft.PublicKey) (int, dbft.PrivateKey, dbft.PublicKey) {
-	if s.wallet != nil {
+	if s.wallet != nil && (s.lease == nil || s.lease.valid()) {
 		for i := range pubs {
 			sh := pubs[i].(*keys.PublicKey).GetScriptHash()
 			acc := s.wallet.GetAccount(sh)
@@ -494,7 +633,18 @@ func (s *service) OnTransaction(tx *transaction.Transaction) {
 }
 
 func (s *service) broadcast(p dbft.ConsensusPayload[util.Uint256]) {
-	if err := p.(*Payload).Sign(s.dbft.Priv.(*keys.PrivateKey)); err != nil {
+	s.updateRound()
+	priv := s.dbft.Priv.(*keys.PrivateKey)
+	if err := s.allowPayloadSigning(priv.PublicKey(), p); err != nil {
+		s.log.Warn("consensus payload is not sent",
+			zap.Stringer("type", p.Type()),
+			zap.Uint32("height", p.Height()),
+			zap.Uint("view", uint(p.ViewNumber())),
+			zap.Error(err))
+		return
+	}
+	s.timeline.onPayload(p.(*Payload))
+	if err := p.(*Payload).Sign(priv); err != nil {
 		s.log.Warn("can't sign consensus payload", zap.Error(err))
 	}
 
@@ -634,6 +784,7 @@ func (s *service) processBlock(b dbft.Block[util.Uint256]) error {
 }
 
 func (s *service) postBlock(b *coreb.Block) {
+	s.timeline.accept(b.Index)
 	s.lastTimestamp = max(s.lastTimestamp, b.Timestamp)
 	s.lastProposal = nil
 }
@@ -749,7 +900,7 @@ func convertKeys(validators []dbft.PublicKey) (pubs []*keys.PublicKey) {
 }
 
 func (s *service) newBlockFromContext(ctx *dbft.Context[util.Uint256]) dbft.Block[util.Uint256] {
-	block := &neoBlock{network: s.ProtocolConfiguration.Magic}
+	block := &neoBlock{network: s.ProtocolConfiguration.Magic, signGuard: s.allowBlockSigning}
 
 	block.Block.Timestamp = ctx.Timestamp / nsInMs
 	block.Block.Nonce = ctx.Nonce
@@ -1,6 +1,7 @@
 package consensus
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -391,6 +392,79 @@ func TestService_OnPayload(t *testing.T) {
 	shouldReceive(t, srv.messages)
 }
 
+func TestService_Standby(t *testing.T) {
+	var (
+		dir       = t.TempDir()
+		leasePath = filepath.Join(dir, "lease")
+		active    = newLease(leasePath, "active", time.Hour, time.Second)
+	)
+	ok, err := active.tryAcquire()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	var sent []*npayload.Extensible
+	srv := newTestServiceWithConfig(t, newTestChain(t, false), func(c *Config) {
+		c.Broadcast = func(p *npayload.Extensible) { sent = append(sent, p) }
+		c.SigningProtectionDB = filepath.Join(dir, "protection.json")
+		c.Standby = config.ConsensusStandby{
+			Enabled:   true,
+			LeaseFile: leasePath,
+			NodeID:    "standby",
+		}
+	})
+	srv.renewLease()
+	require.False(t, srv.leaseHeld)
+	srv.dbft.Start(0)
+	require.Equal(t, -1, srv.dbft.MyIndex)
+
+	// Active node has gone.
+	require.NoError(t, active.release())
+	srv.renewLease()
+	require.True(t, srv.leaseHeld)
+	srv.dbft.Reset(0)
+	require.NotEqual(t, -1, srv.dbft.MyIndex)
+
+	// Block can be signed once per height.
+	priv := srv.dbft.Priv.(*keys.PrivateKey)
+	b := srv.newBlockFromContext(&srv.dbft.Context)
+	require.NoError(t, b.Sign(priv))
+	require.NoError(t, b.Sign(priv))
+	b2 := srv.newBlockFromContext(&srv.dbft.Context).(*neoBlock)
+	b2.Block.Nonce++
+	require.ErrorIs(t, b2.Sign(priv), errDoubleSign)
+
+	// Commit with the signature can be sent.
+	srv.broadcast(&Payload{message: message{
+		Type:       commitType,
+		BlockIndex: b.Index(),
+		payload:    &commit{},
+	}})
+	require.Len(t, sent, 1)
+
+	// Signed point is stored in the lease file.
+	rec, err := srv.lease.read()
+	require.NoError(t, err)
+	require.Equal(t, b.Index(), rec.Height)
+	require.Equal(t, b.Hash(), rec.Hash)
+
+	// Nothing is signed without lease.
+	srv.lease = newLease(leasePath, "other", time.Hour, time.Second)
+	require.ErrorIs(t, b.Sign(priv), errLeaseNotHeld)
+}
+
+func TestNewServiceStandbyWithoutProtection(t *testing.T) {
+	bc := newTestChain(t, false)
+	_, err := NewService(Config{
+		Logger: zaptest.NewLogger(t),
+		Chain:  bc,
+		Standby: config.ConsensusStandby{
+			Enabled:   true,
+			LeaseFile: filepath.Join(t.TempDir(), "lease"),
+		},
+	})
+	require.Error(t, err)
+}
+
 func TestVerifyBlock(t *testing.T) {
 	srv := newTestService(t)
 
@@ -501,7 +575,11 @@ func newTestService(t *testing.T) *service {
 }
 
 func newTestServiceWithChain(t *testing.T, bc *core.Blockchain) *service {
-	srv, err := NewService(Config{
+	return newTestServiceWithConfig(t, bc, nil)
+}
+
+func newTestServiceWithConfig(t *testing.T, bc *core.Blockchain, f func(*Config)) *service {
+	cfg := Config{
 		Logger:                zaptest.NewLogger(t),
 		Broadcast:             func(*npayload.Extensible) {},
 		Chain:                 bc,
@@ -514,7 +592,11 @@ func newTestServiceWithChain(t *testing.T, bc *core.Blockchain) *service {
 			Path:     "./testdata/wallet1.json",
 			Password: "one",
 		},
-	})
+	}
+	if f != nil {
+		f(&cfg)
+	}
+	srv, err := NewService(cfg)
 	require.NoError(t, err)
 
 	return srv.(*service)
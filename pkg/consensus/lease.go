@@ -0,0 +1,227 @@
+package consensus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// lease is a file-based lease used in hot-standby mode. Nodes sharing the same
+// validator key compete for the lease and only the holder can sign consensus
+// messages. The holder renews it periodically, so the lease expires if the
+// holder dies allowing some other node to take over. The lease also keeps the
+// latest point signed by any holder, so the node taking over never signs
+// anything at or below it.
+type lease struct {
+	path  string
+	owner string
+	ttl   time.Duration
+	// skew is the maximum clock difference between nodes, the lease can only
+	// be taken over once it's expired by more than that.
+	skew time.Duration
+
+	lock sync.RWMutex
+	// validUntil is the local time the lease held by this node expires at,
+	// zero if the lease is not held.
+	validUntil time.Time
+	// fence is the latest point signed by the previous holder, nil if the
+	// lease was never taken over from another node.
+	fence *leaseRecord
+}
+
+// leaseRecord is the lease file contents.
+type leaseRecord struct {
+	Owner string `json:"owner"`
+	// Expires is a Unix timestamp in milliseconds.
+	Expires int64 `json:"expires"`
+	// Height, View and Hash describe the latest proposal, preparation,
+	// commit or block signed by any lease holder (see isHashBound).
+	Height uint32       `json:"height"`
+	View   byte         `json:"view"`
+	Hash   util.Uint256 `json:"hash"`
+}
+
+func newLease(path string, owner string, ttl time.Duration, skew time.Duration) *lease {
+	return &lease{
+		path:  path,
+		owner: owner,
+		ttl:   ttl,
+		skew:  skew,
+	}
+}
+
+// valid returns true if the lease is held by this node and is not expired.
+func (l *lease) valid() bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return time.Now().Before(l.validUntil)
+}
+
+// tryAcquire tries to acquire or renew the lease. It returns true if the lease
+// is held by this node after the call.
+func (l *lease) tryAcquire() (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	unlock, err := l.lockFile()
+	if err != nil {
+		// Can't renew, but the lease is still valid until it expires.
+		return time.Now().Before(l.validUntil), err
+	}
+	defer unlock()
+
+	// Take the time before reading the file, so that our own expiration
+	// estimation is never later than the one stored in the file.
+	now := time.Now()
+	rec, err := l.read()
+	if err != nil {
+		l.validUntil = time.Time{}
+		return false, err
+	}
+	if rec != nil && rec.Owner != l.owner && now.UnixMilli() <= rec.Expires+l.skew.Milliseconds() {
+		l.validUntil = time.Time{}
+		return false, nil
+	}
+	var newRec = leaseRecord{Owner: l.owner}
+	if rec != nil {
+		newRec = *rec
+		newRec.Owner = l.owner
+		if rec.Owner != l.owner {
+			fence := *rec
+			l.fence = &fence
+		}
+	}
+	newRec.Expires = now.Add(l.ttl).UnixMilli()
+	if err := l.write(newRec); err != nil {
+		l.validUntil = time.Time{}
+		return false, err
+	}
+	l.validUntil = now.Add(l.ttl)
+	return true, nil
+}
+
+// check returns an error if the data of the given kind can't be signed at the
+// given height and view because it's not after the latest point signed by the
+// previous lease holder. Commits and blocks are not signed at the height of
+// this point at all since the previous holder could have committed to
+// something at this height.
+func (l *lease) check(kind string, height uint32, view byte) error {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if l.fence == nil || !isHashBound(kind) {
+		return nil
+	}
+	if !isBefore(l.fence.Height, l.fence.View, height, view) ||
+		(isOncePerHeight(kind) && height <= l.fence.Height) {
+		return fmt.Errorf("%w: %s at height %d, view %d, previous lease holder signed at height %d, view %d",
+			errDoubleSign, kind, height, view, l.fence.Height, l.fence.View)
+	}
+	return nil
+}
+
+// recordSigned stores the point signed by this node in the lease file. It
+// fails if the lease is not held by this node anymore.
+func (l *lease) recordSigned(height uint32, view byte, h util.Uint256) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	unlock, err := l.lockFile()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	rec, err := l.read()
+	if err != nil {
+		return err
+	}
+	if rec == nil || rec.Owner != l.owner || !time.Now().Before(l.validUntil) {
+		return errLeaseNotHeld
+	}
+	if !isBefore(rec.Height, rec.View, height, view) {
+		return nil
+	}
+	rec.Height, rec.View, rec.Hash = height, view, h
+	return l.write(*rec)
+}
+
+// release drops the lease if it's held by this node. The lease file is kept
+// with the latest signed point, but it's expired immediately.
+func (l *lease) release() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.validUntil.IsZero() {
+		return nil
+	}
+	l.validUntil = time.Time{}
+	unlock, err := l.lockFile()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	rec, err := l.read()
+	if err != nil || rec == nil || rec.Owner != l.owner {
+		return err
+	}
+	rec.Expires = 0
+	return l.write(*rec)
+}
+
+// write replaces the lease file contents.
+func (l *lease) write(rec leaseRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if err := writeFileSync(l.path, data); err != nil {
+		return fmt.Errorf("can't write lease file: %w", err)
+	}
+	return nil
+}
+
+// read returns the current lease file contents or nil if there is no lease
+// file.
+func (l *lease) read() (*leaseRecord, error) {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("can't read lease file: %w", err)
+	}
+	rec := new(leaseRecord)
+	if err := json.Unmarshal(data, rec); err != nil {
+		return nil, fmt.Errorf("can't decode lease file: %w", err)
+	}
+	return rec, nil
+}
+
+// lockFile creates an exclusive lock file to serialize lease file updates
+// between nodes. Stale locks (older than lease TTL) are removed.
+func (l *lease) lockFile() (func(), error) {
+	var path = l.path + ".lock"
+	for range 2 {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("can't lock lease file: %w", err)
+		}
+		st, err := os.Stat(path)
+		if err == nil {
+			if time.Since(st.ModTime()) < l.ttl {
+				break
+			}
+			_ = os.Remove(path)
+		}
+	}
+	return nil, errors.New("lease file is locked by another node")
+}
@@ -0,0 +1,139 @@
+package consensus
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lease")
+	l1 := newLease(path, "one", time.Hour, time.Minute)
+	l2 := newLease(path, "two", time.Hour, time.Minute)
+
+	require.False(t, l1.valid())
+	ok, err := l1.tryAcquire()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.True(t, l1.valid())
+
+	ok, err = l2.tryAcquire()
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.False(t, l2.valid())
+
+	// Renewal.
+	ok, err = l1.tryAcquire()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Lease expired by less than the maximum clock skew can't be taken over.
+	data, err := json.Marshal(leaseRecord{Owner: "one", Expires: time.Now().Add(-time.Second).UnixMilli()})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	ok, err = l2.tryAcquire()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Expired lease can be taken over.
+	data, err = json.Marshal(leaseRecord{Owner: "one", Expires: time.Now().Add(-2 * time.Minute).UnixMilli()})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	ok, err = l2.tryAcquire()
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = l1.tryAcquire()
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.False(t, l1.valid())
+
+	// Release by non-holder is no-op.
+	require.NoError(t, l1.release())
+	require.FileExists(t, path)
+	require.NoError(t, l2.release())
+	require.FileExists(t, path)
+	require.False(t, l2.valid())
+	ok, err = l1.tryAcquire()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	t.Run("locked", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path+".lock", nil, 0o600))
+		ok, err := l1.tryAcquire()
+		require.Error(t, err)
+		require.True(t, ok) // Not renewed, but not expired yet.
+		require.True(t, l1.valid())
+
+		// Stale lock is removed.
+		stale := time.Now().Add(-2 * time.Hour)
+		require.NoError(t, os.Chtimes(path+".lock", stale, stale))
+		ok, err = l1.tryAcquire()
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.NoFileExists(t, path+".lock")
+	})
+}
+
+func TestLeaseSignedPoint(t *testing.T) {
+	var (
+		path   = filepath.Join(t.TempDir(), "lease")
+		active = newLease(path, "active", time.Hour, time.Second)
+		backup = newLease(path, "backup", time.Hour, time.Second)
+		commit = dbft.CommitType.String()
+		cv     = dbft.ChangeViewType.String()
+	)
+	ok, err := active.tryAcquire()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, active.check(commit, 1, 0)) // Not taken over, nothing to check.
+	require.NoError(t, active.recordSigned(5, 1, util.Uint256{1}))
+	require.NoError(t, active.recordSigned(4, 0, util.Uint256{2})) // Older points are not stored.
+	require.ErrorIs(t, backup.recordSigned(6, 0, util.Uint256{3}), errLeaseNotHeld)
+
+	// Renewal keeps the point.
+	ok, err = active.tryAcquire()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, active.release())
+
+	ok, err = backup.tryAcquire()
+	require.NoError(t, err)
+	require.True(t, ok)
+	rec, err := backup.read()
+	require.NoError(t, err)
+	require.Equal(t, leaseRecord{Owner: "backup", Expires: rec.Expires, Height: 5, View: 1, Hash: util.Uint256{1}}, *rec)
+
+	for _, tc := range []struct {
+		kind   string
+		height uint32
+		view   byte
+		ok     bool
+	}{
+		{dbft.PrepareRequestType.String(), 5, 1, false},
+		{dbft.PrepareRequestType.String(), 5, 0, false},
+		{dbft.PrepareRequestType.String(), 5, 2, true},
+		{commit, 5, 2, false},
+		{blockKind, 5, 2, false},
+		{commit, 6, 0, true},
+		{blockKind, 6, 0, true},
+		{cv, 5, 0, true},
+		{dbft.RecoveryMessageType.String(), 4, 0, true},
+	} {
+		err := backup.check(tc.kind, tc.height, tc.view)
+		if tc.ok {
+			require.NoError(t, err, "%s %d %d", tc.kind, tc.height, tc.view)
+		} else {
+			require.ErrorIs(t, err, errDoubleSign, "%s %d %d", tc.kind, tc.height, tc.view)
+		}
+	}
+	require.NoError(t, backup.recordSigned(6, 0, util.Uint256{3}))
+	rec, err = backup.read()
+	require.NoError(t, err)
+	require.Equal(t, uint32(6), rec.Height)
+}
@@ -0,0 +1,118 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// dBFT round phases used as metric labels.
+const (
+	phasePrepareRequest  = "prepare_request"
+	phasePrepareResponse = "prepare_response"
+	phaseCommit          = "commit"
+)
+
+// Metrics used in monitoring service.
+var (
+	// consensusHeight prometheus metric.
+	consensusHeight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Index of the block dBFT is working on",
+			Name:      "consensus_height",
+			Namespace: "neogo",
+		},
+	)
+	// consensusView prometheus metric.
+	consensusView = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Current dBFT view number",
+			Name:      "consensus_view",
+			Namespace: "neogo",
+		},
+	)
+	// consensusPhaseTime prometheus metric.
+	consensusPhaseTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Help:      "Time spent in dBFT round phases for accepted blocks",
+			Name:      "consensus_phase_time",
+			Namespace: "neogo",
+		},
+		[]string{"phase"},
+	)
+	// consensusChangeViews prometheus metric.
+	consensusChangeViews = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of ChangeView messages seen by reason",
+			Name:      "consensus_change_views",
+			Namespace: "neogo",
+		},
+		[]string{"reason"},
+	)
+	// consensusRecoveries prometheus metric.
+	consensusRecoveries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of recovery messages seen by type",
+			Name:      "consensus_recovery_messages",
+			Namespace: "neogo",
+		},
+		[]string{"type"},
+	)
+	// consensusMissingValidators prometheus metric.
+	consensusMissingValidators = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of dBFT rounds validator has sent no messages in",
+			Name:      "consensus_missing_validator",
+			Namespace: "neogo",
+		},
+		[]string{"validator"},
+	)
+	// consensusLateValidators prometheus metric.
+	consensusLateValidators = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of accepted blocks validator has not sent Commit for in time",
+			Name:      "consensus_late_validator",
+			Namespace: "neogo",
+		},
+		[]string{"validator"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		consensusHeight,
+		consensusView,
+		consensusPhaseTime,
+		consensusChangeViews,
+		consensusRecoveries,
+		consensusMissingValidators,
+		consensusLateValidators,
+	)
+}
+
+func updateRoundMetrics(height uint32, view byte) {
+	consensusHeight.Set(float64(height))
+	consensusView.Set(float64(view))
+}
+
+func addPhaseTimeMetric(phase string, d time.Duration) {
+	consensusPhaseTime.WithLabelValues(phase).Observe(d.Seconds())
+}
+
+func addChangeViewMetric(reason dbft.ChangeViewReason) {
+	consensusChangeViews.WithLabelValues(reason.String()).Inc()
+}
+
+func addRecoveryMetric(typ dbft.MessageType) {
+	consensusRecoveries.WithLabelValues(typ.String()).Inc()
+}
+
+func addMissingValidatorMetric(pub *keys.PublicKey) {
+	consensusMissingValidators.WithLabelValues(pub.StringCompressed()).Inc()
+}
+
+func addLateValidatorMetric(pub *keys.PublicKey) {
+	consensusLateValidators.WithLabelValues(pub.StringCompressed()).Inc()
+}
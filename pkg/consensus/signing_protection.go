@@ -0,0 +1,232 @@
+package consensus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// blockKind is the signing protection record kind used for block signatures
+// (other kinds are consensus message types).
+const blockKind = "Block"
+
+// errDoubleSign is returned when signing is refused because it conflicts with
+// something signed earlier.
+var errDoubleSign = errors.New("refusing to sign: conflicts with previously signed data")
+
+type (
+	// signingProtection is a persistent record of consensus data signed by
+	// local validator keys. It's used to prevent signing conflicting data
+	// (like two different blocks for the same height) or data for the past
+	// heights and views even after node restarts.
+	signingProtection struct {
+		path string
+
+		lock    sync.Mutex
+		records signingRecords
+	}
+
+	// signingRecords contains the latest signed data per kind (consensus
+	// message type or block) for every key (compressed public key in hex).
+	signingRecords map[string]map[string]signedRecord
+
+	// signedRecord describes the latest signed data of some kind.
+	signedRecord struct {
+		Height uint32       `json:"height"`
+		View   byte         `json:"view"`
+		Hash   util.Uint256 `json:"hash"`
+	}
+)
+
+// openSigningProtection loads signing protection database from the given
+// path, a new one is created if the file doesn't exist.
+func openSigningProtection(path string) (*signingProtection, error) {
+	sp := &signingProtection{
+		path:    path,
+		records: make(signingRecords),
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return sp, sp.save()
+		}
+		return nil, fmt.Errorf("can't read signing protection DB: %w", err)
+	}
+	if err := json.Unmarshal(data, &sp.records); err != nil {
+		return nil, fmt.Errorf("can't decode signing protection DB: %w", err)
+	}
+	if sp.records == nil {
+		sp.records = make(signingRecords)
+	}
+	return sp, nil
+}
+
+// ExportSigningProtection writes signing protection database stored at the
+// given path to w in JSON format.
+func ExportSigningProtection(path string, w io.Writer) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("can't read signing protection DB: %w", err)
+	}
+	var recs signingRecords
+	if err := json.Unmarshal(data, &recs); err != nil {
+		return fmt.Errorf("can't decode signing protection DB: %w", err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(recs)
+}
+
+// ImportSigningProtection merges the signing protection data from r (in the
+// format produced by [ExportSigningProtection]) into the database stored at
+// the given path (it's created if missing). The latest records are kept for
+// every key and kind, so the resulting database never allows to sign
+// anything either of the sources doesn't allow. It must not be used while
+// the node using this database is running.
+func ImportSigningProtection(path string, r io.Reader) error {
+	var recs signingRecords
+	if err := json.NewDecoder(r).Decode(&recs); err != nil {
+		return fmt.Errorf("can't decode signing protection data: %w", err)
+	}
+	for key := range recs {
+		if _, err := keys.NewPublicKeyFromString(key); err != nil {
+			return fmt.Errorf("invalid key %s: %w", key, err)
+		}
+	}
+	sp, err := openSigningProtection(path)
+	if err != nil {
+		return err
+	}
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	for key, kinds := range recs {
+		if sp.records[key] == nil {
+			sp.records[key] = make(map[string]signedRecord)
+		}
+		for kind, rec := range kinds {
+			if old, ok := sp.records[key][kind]; !ok || isBefore(old.Height, old.View, rec.Height, rec.View) {
+				sp.records[key][kind] = rec
+			}
+		}
+	}
+	return sp.save()
+}
+
+// allow checks whether the data with the given hash of the given kind can be
+// signed by pub at the given height and view. If it can, the record is
+// persisted before returning. Only proposals, preparations, commits and
+// blocks (see [isHashBound]) are checked, they can't be signed for heights
+// and views preceding any of the signed ones and they can't be signed with
+// different data for the same height and view (blocks and commits can't be
+// signed with different data for the same height). Other messages can't lead
+// to conflicts, so they're always allowed.
+func (sp *signingProtection) allow(pub *keys.PublicKey, kind string, height uint32, view byte, h util.Uint256) error {
+	if !isHashBound(kind) {
+		return nil
+	}
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+
+	var (
+		key  = pub.StringCompressed()
+		recs = sp.records[key]
+	)
+	for k, r := range recs {
+		if isHashBound(k) && isBefore(height, view, r.Height, r.View) {
+			return fmt.Errorf("%w: %s at height %d, view %d, already signed %s at height %d, view %d",
+				errDoubleSign, kind, height, view, k, r.Height, r.View)
+		}
+	}
+	if last, ok := recs[kind]; ok && last.Height == height && (last.View == view || isOncePerHeight(kind)) {
+		if last.Hash == h {
+			return nil
+		}
+		return fmt.Errorf("%w: %s at height %d, view %d, already signed %s at height %d, view %d",
+			errDoubleSign, kind, height, view, last.Hash.StringLE(), last.Height, last.View)
+	}
+	if recs == nil {
+		recs = make(map[string]signedRecord)
+		sp.records[key] = recs
+	}
+	prev, hadPrev := recs[kind]
+	recs[kind] = signedRecord{Height: height, View: view, Hash: h}
+	if err := sp.save(); err != nil {
+		if hadPrev {
+			recs[kind] = prev
+		} else {
+			delete(recs, kind)
+		}
+		return err
+	}
+	return nil
+}
+
+// isHashBound returns true for kinds of data that can only be signed once per
+// height and view (or height only in case of blocks). Other messages (like
+// ChangeView) can be resent with different contents.
+func isHashBound(kind string) bool {
+	switch kind {
+	case blockKind, dbft.PrepareRequestType.String(), dbft.PrepareResponseType.String(), dbft.CommitType.String():
+		return true
+	}
+	return false
+}
+
+// isOncePerHeight returns true for kinds of data that can only be signed once
+// per height irrespective of view. A block can only be committed once per
+// height, so Commit can't be changed in subsequent views as well.
+func isOncePerHeight(kind string) bool {
+	return kind == blockKind || kind == dbft.CommitType.String()
+}
+
+// isBefore checks whether height and view h1, v1 precede h2, v2.
+func isBefore(h1 uint32, v1 byte, h2 uint32, v2 byte) bool {
+	return h1 < h2 || (h1 == h2 && v1 < v2)
+}
+
+// save writes the database to disk making sure it's synced.
+func (sp *signingProtection) save() error {
+	data, err := json.Marshal(sp.records)
+	if err != nil {
+		return err
+	}
+	return writeFileSync(sp.path, data)
+}
+
+// writeFileSync atomically replaces the file at the given path with the data
+// given and syncs it to disk.
+func writeFileSync(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if errC := tmp.Close(); err == nil {
+		err = errC
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	_ = dir.Sync() // Not supported on some platforms.
+	return nil
+}
@@ -0,0 +1,109 @@
+package consensus
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningProtection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "protection.json")
+	sp, err := openSigningProtection(path)
+	require.NoError(t, err)
+	require.FileExists(t, path)
+
+	_, pub := getTestValidator(0)
+	_, pub1 := getTestValidator(1)
+	var (
+		h1, h2   = random.Uint256(), random.Uint256()
+		commit   = dbft.CommitType.String()
+		prepReq  = dbft.PrepareRequestType.String()
+		prepResp = dbft.PrepareResponseType.String()
+		cv       = dbft.ChangeViewType.String()
+	)
+
+	require.NoError(t, sp.allow(pub, commit, 10, 0, h1))
+	require.NoError(t, sp.allow(pub, commit, 10, 0, h1)) // Same data.
+	require.ErrorIs(t, sp.allow(pub, commit, 10, 0, h2), errDoubleSign)
+	require.ErrorIs(t, sp.allow(pub, commit, 10, 1, h2), errDoubleSign) // Next view.
+	require.ErrorIs(t, sp.allow(pub, commit, 9, 0, h2), errDoubleSign)
+	require.NoError(t, sp.allow(pub1, commit, 10, 0, h2)) // Other key.
+
+	require.NoError(t, sp.allow(pub, prepReq, 10, 0, h1))
+	require.ErrorIs(t, sp.allow(pub, prepReq, 10, 0, h2), errDoubleSign)
+	require.NoError(t, sp.allow(pub, prepResp, 10, 0, h1))
+	require.ErrorIs(t, sp.allow(pub, prepResp, 10, 0, h2), errDoubleSign)
+	require.NoError(t, sp.allow(pub, cv, 10, 0, h1))
+	require.NoError(t, sp.allow(pub, cv, 10, 0, h2))      // ChangeView can be resent.
+	require.NoError(t, sp.allow(pub, prepReq, 10, 1, h2)) // Next view.
+	require.ErrorIs(t, sp.allow(pub, prepReq, 10, 0, h1), errDoubleSign)
+	require.NoError(t, sp.allow(pub, cv, 10, 0, h1)) // Past view, but it can't conflict with anything.
+
+	require.NoError(t, sp.allow(pub, blockKind, 10, 1, h1))
+	require.ErrorIs(t, sp.allow(pub, blockKind, 10, 2, h2), errDoubleSign) // Once per height.
+	require.NoError(t, sp.allow(pub, blockKind, 10, 1, h1))
+
+	// Records survive restart.
+	sp, err = openSigningProtection(path)
+	require.NoError(t, err)
+	require.ErrorIs(t, sp.allow(pub, commit, 10, 0, h2), errDoubleSign)
+	require.ErrorIs(t, sp.allow(pub, prepReq, 10, 1, h1), errDoubleSign)
+	require.NoError(t, sp.allow(pub, commit, 11, 0, h2))
+
+	// View changes and recovery messages are not restricted by commits.
+	require.NoError(t, sp.allow(pub, cv, 11, 0, h1))
+	require.NoError(t, sp.allow(pub, cv, 10, 3, h1))
+	require.NoError(t, sp.allow(pub, dbft.RecoveryRequestType.String(), 10, 0, h1))
+	require.NoError(t, sp.allow(pub, dbft.RecoveryMessageType.String(), 9, 0, h1))
+	require.ErrorIs(t, sp.allow(pub, prepResp, 10, 5, h1), errDoubleSign)
+
+	t.Run("corrupted", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+		_, err := openSigningProtection(path)
+		require.Error(t, err)
+	})
+}
+
+func TestSigningProtectionExportImport(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		src    = filepath.Join(dir, "src.json")
+		dst    = filepath.Join(dir, "dst.json")
+		commit = dbft.CommitType.String()
+		h1, h2 = random.Uint256(), random.Uint256()
+	)
+	_, pub := getTestValidator(0)
+	_, pub1 := getTestValidator(1)
+
+	sp, err := openSigningProtection(src)
+	require.NoError(t, err)
+	require.NoError(t, sp.allow(pub, commit, 10, 0, h1))
+	require.NoError(t, sp.allow(pub1, commit, 5, 0, h1))
+
+	dsp, err := openSigningProtection(dst)
+	require.NoError(t, err)
+	require.NoError(t, dsp.allow(pub1, commit, 7, 0, h2))
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, ExportSigningProtection(src, buf))
+	require.NoError(t, ImportSigningProtection(dst, buf))
+
+	dsp, err = openSigningProtection(dst)
+	require.NoError(t, err)
+	require.ErrorIs(t, dsp.allow(pub, commit, 10, 0, h2), errDoubleSign) // Imported.
+	require.ErrorIs(t, dsp.allow(pub, commit, 10, 1, h2), errDoubleSign) // Imported, next view.
+	require.ErrorIs(t, dsp.allow(pub1, commit, 6, 0, h1), errDoubleSign) // Local one is newer.
+	require.NoError(t, dsp.allow(pub1, commit, 8, 0, h1))
+
+	t.Run("missing", func(t *testing.T) {
+		require.Error(t, ExportSigningProtection(filepath.Join(dir, "missing"), buf))
+	})
+	t.Run("bad key", func(t *testing.T) {
+		require.Error(t, ImportSigningProtection(dst, bytes.NewBufferString(`{"abc":{}}`)))
+	})
+}
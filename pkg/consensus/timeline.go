@@ -0,0 +1,238 @@
+package consensus
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// maxRoundHistory is the number of finished dBFT rounds kept by the timeline.
+const maxRoundHistory = 32
+
+// State is a snapshot of the consensus service state.
+type State struct {
+	// Height is the index of the block being agreed upon.
+	Height uint32
+	// View is the current view number.
+	View byte
+	// Primary is the index of the primary (speaker) node for the current view.
+	Primary uint
+	// Index is the index of this node in Validators, -1 if it's not a
+	// validator (watch-only mode).
+	Index int
+	// Validators is the list of validators for the current height.
+	Validators []*keys.PublicKey
+	// Rounds contains timelines of the latest rounds, ordered from the
+	// oldest to the newest one. The last element is the current round.
+	Rounds []Round
+}
+
+// Round is a timeline of a single dBFT round (a view at some height).
+type Round struct {
+	// Height is the index of the block being agreed upon.
+	Height uint32
+	// View is the view number.
+	View byte
+	// Primary is the index of the primary (speaker) node for this view.
+	Primary uint
+	// Start is the time this round was started at.
+	Start time.Time
+	// PrepareRequest is the time PrepareRequest was sent or received, zero
+	// if there was none.
+	PrepareRequest time.Time
+	// Commit is the time the first Commit was sent or received, i.e. when
+	// some node collected enough PrepareResponses. Zero if there was none.
+	Commit time.Time
+	// Accepted is the time the block was accepted, zero if the round has
+	// not produced a block.
+	Accepted time.Time
+	// ChangeViews counts ChangeView messages by their reasons.
+	ChangeViews map[dbft.ChangeViewReason]int
+	// RecoveryRequests is the number of RecoveryRequest messages seen.
+	RecoveryRequests int
+	// RecoveryMessages is the number of RecoveryMessage messages seen.
+	RecoveryMessages int
+	// Late contains validators that have sent some messages in this round,
+	// but their Commit wasn't received before the block was accepted.
+	Late []*keys.PublicKey
+	// Missing contains validators no messages were received from in this
+	// round.
+	Missing []*keys.PublicKey
+
+	validators []*keys.PublicKey
+	seen       []bool
+	committed  []bool
+}
+
+// timeline tracks dBFT rounds for monitoring purposes. It's updated from the
+// consensus event loop and can be read concurrently.
+type timeline struct {
+	lock    sync.RWMutex
+	myIndex int
+	current *Round
+	history []Round
+}
+
+// update starts a new round if the given height or view differs from the
+// current one.
+func (t *timeline) update(height uint32, view byte, primary uint, myIndex int, validators []dbft.PublicKey) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.current != nil && t.current.Height == height && t.current.View == view {
+		return
+	}
+	t.finish()
+	t.myIndex = myIndex
+	t.current = &Round{
+		Height:      height,
+		View:        view,
+		Primary:     primary,
+		Start:       time.Now(),
+		ChangeViews: make(map[dbft.ChangeViewReason]int),
+		validators:  convertKeys(validators),
+		seen:        make([]bool, len(validators)),
+		committed:   make([]bool, len(validators)),
+	}
+	updateRoundMetrics(height, view)
+}
+
+// finish moves the current round into history updating metrics. It must be
+// called with the lock held.
+func (t *timeline) finish() {
+	if t.current == nil {
+		return
+	}
+	r := t.current
+	r.Missing = r.missing()
+	for _, pub := range r.Missing {
+		addMissingValidatorMetric(pub)
+	}
+	for _, pub := range r.Late {
+		addLateValidatorMetric(pub)
+	}
+	if len(t.history) == maxRoundHistory {
+		t.history = slices.Delete(t.history, 0, 1)
+	}
+	t.history = append(t.history, *r)
+	t.current = nil
+}
+
+// onPayload records the consensus message (either sent or received).
+func (t *timeline) onPayload(p *Payload) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	r := t.current
+	if r == nil || r.Height != p.Height() {
+		return
+	}
+	var (
+		idx     = int(p.ValidatorIndex())
+		curView = p.ViewNumber() == r.View
+		now     = time.Now()
+	)
+	if idx < len(r.seen) && p.Type() != dbft.RecoveryRequestType && p.Type() != dbft.RecoveryMessageType {
+		r.seen[idx] = true
+	}
+	switch p.Type() {
+	case dbft.PrepareRequestType:
+		if curView && r.PrepareRequest.IsZero() {
+			r.PrepareRequest = now
+		}
+	case dbft.CommitType:
+		if !curView {
+			break
+		}
+		if r.Commit.IsZero() {
+			r.Commit = now
+		}
+		if idx < len(r.committed) {
+			r.committed[idx] = true
+		}
+	case dbft.ChangeViewType:
+		reason := p.GetChangeView().Reason()
+		r.ChangeViews[reason]++
+		addChangeViewMetric(reason)
+	case dbft.RecoveryRequestType:
+		r.RecoveryRequests++
+		addRecoveryMetric(p.Type())
+	case dbft.RecoveryMessageType:
+		r.RecoveryMessages++
+		addRecoveryMetric(p.Type())
+	}
+}
+
+// accept marks the current round as the one that has produced a block with
+// the given index.
+func (t *timeline) accept(index uint32) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	r := t.current
+	if r == nil || r.Height != index || !r.Accepted.IsZero() {
+		return
+	}
+	r.Accepted = time.Now()
+	r.Late = r.late()
+	if !r.PrepareRequest.IsZero() {
+		addPhaseTimeMetric(phasePrepareRequest, r.PrepareRequest.Sub(r.Start))
+		if !r.Commit.IsZero() {
+			addPhaseTimeMetric(phasePrepareResponse, r.Commit.Sub(r.PrepareRequest))
+		}
+	}
+	if !r.Commit.IsZero() {
+		addPhaseTimeMetric(phaseCommit, r.Accepted.Sub(r.Commit))
+	}
+}
+
+// state returns the current state snapshot.
+func (t *timeline) state() State {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	var res = State{
+		Index:  -1,
+		Rounds: make([]Round, 0, len(t.history)+1),
+	}
+	res.Rounds = append(res.Rounds, t.history...)
+	if r := t.current; r != nil {
+		res.Height = r.Height
+		res.View = r.View
+		res.Primary = r.Primary
+		res.Index = t.myIndex
+		res.Validators = r.validators
+		cur := *r
+		cur.ChangeViews = maps.Clone(r.ChangeViews)
+		cur.Missing = r.missing()
+		res.Rounds = append(res.Rounds, cur)
+	}
+	return res
+}
+
+// missing returns validators that haven't sent anything in this round.
+func (r *Round) missing() []*keys.PublicKey {
+	var res []*keys.PublicKey
+	for i := range r.validators {
+		if !r.seen[i] {
+			res = append(res, r.validators[i])
+		}
+	}
+	return res
+}
+
+// late returns validators that have participated in this round, but haven't
+// sent their Commit yet.
+func (r *Round) late() []*keys.PublicKey {
+	var res []*keys.PublicKey
+	for i := range r.validators {
+		if r.seen[i] && !r.committed[i] {
+			res = append(res, r.validators[i])
+		}
+	}
+	return res
+}
@@ -0,0 +1,82 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeline(t *testing.T) {
+	var (
+		tl   timeline
+		pubs = make([]dbft.PublicKey, 4)
+		vals = make([]*keys.PublicKey, 4)
+	)
+	for i := range pubs {
+		_, vals[i] = getTestValidator(i)
+		pubs[i] = vals[i]
+	}
+	newMsg := func(typ messageType, index byte, height uint32, view byte, p io.Serializable) *Payload {
+		return &Payload{message: message{Type: typ, ValidatorIndex: index, BlockIndex: height, ViewNumber: view, payload: p}}
+	}
+
+	require.Equal(t, -1, tl.state().Index)
+
+	tl.update(10, 0, 1, 0, pubs)
+	tl.onPayload(newMsg(prepareRequestType, 1, 10, 0, &prepareRequest{}))
+	tl.onPayload(newMsg(prepareResponseType, 0, 10, 0, &prepareResponse{}))
+	tl.onPayload(newMsg(changeViewType, 2, 10, 0, &changeView{newViewNumber: 1, reason: dbft.CVTxNotFound}))
+	tl.onPayload(newMsg(recoveryRequestType, 2, 10, 0, &recoveryRequest{}))
+	tl.onPayload(newMsg(commitType, 0, 10, 0, &commit{}))
+	tl.onPayload(newMsg(commitType, 1, 10, 0, &commit{}))
+	tl.onPayload(newMsg(commitType, 3, 9, 0, &commit{})) // Other height, ignored.
+
+	st := tl.state()
+	require.Equal(t, uint32(10), st.Height)
+	require.Equal(t, uint(1), st.Primary)
+	require.Equal(t, 0, st.Index)
+	require.Equal(t, vals, st.Validators)
+	require.Len(t, st.Rounds, 1)
+	r := st.Rounds[0]
+	require.False(t, r.PrepareRequest.IsZero())
+	require.False(t, r.Commit.IsZero())
+	require.True(t, r.Accepted.IsZero())
+	require.Equal(t, map[dbft.ChangeViewReason]int{dbft.CVTxNotFound: 1}, r.ChangeViews)
+	require.Equal(t, 1, r.RecoveryRequests)
+	require.Equal(t, []*keys.PublicKey{vals[3]}, r.Missing)
+
+	tl.accept(9) // Wrong height.
+	require.True(t, tl.state().Rounds[0].Accepted.IsZero())
+	tl.accept(10)
+
+	// View change at the next height.
+	tl.update(11, 0, 2, 0, pubs)
+	tl.update(11, 0, 2, 0, pubs) // No-op.
+	tl.update(11, 1, 3, 0, pubs)
+
+	st = tl.state()
+	require.Equal(t, byte(1), st.View)
+	require.Len(t, st.Rounds, 3)
+
+	r = st.Rounds[0]
+	require.Equal(t, uint32(10), r.Height)
+	require.False(t, r.Accepted.IsZero())
+	require.Equal(t, []*keys.PublicKey{vals[2]}, r.Late)
+	require.Equal(t, []*keys.PublicKey{vals[3]}, r.Missing)
+
+	r = st.Rounds[1]
+	require.Equal(t, uint32(11), r.Height)
+	require.Equal(t, byte(0), r.View)
+	require.True(t, r.Accepted.IsZero())
+	require.Nil(t, r.Late)
+	require.Equal(t, vals, r.Missing)
+
+	for range maxRoundHistory {
+		tl.update(st.Height+1, 0, 0, 0, pubs)
+		st = tl.state()
+	}
+	require.Len(t, st.Rounds, maxRoundHistory+1)
+}
@@ -2,7 +2,7 @@ package core
 
 import (
 	"bytes"
-	"encoding/binary"
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -17,9 +17,11 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/limits"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/checkpoint"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/syscalls"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
@@ -52,6 +54,7 @@ const (
 	// multisignature account during native GAS contract initialization.
 	DefaultInitialGAS                      = 52000000_00000000
 	defaultGCPeriod                        = 10000
+	defaultGCChunk                         = 20000
 	defaultMemPoolSize                     = 50000
 	defaultP2PNotaryRequestPayloadPoolSize = 1000
 	defaultMaxBlockSize                    = 262144
@@ -186,6 +189,10 @@ type Blockchain struct {
 	// removal (performed in storeBlock()) with transfer/MPT GC (tryRunGC())
 	gcBlockTimes *lru.Cache[uint32, uint64]
 
+	// gc is the state of the current incremental garbage collection
+	// cycle, it's only accessed from the Run() goroutine.
+	gc gcState
+
 	// Stop synchronization mechanisms.
 	stopCh      chan struct{}
 	runToExitCh chan struct{}
@@ -215,6 +222,16 @@ type Blockchain struct {
 
 	stateRoot *stateroot.Module
 
+	// stateCheckpoints passes state checkpoints to be written to the
+	// checkpoint writer routine.
+	stateCheckpoints chan *checkpoint.Header
+	// checkpointOrigin is the index of the full state checkpoint being
+	// written (zero if there is none), storage values for this height are
+	// saved by storeBlock until it's done. Protected by lock.
+	checkpointOrigin uint32
+	// checkpointUploader is the service written checkpoints are passed to.
+	checkpointUploader atomic.Pointer[CheckpointUploader]
+
 	// Notification subsystem.
 	events  chan bcEvent
 	subCh   chan any
@@ -241,6 +258,8 @@ type StateRoot interface {
 type bcEvent struct {
 	block          *block.Block
 	appExecResults []*state.AppExecResult
+	storageDiff    []state.StorageDiff
+	contracts      map[int32]util.Uint160
 }
 
 // transferData is used for transfer caching during storeBlock.
@@ -357,6 +376,18 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		cfg.Ledger.GarbageCollectionPeriod = defaultGCPeriod
 		log.Info("GarbageCollectionPeriod is not set or wrong, using default value", zap.Uint32("GarbageCollectionPeriod", cfg.Ledger.GarbageCollectionPeriod))
 	}
+	if cfg.Ledger.RemoveUntraceableBlocks && cfg.Ledger.GarbageCollectionChunk == 0 {
+		cfg.Ledger.GarbageCollectionChunk = defaultGCChunk
+		log.Info("GarbageCollectionChunk is not set or wrong, using default value", zap.Uint32("GarbageCollectionChunk", cfg.Ledger.GarbageCollectionChunk))
+	}
+	if cfg.Ledger.StateCheckpoints.Enabled && !cfg.StateRootInHeader {
+		// Checkpoint state root can only be verified against the next header.
+		return nil, errors.New("StateCheckpoints are enabled, but StateRootInHeader is off")
+	}
+	if cfg.Ledger.StateCheckpoints.Enabled && cfg.Ledger.StateCheckpoints.Interval == 0 {
+		cfg.Ledger.StateCheckpoints.Interval = DefaultStateSyncInterval
+		log.Info("StateCheckpoints Interval is not set or wrong, using default value", zap.Uint32("Interval", cfg.Ledger.StateCheckpoints.Interval))
+	}
 	bc := &Blockchain{
 		config:      cfg,
 		dao:         dao.NewSimple(s, cfg.StateRootInHeader),
@@ -370,6 +401,8 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		subCh:       make(chan any),
 		unsubCh:     make(chan any),
 		contracts:   *native.NewContracts(cfg.ProtocolConfiguration),
+
+		stateCheckpoints: make(chan *checkpoint.Header, 1),
 	}
 
 	bc.persistCond = sync.NewCond(&bc.lock)
@@ -457,6 +490,13 @@ func (bc *Blockchain) SetNotary(mod native.NotaryService) {
 	bc.contracts.Designate.NotaryService.Store(&mod)
 }
 
+// SetCheckpointUploader sets the service state checkpoints are passed to
+// once they're written. It can safely be called on the running blockchain.
+// To unregister the service use SetCheckpointUploader(nil).
+func (bc *Blockchain) SetCheckpointUploader(mod CheckpointUploader) {
+	bc.checkpointUploader.Store(&mod)
+}
+
 func (bc *Blockchain) init() error {
 	// If we could not find the version in the Store, we know that there is nothing stored.
 	ver, err := bc.dao.GetVersion()
@@ -657,6 +697,7 @@ func (bc *Blockchain) jumpToStateInternal(p uint32, stage stateChangeStage) erro
 			cache.Store.Delete(k)
 			return true
 		})
+		bc.clearStateCheckpoints(cache)
 
 		// After current state is updated, we need to remove outdated state-related data if so.
 		// The only outdated data we might have is genesis-related data, so check it.
@@ -690,14 +731,28 @@ func (bc *Blockchain) jumpToStateInternal(p uint32, stage stateChangeStage) erro
 	default:
 		return fmt.Errorf("unknown state jump stage: %d", stage)
 	}
-	block, err := bc.dao.GetBlock(bc.GetHeaderHash(p + 1))
-	if err != nil {
-		return fmt.Errorf("failed to get block to init MPT: %w", err)
+	var (
+		sr  *state.MPTRoot
+		err error
+	)
+	if bc.config.StateRootInHeader {
+		block, err := bc.dao.GetBlock(bc.GetHeaderHash(p + 1))
+		if err != nil {
+			return fmt.Errorf("failed to get block to init MPT: %w", err)
+		}
+		sr = &state.MPTRoot{
+			Index: p,
+			Root:  block.PrevStateRoot,
+		}
+	} else {
+		// State root signed by state validators is stored by the state
+		// sync module.
+		sr, err = bc.stateRoot.GetStateRoot(p)
+		if err != nil {
+			return fmt.Errorf("failed to get state root to init MPT: %w", err)
+		}
 	}
-	bc.stateRoot.JumpToState(&state.MPTRoot{
-		Index: p,
-		Root:  block.PrevStateRoot,
-	})
+	bc.stateRoot.JumpToState(sr)
 
 	bc.dao.Store.Delete(jumpStageKey)
 
@@ -999,6 +1054,7 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 		upperCache.DeleteHeaderHashes(height+1, headerBatchCount)
 		upperCache.StoreAsCurrentBlock(b)
 		upperCache.PutCurrentHeader(b.Hash(), height)
+		bc.clearStateCheckpoints(upperCache)
 		v.StoragePrefix = statesync.TemporaryPrefix(v.StoragePrefix)
 		upperCache.PutVersion(v)
 		// It's important to manually change the cache's Version at this stage, so that native cache
@@ -1140,7 +1196,22 @@ func (bc *Blockchain) isHardforkEnabled(hf *config.Hardfork, blockHeight uint32)
 func (bc *Blockchain) Run() {
 	bc.isRunning.Store(true)
 	persistTimer := time.NewTimer(persistInterval)
+	var dbStatsDone, checkpointsDone chan struct{}
+	if bc.config.Ledger.DBStatsInterval > 0 {
+		dbStatsDone = make(chan struct{})
+		go bc.collectDBStats(dbStatsDone)
+	}
+	if bc.config.Ledger.StateCheckpoints.Enabled {
+		checkpointsDone = make(chan struct{})
+		go bc.writeStateCheckpoints(checkpointsDone)
+	}
 	defer func() {
+		if dbStatsDone != nil {
+			<-dbStatsDone
+		}
+		if checkpointsDone != nil {
+			<-checkpointsDone
+		}
 		if _, err := bc.persist(); err != nil {
 			bc.log.Warn("failed to persist", zap.Error(err))
 		}
@@ -1175,9 +1246,35 @@ func (bc *Blockchain) Run() {
 	}
 }
 
-func (bc *Blockchain) tryRunGC(oldHeight uint32) time.Duration {
-	var dur time.Duration
+// collectDBStats periodically collects DB statistics for Prometheus metrics
+// until the Blockchain is stopped.
+func (bc *Blockchain) collectDBStats(done chan struct{}) {
+	defer close(done)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-bc.stopCh
+		cancel()
+	}()
+	ticker := time.NewTicker(bc.config.Ledger.DBStatsInterval)
+	defer ticker.Stop()
+	for {
+		start := time.Now()
+		stats, err := storage.CollectStats(ctx, bc.store)
+		if err != nil {
+			return // Blockchain is stopped.
+		}
+		updateDBStatsMetrics(stats)
+		bc.log.Debug("DB statistics collected", zap.Duration("took", time.Since(start)))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
 
+func (bc *Blockchain) tryRunGC(oldHeight uint32) time.Duration {
 	newHeight := atomic.LoadUint32(&bc.persistedHeight)
 	var tgtBlock = int64(newHeight)
 
@@ -1195,10 +1292,12 @@ func (bc *Blockchain) tryRunGC(oldHeight uint32) time.Duration {
 	oldHeight /= bc.config.Ledger.GarbageCollectionPeriod
 	newHeight /= bc.config.Ledger.GarbageCollectionPeriod
 	if tgtBlock > int64(bc.config.Ledger.GarbageCollectionPeriod) && newHeight != oldHeight {
-		dur = bc.removeOldTransfers(uint32(tgtBlock))
-		dur += bc.stateRoot.GC(uint32(tgtBlock), bc.store)
+		bc.startGC(uint32(tgtBlock))
+	}
+	if bc.gc.stage == gcIdle {
+		return 0
 	}
-	return dur
+	return bc.gcStep()
 }
 
 // resetTransfers is a helper function that strips the top newest NEP17 and NEP11 transfer logs
@@ -1338,66 +1437,6 @@ func appendTokenTransferInfo(transferData *state.TokenTransferInfo,
 	}
 }
 
-func (bc *Blockchain) removeOldTransfers(index uint32) time.Duration {
-	bc.log.Info("starting transfer data garbage collection", zap.Uint32("index", index))
-	var (
-		err     error
-		kept    int64
-		removed int64
-		start   = time.Now()
-		ts, ok  = bc.gcBlockTimes.Get(index)
-	)
-
-	if !ok {
-		dur := time.Since(start)
-		bc.log.Error("failed to get block timestamp transfer GC", zap.Duration("time", dur), zap.Uint32("index", index))
-		return dur
-	}
-
-	prefixes := []byte{byte(storage.STNEP11Transfers), byte(storage.STNEP17Transfers)}
-
-	for i := range prefixes {
-		var acc util.Uint160
-		var canDrop bool
-
-		err = bc.store.SeekGC(storage.SeekRange{
-			Prefix:    prefixes[i : i+1],
-			Backwards: true, // From new to old.
-		}, func(k, v []byte) bool {
-			// We don't look inside of the batches, it requires too much effort, instead
-			// we drop batches that are confirmed to contain outdated entries.
-			var batchAcc util.Uint160
-			var batchTs = binary.BigEndian.Uint64(k[1+util.Uint160Size:])
-			copy(batchAcc[:], k[1:])
-
-			if batchAcc != acc { // Some new account we're iterating over.
-				acc = batchAcc
-			} else if canDrop { // We've seen this account and all entries in this batch are guaranteed to be outdated.
-				removed++
-				return false
-			}
-			// We don't know what's inside, so keep the current
-			// batch anyway, but allow to drop older ones.
-			canDrop = batchTs <= ts
-			kept++
-			return true
-		})
-		if err != nil {
-			break
-		}
-	}
-	dur := time.Since(start)
-	if err != nil {
-		bc.log.Error("failed to flush transfer data GC changeset", zap.Duration("time", dur), zap.Error(err))
-	} else {
-		bc.log.Info("finished transfer data garbage collection",
-			zap.Int64("removed", removed),
-			zap.Int64("kept", kept),
-			zap.Duration("time", dur))
-	}
-	return dur
-}
-
 // notificationDispatcher manages subscription to events and broadcasts new events.
 func (bc *Blockchain) notificationDispatcher() {
 	var (
@@ -1409,6 +1448,7 @@ func (bc *Blockchain) notificationDispatcher() {
 		txFeed           = make(map[chan *transaction.Transaction]bool)
 		notificationFeed = make(map[chan *state.ContainedNotificationEvent]bool)
 		executionFeed    = make(map[chan *state.AppExecResult]bool)
+		storageFeed      = make(map[chan *state.StorageChangeEvent]bool)
 	)
 	for {
 		select {
@@ -1426,6 +1466,8 @@ func (bc *Blockchain) notificationDispatcher() {
 				notificationFeed[ch] = true
 			case chan *state.AppExecResult:
 				executionFeed[ch] = true
+			case chan *state.StorageChangeEvent:
+				storageFeed[ch] = true
 			default:
 				panic(fmt.Sprintf("bad subscription: %T", sub))
 			}
@@ -1441,6 +1483,8 @@ func (bc *Blockchain) notificationDispatcher() {
 				delete(notificationFeed, ch)
 			case chan *state.AppExecResult:
 				delete(executionFeed, ch)
+			case chan *state.StorageChangeEvent:
+				delete(storageFeed, ch)
 			default:
 				panic(fmt.Sprintf("bad unsubscription: %T", unsub))
 			}
@@ -1505,6 +1549,18 @@ func (bc *Blockchain) notificationDispatcher() {
 					}
 				}
 			}
+			if len(storageFeed) != 0 {
+				for i := range event.storageDiff {
+					for ch := range storageFeed {
+						ch <- &state.StorageChangeEvent{
+							BlockHash:   event.block.Hash(),
+							BlockIndex:  event.block.Index,
+							Contract:    event.contracts[event.storageDiff[i].ID],
+							StorageDiff: event.storageDiff[i],
+						}
+					}
+				}
+			}
 			for ch := range headerFeed {
 				ch <- &event.block.Header
 			}
@@ -1794,7 +1850,8 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	appExecResults = append(appExecResults, aer)
 	aerchan <- aer
 	close(aerchan)
-	b := mpt.MapToMPTBatch(cache.Store.GetStorageChanges())
+	storageChanges := cache.Store.GetStorageChanges()
+	b := mpt.MapToMPTBatch(storageChanges)
 	mpt, sr, err := bc.stateRoot.AddMPTBatch(block.Index, b, cache.Store)
 	if err != nil {
 		// Release goroutines, don't care about errors, we already have one.
@@ -1818,6 +1875,21 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		}
 	}
 
+	if bc.config.Ledger.StateCheckpoints.Enabled {
+		storeStorageDelta(cache, block.Index, bc.config.Ledger.StateCheckpoints.Interval, storageChanges)
+	}
+	var (
+		storageDiff []state.StorageDiff
+		contracts   map[int32]util.Uint160
+	)
+	if bc.config.Ledger.SaveStorageDiffs {
+		storageDiff, contracts, err = bc.storeStorageDiff(cache, block.Index, storageChanges)
+		if err != nil {
+			// Release goroutines, don't care about errors, we already have one.
+			<-aerdone
+			return err
+		}
+	}
 	if bc.config.Ledger.SaveStorageBatch {
 		bc.lastBatch = cache.GetBatch()
 	}
@@ -1848,11 +1920,17 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		bc.lock.Unlock()
 		return err
 	}
+	if bc.config.Ledger.StateCheckpoints.Enabled {
+		bc.storeCheckpointOrigin(cache, block.Index, storageChanges)
+	}
 	_, err = cache.Persist()
 	if err != nil {
 		bc.lock.Unlock()
 		return err
 	}
+	if bc.config.Ledger.StateCheckpoints.Enabled {
+		bc.requestStateCheckpoint(block.Index, sr.Root)
+	}
 
 	mpt.Store = bc.dao.Store
 	bc.stateRoot.UpdateCurrentLocal(mpt, sr)
@@ -1873,7 +1951,7 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	// is no one to read this event. And it doesn't make much sense as event
 	// anyway.
 	if block.Index != 0 {
-		bc.events <- bcEvent{block, appExecResults}
+		bc.events <- bcEvent{block, appExecResults, storageDiff, contracts}
 	}
 	return nil
 }
@@ -2262,6 +2340,13 @@ func (bc *Blockchain) GetAppExecResults(hash util.Uint256, trig trigger.Type) ([
 	return bc.dao.GetAppExecResults(hash, trig)
 }
 
+// GetStorageDiff returns contract storage changes made by the block with the
+// given index. They're only available for blocks processed with
+// SaveStorageDiffs setting enabled.
+func (bc *Blockchain) GetStorageDiff(index uint32) ([]state.StorageDiff, error) {
+	return bc.dao.GetStorageDiff(index)
+}
+
 // GetStorageItem returns an item from storage.
 func (bc *Blockchain) GetStorageItem(id int32, key []byte) state.StorageItem {
 	return bc.dao.GetStorageItem(id, key)
@@ -2440,6 +2525,18 @@ func (bc *Blockchain) SubscribeForExecutions(ch chan *state.AppExecResult) {
 	bc.subCh <- ch
 }
 
+// SubscribeForStorageChanges adds given channel to contract storage change
+// event broadcasting, so when an in-block contract storage item is changed
+// you'll receive the change via this channel (changes are sent after
+// executions and notifications of the block). Events are only generated with
+// SaveStorageDiffs setting enabled. Make sure it's read from regularly as not
+// reading these events might affect other Blockchain functions. Make sure
+// you're not changing the received events, as it may affect the functionality
+// of Blockchain and other subscribers.
+func (bc *Blockchain) SubscribeForStorageChanges(ch chan *state.StorageChangeEvent) {
+	bc.subCh <- ch
+}
+
 // UnsubscribeFromBlocks unsubscribes given channel from new block notifications,
 // you can close it afterwards. Passing non-subscribed channel is a no-op, but
 // the method can read from this channel (discarding any read data).
@@ -2512,6 +2609,21 @@ unsubloop:
 	}
 }
 
+// UnsubscribeFromStorageChanges unsubscribes given channel from contract
+// storage change notifications, you can close it afterwards. Passing
+// non-subscribed channel is a no-op, but the method can read from this
+// channel (discarding any read data).
+func (bc *Blockchain) UnsubscribeFromStorageChanges(ch chan *state.StorageChangeEvent) {
+unsubloop:
+	for {
+		select {
+		case <-ch:
+		case bc.unsubCh <- ch:
+			break unsubloop
+		}
+	}
+}
+
 // CalculateClaimable calculates the amount of GAS generated by owning specified
 // amount of NEO between specified blocks.
 func (bc *Blockchain) CalculateClaimable(acc util.Uint160, endHeight uint32) (*big.Int, error) {
@@ -3132,7 +3244,7 @@ func (bc *Blockchain) newInteropContext(trigger trigger.Type, d *dao.Simple, blo
 		baseStorageFee = bc.contracts.Policy.GetStoragePriceInternal(d)
 	}
 	ic := interop.NewContext(trigger, bc, d, baseExecFee, baseStorageFee, native.GetContract, bc.contracts.Contracts, contract.LoadToken, block, tx, bc.log)
-	ic.Functions = systemInterops
+	ic.Functions = syscalls.Functions()
 	switch {
 	case tx != nil:
 		ic.Container = tx
@@ -3,7 +3,10 @@ package core
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	gio "io"
+	"path/filepath"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -13,6 +16,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/chaindump"
+	"github.com/nspcc-dev/neo-go/pkg/core/checkpoint"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -21,6 +26,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
@@ -107,7 +113,11 @@ func TestRemoveOldTransfers(t *testing.T) {
 	_, err = bc.dao.Persist()
 	require.NoError(t, err)
 	_ = bc.gcBlockTimes.Add(0, h.Timestamp)
-	_ = bc.removeOldTransfers(0)
+	bc.config.Ledger.GarbageCollectionChunk = 1 // Every batch is checked by a separate step.
+	bc.startGC(0)
+	for bc.gc.stage != gcIdle {
+		_ = bc.gcStep()
+	}
 
 	for i := range uint32(2) {
 		log, err := bc.dao.GetTokenTransferLog(acc1, older, i, false)
@@ -140,6 +150,122 @@ func TestRemoveOldTransfers(t *testing.T) {
 	}
 }
 
+func TestGCNewTargetKeepsStageTimestamp(t *testing.T) {
+	bc := newTestChain(t)
+	for i := range uint32(3) {
+		bc.dao.PutTokenTransferLog(util.Uint160{byte(i)}, 1, 0, true, &state.TokenTransferLog{Raw: []byte{1}})
+	}
+	_, err := bc.dao.Persist()
+	require.NoError(t, err)
+	_ = bc.gcBlockTimes.Add(1, 100)
+	_ = bc.gcBlockTimes.Add(2, 200)
+	bc.config.Ledger.GarbageCollectionChunk = 1
+
+	bc.startGC(1)
+	require.Equal(t, gcNEP11Transfers, bc.gc.stage)
+	_ = bc.gcStep()
+	require.Equal(t, gcNEP11Transfers, bc.gc.stage)
+
+	// New target doesn't affect the stage in progress.
+	bc.startGC(2)
+	require.Equal(t, uint32(2), bc.gc.index)
+	require.Equal(t, uint64(100), bc.gc.ts)
+	for bc.gc.stage == gcNEP11Transfers {
+		_ = bc.gcStep()
+	}
+	require.Equal(t, gcNEP17Transfers, bc.gc.stage)
+	require.Equal(t, uint64(200), bc.gc.ts)
+}
+
+func TestIncrementalMPTGC(t *testing.T) {
+	bc := initTestChain(t, nil, func(c *config.Config) {
+		c.ApplicationConfiguration.RemoveUntraceableBlocks = true
+		c.ApplicationConfiguration.GarbageCollectionChunk = 2
+	})
+	mptNode := func(active bool, h uint32) []byte {
+		v := []byte{0xAA, 0xBB, 0, 0, 0, 0, 0}
+		if active {
+			v[2] = 1
+		}
+		binary.LittleEndian.PutUint32(v[3:], h)
+		return v
+	}
+	mptKey := func(b byte) []byte {
+		return append([]byte{byte(storage.DataMPT)}, util.Uint256{b}.BytesBE()...)
+	}
+	var (
+		stale   = mptKey(1)
+		fresh   = mptKey(2)
+		revived = mptKey(3)
+		active  = mptKey(4)
+	)
+	bc.dao.Store.Put(stale, mptNode(false, 5))
+	bc.dao.Store.Put(fresh, mptNode(false, 15))
+	bc.dao.Store.Put(revived, mptNode(false, 3))
+	bc.dao.Store.Put(active, mptNode(true, 1))
+	_, err := bc.dao.Persist()
+	require.NoError(t, err)
+	var before int
+	bc.store.Seek(storage.SeekRange{Prefix: []byte{byte(storage.DataMPT)}}, func(_, _ []byte) bool {
+		before++
+		return true
+	})
+
+	// Not yet persisted block makes the node active again.
+	bc.dao.Store.Put(revived, mptNode(true, 1))
+
+	bc.startGC(10)
+	for bc.gc.stage != gcIdle {
+		_ = bc.gcStep()
+	}
+
+	_, err = bc.store.Get(stale)
+	require.ErrorIs(t, err, storage.ErrKeyNotFound)
+	for _, k := range [][]byte{fresh, revived, active} {
+		_, err = bc.store.Get(k)
+		require.NoError(t, err)
+	}
+	var after int
+	bc.store.Seek(storage.SeekRange{Prefix: []byte{byte(storage.DataMPT)}}, func(_, _ []byte) bool {
+		after++
+		return true
+	})
+	require.Equal(t, before-1, after)
+
+	_, err = bc.dao.Persist()
+	require.NoError(t, err)
+	v, err := bc.store.Get(revived)
+	require.NoError(t, err)
+	require.Equal(t, mptNode(true, 1), v)
+}
+
+func TestBlockchain_DBStatsMetrics(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ApplicationConfiguration.DBStatsInterval = 10 * time.Millisecond
+	})
+	_, err := bc.persist()
+	require.NoError(t, err)
+
+	getKeys := func() float64 {
+		mfs, err := prometheus.DefaultGatherer.Gather()
+		require.NoError(t, err)
+		for _, mf := range mfs {
+			if mf.GetName() != "neogo_db_keys" {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				for _, l := range m.GetLabel() {
+					if l.GetName() == "prefix" && l.GetValue() == storage.DataExecutable.String() {
+						return m.GetGauge().GetValue()
+					}
+				}
+			}
+		}
+		return 0
+	}
+	require.Eventually(t, func() bool { return getKeys() > 0 }, time.Second, 10*time.Millisecond)
+}
+
 func checkNewBlockchainErr(t *testing.T, cfg func(c *config.Config), store storage.Store, errText string) {
 	unitTestNetCfg, err := config.Load("../../config", testchain.Network())
 	require.NoError(t, err)
@@ -165,6 +291,121 @@ func TestNewBlockchainIncosistencies(t *testing.T) {
 			c.ProtocolConfiguration.P2PStateExchangeExtensions = true
 		}, storage.NewMemoryStore(), "P2PStatesExchangeExtensions are enabled, but StateRootInHeader is off")
 	})
+	t.Run("state checkpoints without state root", func(t *testing.T) {
+		checkNewBlockchainErr(t, func(c *config.Config) {
+			c.ApplicationConfiguration.Ledger.StateCheckpoints = config.StateCheckpoints{
+				Enabled: true,
+				Path:    t.TempDir(),
+			}
+		}, storage.NewMemoryStore(), "StateCheckpoints are enabled, but StateRootInHeader is off")
+	})
+}
+
+func TestBlockchain_WriteStateCheckpoint(t *testing.T) {
+	const interval = 3
+	dir := t.TempDir()
+	bc := initTestChain(t, nil, func(c *config.Config) {
+		c.ProtocolConfiguration.StateRootInHeader = true
+		c.ApplicationConfiguration.Ledger.StateCheckpoints = config.StateCheckpoints{
+			Enabled:  true,
+			Interval: interval,
+			Path:     dir,
+		}
+	})
+	// Checkpoint writer is not started without Run, checkpoints are written
+	// explicitly after blocks following them are stored.
+	go bc.notificationDispatcher()
+	t.Cleanup(func() { close(bc.stopCh) })
+	var uploaded testCheckpointUploader
+	bc.SetCheckpointUploader(&uploaded)
+
+	addBlocks := func() {
+		for range interval {
+			require.NoError(t, bc.AddBlock(bc.newBlock()))
+		}
+	}
+	countItems := func(p storage.KeyPrefix) int {
+		var n int
+		bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(p)}}, func(_, _ []byte) bool {
+			n++
+			return true
+		})
+		return n
+	}
+	checkRoot := func(index uint32) {
+		chn, err := checkpoint.Chain(dir, bc.config.Magic, index)
+		require.NoError(t, err)
+		require.Equal(t, index, chn[len(chn)-1].Index)
+		items := make(map[string][]byte)
+		for _, info := range chn {
+			cr, err := checkpoint.Open(info.Path)
+			require.NoError(t, err)
+			for {
+				it, err := cr.Next()
+				if errors.Is(err, gio.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				k := string(append([]byte{byte(storage.STStorage)}, it.Key...))
+				if it.Value == nil {
+					delete(items, k)
+				} else {
+					items[k] = it.Value
+				}
+			}
+			require.NoError(t, cr.Close())
+		}
+		tr := mpt.NewTrie(nil, mpt.ModeAll, storage.NewMemCachedStore(storage.NewMemoryStore()))
+		_, err = tr.PutBatch(mpt.MapToMPTBatch(items))
+		require.NoError(t, err)
+		sr, err := bc.stateRoot.GetStateRoot(index)
+		require.NoError(t, err)
+		require.Equal(t, sr.Root, tr.StateRoot())
+	}
+
+	addBlocks()
+	h := <-bc.stateCheckpoints
+	require.True(t, h.Full)
+	require.Equal(t, uint32(interval), h.Index)
+
+	// Storage changes made after the full checkpoint don't get into it.
+	addBlocks()
+	require.Equal(t, 0, len(bc.stateCheckpoints)) // Full checkpoint is in progress.
+	require.NotEqual(t, 0, countItems(storage.STStorageOrigin))
+	bc.writeStateCheckpoint(h)
+	checkRoot(interval)
+	require.Equal(t, uint32(0), bc.checkpointOrigin)
+	require.Equal(t, 0, countItems(storage.STStorageOrigin))
+	last, err := bc.dao.GetStateCheckpoint()
+	require.NoError(t, err)
+	require.Equal(t, uint32(interval), last)
+	require.Equal(t, testCheckpointUploader{{Header: *h, Path: filepath.Join(dir, checkpoint.FileName(interval))}}, uploaded)
+
+	addBlocks()
+	h = <-bc.stateCheckpoints
+	require.False(t, h.Full)
+	require.Equal(t, uint32(interval), h.PrevIndex)
+	require.Equal(t, uint32(3*interval), h.Index)
+	addBlocks()
+	bc.writeStateCheckpoint(h)
+	checkRoot(3 * interval)
+	require.Equal(t, 2, len(uploaded))
+	require.Equal(t, *h, uploaded[1].Header)
+	var gens []uint32
+	bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(storage.STStorageDelta)}}, func(k, _ []byte) bool {
+		gens = append(gens, binary.BigEndian.Uint32(k[1:]))
+		return true
+	})
+	require.NotEmpty(t, gens)
+	for _, g := range gens {
+		require.Equal(t, uint32(4*interval), g)
+	}
+
+	// Recorded changes are not applicable to the reset contract storage.
+	require.NoError(t, bc.Reset(2*interval))
+	require.Equal(t, 0, countItems(storage.STStorageDelta))
+	_, err = bc.dao.GetStateCheckpoint()
+	require.Error(t, err)
 }
 
 func TestBlockchain_InitWithIncompleteStateJump(t *testing.T) {
@@ -258,6 +499,25 @@ func TestBlockchain_InitWithIncompleteStateJump(t *testing.T) {
 			checkNewBlockchainErr(t, spountCfg, bcSpout.dao.Store, errText)
 		})
 	}
+	t.Run("state checkpoints data", func(t *testing.T) {
+		bcSpout.dao.Store.Put(bPrefix, []byte{byte(stateJumpStarted)})
+		point := make([]byte, 4)
+		binary.LittleEndian.PutUint32(point, uint32(stateSyncPoint))
+		bcSpout.dao.Store.Put([]byte{byte(storage.SYSStateSyncPoint)}, point)
+		bcSpout.dao.Store.Put([]byte{byte(storage.STStorageDelta), 0, 0, 0, 4, 1, 2, 3}, []byte{deltaDelete})
+		bcSpout.dao.Store.Put([]byte{byte(storage.STStorageOrigin), 1, 2, 3}, []byte{deltaDelete})
+		bcSpout.dao.PutStateCheckpoint(4)
+
+		bc := initTestChain(t, bcSpout.dao.Store, spountCfg)
+		_, err := bc.dao.GetStateCheckpoint()
+		require.Error(t, err)
+		for _, p := range []storage.KeyPrefix{storage.STStorageDelta, storage.STStorageOrigin} {
+			bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(p)}}, func(k, _ []byte) bool {
+				t.Fatalf("unexpected key %x", k)
+				return false
+			})
+		}
+	})
 }
 
 func TestChainWithVolatileNumOfValidators(t *testing.T) {
@@ -431,6 +691,12 @@ func TestNewBlockchain_InitHardforks(t *testing.T) {
 	})
 }
 
+type testCheckpointUploader []checkpoint.Info
+
+func (u *testCheckpointUploader) UploadCheckpoint(info checkpoint.Info) {
+	*u = append(*u, info)
+}
+
 type nopCloserStorage struct {
 	storage.Store
 }
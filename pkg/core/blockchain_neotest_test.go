@@ -1,9 +1,11 @@
 package core_test
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	goio "io"
 	"math/big"
 	"path/filepath"
 	"slices"
@@ -20,6 +22,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/checkpoint"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
@@ -2762,3 +2765,180 @@ func TestRuntimeNotifyRefcounting(t *testing.T) {
 		require.Equal(t, expected, aer[0].Events[i])
 	}
 }
+
+func TestBlockchain_StateCheckpoints(t *testing.T) {
+	t.Run("full state", func(t *testing.T) {
+		testStateCheckpoints(t, false)
+	})
+	t.Run("KeepOnlyLatestState", func(t *testing.T) {
+		testStateCheckpoints(t, true)
+	})
+}
+
+func testStateCheckpoints(t *testing.T, keepLatest bool) {
+	const interval = 5
+	dir := t.TempDir()
+	bc, validators, committee := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+		c.StateRootInHeader = true
+		c.Ledger.KeepOnlyLatestState = keepLatest
+		c.Ledger.StateCheckpoints = config.StateCheckpoints{
+			Enabled:  true,
+			Interval: interval,
+			Path:     dir,
+		}
+	})
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	basicchain.Init(t, "../../", e)
+
+	// Checkpoints are written in background and some of them can be
+	// skipped if the writer is busy, so add blocks until there is an
+	// incremental one.
+	var infos []checkpoint.Info
+	require.Eventually(t, func() bool {
+		for range interval {
+			e.AddNewBlock(t)
+		}
+		var err error
+		infos, err = checkpoint.List(dir, bc.GetConfig().Magic)
+		require.NoError(t, err)
+		return len(infos) > 1
+	}, 5*time.Second, 50*time.Millisecond)
+	require.True(t, infos[0].Full)
+	for i, info := range infos {
+		require.Equal(t, uint32(0), info.Index%interval)
+		if i > 0 {
+			require.False(t, info.Full)
+			require.Equal(t, infos[i-1].Index, info.PrevIndex)
+		}
+		sr, err := bc.GetStateModule().GetStateRoot(info.Index)
+		require.NoError(t, err)
+		require.Equal(t, sr.Root, info.Root)
+	}
+
+	// Restore from the latest checkpoint that is not the top block.
+	chn, err := checkpoint.Chain(dir, bc.GetConfig().Magic, bc.BlockHeight()-1)
+	require.NoError(t, err)
+	require.Equal(t, infos[0], chn[0])
+	last := chn[len(chn)-1]
+	require.True(t, last.Index < bc.BlockHeight())
+
+	withStateRoot := func(c *config.Blockchain) {
+		c.StateRootInHeader = true
+	}
+	getBlock := func(i uint32) *block.Block {
+		b, err := bc.GetBlock(bc.GetHeaderHash(i))
+		require.NoError(t, err)
+		return b
+	}
+	bc2, _, _ := chain.NewMultiWithCustomConfig(t, withStateRoot)
+	r, err := bc2.NewCheckpointRestorer(last.Index, last.Root)
+	require.NoError(t, err)
+	for i := uint32(1); i <= last.Index; i++ {
+		require.NoError(t, r.AddBlock(getBlock(i)))
+	}
+	for _, info := range chn {
+		cr, err := checkpoint.Open(info.Path)
+		require.NoError(t, err)
+		var items []checkpoint.Item
+		for {
+			it, err := cr.Next()
+			if errors.Is(err, goio.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			items = append(items, it)
+		}
+		require.NoError(t, cr.Close())
+		require.NoError(t, r.AddItems(items))
+	}
+	require.Error(t, r.Finalize(&getBlock(last.Index).Header))
+	require.NoError(t, r.Finalize(&getBlock(last.Index+1).Header))
+	require.Equal(t, last.Index, bc2.BlockHeight())
+	require.Equal(t, last.Root, bc2.GetStateModule().CurrentLocalStateRoot())
+
+	for i := last.Index + 1; i <= bc.BlockHeight(); i++ {
+		b, err := bc.GetBlock(bc.GetHeaderHash(i))
+		require.NoError(t, err)
+		require.NoError(t, bc2.AddBlock(b))
+	}
+	require.Equal(t, bc.GetStateModule().CurrentLocalStateRoot(), bc2.GetStateModule().CurrentLocalStateRoot())
+	require.Equal(t, bc.GetStorageItem(basicchain.RublesContractID, []byte("testkey")), bc2.GetStorageItem(basicchain.RublesContractID, []byte("testkey")))
+
+	t.Run("non-empty chain", func(t *testing.T) {
+		_, err := bc.NewCheckpointRestorer(last.Index, last.Root)
+		require.Error(t, err)
+	})
+	t.Run("no StateRootInHeader", func(t *testing.T) {
+		bc3, _, _ := chain.NewMulti(t)
+		_, err := bc3.NewCheckpointRestorer(last.Index, last.Root)
+		require.Error(t, err)
+	})
+	t.Run("root mismatch", func(t *testing.T) {
+		bc3, _, _ := chain.NewMultiWithCustomConfig(t, withStateRoot)
+		r, err := bc3.NewCheckpointRestorer(infos[0].Index, util.Uint256{1, 2, 3})
+		require.NoError(t, err)
+		for i := uint32(1); i <= infos[0].Index; i++ {
+			require.NoError(t, r.AddBlock(getBlock(i)))
+		}
+		require.Error(t, r.Finalize(&getBlock(infos[0].Index+1).Header))
+	})
+}
+
+func TestBlockchain_StorageDiffs(t *testing.T) {
+	bc, validators, committee := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Ledger.SaveStorageDiffs = true
+	})
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	gasHash := e.NativeHash(t, nativenames.Gas)
+	gasID := e.NativeID(t, nativenames.Gas)
+
+	ch := make(chan *state.StorageChangeEvent, 100)
+	bc.SubscribeForStorageChanges(ch)
+	t.Cleanup(func() { bc.UnsubscribeFromStorageChanges(ch) })
+
+	acc := random.Uint160()
+	e.ValidatorInvoker(gasHash).Invoke(t, true, "transfer", e.Validator.ScriptHash(), acc, 1000, nil)
+	b := e.TopBlock(t)
+
+	diff, err := bc.GetStorageDiff(b.Index)
+	require.NoError(t, err)
+	require.NotEmpty(t, diff)
+
+	var found bool
+	for i, d := range diff {
+		if i > 0 {
+			require.True(t, d.ID != diff[i-1].ID || string(d.Key) != string(diff[i-1].Key))
+		}
+		require.NotEqual(t, d.Old, d.New)
+		actual := bc.GetStorageItem(d.ID, d.Key)
+		if d.New == nil {
+			require.Nil(t, actual)
+		} else {
+			require.Equal(t, state.StorageItem(d.New), actual)
+		}
+		if d.ID == gasID && bytes.Equal(d.Key, append([]byte{20}, acc.BytesBE()...)) {
+			found = true
+			require.Nil(t, d.Old)
+		}
+
+		var ev *state.StorageChangeEvent
+		require.Eventually(t, func() bool {
+			select {
+			case ev = <-ch:
+				return true
+			default:
+				return false
+			}
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, b.Hash(), ev.BlockHash)
+		require.Equal(t, b.Index, ev.BlockIndex)
+		require.Equal(t, d, ev.StorageDiff)
+		expected, err := bc.GetContractScriptHash(d.ID)
+		require.NoError(t, err)
+		require.Equal(t, expected, ev.Contract)
+	}
+	require.True(t, found)
+
+	_, err = bc.GetStorageDiff(b.Index + 1)
+	require.ErrorIs(t, err, storage.ErrKeyNotFound)
+}
@@ -0,0 +1,297 @@
+/*
+Package checkpoint implements state checkpoint files format.
+
+Checkpoint contains contract storage state for some height. It's either full
+(containing all contract storage items) or incremental (containing changes
+made since the previous checkpoint). The file starts with a header followed
+by a sequence of items, each item is either a put (key and value) or a delete
+(key only), MPT keys (contract ID and item key) are used. The sequence ends
+with a special terminating marker, so truncated files are detected.
+*/
+package checkpoint
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	gio "io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Version is the current checkpoint format version.
+const Version = 0
+
+const (
+	itemPut    byte = 0
+	itemDelete byte = 1
+	itemEnd    byte = 0xff
+
+	filePrefix = "checkpoint-"
+	fileSuffix = ".bin"
+)
+
+// ErrNoCheckpoint is returned when there is no suitable checkpoint.
+var ErrNoCheckpoint = errors.New("no suitable checkpoint")
+
+// Header is the checkpoint header.
+type Header struct {
+	// Magic is the network magic.
+	Magic netmode.Magic
+	// Index is the index of the block the checkpoint is made for.
+	Index uint32
+	// Full is true for checkpoints containing all contract storage items.
+	Full bool
+	// PrevIndex is the index of the previous checkpoint incremental
+	// checkpoint contains changes against, it's zero for full ones.
+	PrevIndex uint32
+	// Root is the state root hash for Index.
+	Root util.Uint256
+}
+
+// Item is a single contract storage change.
+type Item struct {
+	// Key is the MPT key (contract ID and storage item key).
+	Key []byte
+	// Value is the item value, it's nil for deleted items.
+	Value []byte
+}
+
+// EncodeBinary implements io.Serializable.
+func (h *Header) EncodeBinary(w *io.BinWriter) {
+	w.WriteB(Version)
+	w.WriteU32LE(uint32(h.Magic))
+	w.WriteU32LE(h.Index)
+	w.WriteBool(h.Full)
+	w.WriteU32LE(h.PrevIndex)
+	h.Root.EncodeBinary(w)
+}
+
+// DecodeBinary implements io.Serializable.
+func (h *Header) DecodeBinary(r *io.BinReader) {
+	if v := r.ReadB(); r.Err == nil && v != Version {
+		r.Err = fmt.Errorf("unsupported checkpoint version %d", v)
+		return
+	}
+	h.Magic = netmode.Magic(r.ReadU32LE())
+	h.Index = r.ReadU32LE()
+	h.Full = r.ReadBool()
+	h.PrevIndex = r.ReadU32LE()
+	h.Root.DecodeBinary(r)
+}
+
+// FileName returns checkpoint file name for the given index.
+func FileName(index uint32) string {
+	return fmt.Sprintf("%s%010d%s", filePrefix, index, fileSuffix)
+}
+
+// Writer writes checkpoint file. Data are written to a temporary file first,
+// it's renamed to the final name on Close, so incomplete checkpoints are never
+// visible.
+type Writer struct {
+	f    *os.File
+	path string
+	bw   *bufio.Writer
+	w    *io.BinWriter
+}
+
+// Create creates a new checkpoint file with the given header in the given
+// directory.
+func Create(dir string, h *Header) (*Writer, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	path := filepath.Join(dir, FileName(h.Index))
+	f, err := os.Create(path + ".tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint file: %w", err)
+	}
+	bw := bufio.NewWriter(f)
+	cw := &Writer{
+		f:    f,
+		path: path,
+		bw:   bw,
+		w:    io.NewBinWriterFromIO(bw),
+	}
+	h.EncodeBinary(cw.w)
+	return cw, nil
+}
+
+// Put writes storage item with the given key and value.
+func (cw *Writer) Put(key, value []byte) {
+	cw.w.WriteB(itemPut)
+	cw.w.WriteVarBytes(key)
+	cw.w.WriteVarBytes(value)
+}
+
+// Delete writes storage item deletion.
+func (cw *Writer) Delete(key []byte) {
+	cw.w.WriteB(itemDelete)
+	cw.w.WriteVarBytes(key)
+}
+
+// Close finalizes the checkpoint file. If there were any errors, the file is
+// removed.
+func (cw *Writer) Close() error {
+	cw.w.WriteB(itemEnd)
+	err := cw.w.Err
+	if err == nil {
+		err = cw.bw.Flush()
+	}
+	if err == nil {
+		err = cw.f.Sync()
+	}
+	if cerr := cw.f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(cw.f.Name(), cw.path)
+	}
+	if err != nil {
+		_ = os.Remove(cw.f.Name())
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Abort closes and removes the temporary checkpoint file.
+func (cw *Writer) Abort() {
+	_ = cw.f.Close()
+	_ = os.Remove(cw.f.Name())
+}
+
+// Reader reads checkpoint file.
+type Reader struct {
+	Header
+	f *os.File
+	r *io.BinReader
+}
+
+// Open opens checkpoint file and reads its header.
+func Open(path string) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	cr := &Reader{
+		f: f,
+		r: io.NewBinReaderFromIO(bufio.NewReader(f)),
+	}
+	cr.Header.DecodeBinary(cr.r)
+	if cr.r.Err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to read checkpoint header: %w", cr.r.Err)
+	}
+	return cr, nil
+}
+
+// Next returns the next item from the checkpoint, io.EOF is returned after
+// the last one.
+func (cr *Reader) Next() (Item, error) {
+	var it Item
+	typ := cr.r.ReadB()
+	switch {
+	case cr.r.Err != nil:
+	case typ == itemEnd:
+		return it, gio.EOF
+	case typ == itemPut:
+		it.Key = cr.r.ReadVarBytes()
+		it.Value = cr.r.ReadVarBytes()
+		if it.Value == nil {
+			it.Value = []byte{}
+		}
+	case typ == itemDelete:
+		it.Key = cr.r.ReadVarBytes()
+	default:
+		return it, fmt.Errorf("invalid item type %d", typ)
+	}
+	if cr.r.Err != nil {
+		if errors.Is(cr.r.Err, gio.EOF) {
+			return it, gio.ErrUnexpectedEOF
+		}
+		return it, cr.r.Err
+	}
+	return it, nil
+}
+
+// Close closes the checkpoint file.
+func (cr *Reader) Close() error {
+	return cr.f.Close()
+}
+
+// Info describes a checkpoint file.
+type Info struct {
+	Header
+	Path string
+}
+
+// List returns headers of all checkpoints for the network with the given
+// magic found in the directory sorted by index.
+func List(dir string, magic netmode.Magic) ([]Info, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint directory: %w", err)
+	}
+	var res []Info
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), filePrefix) || !strings.HasSuffix(e.Name(), fileSuffix) {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		cr, err := Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		_ = cr.Close()
+		if cr.Magic != magic {
+			continue
+		}
+		res = append(res, Info{Header: cr.Header, Path: path})
+	}
+	slices.SortFunc(res, func(a, b Info) int {
+		return int(int64(a.Index) - int64(b.Index))
+	})
+	return res, nil
+}
+
+// Chain returns a sequence of checkpoints starting from a full one that
+// allows to restore the state for the highest possible height not exceeding
+// maxIndex.
+func Chain(dir string, magic netmode.Magic, maxIndex uint32) ([]Info, error) {
+	all, err := List(dir, magic)
+	if err != nil {
+		return nil, err
+	}
+	byIndex := make(map[uint32]Info, len(all))
+	for _, c := range all {
+		byIndex[c.Index] = c
+	}
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i].Index > maxIndex {
+			continue
+		}
+		var (
+			chain = []Info{all[i]}
+			ok    = true
+		)
+		for !chain[0].Full {
+			prev, found := byIndex[chain[0].PrevIndex]
+			if !found || prev.Index >= chain[0].Index {
+				ok = false
+				break
+			}
+			chain = append([]Info{prev}, chain...)
+		}
+		if ok {
+			return chain, nil
+		}
+	}
+	return nil, ErrNoCheckpoint
+}
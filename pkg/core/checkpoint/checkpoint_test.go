@@ -0,0 +1,114 @@
+package checkpoint
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCheckpoint(t *testing.T, dir string, h Header, items []Item) {
+	w, err := Create(dir, &h)
+	require.NoError(t, err)
+	for _, it := range items {
+		if it.Value == nil {
+			w.Delete(it.Key)
+		} else {
+			w.Put(it.Key, it.Value)
+		}
+	}
+	require.NoError(t, w.Close())
+}
+
+func readItems(t *testing.T, path string) ([]Item, error) {
+	r, err := Open(path)
+	require.NoError(t, err)
+	defer r.Close()
+	var res []Item
+	for {
+		it, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		if err != nil {
+			return res, err
+		}
+		res = append(res, it)
+	}
+}
+
+func TestWriterReader(t *testing.T) {
+	var (
+		dir   = t.TempDir()
+		h     = Header{Magic: netmode.UnitTestNet, Index: 10, PrevIndex: 5, Root: util.Uint256{1, 2, 3}}
+		items = []Item{
+			{Key: []byte{1, 0, 0, 0, 1}, Value: []byte{1, 2, 3}},
+			{Key: []byte{1, 0, 0, 0, 2}},
+			{Key: []byte{2, 0, 0, 0, 1}, Value: []byte{}},
+		}
+	)
+	writeCheckpoint(t, dir, h, items)
+	path := filepath.Join(dir, FileName(h.Index))
+
+	r, err := Open(path)
+	require.NoError(t, err)
+	require.Equal(t, h, r.Header)
+	require.NoError(t, r.Close())
+
+	actual, err := readItems(t, path)
+	require.NoError(t, err)
+	require.Equal(t, items, actual)
+
+	t.Run("truncated", func(t *testing.T) {
+		fi, err := os.Stat(path)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(path, fi.Size()-1))
+		_, err = readItems(t, path)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
+
+func TestChain(t *testing.T) {
+	dir := t.TempDir()
+	for _, h := range []Header{
+		{Magic: netmode.UnitTestNet, Index: 10, Full: true},
+		{Magic: netmode.UnitTestNet, Index: 20, PrevIndex: 10},
+		{Magic: netmode.UnitTestNet, Index: 30, PrevIndex: 20},
+		{Magic: netmode.UnitTestNet, Index: 50, PrevIndex: 40}, // Broken chain.
+		{Magic: netmode.TestNet, Index: 60, Full: true},
+	} {
+		writeCheckpoint(t, dir, h, nil)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unrelated"), []byte{1, 2, 3}, 0o644))
+
+	all, err := List(dir, netmode.UnitTestNet)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(all))
+
+	indexes := func(infos []Info) []uint32 {
+		var res []uint32
+		for _, i := range infos {
+			res = append(res, i.Index)
+		}
+		return res
+	}
+	chain, err := Chain(dir, netmode.UnitTestNet, 100)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{10, 20, 30}, indexes(chain))
+
+	chain, err = Chain(dir, netmode.UnitTestNet, 25)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{10, 20}, indexes(chain))
+
+	_, err = Chain(dir, netmode.UnitTestNet, 5)
+	require.ErrorIs(t, err, ErrNoCheckpoint)
+
+	chain, err = Chain(dir, netmode.TestNet, 100)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{60}, indexes(chain))
+}
@@ -414,6 +414,47 @@ func (dao *Simple) makeStorageItemKey(id int32, key []byte) []byte {
 
 // -- end storage item.
 
+// -- start storage diff.
+
+// GetStorageDiff returns contract storage changes made by the block with the
+// given index.
+func (dao *Simple) GetStorageDiff(index uint32) ([]state.StorageDiff, error) {
+	b, err := dao.Store.Get(dao.mkStorageDiffKey(index))
+	if err != nil {
+		return nil, err
+	}
+	var (
+		r    = io.NewBinReaderFromBuf(b)
+		diff []state.StorageDiff
+	)
+	r.ReadArray(&diff)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return diff, nil
+}
+
+// PutStorageDiff stores contract storage changes made by the block with the
+// given index.
+func (dao *Simple) PutStorageDiff(index uint32, diff []state.StorageDiff) error {
+	buf := dao.getDataBuf()
+	buf.WriteArray(diff)
+	if buf.Err != nil {
+		return buf.Err
+	}
+	dao.Store.Put(dao.mkStorageDiffKey(index), buf.Bytes())
+	return nil
+}
+
+func (dao *Simple) mkStorageDiffKey(index uint32) []byte {
+	b := dao.getKeyBuf(1 + 4)
+	b[0] = byte(storage.STStorageDiff)
+	binary.BigEndian.PutUint32(b[1:], index)
+	return b
+}
+
+// -- end storage diff.
+
 // -- other.
 
 // GetBlock returns Block by the given hash if it exists in the store.
@@ -566,6 +607,15 @@ func (dao *Simple) GetStateSyncPoint() (uint32, error) {
 	return binary.LittleEndian.Uint32(b), nil
 }
 
+// GetStateCheckpoint returns the index of the latest state checkpoint.
+func (dao *Simple) GetStateCheckpoint() (uint32, error) {
+	b, err := dao.Store.Get(dao.mkKeyPrefix(storage.SYSStateCheckpoint))
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
 // GetStateSyncCurrentBlockHeight returns the current block height stored during state
 // synchronization process.
 func (dao *Simple) GetStateSyncCurrentBlockHeight() (uint32, error) {
@@ -669,6 +719,18 @@ func (dao *Simple) PutStateSyncPoint(p uint32) {
 	dao.Store.Put(dao.mkKeyPrefix(storage.SYSStateSyncPoint), buf.Bytes())
 }
 
+// PutStateCheckpoint stores the index of the latest state checkpoint.
+func (dao *Simple) PutStateCheckpoint(index uint32) {
+	buf := dao.getDataBuf()
+	buf.WriteU32LE(index)
+	dao.Store.Put(dao.mkKeyPrefix(storage.SYSStateCheckpoint), buf.Bytes())
+}
+
+// DeleteStateCheckpoint removes the index of the latest state checkpoint.
+func (dao *Simple) DeleteStateCheckpoint() {
+	dao.Store.Delete(dao.mkKeyPrefix(storage.SYSStateCheckpoint))
+}
+
 // PutStateSyncCurrentBlockHeight stores the current block height during state synchronization process.
 func (dao *Simple) PutStateSyncCurrentBlockHeight(h uint32) {
 	buf := dao.getDataBuf()
@@ -825,6 +887,7 @@ func (dao *Simple) DeleteBlock(h util.Uint256, dropHeader bool) (uint64, error)
 			}
 		}
 	}
+	dao.Store.Delete(dao.mkStorageDiffKey(b.Index))
 
 	return b.Timestamp, nil
 }
@@ -62,6 +62,22 @@ func TestDeleteStorageItem(t *testing.T) {
 	require.Nil(t, gotStorageItem)
 }
 
+func TestPutGetStorageDiff(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+
+	_, err := dao.GetStorageDiff(1)
+	require.ErrorIs(t, err, storage.ErrKeyNotFound)
+
+	expected := []state.StorageDiff{
+		{ID: 1, Key: []byte{1}, New: []byte{2}},
+		{ID: -1, Key: []byte{3}, Old: []byte{4}},
+	}
+	require.NoError(t, dao.PutStorageDiff(1, expected))
+	actual, err := dao.GetStorageDiff(1)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
 func TestGetBlock_NotExists(t *testing.T) {
 	dao := NewSimple(storage.NewMemoryStore(), false)
 	hash := random.Uint256()
@@ -0,0 +1,263 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+// gcStage is a stage of incremental garbage collection cycle, every stage
+// sweeps a single DB prefix.
+type gcStage byte
+
+const (
+	gcIdle gcStage = iota
+	gcNEP11Transfers
+	gcNEP17Transfers
+	gcMPT
+)
+
+// gcState is the state of incremental garbage collection cycle kept between
+// steps. Unreachable MPT nodes are marked by reference counting when blocks
+// are stored (see mpt.Trie), so GC only needs to sweep the DB. It's done in
+// chunks of GarbageCollectionChunk entries after every persist, so the DB is
+// never locked for the whole sweep.
+type gcState struct {
+	stage gcStage
+	// index is the target height, everything that's outdated at this height
+	// is removed.
+	index uint32
+	// ts is the timestamp of the target block used for transfer data GC.
+	// It's not changed until the current stage is finished since all batches
+	// of a stage must be checked against the same timestamp, see nextTs.
+	ts uint64
+	// nextTs is the timestamp of the new target block set by startGC during
+	// the cycle, it's applied when the current stage ends.
+	nextTs uint64
+	// cursor is the last key checked by the previous step of this stage.
+	cursor []byte
+
+	// Transfer data sweep state, see gcKeepTransfers.
+	acc     util.Uint160
+	canDrop bool
+
+	// Stage statistics.
+	start     time.Time
+	removed   int64
+	kept      int64
+	reclaimed int64
+}
+
+// prefix returns DB prefix swept by the stage.
+func (s gcStage) prefix() storage.KeyPrefix {
+	switch s {
+	case gcNEP11Transfers:
+		return storage.STNEP11Transfers
+	case gcNEP17Transfers:
+		return storage.STNEP17Transfers
+	default:
+		return storage.DataMPT
+	}
+}
+
+// String returns the type of data swept by the stage (used in logs and
+// metrics).
+func (s gcStage) String() string {
+	if s == gcMPT {
+		return "mpt"
+	}
+	return "transfers"
+}
+
+// startGC starts a new garbage collection cycle for the given target height.
+// If the previous cycle is not yet finished, it's continued with the new
+// target.
+func (bc *Blockchain) startGC(index uint32) {
+	ts, ok := bc.gcBlockTimes.Get(index)
+	if bc.gc.stage != gcIdle {
+		bc.log.Info("garbage collection is not finished, continuing with new target",
+			zap.Uint32("index", index), zap.Stringer("stage", bc.gc.stage))
+		bc.gc.index = index
+		if ok {
+			bc.gc.nextTs = ts
+		}
+		return
+	}
+	bc.gc = gcState{
+		index: index,
+		ts:    ts,
+	}
+	if !ok {
+		bc.log.Error("failed to get block timestamp transfer GC", zap.Uint32("index", index))
+		bc.nextGCStage(gcNEP17Transfers)
+		return
+	}
+	bc.nextGCStage(gcIdle)
+}
+
+// nextGCStage switches GC to the stage following the given one.
+func (bc *Blockchain) nextGCStage(prev gcStage) {
+	var next = gcIdle
+
+	switch prev {
+	case gcIdle:
+		next = gcNEP11Transfers
+	case gcNEP11Transfers:
+		next = gcNEP17Transfers
+	case gcNEP17Transfers:
+		if bc.config.Ledger.RemoveUntraceableBlocks {
+			next = gcMPT
+		}
+	}
+	if bc.gc.nextTs != 0 {
+		bc.gc.ts = bc.gc.nextTs
+		bc.gc.nextTs = 0
+	}
+	bc.gc.stage = next
+	bc.gc.cursor = nil
+	bc.gc.acc = util.Uint160{}
+	bc.gc.canDrop = false
+	bc.gc.start = time.Now()
+	bc.gc.removed = 0
+	bc.gc.kept = 0
+	bc.gc.reclaimed = 0
+	if next != gcIdle {
+		bc.log.Info("starting "+next.String()+" garbage collection", zap.Uint32("index", bc.gc.index))
+	}
+}
+
+// gcStep performs a single step of garbage collection checking up to
+// GarbageCollectionChunk DB entries. Outdated entries are removed from the
+// persistent store directly, while the latest state is checked via the write
+// cache, so entries revived by not yet persisted blocks are never removed.
+// Steps are never performed concurrently with persist, thus newer changes
+// always overwrite removals made by GC.
+func (bc *Blockchain) gcStep() time.Duration {
+	var (
+		start = time.Now()
+		limit = int(bc.config.Ledger.GarbageCollectionChunk)
+	)
+	if limit == 0 {
+		limit = defaultGCChunk
+	}
+	for bc.gc.stage != gcIdle && limit > 0 {
+		checked, err := bc.gcSweep(limit)
+		if err != nil {
+			bc.log.Error("failed to flush "+bc.gc.stage.String()+" GC changeset", zap.Duration("time", time.Since(start)), zap.Error(err))
+			break
+		}
+		limit -= checked
+	}
+	dur := time.Since(start)
+	updateGCPauseMetric(dur)
+	return dur
+}
+
+// gcSweep checks up to limit entries of the current GC stage starting from
+// the stage cursor and switches to the next stage if this one is finished.
+// It returns the number of checked entries.
+func (bc *Blockchain) gcSweep(limit int) (int, error) {
+	var (
+		g        = &bc.gc
+		finished = true
+		checked  int
+		last     []byte
+		removed  = make(map[string][]byte)
+		bytesCnt int64
+		rng      = storage.SeekRange{
+			Prefix: []byte{byte(g.stage.prefix())},
+			// Transfer data is traversed from new to old.
+			Backwards: g.stage != gcMPT,
+		}
+	)
+	if g.cursor != nil {
+		rng.Start = g.cursor[1:]
+	}
+	bc.dao.Store.Seek(rng, func(k, v []byte) bool {
+		if g.cursor != nil && bytes.Equal(k, g.cursor) {
+			return true // Start is inclusive and it's checked already.
+		}
+		if checked == limit {
+			finished = false
+			return false
+		}
+		checked++
+		last = append(last[:0], k...)
+		if !bc.gcKeep(k, v) {
+			removed[string(k)] = nil
+			bytesCnt += int64(len(k) + len(v))
+		}
+		return true
+	})
+	if len(removed) != 0 {
+		err := bc.store.PutChangeSet(removed, nil)
+		if err != nil {
+			return checked, err
+		}
+	}
+	if last != nil {
+		g.cursor = last
+	}
+	g.removed += int64(len(removed))
+	g.kept += int64(checked - len(removed))
+	g.reclaimed += bytesCnt
+	updateGCMetrics(g.stage.String(), len(removed), bytesCnt)
+
+	bc.log.Debug("garbage collection step",
+		zap.Stringer("stage", g.stage),
+		zap.Int("checked", checked),
+		zap.Int("removed", len(removed)))
+	if finished {
+		bc.log.Info("finished "+g.stage.String()+" garbage collection",
+			zap.Uint32("index", g.index),
+			zap.Int64("removed", g.removed),
+			zap.Int64("kept", g.kept),
+			zap.Int64("reclaimed", g.reclaimed),
+			zap.Duration("time", time.Since(g.start)))
+		bc.nextGCStage(g.stage)
+	}
+	return checked, nil
+}
+
+// gcKeep returns true if the given DB entry should be kept by the current GC
+// stage.
+func (bc *Blockchain) gcKeep(k, v []byte) bool {
+	if bc.gc.stage == gcMPT {
+		if !mpt.IsActiveValue(v) {
+			h := binary.LittleEndian.Uint32(v[len(v)-4:])
+			if h <= bc.gc.index {
+				return false
+			}
+		}
+		return true
+	}
+	return bc.gcKeepTransfers(k)
+}
+
+// gcKeepTransfers checks transfer log batch key, it relies on backwards
+// traversal (from new batches to old ones for every account).
+func (bc *Blockchain) gcKeepTransfers(k []byte) bool {
+	// We don't look inside of the batches, it requires too much effort, instead
+	// we drop batches that are confirmed to contain outdated entries.
+	var (
+		g        = &bc.gc
+		batchAcc util.Uint160
+		batchTs  = binary.BigEndian.Uint64(k[1+util.Uint160Size:])
+	)
+	copy(batchAcc[:], k[1:])
+
+	if batchAcc != g.acc { // Some new account we're iterating over.
+		g.acc = batchAcc
+	} else if g.canDrop { // We've seen this account and all entries in this batch are guaranteed to be outdated.
+		return false
+	}
+	// We don't know what's inside, so keep the current
+	// batch anyway, but allow to drop older ones.
+	g.canDrop = batchTs <= g.ts
+	return true
+}
@@ -0,0 +1,100 @@
+// Package syscalls contains the list of system interop functions available to
+// contracts together with their prices.
+package syscalls
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/crypto"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/iterator"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+)
+
+// Functions returns system interop functions sorted by ID. The result must not
+// be modified.
+func Functions() []interop.Function {
+	return functions
+}
+
+// GetPrice returns the price of the system interop function with the specified
+// ID (it's to be multiplied by the execution fee factor). False is returned for
+// unknown functions.
+func GetPrice(id uint32) (int64, bool) {
+	n, ok := slices.BinarySearchFunc(functions, id, func(f interop.Function, id uint32) int {
+		return cmp.Compare(f.ID, id)
+	})
+	if !ok {
+		return 0, false
+	}
+	return functions[n].Price, true
+}
+
+// All lists are sorted, keep 'em this way, please.
+var functions = []interop.Function{
+	{Name: interopnames.SystemContractCall, Func: contract.Call, Price: 1 << 15,
+		RequiredFlags: callflag.ReadStates | callflag.AllowCall, ParamCount: 4},
+	{Name: interopnames.SystemContractCallNative, Func: native.Call, Price: 0, ParamCount: 1},
+	{Name: interopnames.SystemContractCreateMultisigAccount, Func: contract.CreateMultisigAccount, Price: 0, ParamCount: 2},
+	{Name: interopnames.SystemContractCreateStandardAccount, Func: contract.CreateStandardAccount, Price: 0, ParamCount: 1},
+	{Name: interopnames.SystemContractGetCallFlags, Func: contract.GetCallFlags, Price: 1 << 10},
+	{Name: interopnames.SystemContractNativeOnPersist, Func: native.OnPersist, Price: 0, RequiredFlags: callflag.States},
+	{Name: interopnames.SystemContractNativePostPersist, Func: native.PostPersist, Price: 0, RequiredFlags: callflag.States},
+	{Name: interopnames.SystemCryptoCheckMultisig, Func: crypto.ECDSASecp256r1CheckMultisig, Price: 0, ParamCount: 2},
+	{Name: interopnames.SystemCryptoCheckSig, Func: crypto.ECDSASecp256r1CheckSig, Price: fee.ECDSAVerifyPrice, ParamCount: 2},
+	{Name: interopnames.SystemIteratorNext, Func: iterator.Next, Price: 1 << 15, ParamCount: 1},
+	{Name: interopnames.SystemIteratorValue, Func: iterator.Value, Price: 1 << 4, ParamCount: 1},
+	{Name: interopnames.SystemRuntimeBurnGas, Func: runtime.BurnGas, Price: 1 << 4, ParamCount: 1},
+	{Name: interopnames.SystemRuntimeCheckWitness, Func: runtime.CheckWitness, Price: 1 << 10,
+		RequiredFlags: callflag.NoneFlag, ParamCount: 1},
+	{Name: interopnames.SystemRuntimeCurrentSigners, Func: runtime.CurrentSigners, Price: 1 << 4,
+		RequiredFlags: callflag.NoneFlag},
+	{Name: interopnames.SystemRuntimeGasLeft, Func: runtime.GasLeft, Price: 1 << 4},
+	{Name: interopnames.SystemRuntimeGetAddressVersion, Func: runtime.GetAddressVersion, Price: 1 << 3},
+	{Name: interopnames.SystemRuntimeGetCallingScriptHash, Func: runtime.GetCallingScriptHash, Price: 1 << 4},
+	{Name: interopnames.SystemRuntimeGetEntryScriptHash, Func: runtime.GetEntryScriptHash, Price: 1 << 4},
+	{Name: interopnames.SystemRuntimeGetExecutingScriptHash, Func: runtime.GetExecutingScriptHash, Price: 1 << 4},
+	{Name: interopnames.SystemRuntimeGetInvocationCounter, Func: runtime.GetInvocationCounter, Price: 1 << 4},
+	{Name: interopnames.SystemRuntimeGetNetwork, Func: runtime.GetNetwork, Price: 1 << 3},
+	{Name: interopnames.SystemRuntimeGetNotifications, Func: runtime.GetNotifications, Price: 1 << 12, ParamCount: 1},
+	{Name: interopnames.SystemRuntimeGetRandom, Func: runtime.GetRandom, Price: 0},
+	{Name: interopnames.SystemRuntimeGetScriptContainer, Func: runtime.GetScriptContainer, Price: 1 << 3},
+	{Name: interopnames.SystemRuntimeGetTime, Func: runtime.GetTime, Price: 1 << 3, RequiredFlags: callflag.ReadStates},
+	{Name: interopnames.SystemRuntimeGetTrigger, Func: runtime.GetTrigger, Price: 1 << 3},
+	{Name: interopnames.SystemRuntimeLoadScript, Func: runtime.LoadScript, Price: 1 << 15, RequiredFlags: callflag.AllowCall,
+		ParamCount: 3},
+	{Name: interopnames.SystemRuntimeLog, Func: runtime.Log, Price: 1 << 15, RequiredFlags: callflag.AllowNotify,
+		ParamCount: 1},
+	{Name: interopnames.SystemRuntimeNotify, Func: runtime.Notify, Price: 1 << 15, RequiredFlags: callflag.AllowNotify,
+		ParamCount: 2},
+	{Name: interopnames.SystemRuntimePlatform, Func: runtime.Platform, Price: 1 << 3},
+	{Name: interopnames.SystemStorageDelete, Func: storage.Delete, Price: 1 << 15,
+		RequiredFlags: callflag.WriteStates, ParamCount: 2},
+	{Name: interopnames.SystemStorageFind, Func: storage.Find, Price: 1 << 15, RequiredFlags: callflag.ReadStates,
+		ParamCount: 3},
+	{Name: interopnames.SystemStorageGet, Func: storage.Get, Price: 1 << 15, RequiredFlags: callflag.ReadStates,
+		ParamCount: 2},
+	{Name: interopnames.SystemStorageGetContext, Func: storage.GetContext, Price: 1 << 4,
+		RequiredFlags: callflag.ReadStates},
+	{Name: interopnames.SystemStorageGetReadOnlyContext, Func: storage.GetReadOnlyContext, Price: 1 << 4,
+		RequiredFlags: callflag.ReadStates},
+	{Name: interopnames.SystemStoragePut, Func: storage.Put, Price: 1 << 15, RequiredFlags: callflag.WriteStates,
+		ParamCount: 3},
+	{Name: interopnames.SystemStorageAsReadOnly, Func: storage.ContextAsReadOnly, Price: 1 << 4,
+		RequiredFlags: callflag.ReadStates, ParamCount: 1},
+}
+
+// init initializes IDs in the global interop slice.
+func init() {
+	for i := range functions {
+		functions[i].ID = interopnames.ToID([]byte(functions[i].Name))
+	}
+	interop.Sort(functions)
+}
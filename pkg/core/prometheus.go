@@ -1,6 +1,9 @@
 package core
 
 import (
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -38,6 +41,50 @@ var (
 			Namespace: "neogo",
 		},
 	)
+	// gcReclaimedBytes prometheus metric.
+	gcReclaimedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of bytes reclaimed by garbage collector by data type",
+			Name:      "gc_reclaimed_bytes",
+			Namespace: "neogo",
+		},
+		[]string{"type"},
+	)
+	// gcRemovedItems prometheus metric.
+	gcRemovedItems = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of DB entries removed by garbage collector by data type",
+			Name:      "gc_removed_items",
+			Namespace: "neogo",
+		},
+		[]string{"type"},
+	)
+	// gcPauseTime prometheus metric.
+	gcPauseTime = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Help:      "Time spent in a single garbage collection step (seconds)",
+			Name:      "gc_pause_time",
+			Namespace: "neogo",
+		},
+	)
+	// dbKeys prometheus metric.
+	dbKeys = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Number of DB entries by key prefix",
+			Name:      "db_keys",
+			Namespace: "neogo",
+		},
+		[]string{"prefix"},
+	)
+	// dbSize prometheus metric.
+	dbSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Size of DB entries (keys and values) by key prefix in bytes",
+			Name:      "db_size",
+			Namespace: "neogo",
+		},
+		[]string{"prefix"},
+	)
 	// mempoolUnsortedTx prometheus metric.
 	mempoolUnsortedTx = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -55,6 +102,11 @@ func init() {
 		estimatedPersistVelocity,
 		headerHeight,
 		mempoolUnsortedTx,
+		gcReclaimedBytes,
+		gcRemovedItems,
+		gcPauseTime,
+		dbKeys,
+		dbSize,
 	)
 }
 
@@ -78,3 +130,24 @@ func updateBlockHeightMetric(bHeight uint32) {
 func updateMempoolMetrics(unsortedTxnLen int) {
 	mempoolUnsortedTx.Set(float64(unsortedTxnLen))
 }
+
+// updateGCMetrics updates garbage collector metrics for the given data type.
+func updateGCMetrics(typ string, removed int, reclaimed int64) {
+	gcRemovedItems.WithLabelValues(typ).Add(float64(removed))
+	gcReclaimedBytes.WithLabelValues(typ).Add(float64(reclaimed))
+}
+
+// updateGCPauseMetric updates garbage collection step duration metric.
+func updateGCPauseMetric(d time.Duration) {
+	gcPauseTime.Observe(d.Seconds())
+}
+
+// updateDBStatsMetrics updates DB statistics metrics.
+func updateDBStatsMetrics(stats storage.Stats) {
+	dbKeys.Reset()
+	dbSize.Reset()
+	for p, s := range stats.Prefixes {
+		dbKeys.WithLabelValues(p.String()).Set(float64(s.Keys))
+		dbSize.WithLabelValues(p.String()).Set(float64(s.Size))
+	}
+}
@@ -0,0 +1,84 @@
+package state
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Storage diff value presence flags.
+const (
+	storageDiffHasOld byte = 1 << iota
+	storageDiffHasNew
+)
+
+// StorageDiff is a single contract storage item change made by some block.
+type StorageDiff struct {
+	// ID is the ID of the contract the item belongs to.
+	ID int32 `json:"id"`
+	// Key is the storage item key.
+	Key []byte `json:"key"`
+	// Old is the item value before the block, it's nil for created items.
+	Old []byte `json:"oldvalue"`
+	// New is the item value after the block, it's nil for deleted items.
+	New []byte `json:"newvalue"`
+}
+
+// StorageChangeEvent is a contract storage item change made by some block
+// along with the block and contract information.
+type StorageChangeEvent struct {
+	// BlockHash is the hash of the block that made the change.
+	BlockHash util.Uint256 `json:"blockhash"`
+	// BlockIndex is the index of the block that made the change.
+	BlockIndex uint32 `json:"blockindex"`
+	// Contract is the hash of the contract the item belongs to.
+	Contract util.Uint160 `json:"contract"`
+	StorageDiff
+}
+
+// EncodeBinary implements the io.Serializable interface.
+func (d *StorageDiff) EncodeBinary(w *io.BinWriter) {
+	var flags byte
+	if d.Old != nil {
+		flags |= storageDiffHasOld
+	}
+	if d.New != nil {
+		flags |= storageDiffHasNew
+	}
+	w.WriteU32LE(uint32(d.ID))
+	w.WriteVarBytes(d.Key)
+	w.WriteB(flags)
+	if d.Old != nil {
+		w.WriteVarBytes(d.Old)
+	}
+	if d.New != nil {
+		w.WriteVarBytes(d.New)
+	}
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (d *StorageDiff) DecodeBinary(r *io.BinReader) {
+	d.ID = int32(r.ReadU32LE())
+	d.Key = r.ReadVarBytes()
+	flags := r.ReadB()
+	if flags&^(storageDiffHasOld|storageDiffHasNew) != 0 {
+		r.Err = errors.New("invalid storage diff flags")
+		return
+	}
+	d.Old, d.New = nil, nil
+	if flags&storageDiffHasOld != 0 {
+		d.Old = readNonNilBytes(r)
+	}
+	if flags&storageDiffHasNew != 0 {
+		d.New = readNonNilBytes(r)
+	}
+}
+
+func readNonNilBytes(r *io.BinReader) []byte {
+	b := r.ReadVarBytes()
+	if b == nil {
+		b = []byte{}
+	}
+	return b
+}
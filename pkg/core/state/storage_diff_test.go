@@ -0,0 +1,34 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageDiff_EncodeDecodeBinary(t *testing.T) {
+	for _, d := range []StorageDiff{
+		{ID: 1, Key: []byte{1, 2}, New: []byte{3}},
+		{ID: -5, Key: []byte{1, 2}, Old: []byte{3}},
+		{ID: 7, Key: []byte{}, Old: []byte{}, New: []byte{4, 5}},
+	} {
+		testserdes.EncodeDecodeBinary(t, &d, new(StorageDiff))
+	}
+
+	t.Run("invalid flags", func(t *testing.T) {
+		require.Error(t, testserdes.DecodeBinary([]byte{1, 0, 0, 0, 0, 4}, new(StorageDiff)))
+	})
+}
+
+func TestStorageChangeEvent_MarshalJSON(t *testing.T) {
+	e := &StorageChangeEvent{
+		BlockIndex: 10,
+		StorageDiff: StorageDiff{
+			ID:  1,
+			Key: []byte{1, 2},
+			New: []byte{},
+		},
+	}
+	testserdes.MarshalUnmarshalJSON(t, e, new(StorageChangeEvent))
+}
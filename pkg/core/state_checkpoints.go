@@ -0,0 +1,497 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/checkpoint"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/statesync"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+// Storage delta item types.
+const (
+	deltaPut    byte = 0
+	deltaDelete byte = 1
+)
+
+// CheckpointUploader is a service uploading state checkpoints written by
+// the node. UploadCheckpoint is called from the checkpoint writer routine,
+// so it must not block.
+type CheckpointUploader interface {
+	UploadCheckpoint(checkpoint.Info)
+}
+
+// checkpointMPTBatchSize is the number of storage items added to MPT at once
+// when restoring from a checkpoint.
+const checkpointMPTBatchSize = 10000
+
+// storeStorageDelta records contract storage changes made by the block with
+// the given index. Changes are grouped by the checkpoint they belong to (the
+// nearest checkpoint height not less than the block index), so that the
+// checkpoint writer can work with changes that are not modified anymore.
+func storeStorageDelta(cache *dao.Simple, index uint32, interval uint32, changes map[string][]byte) {
+	var gen = (index + interval - 1) / interval * interval
+
+	for k, v := range changes {
+		key := make([]byte, 5+len(k)-1)
+		key[0] = byte(storage.STStorageDelta)
+		binary.BigEndian.PutUint32(key[1:], gen)
+		copy(key[5:], k[1:])
+		if v == nil {
+			cache.Store.Put(key, []byte{deltaDelete})
+		} else {
+			cache.Store.Put(key, append([]byte{deltaPut}, v...))
+		}
+	}
+}
+
+// storeCheckpointOrigin saves the values contract storage items changed by
+// the block with the given index had at the height of the full checkpoint
+// being written, if there is any. It must be called with the lock held
+// before the block changes are persisted.
+func (bc *Blockchain) storeCheckpointOrigin(cache *dao.Simple, index uint32, changes map[string][]byte) {
+	if bc.checkpointOrigin == 0 || index <= bc.checkpointOrigin {
+		return
+	}
+	for k := range changes {
+		key := []byte(k)
+		key[0] = byte(storage.STStorageOrigin)
+		if _, err := bc.dao.Store.Get(key); err == nil {
+			continue // Only the first change matters.
+		}
+		v, err := bc.dao.Store.Get([]byte(k))
+		if err != nil {
+			cache.Store.Put(key, []byte{deltaDelete})
+		} else {
+			cache.Store.Put(key, append([]byte{deltaPut}, v...))
+		}
+	}
+}
+
+// requestStateCheckpoint passes a checkpoint for the block with the given
+// index to the checkpoint writer if needed. It must be called with the lock
+// held after the block changes are persisted. Checkpoints are skipped if
+// the writer is busy, changes are kept in the DB and the next checkpoint
+// covers them.
+func (bc *Blockchain) requestStateCheckpoint(index uint32, root util.Uint256) {
+	if index == 0 || index%bc.config.Ledger.StateCheckpoints.Interval != 0 {
+		return
+	}
+	if bc.checkpointOrigin != 0 {
+		bc.log.Warn("full state checkpoint is still being written, skipping checkpoint",
+			zap.Uint32("index", index), zap.Uint32("full", bc.checkpointOrigin))
+		return
+	}
+	h := &checkpoint.Header{
+		Magic: bc.config.Magic,
+		Index: index,
+		Root:  root,
+	}
+	last, err := bc.dao.GetStateCheckpoint()
+	if err == nil {
+		h.PrevIndex = last
+	} else {
+		h.Full = true
+		// Drop the data left from the interrupted full checkpoint.
+		cache := bc.dao.GetPrivate()
+		deleteByPrefix(cache, bc.dao.Store, storage.STStorageOrigin)
+		_, err = cache.Persist()
+		if err != nil {
+			bc.log.Error("failed to remove stale state checkpoint data", zap.Error(err))
+			return
+		}
+	}
+	select {
+	case bc.stateCheckpoints <- h:
+	default:
+		bc.log.Warn("state checkpoint writer is busy, skipping checkpoint", zap.Uint32("index", index))
+		return
+	}
+	if h.Full {
+		bc.checkpointOrigin = index
+	}
+}
+
+// writeStateCheckpoints writes state checkpoints requested by storeBlock
+// until the Blockchain is stopped.
+func (bc *Blockchain) writeStateCheckpoints(done chan struct{}) {
+	defer close(done)
+	for {
+		select {
+		case <-bc.stopCh:
+			return
+		case h := <-bc.stateCheckpoints:
+			bc.writeStateCheckpoint(h)
+		}
+	}
+}
+
+// writeStateCheckpoint writes state checkpoint with the given header. Full
+// checkpoints contain current contract storage items overridden by the
+// values saved by storeCheckpointOrigin (these are written after, so they
+// take precedence when items are applied in order). Incremental ones
+// contain all changes recorded for the checkpoints up to the given one.
+// Recorded changes are removed once the checkpoint is written, they're kept
+// in the DB on failure and the next checkpoint covers them.
+func (bc *Blockchain) writeStateCheckpoint(h *checkpoint.Header) {
+	var (
+		count   int
+		keys    [][]byte
+		stopped bool
+		cont    = func() bool {
+			select {
+			case <-bc.stopCh:
+				stopped = true
+				return false
+			default:
+				return true
+			}
+		}
+	)
+	w, err := checkpoint.Create(bc.config.Ledger.StateCheckpoints.Path, h)
+	if err != nil {
+		bc.log.Error("failed to create state checkpoint", zap.Uint32("index", h.Index), zap.Error(err))
+		bc.finishStateCheckpoint(h, nil, false)
+		return
+	}
+	if h.Full {
+		bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(bc.dao.Version.StoragePrefix)}}, func(k, v []byte) bool {
+			w.Put(k[1:], v)
+			count++
+			return cont()
+		})
+		bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(storage.STStorageOrigin)}}, func(k, v []byte) bool {
+			if v[0] == deltaDelete {
+				w.Delete(k[1:])
+			} else {
+				w.Put(k[1:], v[1:])
+			}
+			return cont()
+		})
+	}
+	bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(storage.STStorageDelta)}}, func(k, v []byte) bool {
+		if binary.BigEndian.Uint32(k[1:]) > h.Index {
+			return false
+		}
+		if !h.Full {
+			if v[0] == deltaDelete {
+				w.Delete(k[5:])
+			} else {
+				w.Put(k[5:], v[1:])
+			}
+			count++
+		}
+		// #1468, but don't need to copy here, because it is done by Store.
+		keys = append(keys, k)
+		return cont()
+	})
+	if stopped {
+		w.Abort()
+		return
+	}
+	err = w.Close()
+	if err != nil {
+		bc.log.Error("failed to write state checkpoint", zap.Uint32("index", h.Index), zap.Error(err))
+		bc.finishStateCheckpoint(h, nil, false)
+		return
+	}
+	if !bc.finishStateCheckpoint(h, keys, true) {
+		return
+	}
+	bc.log.Info("state checkpoint is written",
+		zap.Uint32("index", h.Index),
+		zap.Bool("full", h.Full),
+		zap.Int("items", count))
+	if up := bc.checkpointUploader.Load(); up != nil && *up != nil {
+		(*up).UploadCheckpoint(checkpoint.Info{
+			Header: *h,
+			Path:   filepath.Join(bc.config.Ledger.StateCheckpoints.Path, checkpoint.FileName(h.Index)),
+		})
+	}
+}
+
+// finishStateCheckpoint removes the data used to write the given checkpoint
+// and stores its index as the latest one if it's written successfully.
+func (bc *Blockchain) finishStateCheckpoint(h *checkpoint.Header, keys [][]byte, written bool) bool {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	cache := bc.dao.GetPrivate()
+	if h.Full {
+		deleteByPrefix(cache, bc.dao.Store, storage.STStorageOrigin)
+		bc.checkpointOrigin = 0
+	}
+	for _, k := range keys {
+		cache.Store.Delete(k)
+	}
+	if written {
+		cache.PutStateCheckpoint(h.Index)
+	}
+	_, err := cache.Persist()
+	if err != nil {
+		bc.log.Error("failed to persist state checkpoint data", zap.Uint32("index", h.Index), zap.Error(err))
+		return false
+	}
+	return written
+}
+
+// clearStateCheckpoints removes the data recorded for state checkpoints and
+// the latest checkpoint index via the given cache, so that the next
+// checkpoint is a full one. It's used when contract storage is replaced by
+// state jumps and resets since recorded changes are not applicable to the
+// new storage.
+func (bc *Blockchain) clearStateCheckpoints(cache *dao.Simple) {
+	deleteByPrefix(cache, bc.dao.Store, storage.STStorageDelta)
+	deleteByPrefix(cache, bc.dao.Store, storage.STStorageOrigin)
+	cache.DeleteStateCheckpoint()
+	bc.checkpointOrigin = 0
+}
+
+// deleteByPrefix deletes all items with the given prefix found in the store
+// via the given cache.
+func deleteByPrefix(cache *dao.Simple, s storage.Store, p storage.KeyPrefix) {
+	s.Seek(storage.SeekRange{Prefix: []byte{byte(p)}}, func(k, _ []byte) bool {
+		// #1468, but don't need to copy here, because it is done by Store.
+		cache.Store.Delete(k)
+		return true
+	})
+}
+
+// CheckpointRestorer restores Blockchain state from state checkpoints. It's
+// applicable to the chain that has genesis block only and StateRootInHeader
+// enabled, since the header following the checkpoint is the only trusted
+// source of the state root for it. Blocks up to the checkpoint height are
+// stored without execution (so there are no application logs and token
+// transfers for them) and contract storage is restored from checkpoint items,
+// then the chain jumps to the checkpoint state and continues regular
+// processing.
+type CheckpointRestorer struct {
+	bc     *Blockchain
+	index  uint32
+	root   util.Uint256
+	height uint32
+	prefix storage.KeyPrefix
+}
+
+// NewCheckpointRestorer returns CheckpointRestorer for the checkpoint with the
+// given index and state root hash.
+func (bc *Blockchain) NewCheckpointRestorer(index uint32, root util.Uint256) (*CheckpointRestorer, error) {
+	if bc.BlockHeight() != 0 || bc.HeaderHeight() != 0 {
+		return nil, fmt.Errorf("chain is not empty: block height %d, header height %d", bc.BlockHeight(), bc.HeaderHeight())
+	}
+	if index == 0 {
+		return nil, errors.New("can't restore state for the genesis block")
+	}
+	if !bc.config.StateRootInHeader {
+		return nil, errors.New("checkpoint state root can't be verified without StateRootInHeader")
+	}
+	// The genesis state is going to be replaced, so remove its MPT nodes
+	// the same way state sync does, along with anything left from the
+	// previous restore attempt.
+	err := bc.stateRoot.CleanStorage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove outdated MPT data: %w", err)
+	}
+	prefix := statesync.TemporaryPrefix(bc.dao.Version.StoragePrefix)
+	cache := bc.dao.GetPrivate()
+	bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(prefix)}}, func(k, _ []byte) bool {
+		cache.Store.Delete(k)
+		return true
+	})
+	_, err = cache.Persist()
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove stale storage items: %w", err)
+	}
+	return &CheckpointRestorer{
+		bc:     bc,
+		index:  index,
+		root:   root,
+		prefix: prefix,
+	}, nil
+}
+
+// AddBlock adds block header to the chain and stores the block without
+// execution. Blocks must be added sequentially up to the checkpoint height.
+func (r *CheckpointRestorer) AddBlock(b *block.Block) error {
+	if b.Index != r.height+1 {
+		return fmt.Errorf("expected block %d, got %d", r.height+1, b.Index)
+	}
+	if b.Index > r.index {
+		return fmt.Errorf("block %d is above the checkpoint height %d", b.Index, r.index)
+	}
+	if r.bc.config.StateRootInHeader != b.StateRootEnabled {
+		return fmt.Errorf("stateroot setting mismatch: %v != %v", r.bc.config.StateRootInHeader, b.StateRootEnabled)
+	}
+	err := r.bc.AddHeaders(&b.Header)
+	if err != nil {
+		return err
+	}
+	if !r.bc.config.SkipBlockVerification {
+		merkle := b.ComputeMerkleRoot()
+		if !b.MerkleRoot.Equals(merkle) {
+			return errors.New("invalid block: MerkleRoot mismatch")
+		}
+	}
+	cache := r.bc.dao.GetPrivate()
+	if err := cache.StoreAsBlock(b, nil, nil); err != nil {
+		return err
+	}
+	for _, tx := range b.Transactions {
+		if err := cache.StoreAsTransaction(tx, b.Index, nil); err != nil {
+			return err
+		}
+	}
+	_, err = cache.Persist()
+	if err != nil {
+		return fmt.Errorf("failed to persist block %d: %w", b.Index, err)
+	}
+	r.height = b.Index
+	return nil
+}
+
+// AddItems applies checkpoint items to the contract storage being restored.
+// Items from all checkpoints (starting from the full one) must be added in
+// order.
+func (r *CheckpointRestorer) AddItems(items []checkpoint.Item) error {
+	cache := r.bc.dao.GetPrivate()
+	for _, it := range items {
+		k := append([]byte{byte(r.prefix)}, it.Key...)
+		if it.Value == nil {
+			cache.Store.Delete(k)
+		} else {
+			cache.Store.Put(k, it.Value)
+		}
+	}
+	_, err := cache.Persist()
+	if err != nil {
+		return fmt.Errorf("failed to persist contract storage items: %w", err)
+	}
+	return nil
+}
+
+// Finalize adds the header following the checkpoint to the chain, builds MPT
+// from the restored contract storage, checks its root against the checkpoint
+// and the state root from the given header and makes the chain jump to the
+// checkpoint state.
+func (r *CheckpointRestorer) Finalize(next *block.Header) error {
+	if r.height != r.index {
+		return fmt.Errorf("blocks are stored up to %d, checkpoint height is %d", r.height, r.index)
+	}
+	if next.Index != r.index+1 {
+		return fmt.Errorf("expected header %d, got %d", r.index+1, next.Index)
+	}
+	if r.bc.HeaderHeight() == r.index {
+		err := r.bc.AddHeaders(next)
+		if err != nil {
+			return err
+		}
+	}
+	h, err := r.bc.GetHeader(r.bc.GetHeaderHash(r.index + 1))
+	if err != nil {
+		return fmt.Errorf("failed to get next header: %w", err)
+	}
+	if !h.PrevStateRoot.Equals(r.root) {
+		return fmt.Errorf("state root mismatch at height %d: header has %s, checkpoint has %s",
+			r.index, h.PrevStateRoot.StringLE(), r.root.StringLE())
+	}
+	var mode mpt.TrieMode
+	// No need to enable GC here, it only has latest things.
+	if r.bc.config.Ledger.KeepOnlyLatestState || r.bc.config.Ledger.RemoveUntraceableBlocks {
+		mode |= mpt.ModeLatest
+	}
+	var (
+		tr    = mpt.NewTrie(nil, mode, storage.NewMemCachedStore(r.bc.dao.Store))
+		start []byte
+	)
+	for {
+		batch := make(map[string][]byte, checkpointMPTBatchSize)
+		r.bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(r.prefix)}, Start: start}, func(k, v []byte) bool {
+			if len(batch) == checkpointMPTBatchSize {
+				return false
+			}
+			if start != nil && bytes.Equal(k[1:], start) {
+				return true
+			}
+			batch[string(k)] = v
+			start = k[1:]
+			return true
+		})
+		if len(batch) == 0 {
+			break
+		}
+		_, err := tr.PutBatch(mpt.MapToMPTBatch(batch))
+		if err != nil {
+			return fmt.Errorf("failed to add contract storage items to MPT: %w", err)
+		}
+		tr.Flush(r.index)
+		tr.Collapse(10)
+		_, err = tr.Store.Persist()
+		if err != nil {
+			return fmt.Errorf("failed to persist MPT: %w", err)
+		}
+	}
+	root := tr.StateRoot()
+	if !root.Equals(r.root) {
+		return fmt.Errorf("state root mismatch at height %d: checkpoint has %s, got %s",
+			r.index, r.root.StringLE(), root.StringLE())
+	}
+	return r.bc.jumpToCheckpoint(r.index, root)
+}
+
+// jumpToCheckpoint changes Blockchain state to the one restored from the
+// checkpoint with the given index and state root hash.
+func (bc *Blockchain) jumpToCheckpoint(p uint32, root util.Uint256) error {
+	bc.addLock.Lock()
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+	defer bc.addLock.Unlock()
+
+	bc.log.Info("jumping to state checkpoint", zap.Uint32("index", p))
+
+	oldPrefix := bc.dao.Version.StoragePrefix
+	v, err := bc.dao.GetVersion()
+	if err != nil {
+		return fmt.Errorf("failed to get dao.Version: %w", err)
+	}
+	v.StoragePrefix = statesync.TemporaryPrefix(oldPrefix)
+
+	cache := bc.dao.GetPrivate()
+	bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(oldPrefix)}}, func(k, _ []byte) bool {
+		cache.Store.Delete(k)
+		return true
+	})
+	b, err := bc.dao.GetBlock(bc.GetHeaderHash(p))
+	if err != nil {
+		return fmt.Errorf("failed to get current block: %w", err)
+	}
+	bc.clearStateCheckpoints(cache)
+	cache.StoreAsCurrentBlock(b)
+	cache.PutStateSyncPoint(p)
+	cache.PutVersion(v)
+	_, err = cache.Persist()
+	if err != nil {
+		return fmt.Errorf("failed to persist state jump: %w", err)
+	}
+	bc.dao.Version = v
+	bc.persistent.Version = v
+
+	bc.stateRoot.JumpToState(&state.MPTRoot{
+		Index: p,
+		Root:  root,
+	})
+	err = bc.resetRAMState(p, false)
+	if err != nil {
+		return fmt.Errorf("failed to update in-memory blockchain data: %w", err)
+	}
+	return nil
+}
@@ -298,6 +298,10 @@ func (s *Module) ResetState(height uint32, cache *storage.MemCachedStore) error
 }
 
 // GC performs garbage collection.
+//
+// Deprecated: Blockchain performs incremental MPT garbage collection itself
+// now, this method sweeps the whole DB at once and will be removed in future
+// versions.
 func (s *Module) GC(index uint32, store storage.Store) time.Duration {
 	if !s.mode.GC() {
 		panic("stateroot: GC invoked, but not enabled")
@@ -7,7 +7,10 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 )
 
 var (
@@ -82,3 +85,24 @@ func (s *Module) AddStateRoot(sr *state.MPTRoot) error {
 	}
 	return nil
 }
+
+// AddStateSyncRoot checks the witness of the state root for the state
+// synchronisation point against the given state validators (designated for
+// this height) and stores it. Unlike AddStateRoot it doesn't require local
+// state root for this height, so it can be used by the state sync module
+// before the state jump.
+func (s *Module) AddStateSyncRoot(sr *state.MPTRoot, pubs keys.PublicKeys) error {
+	if len(sr.Witness) != 1 {
+		return errors.New("no witness")
+	}
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(pubs)
+	if err != nil {
+		return fmt.Errorf("invalid state validators: %w", err)
+	}
+	_, err = s.verifier(hash.Hash160(script), sr, &sr.Witness[0], maxVerificationGAS)
+	if err != nil {
+		return err
+	}
+	putStateRoot(s.Store, makeStateRootKey(sr.Index), sr)
+	return nil
+}
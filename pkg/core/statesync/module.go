@@ -10,6 +10,12 @@ includes the following stages:
 2. Fetching MPT nodes for height P stating from the corresponding state root.
 3. Fetching blocks starting from height P-MaxTraceableBlocks (or 0) up to P.
 
+Instead of MPT nodes, contract storage items for height P may be provided (e.g.
+from the state snapshot stored in NeoFS). In this case the MPT is rebuilt from
+these items and its root is checked against the state root from the header P+1.
+If StateRootInHeader is off, it's checked against the state root signed by state
+validators designated (in the restored RoleManagement contract storage) for P.
+
 Steps 2 and 3 are being performed in parallel. Once all the data are collected
 and stored in the db, an atomic state jump is occurred to the state sync point P.
 Further node operation process is performed using standard sync mechanism until
@@ -18,6 +24,7 @@ the node reaches synchronised state.
 package statesync
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -27,10 +34,15 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/stateroot"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"go.uber.org/zap"
 )
 
@@ -60,6 +72,9 @@ const (
 	blocksSynced
 )
 
+// roleManagementContractID is the ID of the native RoleManagement contract.
+const roleManagementContractID = -8
+
 // Ledger is the interface required from Blockchain for Module to operate.
 type Ledger interface {
 	AddHeaders(...*block.Header) error
@@ -91,6 +106,12 @@ type Module struct {
 	mptpool  *Pool
 
 	billet *mpt.Billet
+	// syncRoot is the state root for the current state synchronisation point
+	// taken from the header P+1 (or the one signed by state validators if
+	// StateRootInHeader is off).
+	syncRoot util.Uint256
+	// trie is used to rebuild MPT from contract storage items.
+	trie *mpt.Trie
 
 	jumpCallback func(p uint32) error
 
@@ -209,7 +230,7 @@ func TemporaryPrefix(currPrefix storage.KeyPrefix) storage.KeyPrefix {
 	case storage.STTempStorage:
 		return storage.STStorage
 	default:
-		panic(fmt.Sprintf("invalid storage prefix: %x", currPrefix))
+		panic(fmt.Sprintf("invalid storage prefix: %s", currPrefix))
 	}
 }
 
@@ -238,22 +259,27 @@ func (s *Module) defineSyncStage() error {
 		s.log.Info("MPT is in sync",
 			zap.Uint32("stateroot height", s.stateMod.CurrentLocalHeight()))
 	} else if s.syncStage&headersSynced != 0 {
-		header, err := s.bc.GetHeader(s.bc.GetHeaderHash(s.syncPoint + 1))
+		root, ok, err := s.getSyncRoot()
 		if err != nil {
-			return fmt.Errorf("failed to get header to initialize MPT billet: %w", err)
+			return err
+		}
+		if !ok {
+			// MPT can only be rebuilt from contract storage items then.
+			return nil
 		}
 		var mode mpt.TrieMode
 		// No need to enable GC here, it only has latest things.
 		if s.bc.GetConfig().Ledger.KeepOnlyLatestState || s.bc.GetConfig().Ledger.RemoveUntraceableBlocks {
 			mode |= mpt.ModeLatest
 		}
-		s.billet = mpt.NewBillet(header.PrevStateRoot, mode,
+		s.syncRoot = root
+		s.billet = mpt.NewBillet(root, mode,
 			TemporaryPrefix(s.dao.Version.StoragePrefix), s.dao.Store)
 		s.log.Info("MPT billet initialized",
 			zap.Uint32("height", s.syncPoint),
-			zap.String("state root", header.PrevStateRoot.StringBE()))
+			zap.String("state root", root.StringBE()))
 		pool := NewPool()
-		pool.Add(header.PrevStateRoot, []byte{})
+		pool.Add(root, []byte{})
 		err = s.billet.Traverse(func(_ []byte, n mpt.Node, _ []byte) bool {
 			nPaths, ok := pool.TryGet(n.Hash())
 			if !ok {
@@ -289,6 +315,26 @@ func (s *Module) defineSyncStage() error {
 	return nil
 }
 
+// getSyncRoot returns the state root for the current state sync point and
+// whether it's known. It's taken from the header P+1 if StateRootInHeader is
+// enabled. Otherwise it's the one signed by state validators that is stored by
+// FinalizeContractStorage, so it's not known until MPT is rebuilt from contract
+// storage items.
+func (s *Module) getSyncRoot() (util.Uint256, bool, error) {
+	if !s.bc.GetConfig().StateRootInHeader {
+		sr, err := s.stateMod.GetStateRoot(s.syncPoint)
+		if err != nil {
+			return util.Uint256{}, false, nil
+		}
+		return sr.Root, true, nil
+	}
+	header, err := s.bc.GetHeader(s.bc.GetHeaderHash(s.syncPoint + 1))
+	if err != nil {
+		return util.Uint256{}, false, fmt.Errorf("failed to get header to initialize MPT billet: %w", err)
+	}
+	return header.PrevStateRoot, true, nil
+}
+
 // getLatestSavedBlock returns either current block index (if it's still relevant
 // to continue state sync process) or H-1 where H is the index of the earliest
 // block that should be saved next.
@@ -429,6 +475,154 @@ func (s *Module) AddMPTNodes(nodes [][]byte) error {
 	return nil
 }
 
+// AddContractStorageItems adds provided contract storage items (MPT keys and
+// values) for the current state sync point to the temporary storage and to
+// the MPT that is being rebuilt from them. Items may be added in any order,
+// but each of them must be added only once. Call FinalizeContractStorage once
+// all items are added.
+func (s *Module) AddContractStorageItems(kvs []storage.KeyValue) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.syncStage&headersSynced == 0 || s.syncStage&mptSynced != 0 {
+		return errors.New("contract storage items were not requested")
+	}
+	if s.trie == nil {
+		var mode mpt.TrieMode
+		// No need to enable GC here, it only has latest things.
+		if s.bc.GetConfig().Ledger.KeepOnlyLatestState || s.bc.GetConfig().Ledger.RemoveUntraceableBlocks {
+			mode |= mpt.ModeLatest
+		}
+		s.trie = mpt.NewTrie(nil, mode, storage.NewMemCachedStore(s.dao.Store))
+	}
+	var (
+		prefix = TemporaryPrefix(s.dao.Version.StoragePrefix)
+		batch  = make(map[string][]byte, len(kvs))
+	)
+	for _, kv := range kvs {
+		k := append([]byte{byte(prefix)}, kv.Key...)
+		batch[string(k)] = kv.Value
+		s.trie.Store.Put(k, kv.Value)
+	}
+	_, err := s.trie.PutBatch(mpt.MapToMPTBatch(batch))
+	if err != nil {
+		return fmt.Errorf("failed to add contract storage items to MPT: %w", err)
+	}
+	s.trie.Flush(s.syncPoint)
+	s.trie.Collapse(10)
+	_, err = s.trie.Store.Persist()
+	if err != nil {
+		return fmt.Errorf("failed to persist contract storage items: %w", err)
+	}
+	return nil
+}
+
+// FinalizeContractStorage checks the root of MPT rebuilt from contract storage
+// items added via AddContractStorageItems against the state root for the
+// current state sync point. If StateRootInHeader is off, this state root is
+// the given one signed by state validators, its witness is checked against
+// state validators designated for the state sync point in the restored
+// RoleManagement contract storage. If it matches, MPT is considered to be in
+// sync.
+func (s *Module) FinalizeContractStorage(sr *state.MPTRoot) error {
+	oldStage := s.syncStage
+	s.lock.Lock()
+	defer func() {
+		if s.syncStage != oldStage {
+			s.notifyStageChanged()
+		}
+	}()
+	defer s.lock.Unlock()
+
+	if s.syncStage&headersSynced == 0 || s.syncStage&mptSynced != 0 {
+		return errors.New("contract storage items were not requested")
+	}
+	var root util.Uint256
+	if s.trie != nil {
+		root = s.trie.StateRoot()
+	}
+	if !s.bc.GetConfig().StateRootInHeader {
+		err := s.addSyncRoot(sr, root)
+		if err != nil {
+			return fmt.Errorf("failed to check signed state root at height %d: %w. "+
+				"Please, drop the database manually and restart the node to run state sync process",
+				s.syncPoint, err)
+		}
+		s.syncRoot = root
+	} else if !root.Equals(s.syncRoot) {
+		return fmt.Errorf("state root mismatch at height %d: expected %s, got %s. "+
+			"Please, drop the database manually and restart the node to run state sync process",
+			s.syncPoint, s.syncRoot.StringBE(), root.StringBE())
+	}
+	s.syncStage |= mptSynced
+	s.log.Info("MPT is in sync",
+		zap.Uint32("height", s.syncPoint),
+		zap.String("state root", root.StringBE()))
+	s.checkSyncIsCompleted()
+	return nil
+}
+
+// addSyncRoot checks the given signed state root against the root of MPT
+// rebuilt from contract storage items and state validators designated for the
+// state sync point and stores it for the state jump.
+func (s *Module) addSyncRoot(sr *state.MPTRoot, root util.Uint256) error {
+	if sr == nil {
+		return errors.New("no signed state root")
+	}
+	if sr.Index != s.syncPoint {
+		return fmt.Errorf("signed state root height mismatch: expected %d, got %d", s.syncPoint, sr.Index)
+	}
+	if !sr.Root.Equals(root) {
+		return fmt.Errorf("state root mismatch: signed %s, got %s", sr.Root.StringBE(), root.StringBE())
+	}
+	pubs, err := s.getStateValidators()
+	if err != nil {
+		return fmt.Errorf("failed to get state validators: %w", err)
+	}
+	if len(pubs) == 0 {
+		return errors.New("no state validators designated")
+	}
+	err = s.stateMod.AddStateSyncRoot(sr, pubs)
+	if err != nil {
+		return fmt.Errorf("invalid state root witness: %w", err)
+	}
+	return nil
+}
+
+// getStateValidators returns state validators designated for the state sync
+// point from the restored RoleManagement contract storage (the same way
+// RoleManagement does it).
+func (s *Module) getStateValidators() (keys.PublicKeys, error) {
+	var (
+		id    int32 = roleManagementContractID
+		ns    native.NodeList
+		res   []byte
+		key   = make([]byte, 1+4+1)
+		start = make([]byte, 4)
+	)
+	key[0] = byte(TemporaryPrefix(s.dao.Version.StoragePrefix))
+	binary.LittleEndian.PutUint32(key[1:], uint32(id))
+	key[5] = byte(noderoles.StateValidator)
+	binary.BigEndian.PutUint32(start, s.syncPoint)
+	s.dao.Store.Seek(storage.SeekRange{
+		Prefix:    key,
+		Start:     start,
+		Backwards: true,
+	}, func(_, v []byte) bool {
+		res = v
+		// Take just the latest item, it's the one we need.
+		return false
+	})
+	if res == nil {
+		return nil, nil
+	}
+	err := stackitem.DeserializeConvertible(res, &ns)
+	if err != nil {
+		return nil, err
+	}
+	return keys.PublicKeys(ns), nil
+}
+
 func (s *Module) restoreNode(n mpt.Node) error {
 	nPaths, ok := s.mptpool.TryGet(n.Hash())
 	if !ok {
@@ -488,6 +682,7 @@ func (s *Module) checkSyncIsCompleted() {
 
 func (s *Module) dispose() {
 	s.billet = nil
+	s.trie = nil
 }
 
 // BlockHeight returns index of the last stored block.
@@ -565,6 +760,15 @@ func (s *Module) GetUnknownMPTNodesBatch(limit int) []util.Uint256 {
 	return s.mptpool.GetBatch(limit)
 }
 
+// GetStateSyncPoint returns the current state synchronisation point P and the
+// state root for it. The state root is only known once headers are in sync.
+func (s *Module) GetStateSyncPoint() (uint32, util.Uint256) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.syncPoint, s.syncRoot
+}
+
 // HeaderHeight returns the height of the latest stored header.
 func (s *Module) HeaderHeight() uint32 {
 	return s.bc.HeaderHeight()
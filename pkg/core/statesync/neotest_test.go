@@ -8,10 +8,19 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 )
 
@@ -471,3 +480,201 @@ func TestStateSyncModule_RestoreBasicChain(t *testing.T) {
 		check(t, true)
 	})
 }
+
+func TestStateSyncModule_RestoreFromContractStorage(t *testing.T) {
+	const (
+		stateSyncInterval = 4
+		maxTraceable      = 6
+		stateSyncPoint    = 16
+	)
+	spoutCfg := func(c *config.Blockchain) {
+		c.StateRootInHeader = true
+		c.P2PStateExchangeExtensions = true
+		c.StateSyncInterval = stateSyncInterval
+		c.MaxTraceableBlocks = maxTraceable
+	}
+	bcSpout, validators, committee := chain.NewMultiWithCustomConfig(t, spoutCfg)
+	e := neotest.NewExecutor(t, bcSpout, validators, committee)
+	for range stateSyncPoint + 2 {
+		e.AddNewBlock(t)
+	}
+
+	boltCfg := func(c *config.Blockchain) {
+		spoutCfg(c)
+		c.Ledger.KeepOnlyLatestState = true
+		c.Ledger.RemoveUntraceableBlocks = true
+	}
+	bcBolt, _, _ := chain.NewMultiWithCustomConfig(t, boltCfg)
+	module := bcBolt.GetStateSyncModule()
+
+	t.Run("error: add contract storage items without initialisation", func(t *testing.T) {
+		require.Error(t, module.AddContractStorageItems([]storage.KeyValue{}))
+		require.Error(t, module.FinalizeContractStorage(nil))
+	})
+
+	require.NoError(t, module.Init(bcSpout.BlockHeight()))
+	headers := make([]*block.Header, 0, bcSpout.HeaderHeight())
+	for i := uint32(1); i <= bcSpout.HeaderHeight(); i++ {
+		h, err := bcSpout.GetHeader(bcSpout.GetHeaderHash(i))
+		require.NoError(t, err)
+		headers = append(headers, h)
+	}
+	require.NoError(t, module.AddHeaders(headers...))
+	require.True(t, module.NeedMPTNodes())
+
+	h, err := bcSpout.GetHeader(bcSpout.GetHeaderHash(stateSyncPoint + 1))
+	require.NoError(t, err)
+	p, root := module.GetStateSyncPoint()
+	require.Equal(t, uint32(stateSyncPoint), p)
+	require.Equal(t, h.PrevStateRoot, root)
+
+	var kvs []storage.KeyValue
+	bcSpout.GetStateModule().SeekStates(root, []byte{}, func(k, v []byte) bool {
+		kvs = append(kvs, storage.KeyValue{Key: bytes.Clone(k), Value: bytes.Clone(v)})
+		return true
+	})
+	require.NotEmpty(t, kvs)
+
+	t.Run("error: state root mismatch", func(t *testing.T) {
+		require.Error(t, module.FinalizeContractStorage(nil))
+		require.True(t, module.NeedMPTNodes())
+	})
+
+	for i := 0; i < len(kvs); i += 7 {
+		require.NoError(t, module.AddContractStorageItems(kvs[i:min(i+7, len(kvs))]))
+	}
+	require.NoError(t, module.FinalizeContractStorage(nil))
+	require.False(t, module.NeedMPTNodes())
+	require.Error(t, module.AddContractStorageItems(kvs[:1]))
+
+	for i := uint32(stateSyncPoint - maxTraceable + 1); i <= stateSyncPoint; i++ {
+		b, err := bcSpout.GetBlock(bcSpout.GetHeaderHash(i))
+		require.NoError(t, err)
+		require.NoError(t, module.AddBlock(b))
+	}
+	require.False(t, module.IsActive())
+	require.Equal(t, uint32(stateSyncPoint), bcBolt.BlockHeight())
+	require.Equal(t, root, bcBolt.GetStateModule().CurrentLocalStateRoot())
+
+	for i := uint32(stateSyncPoint + 1); i <= bcSpout.BlockHeight(); i++ {
+		b, err := bcSpout.GetBlock(bcSpout.GetHeaderHash(i))
+		require.NoError(t, err)
+		require.NoError(t, bcBolt.AddBlock(b))
+	}
+	require.Equal(t, bcSpout.GetStateModule().CurrentLocalStateRoot(), bcBolt.GetStateModule().CurrentLocalStateRoot())
+}
+
+func TestStateSyncModule_RestoreFromContractStorageSigned(t *testing.T) {
+	const (
+		stateSyncInterval = 4
+		maxTraceable      = 6
+		stateSyncPoint    = 16
+	)
+	spoutCfg := func(c *config.Blockchain) {
+		c.StateSyncInterval = stateSyncInterval
+		c.MaxTraceableBlocks = maxTraceable
+	}
+	bcSpout, validators, committee := chain.NewMultiWithCustomConfig(t, spoutCfg)
+	e := neotest.NewExecutor(t, bcSpout, validators, committee)
+
+	stateValidator, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	e.NewInvoker(nativehashes.RoleManagement, validators, committee).Invoke(t, stackitem.Null{}, "designateAsRole",
+		int64(noderoles.StateValidator), []any{stateValidator.PublicKey().Bytes()})
+	for bcSpout.BlockHeight() < stateSyncPoint+2 {
+		e.AddNewBlock(t)
+	}
+
+	sign := func(t *testing.T, sr *state.MPTRoot, priv *keys.PrivateKey) *state.MPTRoot {
+		script, err := smartcontract.CreateDefaultMultiSigRedeemScript(keys.PublicKeys{priv.PublicKey()})
+		require.NoError(t, err)
+		w := io.NewBufBinWriter()
+		emit.Bytes(w.BinWriter, priv.SignHashable(uint32(bcSpout.GetConfig().Magic), sr))
+		require.NoError(t, w.Err)
+		return &state.MPTRoot{
+			Index: sr.Index,
+			Root:  sr.Root,
+			Witness: []transaction.Witness{{
+				InvocationScript:   w.Bytes(),
+				VerificationScript: script,
+			}},
+		}
+	}
+
+	boltCfg := func(c *config.Blockchain) {
+		spoutCfg(c)
+		c.NeoFSStateSyncExtensions = true
+		c.NeoFSBlockFetcher.Enabled = true
+		c.Ledger.KeepOnlyLatestState = true
+		c.Ledger.RemoveUntraceableBlocks = true
+	}
+	bcBolt, _, _ := chain.NewMultiWithCustomConfig(t, boltCfg)
+	module := bcBolt.GetStateSyncModule()
+
+	require.NoError(t, module.Init(bcSpout.BlockHeight()))
+	headers := make([]*block.Header, 0, bcSpout.HeaderHeight())
+	for i := uint32(1); i <= bcSpout.HeaderHeight(); i++ {
+		h, err := bcSpout.GetHeader(bcSpout.GetHeaderHash(i))
+		require.NoError(t, err)
+		headers = append(headers, h)
+	}
+	require.NoError(t, module.AddHeaders(headers...))
+	require.True(t, module.NeedMPTNodes())
+
+	// State root is unknown until it's checked.
+	p, root := module.GetStateSyncPoint()
+	require.Equal(t, uint32(stateSyncPoint), p)
+	require.Equal(t, util.Uint256{}, root)
+
+	sr, err := bcSpout.GetStateModule().GetStateRoot(stateSyncPoint)
+	require.NoError(t, err)
+	var kvs []storage.KeyValue
+	bcSpout.GetStateModule().SeekStates(sr.Root, []byte{}, func(k, v []byte) bool {
+		kvs = append(kvs, storage.KeyValue{Key: bytes.Clone(k), Value: bytes.Clone(v)})
+		return true
+	})
+	require.NotEmpty(t, kvs)
+	for i := 0; i < len(kvs); i += 7 {
+		require.NoError(t, module.AddContractStorageItems(kvs[i:min(i+7, len(kvs))]))
+	}
+
+	otherKey, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	for name, r := range map[string]*state.MPTRoot{
+		"no signed root":  nil,
+		"not signed":      {Index: stateSyncPoint, Root: sr.Root},
+		"wrong height":    sign(t, &state.MPTRoot{Index: stateSyncPoint - 1, Root: sr.Root}, stateValidator),
+		"wrong root":      sign(t, &state.MPTRoot{Index: stateSyncPoint, Root: util.Uint256{1, 2, 3}}, stateValidator),
+		"wrong validator": sign(t, sr, otherKey),
+	} {
+		t.Run("error: "+name, func(t *testing.T) {
+			require.Error(t, module.FinalizeContractStorage(r))
+			require.True(t, module.NeedMPTNodes())
+		})
+	}
+
+	signed := sign(t, sr, stateValidator)
+	require.NoError(t, module.FinalizeContractStorage(signed))
+	require.False(t, module.NeedMPTNodes())
+	_, root = module.GetStateSyncPoint()
+	require.Equal(t, sr.Root, root)
+
+	for i := uint32(stateSyncPoint - maxTraceable + 1); i <= stateSyncPoint; i++ {
+		b, err := bcSpout.GetBlock(bcSpout.GetHeaderHash(i))
+		require.NoError(t, err)
+		require.NoError(t, module.AddBlock(b))
+	}
+	require.False(t, module.IsActive())
+	require.Equal(t, uint32(stateSyncPoint), bcBolt.BlockHeight())
+	require.Equal(t, sr.Root, bcBolt.GetStateModule().CurrentLocalStateRoot())
+	actual, err := bcBolt.GetStateModule().GetStateRoot(stateSyncPoint)
+	require.NoError(t, err)
+	require.Equal(t, signed, actual)
+
+	for i := uint32(stateSyncPoint + 1); i <= bcSpout.BlockHeight(); i++ {
+		b, err := bcSpout.GetBlock(bcSpout.GetHeaderHash(i))
+		require.NoError(t, err)
+		require.NoError(t, bcBolt.AddBlock(b))
+	}
+	require.Equal(t, bcSpout.GetStateModule().CurrentLocalStateRoot(), bcBolt.GetStateModule().CurrentLocalStateRoot())
+}
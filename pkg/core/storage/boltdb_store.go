@@ -18,13 +18,19 @@ var Bucket = []byte("DB")
 // BoltDBStore it is the storage implementation for storing and retrieving
 // blockchain data.
 type BoltDBStore struct {
-	db *bbolt.DB
+	db       *bbolt.DB
+	opts     *bbolt.Options
+	fileMode os.FileMode
 }
 
 // defaultOpenTimeout is the default timeout for performing flock on a bbolt database.
 // bbolt does retries every 50ms during this interval.
 const defaultOpenTimeout = 1 * time.Second
 
+// compactTxMaxSize is the maximum size of a single transaction used for BoltDB
+// compaction.
+const compactTxMaxSize = 64 * 1024 * 1024
+
 // NewBoltDBStore returns a new ready to use BoltDB storage with created bucket.
 func NewBoltDBStore(cfg dbconfig.BoltDBOptions) (*BoltDBStore, error) {
 	cp := *bbolt.DefaultOptions // Do not change bbolt's global variable.
@@ -70,7 +76,7 @@ func NewBoltDBStore(cfg dbconfig.BoltDBOptions) (*BoltDBStore, error) {
 		return nil, err
 	}
 
-	return &BoltDBStore{db: db}, nil
+	return &BoltDBStore{db: db, opts: opts, fileMode: fileMode}, nil
 }
 
 // Get implements the Store interface.
@@ -168,6 +174,48 @@ func boltSeek(txopener func(func(*bbolt.Tx) error) error, rng SeekRange, f func(
 	})
 }
 
+// Compact implements the Compactor interface. BoltDB can't be compacted in
+// place, so all data is copied into a new file that replaces the current one
+// then.
+func (s *BoltDBStore) Compact() error {
+	if s.db.IsReadOnly() {
+		return errors.New("read-only BoltDB can't be compacted")
+	}
+	var (
+		path    = s.db.Path()
+		tmpPath = path + ".compact"
+	)
+	dst, err := bbolt.Open(tmpPath, s.fileMode, &bbolt.Options{Timeout: defaultOpenTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to create compacted BoltDB: %w", err)
+	}
+	err = bbolt.Compact(dst, s.db, compactTxMaxSize)
+	closeErr := dst.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = s.db.Close()
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to compact BoltDB: %w", err)
+	}
+	renameErr := os.Rename(tmpPath, path)
+	db, err := bbolt.Open(path, s.fileMode, s.opts)
+	if err == nil {
+		s.db = db
+	}
+	if renameErr != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace BoltDB with the compacted one: %w", renameErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reopen compacted BoltDB: %w", err)
+	}
+	return nil
+}
+
 // Close releases all db resources.
 func (s *BoltDBStore) Close() error {
 	return s.db.Close()
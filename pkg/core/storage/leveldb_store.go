@@ -9,6 +9,7 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/filter"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // LevelDBStore is the official storage implementation for storing and retrieving
@@ -119,6 +120,12 @@ func (s *LevelDBStore) seek(iter iterator.Iterator, backwards bool, f func(k, v
 	iter.Release()
 }
 
+// Compact implements the Compactor interface, it compacts the whole key
+// range.
+func (s *LevelDBStore) Compact() error {
+	return s.db.CompactRange(util.Range{})
+}
+
 // Close implements the Store interface.
 func (s *LevelDBStore) Close() error {
 	return s.db.Close()
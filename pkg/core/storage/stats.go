@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+)
+
+type (
+	// Stats contains DB usage statistics, see CollectStats.
+	Stats struct {
+		// Prefixes contains statistics for every KeyPrefix found in the DB.
+		Prefixes map[KeyPrefix]EntriesStats
+		// Executables contains DataExecutable statistics by executable type
+		// (ExecBlock or ExecTransaction).
+		Executables map[byte]EntriesStats
+		// Contracts contains contract storage (STStorage and STTempStorage)
+		// statistics by contract ID.
+		Contracts map[int32]EntriesStats
+	}
+
+	// EntriesStats contains statistics for a set of DB entries.
+	EntriesStats struct {
+		// Keys is the number of entries.
+		Keys int64
+		// Size is the total size of keys and values in bytes (before any
+		// DB-specific compression or overhead).
+		Size int64
+	}
+)
+
+// prefixNames contains names of known key prefixes.
+var prefixNames = map[KeyPrefix]string{
+	DataExecutable:                 "DataExecutable",
+	DataMPT:                        "DataMPT",
+	DataMPTAux:                     "DataMPTAux",
+	STStorage:                      "STStorage",
+	STTempStorage:                  "STTempStorage",
+	STNEP11Transfers:               "STNEP11Transfers",
+	STNEP17Transfers:               "STNEP17Transfers",
+	STTokenTransferInfo:            "STTokenTransferInfo",
+	STStorageDelta:                 "STStorageDelta",
+	STStorageDiff:                  "STStorageDiff",
+	STStorageOrigin:                "STStorageOrigin",
+	IXHeaderHashList:               "IXHeaderHashList",
+	SYSCurrentBlock:                "SYSCurrentBlock",
+	SYSCurrentHeader:               "SYSCurrentHeader",
+	SYSStateSyncCurrentBlockHeight: "SYSStateSyncCurrentBlockHeight",
+	SYSStateSyncPoint:              "SYSStateSyncPoint",
+	SYSStateChangeStage:            "SYSStateChangeStage",
+	SYSStateCheckpoint:             "SYSStateCheckpoint",
+	SYSVersion:                     "SYSVersion",
+}
+
+// String implements the fmt.Stringer interface, it returns the name of
+// the prefix constant or its hex representation for unknown prefixes.
+func (p KeyPrefix) String() string {
+	if name, ok := prefixNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%02x", byte(p))
+}
+
+func (e *EntriesStats) add(k, v []byte) {
+	e.Keys++
+	e.Size += int64(len(k) + len(v))
+}
+
+// statsCtxCheckInterval is the number of entries processed by CollectStats
+// between context checks.
+const statsCtxCheckInterval = 1024
+
+// CollectStats walks through all entries of the given Store and returns
+// statistics for them. It can take a lot of time for big DBs, so the process
+// can be interrupted via the given context (its error is returned then).
+func CollectStats(ctx context.Context, s Store) (Stats, error) {
+	var res = Stats{
+		Prefixes:    make(map[KeyPrefix]EntriesStats),
+		Executables: make(map[byte]EntriesStats),
+		Contracts:   make(map[int32]EntriesStats),
+	}
+	// Not every Store supports Seek with an empty prefix, so every
+	// possible prefix is checked separately.
+	var err error
+	for i := 0; i < 256 && err == nil; i++ {
+		var (
+			p     = KeyPrefix(i)
+			stats EntriesStats
+		)
+		s.Seek(SeekRange{Prefix: []byte{byte(p)}}, func(k, v []byte) bool {
+			if stats.Keys%statsCtxCheckInterval == 0 {
+				err = ctx.Err()
+				if err != nil {
+					return false
+				}
+			}
+			stats.add(k, v)
+			switch {
+			case p == DataExecutable && len(v) > 0:
+				e := res.Executables[v[0]]
+				e.add(k, v)
+				res.Executables[v[0]] = e
+			case (p == STStorage || p == STTempStorage) && len(k) >= 5:
+				id := int32(binary.LittleEndian.Uint32(k[1:]))
+				c := res.Contracts[id]
+				c.add(k, v)
+				res.Contracts[id] = c
+			}
+			return true
+		})
+		if stats.Keys != 0 {
+			res.Prefixes[p] = stats
+		}
+	}
+	if err != nil {
+		return Stats{}, err
+	}
+	return res, ctx.Err()
+}
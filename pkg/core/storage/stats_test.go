@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectStats(t *testing.T) {
+	s := NewMemoryStore()
+	contractKey := func(id int32, k string) string {
+		key := []byte{byte(STStorage), 0, 0, 0, 0}
+		binary.LittleEndian.PutUint32(key[1:], uint32(id))
+		return string(key) + k
+	}
+	require.NoError(t, s.PutChangeSet(map[string][]byte{
+		string([]byte{byte(DataExecutable), 1}): {ExecBlock, 1, 2},
+		string([]byte{byte(DataExecutable), 2}): {ExecTransaction, 1},
+		string([]byte{byte(DataExecutable), 3}): {ExecTransaction, 1, 2, 3},
+		string([]byte{byte(DataMPT), 1}):        {1},
+		string([]byte{0x42}):                    {},
+	}, map[string][]byte{
+		contractKey(-1, "a"):  {1},
+		contractKey(-1, "bb"): {1, 2},
+		contractKey(5, "c"):   {1, 2, 3},
+	}))
+
+	stats, err := CollectStats(context.Background(), s)
+	require.NoError(t, err)
+	require.Equal(t, map[KeyPrefix]EntriesStats{
+		DataExecutable:  {Keys: 3, Size: 3 + 2 + 4 + 3*2},
+		DataMPT:         {Keys: 1, Size: 3},
+		STStorage:       {Keys: 3, Size: 6 + 7 + 6 + 1 + 2 + 3},
+		KeyPrefix(0x42): {Keys: 1, Size: 1},
+	}, stats.Prefixes)
+	require.Equal(t, map[byte]EntriesStats{
+		ExecBlock:       {Keys: 1, Size: 5},
+		ExecTransaction: {Keys: 2, Size: 10},
+	}, stats.Executables)
+	require.Equal(t, map[int32]EntriesStats{
+		-1: {Keys: 2, Size: 6 + 1 + 7 + 2},
+		5:  {Keys: 1, Size: 9},
+	}, stats.Contracts)
+
+	require.Equal(t, "DataMPT", DataMPT.String())
+	require.Equal(t, "0x42", KeyPrefix(0x42).String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = CollectStats(ctx, s)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -27,6 +27,9 @@ const (
 	STNEP11Transfers               KeyPrefix = 0x72
 	STNEP17Transfers               KeyPrefix = 0x73
 	STTokenTransferInfo            KeyPrefix = 0x74
+	STStorageDelta                 KeyPrefix = 0x75 // Storage changes for pending state checkpoints.
+	STStorageDiff                  KeyPrefix = 0x76 // Per-block contract storage changes.
+	STStorageOrigin                KeyPrefix = 0x77 // Storage values for the full state checkpoint being written.
 	IXHeaderHashList               KeyPrefix = 0x80
 	SYSCurrentBlock                KeyPrefix = 0xc0
 	SYSCurrentHeader               KeyPrefix = 0xc1
@@ -38,6 +41,7 @@ const (
 	// and the last bit reserved for the state reset process marker (set to 1 on
 	// unfinished state reset and to 0 on unfinished state jump).
 	SYSStateChangeStage KeyPrefix = 0xc4
+	SYSStateCheckpoint  KeyPrefix = 0xc5 // The latest state checkpoint index.
 	SYSVersion          KeyPrefix = 0xf0
 )
 
@@ -98,6 +102,15 @@ type (
 		Close() error
 	}
 
+	// Compactor is an optional interface implemented by Store backends
+	// supporting explicit compaction of their data files.
+	Compactor interface {
+		// Compact compacts the DB reclaiming space occupied by deleted or
+		// overwritten entries. It can take a lot of time and it's not
+		// supposed to be used concurrently with other DB operations.
+		Compact() error
+	}
+
 	// KeyPrefix is a constant byte added as a prefix for each key
 	// stored.
 	KeyPrefix uint8
@@ -223,6 +223,33 @@ func testStoreSeekGC(t *testing.T, s Store) {
 	}
 }
 
+func testStoreCompact(t *testing.T, s Store) {
+	c, ok := s.(Compactor)
+	if !ok {
+		return
+	}
+	kvs := pushSeekDataSet(t, s)
+	err := s.SeekGC(SeekRange{Prefix: []byte("3")}, func(k, v []byte) bool {
+		return false
+	})
+	require.NoError(t, err)
+	require.NoError(t, c.Compact())
+	for i := range kvs[:5] {
+		v, err := s.Get(kvs[i].Key)
+		require.NoError(t, err)
+		require.Equal(t, kvs[i].Value, v)
+	}
+	for _, kv := range kvs[5:] {
+		_, err = s.Get(kv.Key)
+		require.Error(t, err)
+	}
+	// Store remains usable after compaction.
+	require.NoError(t, s.PutChangeSet(map[string][]byte{"4": []byte("four")}, nil))
+	v, err := s.Get([]byte("4"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("four"), v)
+}
+
 func TestAllDBs(t *testing.T) {
 	var DBs = []dbSetup{
 		{"BoltDB", newBoltStoreForTesting},
@@ -231,7 +258,7 @@ func TestAllDBs(t *testing.T) {
 		{"Memory", newMemoryStoreForTesting},
 	}
 	var tests = []dbTestFunction{testStoreGetNonExistent, testStoreSeek,
-		testStoreSeekGC}
+		testStoreSeekGC, testStoreCompact}
 	for _, db := range DBs {
 		for _, test := range tests {
 			s := db.create(t)
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// storeStorageDiff makes a diff from the storage changes made by the block
+// (the ones from the given cache), stores it and returns it along with the
+// hashes of all contracts changed.
+func (bc *Blockchain) storeStorageDiff(cache *dao.Simple, index uint32, changes map[string][]byte) ([]state.StorageDiff, map[int32]util.Uint160, error) {
+	var (
+		diff      = make([]state.StorageDiff, 0, len(changes))
+		contracts = make(map[int32]util.Uint160)
+	)
+	for _, k := range slices.Sorted(maps.Keys(changes)) {
+		key := []byte(k)
+		// bc.dao has the state before the block.
+		old, err := bc.dao.Store.Get(key)
+		if err != nil {
+			old = nil
+		}
+		d := state.StorageDiff{
+			ID:  int32(binary.LittleEndian.Uint32(key[1:])),
+			Key: key[5:],
+			Old: old,
+			New: changes[k],
+		}
+		if (d.Old == nil) == (d.New == nil) && bytes.Equal(d.Old, d.New) {
+			continue // Not really changed.
+		}
+		if _, ok := contracts[d.ID]; !ok {
+			h, err := native.GetContractScriptHash(cache, d.ID)
+			if err != nil {
+				// Contract destroyed by the block.
+				h, err = native.GetContractScriptHash(bc.dao, d.ID)
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get contract %d hash: %w", d.ID, err)
+			}
+			contracts[d.ID] = h
+		}
+		diff = append(diff, d)
+	}
+	err := cache.PutStorageDiff(index, diff)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to store storage diff: %w", err)
+	}
+	return diff, contracts, nil
+}
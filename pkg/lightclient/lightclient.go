@@ -0,0 +1,349 @@
+/*
+Package lightclient provides a way to verify chain data received from
+untrusted RPC nodes without running a full node.
+
+Verifier starts from a trusted header (a checkpoint) and tracks the
+consensus node set by checking every subsequent header: its witness must
+match NextConsensus of the previous header and must be properly signed. Given
+a set of state validators (designated via RoleManagement contract) it also
+checks signed state roots produced by the stateroot service, when
+StateRootInHeader protocol extension is enabled state roots are checked
+against verified headers as well. Verified state roots can then be used to
+check MPT proofs of contract storage items locally with VerifyProof,
+VerifyStorageProof and VerifyContractStateProof.
+*/
+package lightclient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+const (
+	// managementContractID is the ID of the native ContractManagement contract.
+	managementContractID = -1
+	// prefixContract is a prefix used to store contract states inside
+	// ContractManagement native contract.
+	prefixContract = 8
+)
+
+var (
+	// ErrInvalidHeader is returned for headers that can't be chained to the
+	// latest verified one.
+	ErrInvalidHeader = errors.New("invalid header")
+	// ErrInvalidWitness is returned when witness check fails.
+	ErrInvalidWitness = errors.New("invalid witness")
+	// ErrUnverifiedStateRoot is returned when a state root can't be checked
+	// either via headers or via state validators signature.
+	ErrUnverifiedStateRoot = errors.New("unverified state root")
+	// ErrInvalidProof is returned when MPT proof check fails.
+	ErrInvalidProof = errors.New("invalid proof")
+)
+
+// Verifier tracks verified headers and state validators and checks state
+// roots against them. It's safe for concurrent use.
+type Verifier struct {
+	mtx        sync.RWMutex
+	magic      netmode.Magic
+	header     *block.Header
+	validators keys.PublicKeys
+	// roots contains state roots taken from the verified headers (if
+	// StateRootInHeader is enabled), indexed by state height.
+	roots               map[uint32]util.Uint256
+	stateValidators     keys.PublicKeys
+	stateValidatorsHash util.Uint160
+}
+
+// New creates a Verifier for the network with the given magic starting from
+// the trusted header. This header is not checked in any way, so it must be
+// obtained from a reliable source.
+func New(magic netmode.Magic, trusted *block.Header) *Verifier {
+	v := &Verifier{
+		magic:  magic,
+		header: trusted,
+		roots:  make(map[uint32]util.Uint256),
+	}
+	v.validators = parseKeys(trusted.Script.VerificationScript)
+	v.addRoot(trusted)
+	return v
+}
+
+// Height returns the index of the latest verified header.
+func (v *Verifier) Height() uint32 {
+	v.mtx.RLock()
+	defer v.mtx.RUnlock()
+	return v.header.Index
+}
+
+// Header returns the latest verified header.
+func (v *Verifier) Header() *block.Header {
+	v.mtx.RLock()
+	defer v.mtx.RUnlock()
+	return v.header
+}
+
+// NextConsensus returns the script hash of the consensus nodes that are to
+// sign the next header.
+func (v *Verifier) NextConsensus() util.Uint160 {
+	v.mtx.RLock()
+	defer v.mtx.RUnlock()
+	return v.header.NextConsensus
+}
+
+// Validators returns the list of consensus nodes that signed the latest
+// verified header.
+func (v *Verifier) Validators() keys.PublicKeys {
+	v.mtx.RLock()
+	defer v.mtx.RUnlock()
+	return v.validators.Copy()
+}
+
+// AddHeaders checks the given headers and adds them to the verified chain.
+// Headers must be sorted and must directly follow the latest verified one,
+// every header is checked to have a proper hash link to the previous one and
+// a valid witness corresponding to NextConsensus of the previous header.
+// Headers are processed one by one, so if an error is returned all headers
+// before the failed one are already added.
+func (v *Verifier) AddHeaders(hs ...*block.Header) error {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	for _, h := range hs {
+		if h.Index != v.header.Index+1 {
+			return fmt.Errorf("%w: expected index %d, got %d", ErrInvalidHeader, v.header.Index+1, h.Index)
+		}
+		if !h.PrevHash.Equals(v.header.Hash()) {
+			return fmt.Errorf("%w %d: previous hash mismatch", ErrInvalidHeader, h.Index)
+		}
+		if h.Timestamp <= v.header.Timestamp {
+			return fmt.Errorf("%w %d: timestamp is not increasing", ErrInvalidHeader, h.Index)
+		}
+		err := VerifyWitness(v.magic, h, &h.Script, v.header.NextConsensus)
+		if err != nil {
+			return fmt.Errorf("%w %d: %w", ErrInvalidHeader, h.Index, err)
+		}
+		v.header = h
+		v.validators = parseKeys(h.Script.VerificationScript)
+		v.addRoot(h)
+	}
+	return nil
+}
+
+func (v *Verifier) addRoot(h *block.Header) {
+	if h.StateRootEnabled && h.Index > 0 {
+		v.roots[h.Index-1] = h.PrevStateRoot
+	}
+}
+
+// SetStateValidators sets the list of state validators that are used to check
+// state root signatures. These keys can be obtained from RoleManagement
+// contract.
+func (v *Verifier) SetStateValidators(pubs keys.PublicKeys) error {
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(pubs)
+	if err != nil {
+		return fmt.Errorf("invalid state validators: %w", err)
+	}
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	v.stateValidators = pubs.Copy()
+	v.stateValidatorsHash = hash.Hash160(script)
+	return nil
+}
+
+// StateValidators returns the list of state validators set with
+// SetStateValidators.
+func (v *Verifier) StateValidators() keys.PublicKeys {
+	v.mtx.RLock()
+	defer v.mtx.RUnlock()
+	return v.stateValidators.Copy()
+}
+
+// VerifyStateRoot checks the given state root. If there is a verified header
+// with the state root for the same height (which only happens with
+// StateRootInHeader extension) the root hash is compared with it, otherwise
+// the root's witness is checked against state validators.
+func (v *Verifier) VerifyStateRoot(r *state.MPTRoot) error {
+	v.mtx.RLock()
+	defer v.mtx.RUnlock()
+	if root, ok := v.roots[r.Index]; ok {
+		if !root.Equals(r.Root) {
+			return fmt.Errorf("%w: state root %d mismatch: header has %s, got %s",
+				ErrUnverifiedStateRoot, r.Index, root.StringLE(), r.Root.StringLE())
+		}
+		return nil
+	}
+	if len(v.stateValidators) == 0 {
+		return fmt.Errorf("%w: no header for height %d and no state validators", ErrUnverifiedStateRoot, r.Index)
+	}
+	if len(r.Witness) != 1 {
+		return fmt.Errorf("%w: state root %d is not signed", ErrUnverifiedStateRoot, r.Index)
+	}
+	err := VerifyWitness(v.magic, r, &r.Witness[0], v.stateValidatorsHash)
+	if err != nil {
+		return fmt.Errorf("%w: state root %d: %w", ErrUnverifiedStateRoot, r.Index, err)
+	}
+	return nil
+}
+
+// VerifyWitness checks that the witness corresponds to the expected script
+// hash and contains valid signatures of the given hashable data for the
+// network with the given magic. Only standard signature and multisignature
+// witnesses are supported.
+func VerifyWitness(magic netmode.Magic, hh hash.Hashable, w *transaction.Witness, expected util.Uint160) error {
+	if h := w.ScriptHash(); !h.Equals(expected) {
+		return fmt.Errorf("%w: script hash mismatch: expected %s, got %s",
+			ErrInvalidWitness, expected.StringLE(), h.StringLE())
+	}
+	var (
+		nsigs int
+		pubs  [][]byte
+	)
+	if pub, ok := vm.ParseSignatureContract(w.VerificationScript); ok {
+		nsigs, pubs = 1, [][]byte{pub}
+	} else if nsigs, pubs, ok = vm.ParseMultiSigContract(w.VerificationScript); !ok {
+		return fmt.Errorf("%w: non-standard verification script", ErrInvalidWitness)
+	}
+	sigs, err := parseSignatures(w.InvocationScript)
+	if err != nil {
+		return err
+	}
+	if len(sigs) != nsigs {
+		return fmt.Errorf("%w: expected %d signatures, got %d", ErrInvalidWitness, nsigs, len(sigs))
+	}
+	var (
+		digest = hash.NetSha256(uint32(magic), hh)
+		k      int
+	)
+	// Signatures must follow the order of keys, the same way CheckMultisig
+	// does it.
+	for _, sig := range sigs {
+		for ; k < len(pubs); k++ {
+			pub, err := keys.NewPublicKeyFromBytes(pubs[k], nil)
+			if err != nil {
+				return fmt.Errorf("%w: bad public key: %w", ErrInvalidWitness, err)
+			}
+			if pub.Verify(sig, digest[:]) {
+				break
+			}
+		}
+		if k == len(pubs) {
+			return fmt.Errorf("%w: signature check failed", ErrInvalidWitness)
+		}
+		k++
+	}
+	return nil
+}
+
+// parseSignatures returns signatures pushed by the standard invocation
+// script.
+func parseSignatures(script []byte) ([][]byte, error) {
+	var sigs [][]byte
+	for len(script) > 0 {
+		if len(script) < 2+keys.SignatureLen || script[0] != byte(opcode.PUSHDATA1) || script[1] != keys.SignatureLen {
+			return nil, fmt.Errorf("%w: non-standard invocation script", ErrInvalidWitness)
+		}
+		sigs = append(sigs, script[2:2+keys.SignatureLen])
+		script = script[2+keys.SignatureLen:]
+	}
+	return sigs, nil
+}
+
+// parseKeys returns keys from the standard verification script (if it is
+// one).
+func parseKeys(script []byte) keys.PublicKeys {
+	var pubs [][]byte
+	if pub, ok := vm.ParseSignatureContract(script); ok {
+		pubs = [][]byte{pub}
+	} else {
+		_, pubs, _ = vm.ParseMultiSigContract(script)
+	}
+	res := make(keys.PublicKeys, 0, len(pubs))
+	for _, b := range pubs {
+		pub, err := keys.NewPublicKeyFromBytes(b, nil)
+		if err != nil {
+			return nil
+		}
+		res = append(res, pub)
+	}
+	return res
+}
+
+// VerifyProof checks the proof against the given state root hash and returns
+// the value of the proven storage item.
+func VerifyProof(root util.Uint256, p *result.ProofWithKey) ([]byte, error) {
+	val, ok := mpt.VerifyProof(root, p.Key, p.Proof)
+	if !ok {
+		return nil, fmt.Errorf("%w: key %x, root %s", ErrInvalidProof, p.Key, root.StringLE())
+	}
+	return val, nil
+}
+
+// VerifyStorageProof checks the proof of the storage item with the given key
+// belonging to the contract with the given ID against the given state root
+// hash and returns the value of this item.
+func VerifyStorageProof(root util.Uint256, id int32, key []byte, proof [][]byte) ([]byte, error) {
+	return VerifyProof(root, &result.ProofWithKey{
+		Key:   StorageKey(id, key),
+		Proof: proof,
+	})
+}
+
+// VerifyContractStateProof checks the proof of the contract state stored by
+// ContractManagement native contract against the given state root hash and
+// returns this state. It allows to reliably map contract hash to its ID.
+func VerifyContractStateProof(root util.Uint256, h util.Uint160, proof [][]byte) (*state.Contract, error) {
+	val, err := VerifyStorageProof(root, managementContractID, ContractStateKey(h), proof)
+	if err != nil {
+		return nil, err
+	}
+	cs := new(state.Contract)
+	err = stackitem.DeserializeConvertible(val, cs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: can't decode contract state: %w", ErrInvalidProof, err)
+	}
+	if !cs.Hash.Equals(h) {
+		return nil, fmt.Errorf("%w: contract hash mismatch", ErrInvalidProof)
+	}
+	return cs, nil
+}
+
+// StorageKey returns MPT key of the storage item with the given key belonging
+// to the contract with the given ID.
+func StorageKey(id int32, key []byte) []byte {
+	skey := make([]byte, 4+len(key))
+	binary.LittleEndian.PutUint32(skey, uint32(id))
+	copy(skey[4:], key)
+	return skey
+}
+
+// ContractStateKey returns ContractManagement storage key for the state of
+// the contract with the given hash.
+func ContractStateKey(h util.Uint160) []byte {
+	return append([]byte{prefixContract}, h.BytesBE()...)
+}
+
+// CheckStorageKey checks that MPT key belongs to the contract with the given
+// ID and returns the storage item key without contract ID.
+func CheckStorageKey(id int32, mptKey []byte) ([]byte, error) {
+	prefix := StorageKey(id, nil)
+	if !bytes.HasPrefix(mptKey, prefix) {
+		return nil, fmt.Errorf("%w: key %x doesn't belong to contract %d", ErrInvalidProof, mptKey, id)
+	}
+	return mptKey[len(prefix):], nil
+}
@@ -0,0 +1,194 @@
+package lightclient_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/lightclient"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestChain(t *testing.T, stateRootInHeader bool) (*core.Blockchain, []*block.Header) {
+	bc, validators, committee := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+		c.StateRootInHeader = stateRootInHeader
+	})
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	e.GenerateNewBlocks(t, 5)
+
+	hs := make([]*block.Header, bc.HeaderHeight()+1)
+	for i := range hs {
+		h, err := bc.GetHeader(bc.GetHeaderHash(uint32(i)))
+		require.NoError(t, err)
+		hs[i] = h
+	}
+	return bc, hs
+}
+
+// modifyHeader returns a copy of the header changed by f with properly
+// recalculated hash.
+func modifyHeader(t *testing.T, h *block.Header, f func(h *block.Header)) *block.Header {
+	var res = *h
+	f(&res)
+	b, err := testserdes.EncodeBinary(&res)
+	require.NoError(t, err)
+	res = block.Header{StateRootEnabled: h.StateRootEnabled}
+	require.NoError(t, testserdes.DecodeBinary(b, &res))
+	return &res
+}
+
+func TestVerifier_AddHeaders(t *testing.T) {
+	bc, hs := newTestChain(t, false)
+	magic := bc.GetConfig().Magic
+
+	v := lightclient.New(magic, hs[0])
+	require.Equal(t, uint32(0), v.Height())
+	require.Equal(t, hs[0].NextConsensus, v.NextConsensus())
+
+	require.NoError(t, v.AddHeaders(hs[1:3]...))
+	require.Equal(t, uint32(2), v.Height())
+	require.Equal(t, hs[2].Hash(), v.Header().Hash())
+	require.Equal(t, len(bc.GetConfig().StandbyCommittee[:bc.GetConfig().ValidatorsCount]), len(v.Validators()))
+
+	t.Run("gap", func(t *testing.T) {
+		require.ErrorIs(t, v.AddHeaders(hs[4]), lightclient.ErrInvalidHeader)
+	})
+	t.Run("bad prev hash", func(t *testing.T) {
+		h := modifyHeader(t, hs[3], func(h *block.Header) { h.PrevHash = util.Uint256{1, 2, 3} })
+		require.ErrorIs(t, v.AddHeaders(h), lightclient.ErrInvalidHeader)
+	})
+	t.Run("bad next consensus", func(t *testing.T) {
+		h := modifyHeader(t, hs[3], func(h *block.Header) { h.Script.VerificationScript = []byte{byte(opcode.PUSH1)} })
+		require.ErrorIs(t, v.AddHeaders(h), lightclient.ErrInvalidWitness)
+	})
+	t.Run("bad signature", func(t *testing.T) {
+		h := modifyHeader(t, hs[3], func(h *block.Header) { h.Nonce++ })
+		require.ErrorIs(t, v.AddHeaders(h), lightclient.ErrInvalidWitness)
+	})
+	t.Run("wrong magic", func(t *testing.T) {
+		v := lightclient.New(netmode.TestNet, hs[0])
+		require.ErrorIs(t, v.AddHeaders(hs[1]), lightclient.ErrInvalidWitness)
+	})
+	require.Equal(t, uint32(2), v.Height())
+	require.NoError(t, v.AddHeaders(hs[3:]...))
+	require.Equal(t, uint32(len(hs)-1), v.Height())
+}
+
+func signStateRoot(t *testing.T, magic netmode.Magic, r *state.MPTRoot, privs []*keys.PrivateKey) {
+	pubs := make(keys.PublicKeys, len(privs))
+	for i := range privs {
+		pubs[i] = privs[i].PublicKey()
+	}
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(pubs)
+	require.NoError(t, err)
+	m := smartcontract.GetDefaultHonestNodeCount(len(pubs))
+
+	// Signatures must be ordered by keys.
+	sorted := slices.Clone(privs)
+	slices.SortFunc(sorted, func(a, b *keys.PrivateKey) int {
+		return a.PublicKey().Cmp(b.PublicKey())
+	})
+	var invoc []byte
+	for _, p := range sorted[:m] {
+		invoc = append(invoc, byte(opcode.PUSHDATA1), keys.SignatureLen)
+		invoc = append(invoc, p.SignHashable(uint32(magic), r)...)
+	}
+	r.Witness = []transaction.Witness{{InvocationScript: invoc, VerificationScript: script}}
+}
+
+func TestVerifier_VerifyStateRoot(t *testing.T) {
+	t.Run("state validators", func(t *testing.T) {
+		bc, hs := newTestChain(t, false)
+		magic := bc.GetConfig().Magic
+		v := lightclient.New(magic, hs[0])
+
+		privs := make([]*keys.PrivateKey, 4)
+		pubs := make(keys.PublicKeys, len(privs))
+		for i := range privs {
+			var err error
+			privs[i], err = keys.NewPrivateKey()
+			require.NoError(t, err)
+			pubs[i] = privs[i].PublicKey()
+		}
+		r, err := bc.GetStateModule().GetStateRoot(3)
+		require.NoError(t, err)
+		signStateRoot(t, magic, r, privs)
+
+		require.ErrorIs(t, v.VerifyStateRoot(r), lightclient.ErrUnverifiedStateRoot)
+		require.NoError(t, v.SetStateValidators(pubs))
+		require.Equal(t, len(pubs), len(v.StateValidators()))
+		require.NoError(t, v.VerifyStateRoot(r))
+
+		t.Run("modified", func(t *testing.T) {
+			bad := *r
+			bad.Root = util.Uint256{1, 2, 3}
+			require.ErrorIs(t, v.VerifyStateRoot(&bad), lightclient.ErrUnverifiedStateRoot)
+		})
+		t.Run("unsigned", func(t *testing.T) {
+			bad := *r
+			bad.Witness = nil
+			require.ErrorIs(t, v.VerifyStateRoot(&bad), lightclient.ErrUnverifiedStateRoot)
+		})
+		t.Run("other validators", func(t *testing.T) {
+			require.NoError(t, v.SetStateValidators(pubs[:3]))
+			require.ErrorIs(t, v.VerifyStateRoot(r), lightclient.ErrUnverifiedStateRoot)
+		})
+	})
+	t.Run("state root in header", func(t *testing.T) {
+		bc, hs := newTestChain(t, true)
+		v := lightclient.New(bc.GetConfig().Magic, hs[0])
+		require.NoError(t, v.AddHeaders(hs[1:]...))
+
+		r, err := bc.GetStateModule().GetStateRoot(3)
+		require.NoError(t, err)
+		require.NoError(t, v.VerifyStateRoot(r))
+
+		bad := *r
+		bad.Root = util.Uint256{1, 2, 3}
+		require.ErrorIs(t, v.VerifyStateRoot(&bad), lightclient.ErrUnverifiedStateRoot)
+
+		// The last header contains the root for the previous height only.
+		r, err = bc.GetStateModule().GetStateRoot(uint32(len(hs) - 1))
+		require.NoError(t, err)
+		require.ErrorIs(t, v.VerifyStateRoot(r), lightclient.ErrUnverifiedStateRoot)
+	})
+}
+
+func TestVerifyProofs(t *testing.T) {
+	bc, _ := newTestChain(t, false)
+	r, err := bc.GetStateModule().GetStateRoot(bc.BlockHeight())
+	require.NoError(t, err)
+
+	csKey := lightclient.StorageKey(native.ManagementContractID, lightclient.ContractStateKey(nativehashes.NeoToken))
+	proof, err := bc.GetStateModule().GetStateProof(r.Root, csKey)
+	require.NoError(t, err)
+
+	cs, err := lightclient.VerifyContractStateProof(r.Root, nativehashes.NeoToken, proof)
+	require.NoError(t, err)
+	require.Equal(t, nativehashes.NeoToken, cs.Hash)
+
+	_, err = lightclient.VerifyContractStateProof(r.Root, nativehashes.GasToken, proof)
+	require.ErrorIs(t, err, lightclient.ErrInvalidProof)
+	_, err = lightclient.VerifyContractStateProof(util.Uint256{1, 2, 3}, nativehashes.NeoToken, proof)
+	require.ErrorIs(t, err, lightclient.ErrInvalidProof)
+
+	key, err := lightclient.CheckStorageKey(native.ManagementContractID, csKey)
+	require.NoError(t, err)
+	require.Equal(t, lightclient.ContractStateKey(nativehashes.NeoToken), key)
+	_, err = lightclient.CheckStorageKey(1, csKey)
+	require.ErrorIs(t, err, lightclient.ErrInvalidProof)
+}
@@ -136,6 +136,9 @@ const (
 	ErrInvalidProofCode = -607
 	// ErrExecutionFailedCode is returned from a call made a VM execution, but it has failed.
 	ErrExecutionFailedCode = -608
+	// ErrConsensusDisabledCode is returned if consensus service is not enabled in the configuration
+	// (service is not running). Can be returned only by the NeoGo RPC server.
+	ErrConsensusDisabledCode = -609
 )
 
 var (
@@ -253,6 +256,9 @@ var (
 	// ErrExecutionFailed represents an error with code [ErrExecutionFailedCode].
 	// Call made a VM execution, but it has failed.
 	ErrExecutionFailed = NewErrorWithCode(ErrExecutionFailedCode, "Execution failed")
+	// ErrConsensusDisabled represents an error with code [ErrConsensusDisabledCode].
+	// Service is not enabled in the configuration.
+	ErrConsensusDisabled = NewErrorWithCode(ErrConsensusDisabledCode, "Consensus service is not running")
 )
 
 // NewError is an Error constructor that takes Error contents from its parameters.
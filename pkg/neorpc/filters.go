@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/nspcc-dev/neo-go/pkg/config/limits"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/runtime"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -76,6 +77,14 @@ type (
 		Signer *util.Uint160      `json:"signer,omitempty"`
 		Type   *mempoolevent.Type `json:"type,omitempty"`
 	}
+	// StorageChangeFilter is a wrapper structure used for contract storage
+	// change events. It allows to choose changes of the specified contract
+	// storage and/or changes of items with keys starting with the specified
+	// prefix. nil value treated as missing filter.
+	StorageChangeFilter struct {
+		Contract *util.Uint160 `json:"contract,omitempty"`
+		Prefix   []byte        `json:"prefix,omitempty"`
+	}
 )
 
 // SubscriptionFilter is an interface for all subscription filters.
@@ -272,3 +281,28 @@ func (f *NotaryRequestFilter) Copy() *NotaryRequestFilter {
 func (f NotaryRequestFilter) IsValid() error {
 	return nil
 }
+
+// Copy creates a deep copy of the StorageChangeFilter. It handles nil
+// StorageChangeFilter correctly.
+func (f *StorageChangeFilter) Copy() *StorageChangeFilter {
+	if f == nil {
+		return nil
+	}
+	var res = new(StorageChangeFilter)
+	if f.Contract != nil {
+		res.Contract = new(util.Uint160)
+		*res.Contract = *f.Contract
+	}
+	if f.Prefix != nil {
+		res.Prefix = slices.Clone(f.Prefix)
+	}
+	return res
+}
+
+// IsValid implements SubscriptionFilter interface.
+func (f StorageChangeFilter) IsValid() error {
+	if len(f.Prefix) > limits.MaxStorageKeyLen {
+		return fmt.Errorf("%w: StorageChangeFilter prefix must not be longer than %d", ErrInvalidSubscriptionFilter, limits.MaxStorageKeyLen)
+	}
+	return nil
+}
@@ -124,3 +124,27 @@ func TestExecutionFilterCopy(t *testing.T) {
 	*bf.Container = util.Uint256{3, 2, 1}
 	require.NotEqual(t, bf, tf)
 }
+
+func TestStorageChangeFilterCopy(t *testing.T) {
+	var bf, tf *StorageChangeFilter
+
+	require.Nil(t, bf.Copy())
+
+	bf = new(StorageChangeFilter)
+	tf = bf.Copy()
+	require.Equal(t, bf, tf)
+
+	bf.Contract = &util.Uint160{1, 2, 3}
+
+	tf = bf.Copy()
+	require.Equal(t, bf, tf)
+	*bf.Contract = util.Uint160{3, 2, 1}
+	require.NotEqual(t, bf, tf)
+
+	bf.Prefix = []byte{1, 2, 3}
+
+	tf = bf.Copy()
+	require.Equal(t, bf, tf)
+	bf.Prefix[0] = 4
+	require.NotEqual(t, bf, tf)
+}
@@ -0,0 +1,17 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// BlockStorageDiff is the result of `getblockstoragediff` RPC call. It
+// contains contract storage changes made by the block.
+type BlockStorageDiff struct {
+	// Hash is the block hash.
+	Hash util.Uint256 `json:"blockhash"`
+	// Index is the block index.
+	Index uint32 `json:"index"`
+	// Changes contains storage item changes sorted by contract ID and key.
+	Changes []state.StorageDiff `json:"changes"`
+}
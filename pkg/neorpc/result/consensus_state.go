@@ -0,0 +1,57 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+type (
+	// ConsensusState is the result of `getconsensusstate` RPC call. It
+	// describes dBFT state of the consensus node along with the timeline of
+	// the latest rounds.
+	ConsensusState struct {
+		// Height is the index of the block being agreed upon.
+		Height uint32 `json:"height"`
+		// View is the current view number.
+		View byte `json:"view"`
+		// Primary is the index of the primary node for the current view.
+		Primary uint `json:"primary"`
+		// Index is the index of the node in Validators, -1 if the node
+		// is not a validator.
+		Index int `json:"index"`
+		// Validators is the list of validators for the current height.
+		Validators keys.PublicKeys `json:"validators"`
+		// Rounds contains the latest rounds from the oldest to the newest
+		// one, the last one is the current round.
+		Rounds []ConsensusRound `json:"rounds"`
+	}
+
+	// ConsensusRound is a timeline of a single dBFT round (a view at some
+	// height). All timestamps are Unix milliseconds, durations are in
+	// milliseconds. Phase durations are omitted if the phase was not
+	// completed in this round.
+	ConsensusRound struct {
+		Height  uint32 `json:"height"`
+		View    byte   `json:"view"`
+		Primary uint   `json:"primary"`
+		Start   uint64 `json:"start"`
+		// PrepareRequest is the time from the round start to PrepareRequest.
+		PrepareRequest *uint64 `json:"preparerequest,omitempty"`
+		// PrepareResponse is the time from PrepareRequest to the first
+		// Commit (when enough PrepareResponses were collected).
+		PrepareResponse *uint64 `json:"prepareresponse,omitempty"`
+		// Commit is the time from the first Commit to block acceptance.
+		Commit *uint64 `json:"commit,omitempty"`
+		// Accepted is true if this round has produced a block.
+		Accepted bool `json:"accepted"`
+		// ChangeViews is the number of ChangeView messages by reason.
+		ChangeViews      map[string]int `json:"changeviews"`
+		RecoveryRequests int            `json:"recoveryrequests"`
+		RecoveryMessages int            `json:"recoverymessages"`
+		// Late contains validators that have participated in the round,
+		// but their Commit wasn't received before the block was accepted.
+		Late keys.PublicKeys `json:"late,omitempty"`
+		// Missing contains validators nothing was received from in the
+		// round.
+		Missing keys.PublicKeys `json:"missing,omitempty"`
+	}
+)
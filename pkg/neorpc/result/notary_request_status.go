@@ -0,0 +1,61 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+type (
+	// NotaryRequestStatus is the result of `getnotaryrequeststatus` RPC call.
+	// It describes the state of notary requests with the same main
+	// transaction.
+	NotaryRequestStatus struct {
+		// Hash is the main transaction hash.
+		Hash util.Uint256 `json:"hash"`
+		// Height is the current chain height.
+		Height uint32 `json:"height"`
+		// Fallbacks contains fallback transactions from the notary request
+		// pool.
+		Fallbacks []NotaryFallback `json:"fallbacks"`
+		// Tracked is true if the request is processed by the Notary service
+		// running on the node, fields below are only filled if it's set.
+		Tracked bool `json:"tracked"`
+		// NotValidBefore is the minimum NotValidBefore value among the
+		// fallbacks, the main transaction can't be sent after this height.
+		NotValidBefore uint32 `json:"notvalidbefore,omitempty"`
+		// Main is the main transaction with all witnesses collected so far
+		// (except the Notary one).
+		Main *transaction.Transaction `json:"main,omitempty"`
+		// Witnesses contains per-signer witness collection state.
+		Witnesses []NotaryWitness `json:"witnesses,omitempty"`
+		// Completed is true if all main transaction witnesses are collected.
+		Completed bool `json:"completed"`
+		// Sent is true if the main transaction was sent to the network.
+		Sent bool `json:"sent"`
+		// Reason explains why the main transaction is not sent yet.
+		Reason string `json:"reason,omitempty"`
+	}
+
+	// NotaryFallback describes a fallback transaction of a notary request.
+	NotaryFallback struct {
+		Hash            util.Uint256 `json:"hash"`
+		NotValidBefore  uint32       `json:"notvalidbefore"`
+		ValidUntilBlock uint32       `json:"validuntilblock"`
+	}
+
+	// NotaryWitness is the witness collection state for a single main
+	// transaction signer.
+	NotaryWitness struct {
+		Account util.Uint160 `json:"account"`
+		// Type is the witness type: "signature", "multisignature" or
+		// "contract".
+		Type string `json:"type"`
+		// SigsLeft is the number of signatures left to collect.
+		SigsLeft int `json:"sigsleft"`
+		// Signed contains keys the signatures were received for.
+		Signed keys.PublicKeys `json:"signed"`
+		// Missing contains keys no signatures were received for.
+		Missing keys.PublicKeys `json:"missing"`
+	}
+)
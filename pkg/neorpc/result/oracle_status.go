@@ -0,0 +1,57 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+type (
+	// OracleStatus is the result of `getoraclestatus` RPC call. It describes
+	// oracle requests being processed by the oracle node.
+	OracleStatus struct {
+		// Nodes is the list of designated oracle nodes.
+		Nodes keys.PublicKeys `json:"nodes"`
+		// Required is the number of signatures required for the response
+		// transaction.
+		Required int `json:"required"`
+		// Requests contains requests being processed ordered by ID.
+		Requests []OracleRequestStatus `json:"requests"`
+	}
+
+	// OracleRequestStatus is the state of a single oracle request. All
+	// timestamps are Unix milliseconds, durations are in milliseconds.
+	OracleRequestStatus struct {
+		ID uint64 `json:"id"`
+		// URL is omitted if the request wasn't processed by the node yet.
+		URL string `json:"url,omitempty"`
+		// Processed is true if the request data was fetched by the node.
+		Processed bool `json:"processed"`
+		// Code is the response code of the last processing, it's omitted
+		// for not processed requests.
+		Code *transaction.OracleResponseCode `json:"code,omitempty"`
+		// Reason describes why the request failed or why backup response
+		// is used.
+		Reason   string `json:"reason,omitempty"`
+		Attempts int    `json:"attempts"`
+		// LastProcessed is the time of the last processing.
+		LastProcessed uint64 `json:"lastprocessed,omitempty"`
+		// RetryIn is the time left until the next processing attempt, it's
+		// zero if the attempt is overdue.
+		RetryIn *uint64 `json:"retryin,omitempty"`
+		// ExpiresIn is the time left until the request is dropped.
+		ExpiresIn *uint64 `json:"expiresin,omitempty"`
+		// Backup is true if backup response is used.
+		Backup bool `json:"backup"`
+		// Sent is true if the response transaction was sent.
+		Sent bool `json:"sent"`
+		// Signatures contains nodes that have signed the response
+		// transaction.
+		Signatures keys.PublicKeys `json:"signatures"`
+		// BackupSignatures contains nodes that have signed the backup
+		// response transaction.
+		BackupSignatures keys.PublicKeys `json:"backupsignatures"`
+		// Missing contains nodes that haven't signed any of the response
+		// transactions.
+		Missing keys.PublicKeys `json:"missing"`
+	}
+)
@@ -1,6 +1,8 @@
 package rpcevent
 
 import (
+	"bytes"
+
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -110,6 +112,12 @@ func Matches(f Comparator, r Container) bool {
 			}
 		}
 		return senderOk && signerOK && typeOk
+	case neorpc.StorageChangedEventID:
+		filt := filter.(neorpc.StorageChangeFilter)
+		ev := r.EventPayload().(*state.StorageChangeEvent)
+		hashOk := filt.Contract == nil || ev.Contract.Equals(*filt.Contract)
+		prefixOk := bytes.HasPrefix(ev.Key, filt.Prefix)
+		return hashOk && prefixOk
 	default:
 		return false
 	}
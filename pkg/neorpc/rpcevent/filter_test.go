@@ -106,6 +106,13 @@ func TestMatches(t *testing.T) {
 			},
 		},
 	}
+	stContainer := testContainer{
+		id: neorpc.StorageChangedEventID,
+		pld: &state.StorageChangeEvent{
+			Contract:    contract,
+			StorageDiff: state.StorageDiff{Key: []byte{1, 2, 3}},
+		},
+	}
 	missedContainer := testContainer{
 		id: neorpc.MissedEventID,
 	}
@@ -370,6 +377,39 @@ func TestMatches(t *testing.T) {
 			container: ntrContainer,
 			expected:  true,
 		},
+		{
+			name:       "storage change, no filter",
+			comparator: testComparator{id: neorpc.StorageChangedEventID},
+			container:  stContainer,
+			expected:   true,
+		},
+		{
+			name: "storage change, contract mismatch",
+			comparator: testComparator{
+				id:     neorpc.StorageChangedEventID,
+				filter: neorpc.StorageChangeFilter{Contract: &badUint160},
+			},
+			container: stContainer,
+			expected:  false,
+		},
+		{
+			name: "storage change, prefix mismatch",
+			comparator: testComparator{
+				id:     neorpc.StorageChangedEventID,
+				filter: neorpc.StorageChangeFilter{Prefix: []byte{1, 3}},
+			},
+			container: stContainer,
+			expected:  false,
+		},
+		{
+			name: "storage change, filter match",
+			comparator: testComparator{
+				id:     neorpc.StorageChangedEventID,
+				filter: neorpc.StorageChangeFilter{Contract: &contract, Prefix: []byte{1, 2}},
+			},
+			container: stContainer,
+			expected:  true,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -29,6 +29,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/network/extpool"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/services/blockfetcher"
+	"github.com/nspcc-dev/neo-go/pkg/services/statefetcher"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"go.uber.org/zap"
 )
@@ -123,6 +124,7 @@ type (
 		syncHeaderFetcher *blockfetcher.Service
 		syncBlockFetcher  *blockfetcher.Service
 		blockFetcher      *blockfetcher.Service
+		stateFetcher      *statefetcher.Service
 
 		serviceLock    sync.RWMutex
 		services       map[string]Service
@@ -260,6 +262,15 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Sync NeoFS BlockFetcher: %w", err)
 	}
+	if s.NeoFSStateFetcherCfg.Enabled && !s.config.NeoFSStateSyncExtensions {
+		return nil, errors.New("NeoFSStateFetcher is enabled, but NeoFSStateSyncExtensions are off")
+	}
+	s.stateFetcher, err = statefetcher.New(s.stateSync, s.NeoFSStateFetcherCfg, log, func() {
+		s.log.Info("NeoFS StateFetcher finished state snapshot processing")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create NeoFS StateFetcher: %w", err)
+	}
 	if s.config.NeoFSStateSyncExtensions {
 		s.stateSync.SetOnStageChanged(s.stateSyncCallBack)
 	}
@@ -379,6 +390,7 @@ func (s *Server) Shutdown() {
 	s.bFetcherQueue.Discard()
 	s.syncHeaderFetcher.Shutdown()
 	s.syncBlockFetcher.Shutdown()
+	s.stateFetcher.Shutdown()
 	s.blockFetcher.Shutdown()
 	for _, tr := range s.transports {
 		tr.Close()
@@ -409,6 +421,7 @@ func (s *Server) Shutdown() {
 func (s *Server) stateSyncCallBack() {
 	needHeaders := s.stateSync.NeedHeaders()
 	needBlocks := s.stateSync.NeedBlocks()
+	needMPT := s.stateSync.NeedMPTNodes()
 	isActive := s.stateSync.IsActive()
 	if needHeaders {
 		if !s.syncHeaderFetcher.IsShutdown() {
@@ -429,6 +442,11 @@ func (s *Server) stateSyncCallBack() {
 	if !needHeaders && !needBlocks {
 		s.syncBlockFetcher.Shutdown()
 	}
+	if needMPT && s.ServerConfig.NeoFSStateFetcherCfg.Enabled && !s.stateFetcher.IsShutdown() {
+		if err := s.stateFetcher.Start(); err != nil {
+			s.log.Error("skipping NeoFS StateFetcher", zap.Error(err))
+		}
+	}
 	if !isActive {
 		if s.ServerConfig.NeoFSBlockFetcherCfg.Enabled {
 			if err := s.blockFetcher.Start(); err != nil {
@@ -903,7 +921,8 @@ func (s *Server) requestBlocksOrHeaders(p Peer) error {
 	)
 	if s.stateSync.IsActive() {
 		bq = s.stateSync
-		requestMPTNodes = s.stateSync.NeedMPTNodes()
+		// MPT is restored from the state snapshot if NeoFS StateFetcher is enabled.
+		requestMPTNodes = s.stateSync.NeedMPTNodes() && !s.NeoFSStateFetcherCfg.Enabled
 	}
 	if bq.BlockHeight() >= p.LastBlockIndex() {
 		return nil
@@ -78,6 +78,8 @@ type (
 		BroadcastFactor int
 
 		NeoFSBlockFetcherCfg config.NeoFSBlockFetcher
+
+		NeoFSStateFetcherCfg config.NeoFSStateFetcher
 	}
 )
 
@@ -110,6 +112,7 @@ func NewServerConfig(cfg config.Config) (ServerConfig, error) {
 		ExtensiblePoolSize:   appConfig.P2P.ExtensiblePoolSize,
 		BroadcastFactor:      appConfig.P2P.BroadcastFactor,
 		NeoFSBlockFetcherCfg: appConfig.NeoFSBlockFetcher,
+		NeoFSStateFetcherCfg: appConfig.NeoFSStateFetcher,
 	}
 	return c, nil
 }
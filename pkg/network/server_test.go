@@ -53,6 +53,7 @@ func (f *fakeConsensus) OnTransaction(tx *transaction.Transaction) {
 	f.txs = append(f.txs, tx)
 }
 func (f *fakeConsensus) GetPayload(h util.Uint256) *payload.Extensible { panic("implement me") }
+func (f *fakeConsensus) GetState() consensus.State                     { panic("implement me") }
 
 func TestNewServer(t *testing.T) {
 	bc := &fakechain.FakeChain{Blockchain: config.Blockchain{
@@ -84,6 +85,17 @@ func TestNewServer(t *testing.T) {
 		require.Equal(t, 2, s.ServerConfig.MaxPeers)
 		require.Equal(t, 3, s.ServerConfig.AttemptConnPeers)
 	})
+	t.Run("NeoFSStateFetcher", func(t *testing.T) {
+		cfg := ServerConfig{
+			NeoFSStateFetcherCfg: config.NeoFSStateFetcher{
+				InternalService: config.InternalService{Enabled: true},
+				Path:            t.TempDir(),
+			},
+		}
+		bc := &fakechain.FakeChain{Blockchain: config.Blockchain{}}
+		_, err := newServerFromConstructors(cfg, bc, new(fakechain.FakeStateSync), zaptest.NewLogger(t), newFakeTransp, newTestDiscovery)
+		require.ErrorContains(t, err, "NeoFSStateSyncExtensions are off")
+	})
 }
 
 func TestServerStartAndShutdown(t *testing.T) {
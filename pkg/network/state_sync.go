@@ -3,6 +3,8 @@ package network
 import (
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
@@ -10,6 +12,9 @@ import (
 type StateSync interface {
 	blockHeaderQueuer
 	AddMPTNodes([][]byte) error
+	AddContractStorageItems(kvs []storage.KeyValue) error
+	FinalizeContractStorage(sr *state.MPTRoot) error
+	GetStateSyncPoint() (uint32, util.Uint256)
 	Init(currChainHeight uint32) error
 	IsActive() bool
 	IsInitialized() bool
@@ -1,6 +1,7 @@
 package rpcclient
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -10,11 +11,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/lightclient"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
@@ -213,6 +216,31 @@ func (c *Client) GetBlockSysFee(index uint32) (fixedn.Fixed8, error) {
 	return resp, nil
 }
 
+// GetBlockStorageDiff returns contract storage changes made by the block with
+// the given index. This method is only supported by NeoGo servers with
+// SaveStorageDiffs setting enabled.
+func (c *Client) GetBlockStorageDiff(index uint32) (*result.BlockStorageDiff, error) {
+	return c.getBlockStorageDiff(index)
+}
+
+// GetBlockStorageDiffByHash returns contract storage changes made by the block
+// with the given hash. This method is only supported by NeoGo servers with
+// SaveStorageDiffs setting enabled.
+func (c *Client) GetBlockStorageDiffByHash(hash util.Uint256) (*result.BlockStorageDiff, error) {
+	return c.getBlockStorageDiff(hash.StringLE())
+}
+
+func (c *Client) getBlockStorageDiff(param any) (*result.BlockStorageDiff, error) {
+	var (
+		params = []any{param}
+		resp   = new(result.BlockStorageDiff)
+	)
+	if err := c.performRequest("getblockstoragediff", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetConnectionCount returns the current number of the connections for the node.
 func (c *Client) GetConnectionCount() (int, error) {
 	var resp int
@@ -223,6 +251,18 @@ func (c *Client) GetConnectionCount() (int, error) {
 	return resp, nil
 }
 
+// GetConsensusState returns dBFT state and the timeline of the latest rounds
+// of the consensus node. This method is only supported by NeoGo servers with
+// consensus service enabled.
+func (c *Client) GetConsensusState() (*result.ConsensusState, error) {
+	var resp = new(result.ConsensusState)
+
+	if err := c.performRequest("getconsensusstate", nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetCommittee returns the current public keys of NEO nodes in the committee.
 func (c *Client) GetCommittee() (keys.PublicKeys, error) {
 	var resp = new(keys.PublicKeys)
@@ -389,6 +429,18 @@ func (c *Client) GetNEP17Transfers(address util.Uint160, start, stop *uint64, li
 	return resp, nil
 }
 
+// GetOracleStatus returns the state of oracle requests being processed by
+// the oracle node. This method is only supported by NeoGo servers with oracle
+// service enabled.
+func (c *Client) GetOracleStatus() (*result.OracleStatus, error) {
+	var resp = new(result.OracleStatus)
+
+	if err := c.performRequest("getoraclestatus", nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetPeers returns a list of the nodes that the node is currently connected to/disconnected from.
 func (c *Client) GetPeers() (*result.GetPeers, error) {
 	var resp = &result.GetPeers{}
@@ -535,6 +587,116 @@ func (c *Client) GetStateHeight() (*result.StateHeight, error) {
 	return resp, nil
 }
 
+// StateRootVerifier checks state roots received from the RPC server, it's
+// implemented by lightclient.Verifier.
+type StateRootVerifier interface {
+	VerifyStateRoot(r *state.MPTRoot) error
+}
+
+// GetStateRootVerified returns the state root for the specified height
+// checked with the given StateRootVerifier.
+func (c *Client) GetStateRootVerified(v StateRootVerifier, height uint32) (*state.MPTRoot, error) {
+	r, err := c.GetStateRootByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	if r.Index != height {
+		return nil, fmt.Errorf("%w: state root height mismatch: expected %d, got %d", lightclient.ErrUnverifiedStateRoot, height, r.Index)
+	}
+	if err = v.VerifyStateRoot(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// getContractStateVerified returns the contract state for the given contract
+// hash checking its MPT proof against the given (verified) state root hash.
+func (c *Client) getContractStateVerified(root util.Uint256, hash util.Uint160) (*state.Contract, error) {
+	p, err := c.GetProof(root, nativehashes.ContractManagement, lightclient.ContractStateKey(hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract state proof: %w", err)
+	}
+	return lightclient.VerifyContractStateProof(root, hash, p.Proof)
+}
+
+// GetStorageVerified returns the value of the contract storage item with
+// the given key at the given state height. Unlike GetStorageByHash the value
+// is not trusted to the RPC server: the state root is checked with the given
+// StateRootVerifier and contract state and storage item MPT proofs are
+// checked locally against it. An error is returned if any of these checks
+// fails. Missing items can't be proven, so the server error is returned
+// for them as is.
+func (c *Client) GetStorageVerified(v StateRootVerifier, height uint32, hash util.Uint160, key []byte) ([]byte, error) {
+	r, err := c.GetStateRootVerified(v, height)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := c.getContractStateVerified(r.Root, hash)
+	if err != nil {
+		return nil, err
+	}
+	p, err := c.GetProof(r.Root, hash, key)
+	if err != nil {
+		return nil, err
+	}
+	return lightclient.VerifyStorageProof(r.Root, cs.ID, key, p.Proof)
+}
+
+// FindStatesVerified is similar to FindStates, but it uses the state root for
+// the given height checked with the given StateRootVerifier and verifies
+// every returned item: FirstProof and LastProof are checked locally and
+// proofs for all other items are requested via GetProof. An error is
+// returned if any of these checks fails. Notice that MPT proofs can't prove
+// that there are no other items in the requested range, only that all
+// returned items are valid.
+func (c *Client) FindStatesVerified(v StateRootVerifier, height uint32, hash util.Uint160, prefix []byte,
+	start []byte, maxCount *int) (result.FindStates, error) {
+	r, err := c.GetStateRootVerified(v, height)
+	if err != nil {
+		return result.FindStates{}, err
+	}
+	cs, err := c.getContractStateVerified(r.Root, hash)
+	if err != nil {
+		return result.FindStates{}, err
+	}
+	res, err := c.FindStates(r.Root, hash, prefix, start, maxCount)
+	if err != nil {
+		return res, err
+	}
+	for i, kv := range res.Results {
+		if !bytes.HasPrefix(kv.Key, prefix) {
+			return res, fmt.Errorf("%w: key %x doesn't match prefix", lightclient.ErrInvalidProof, kv.Key)
+		}
+		var p *result.ProofWithKey
+		switch {
+		case i == 0 && res.FirstProof != nil:
+			p = res.FirstProof
+		case i == len(res.Results)-1 && res.LastProof != nil:
+			p = res.LastProof
+		default:
+			p, err = c.GetProof(r.Root, hash, kv.Key)
+			if err != nil {
+				return res, fmt.Errorf("failed to get proof for key %x: %w", kv.Key, err)
+			}
+		}
+		key, err := lightclient.CheckStorageKey(cs.ID, p.Key)
+		if err != nil {
+			return res, err
+		}
+		if !bytes.Equal(key, kv.Key) {
+			return res, fmt.Errorf("%w: proof key mismatch for %x", lightclient.ErrInvalidProof, kv.Key)
+		}
+		val, err := lightclient.VerifyProof(r.Root, p)
+		if err != nil {
+			return res, err
+		}
+		if !bytes.Equal(val, kv.Value) {
+			return res, fmt.Errorf("%w: value mismatch for key %x", lightclient.ErrInvalidProof, kv.Key)
+		}
+	}
+	return res, nil
+}
+
 // GetStorageByID returns the stored value according to the contract ID and the stored key.
 func (c *Client) GetStorageByID(id int32, key []byte) ([]byte, error) {
 	return c.getStorage([]any{id, key})
@@ -1026,6 +1188,21 @@ func (c *Client) GetRawNotaryPool() (*result.RawNotaryPool, error) {
 	return resp, nil
 }
 
+// GetNotaryRequestStatus returns the state of notary requests with the given
+// main transaction hash: fallback transactions from the RPC node's notary
+// request pool and signatures collected by the node's Notary service (if it's
+// running).
+func (c *Client) GetNotaryRequestStatus(mainHash util.Uint256) (*result.NotaryRequestStatus, error) {
+	var (
+		params = []any{mainHash.StringLE()}
+		resp   = new(result.NotaryRequestStatus)
+	)
+	if err := c.performRequest("getnotaryrequeststatus", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetBlockNotifications returns notifications from a block organized by trigger type.
 func (c *Client) GetBlockNotifications(blockHash util.Uint256, filter *neorpc.NotificationFilter) (*result.BlockNotifications, error) {
 	var (
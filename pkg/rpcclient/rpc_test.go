@@ -327,6 +327,48 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getconsensusstate": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetConsensusState()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":5,"view":1,"primary":2,"index":0,"validators":["02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e"],"rounds":[{"height":5,"view":0,"primary":1,"start":1000,"preparerequest":10,"accepted":false,"changeviews":{"Timeout":1},"recoveryrequests":1,"recoverymessages":0,"missing":["02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e"]},{"height":5,"view":1,"primary":2,"start":2000,"accepted":false,"changeviews":{},"recoveryrequests":0,"recoverymessages":0}]}}`,
+			result: func(c *Client) any {
+				pub, err := keys.NewPublicKeyFromString("02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e")
+				if err != nil {
+					panic(fmt.Errorf("failed to decode public key: %w", err))
+				}
+				prepReq := uint64(10)
+				return &result.ConsensusState{
+					Height:     5,
+					View:       1,
+					Primary:    2,
+					Index:      0,
+					Validators: keys.PublicKeys{pub},
+					Rounds: []result.ConsensusRound{
+						{
+							Height:           5,
+							View:             0,
+							Primary:          1,
+							Start:            1000,
+							PrepareRequest:   &prepReq,
+							ChangeViews:      map[string]int{"Timeout": 1},
+							RecoveryRequests: 1,
+							Missing:          keys.PublicKeys{pub},
+						},
+						{
+							Height:      5,
+							View:        1,
+							Primary:     2,
+							Start:       2000,
+							ChangeViews: map[string]int{},
+						},
+					},
+				}
+			},
+		},
+	},
 	"getcontractstate": {
 		{
 			name: "positive, by hash",
@@ -562,6 +604,63 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getblockstoragediff": {
+		{
+			name: "by index, positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetBlockStorageDiff(5)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"blockhash":"0x7fba5cf3ab5c7cbf1eb4a1f08d4a2bd6aa0b6fdb6e6c9ce46ba8b6e2d5bd18ab","index":5,"changes":[{"id":-6,"key":"FAE=","oldvalue":null,"newvalue":"AQ=="},{"id":1,"key":"AQ==","oldvalue":"","newvalue":null}]}}`,
+			result: func(c *Client) any {
+				h, err := util.Uint256DecodeStringLE("7fba5cf3ab5c7cbf1eb4a1f08d4a2bd6aa0b6fdb6e6c9ce46ba8b6e2d5bd18ab")
+				if err != nil {
+					panic(err)
+				}
+				return &result.BlockStorageDiff{
+					Hash:  h,
+					Index: 5,
+					Changes: []state.StorageDiff{
+						{ID: -6, Key: []byte{20, 1}, New: []byte{1}},
+						{ID: 1, Key: []byte{1}, Old: []byte{}},
+					},
+				}
+			},
+		},
+	},
+	"getoraclestatus": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetOracleStatus()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"nodes":["02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e"],"required":1,"requests":[{"id":3,"url":"https://example.com","processed":true,"code":"NotFound","attempts":1,"lastprocessed":1000,"retryin":500,"expiresin":3600000,"backup":false,"sent":false,"signatures":[],"backupsignatures":["02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e"],"missing":[]}]}}`,
+			result: func(c *Client) any {
+				pub, err := keys.NewPublicKeyFromString("02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e")
+				if err != nil {
+					panic(fmt.Errorf("failed to decode public key: %w", err))
+				}
+				code := transaction.NotFound
+				retryIn, expiresIn := uint64(500), uint64(3600000)
+				return &result.OracleStatus{
+					Nodes:    keys.PublicKeys{pub},
+					Required: 1,
+					Requests: []result.OracleRequestStatus{{
+						ID:               3,
+						URL:              "https://example.com",
+						Processed:        true,
+						Code:             &code,
+						Attempts:         1,
+						LastProcessed:    1000,
+						RetryIn:          &retryIn,
+						ExpiresIn:        &expiresIn,
+						Signatures:       keys.PublicKeys{},
+						BackupSignatures: keys.PublicKeys{pub},
+						Missing:          keys.PublicKeys{},
+					}},
+				}
+			},
+		},
+	},
 	"getpeers": {
 		{
 			name: "positive",
@@ -1441,6 +1540,37 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getnotaryrequeststatus": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetNotaryRequestStatus(util.Uint256{1, 2, 3})
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"hash":"0x0000000000000000000000000000000000000000000000000000000000030201","height":10,"fallbacks":[{"hash":"0x0000000000000000000000000000000000000000000000000000000000000004","notvalidbefore":20,"validuntilblock":30}],"tracked":true,"notvalidbefore":20,"witnesses":[{"account":"0x0000000000000000000000000000000000000005","type":"signature","sigsleft":1,"signed":[],"missing":["03c089d7122b840a4935234e82e26ae5efd0c2acb627239dc9f207311337b6f2c1"]}],"completed":false,"sent":false,"reason":"1 signature(s) left to collect"}}`,
+			result: func(c *Client) any {
+				pub, _ := keys.NewPublicKeyFromString("03c089d7122b840a4935234e82e26ae5efd0c2acb627239dc9f207311337b6f2c1")
+				return &result.NotaryRequestStatus{
+					Hash:   util.Uint256{1, 2, 3},
+					Height: 10,
+					Fallbacks: []result.NotaryFallback{{
+						Hash:            util.Uint256{4},
+						NotValidBefore:  20,
+						ValidUntilBlock: 30,
+					}},
+					Tracked:        true,
+					NotValidBefore: 20,
+					Witnesses: []result.NotaryWitness{{
+						Account:  util.Uint160{5},
+						Type:     "signature",
+						SigsLeft: 1,
+						Signed:   keys.PublicKeys{},
+						Missing:  keys.PublicKeys{pub},
+					}},
+					Reason: "1 signature(s) left to collect",
+				}
+			},
+		},
+	},
 	"getblocknotifications": {
 		{
 			name: "positive, nil filter",
@@ -208,6 +208,52 @@ func (r *headerOfAddedBlockReceiver) Close() {
 	close(r.ch)
 }
 
+// storageChangeReceiver stores information about storage change events subscriber.
+type storageChangeReceiver struct {
+	filter *neorpc.StorageChangeFilter
+	ch     chan<- *state.StorageChangeEvent
+}
+
+// EventID implements neorpc.Comparator interface.
+func (r *storageChangeReceiver) EventID() neorpc.EventID {
+	return neorpc.StorageChangedEventID
+}
+
+// Filter implements neorpc.Comparator interface.
+func (r *storageChangeReceiver) Filter() neorpc.SubscriptionFilter {
+	if r.filter == nil {
+		return nil
+	}
+	return *r.filter
+}
+
+// Receiver implements notificationReceiver interface.
+func (r *storageChangeReceiver) Receiver() any {
+	return r.ch
+}
+
+// TrySend implements notificationReceiver interface.
+func (r *storageChangeReceiver) TrySend(ntf Notification, nonBlocking bool) (bool, bool) {
+	if rpcevent.Matches(r, ntf) {
+		if nonBlocking {
+			select {
+			case r.ch <- ntf.Value.(*state.StorageChangeEvent):
+			default:
+				return true, true
+			}
+		} else {
+			r.ch <- ntf.Value.(*state.StorageChangeEvent)
+		}
+		return true, false
+	}
+	return false, false
+}
+
+// Close implements notificationReceiver interface.
+func (r *storageChangeReceiver) Close() {
+	close(r.ch)
+}
+
 // txReceiver stores information about transaction events subscriber.
 type txReceiver struct {
 	filter *neorpc.TxFilter
@@ -574,6 +620,8 @@ readloop:
 					break readloop
 				}
 				ntf.Value = &block.New(sr).Header
+			case neorpc.StorageChangedEventID:
+				ntf.Value = new(state.StorageChangeEvent)
 			case neorpc.MissedEventID:
 				// No value.
 			default:
@@ -834,6 +882,28 @@ func (c *WSClient) ReceiveHeadersOfAddedBlocks(flt *neorpc.BlockFilter, rcvr cha
 	return c.performSubscription(params, r)
 }
 
+// ReceiveStorageChanges registers provided channel as a receiver for contract
+// storage change events. Events can be filtered by the given
+// [neorpc.StorageChangeFilter], nil value doesn't add any filter. This
+// subscription is only supported by NeoGo servers with SaveStorageDiffs
+// setting enabled. See WSClient comments for generic Receive* behaviour
+// details.
+func (c *WSClient) ReceiveStorageChanges(flt *neorpc.StorageChangeFilter, rcvr chan<- *state.StorageChangeEvent) (string, error) {
+	if rcvr == nil {
+		return "", ErrNilNotificationReceiver
+	}
+	params := []any{"storage_changed"}
+	if flt != nil {
+		flt = flt.Copy()
+		params = append(params, *flt)
+	}
+	r := &storageChangeReceiver{
+		filter: flt,
+		ch:     rcvr,
+	}
+	return c.performSubscription(params, r)
+}
+
 // ReceiveTransactions registers provided channel as a receiver for new transaction
 // events. Events can be filtered by the given TxFilter, nil value doesn't add any
 // filter. See WSClient comments for generic Receive* behaviour details.
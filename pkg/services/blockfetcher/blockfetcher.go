@@ -107,7 +107,7 @@ func New(chain Ledger, cfg config.NeoFSBlockFetcher, logger *zap.Logger, put fun
 		return &Service{}, nil
 	}
 	if cfg.UnlockWallet.Path != "" {
-		walletFromFile, err := wallet.NewWalletFromFile(cfg.UnlockWallet.Path)
+		walletFromFile, err := wallet.NewWalletFromFileWithPassword(cfg.UnlockWallet.Path, cfg.UnlockWallet.Password)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,295 @@
+/*
+Package checkpointuploader implements a service uploading state checkpoints
+written by the node to NeoFS.
+*/
+package checkpointuploader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/checkpoint"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/neofs"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neofs-sdk-go/client"
+	"github.com/nspcc-dev/neofs-sdk-go/container"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	"github.com/nspcc-dev/neofs-sdk-go/pool"
+	"github.com/nspcc-dev/neofs-sdk-go/user"
+	"go.uber.org/zap"
+)
+
+// queueSize is the number of checkpoints that can wait for upload.
+const queueSize = 16
+
+// Ledger is an interface to Blockchain sufficient for Service.
+type Ledger interface {
+	GetConfig() config.Blockchain
+}
+
+// neoFSPool is a subset of NeoFS pool methods used by Service.
+type neoFSPool interface {
+	Dial(ctx context.Context) error
+	ContainerGet(ctx context.Context, id cid.ID, prm client.PrmContainerGet) (container.Container, error)
+	ObjectPutInit(ctx context.Context, hdr object.Object, signer user.Signer, prm client.PrmObjectPutInit) (client.ObjectWriter, error)
+	Close() error
+}
+
+// Service is a service that uploads state checkpoints to NeoFS.
+type Service struct {
+	log         *zap.Logger
+	cfg         config.NeoFSCheckpoints
+	chain       Ledger
+	pool        neoFSPool
+	signer      user.Signer
+	containerID cid.ID
+
+	queue chan checkpoint.Info
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+	started   atomic.Bool
+	stopped   atomic.Bool
+	done      chan struct{}
+}
+
+// New creates a new checkpoint uploader Service.
+func New(chain Ledger, cfg config.NeoFSCheckpoints, logger *zap.Logger) (*Service, error) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = neofs.DefaultTimeout
+	}
+	if cfg.CheckpointAttribute == "" {
+		cfg.CheckpointAttribute = neofs.DefaultCheckpointAttribute
+	}
+	var containerID cid.ID
+	if err := containerID.DecodeString(cfg.ContainerID); err != nil {
+		return nil, fmt.Errorf("invalid container ID: %w", err)
+	}
+	w, err := wallet.NewWalletFromFileWithPassword(cfg.UnlockWallet.Path, cfg.UnlockWallet.Password)
+	if err != nil {
+		return nil, err
+	}
+	var account *wallet.Account
+	for _, acc := range w.Accounts {
+		if err := acc.Decrypt(cfg.UnlockWallet.Password, w.Scrypt); err == nil {
+			account = acc
+			break
+		}
+	}
+	if account == nil {
+		return nil, errors.New("failed to decrypt any account in the wallet")
+	}
+	signer := user.NewAutoIDSignerRFC6979(account.PrivateKey().PrivateKey)
+	params := pool.DefaultOptions()
+	params.SetHealthcheckTimeout(neofs.DefaultHealthcheckTimeout)
+	params.SetNodeDialTimeout(neofs.DefaultDialTimeout)
+	params.SetNodeStreamTimeout(neofs.DefaultStreamTimeout)
+	p, err := pool.New(pool.NewFlatNodeParams(cfg.Addresses), signer, params)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		log:         logger,
+		cfg:         cfg,
+		chain:       chain,
+		pool:        neofs.PoolWrapper{Pool: p},
+		signer:      signer,
+		containerID: containerID,
+
+		queue: make(chan checkpoint.Info, queueSize),
+
+		ctx:       ctx,
+		ctxCancel: cancel,
+		done:      make(chan struct{}),
+	}, nil
+}
+
+// Name returns service name.
+func (s *Service) Name() string {
+	return "checkpointuploader"
+}
+
+// Start runs the service in a separate goroutine. The service only starts
+// once, subsequent calls to Start are no-op.
+func (s *Service) Start() {
+	if !s.started.CompareAndSwap(false, true) {
+		return
+	}
+	s.log.Info("starting NeoFS checkpoint uploader service")
+	go s.run()
+}
+
+// Shutdown stops the service. It can only be called once, subsequent calls
+// to Shutdown on the same instance are no-op. The instance that was stopped
+// can not be started again by calling Start (use a new instance if needed).
+func (s *Service) Shutdown() {
+	if !s.stopped.CompareAndSwap(false, true) {
+		return
+	}
+	s.log.Info("shutting down NeoFS checkpoint uploader service")
+	s.ctxCancel()
+	if s.started.Load() {
+		<-s.done
+	}
+	_ = s.log.Sync()
+}
+
+// UploadCheckpoint implements core.CheckpointUploader interface. It queues
+// the checkpoint for upload, the checkpoint is skipped if the queue is full.
+func (s *Service) UploadCheckpoint(info checkpoint.Info) {
+	select {
+	case s.queue <- info:
+	default:
+		s.log.Warn("NeoFS checkpoint uploader service: queue is full, checkpoint is not uploaded",
+			zap.Uint32("index", info.Index),
+			zap.String("path", info.Path))
+	}
+}
+
+func (s *Service) run() {
+	defer close(s.done)
+	if err := s.pool.Dial(s.ctx); err != nil {
+		if s.ctx.Err() == nil {
+			s.log.Error("NeoFS checkpoint uploader service: failed to dial NeoFS pool", zap.Error(err))
+		}
+		return
+	}
+	defer func() { _ = s.pool.Close() }()
+	if err := s.checkContainer(); err != nil {
+		if s.ctx.Err() == nil {
+			s.log.Error("NeoFS checkpoint uploader service: checkpoints won't be uploaded", zap.Error(err))
+		}
+		return
+	}
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case info := <-s.queue:
+			start := time.Now()
+			id, err := s.upload(info)
+			if err != nil {
+				if s.ctx.Err() == nil {
+					s.log.Error("NeoFS checkpoint uploader service: failed to upload checkpoint",
+						zap.Uint32("index", info.Index),
+						zap.String("path", info.Path),
+						zap.Error(err))
+				}
+				continue
+			}
+			s.log.Info("NeoFS checkpoint uploader service: uploaded checkpoint",
+				zap.String("object ID", id),
+				zap.Uint32("index", info.Index),
+				zap.Bool("full", info.Full),
+				zap.Duration("time spent", time.Since(start)))
+		}
+	}
+}
+
+// checkContainer checks that the configured container belongs to the current
+// network.
+func (s *Service) checkContainer() error {
+	var (
+		containerObj container.Container
+		err          error
+	)
+	err = s.retry(func() error {
+		containerObj, err = s.pool.ContainerGet(s.ctx, s.containerID, client.PrmContainerGet{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get container: %w", err)
+	}
+	containerMagic := containerObj.Attribute("Magic")
+	if containerMagic != strconv.Itoa(int(s.chain.GetConfig().Magic)) {
+		return fmt.Errorf("container magic mismatch: expected %d, got %s", s.chain.GetConfig().Magic, containerMagic)
+	}
+	return nil
+}
+
+// upload uploads checkpoint file as NeoFS object and returns its ID.
+func (s *Service) upload(info checkpoint.Info) (string, error) {
+	f, err := os.Open(info.Path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var (
+		hdr              object.Object
+		prmObjectPutInit client.PrmObjectPutInit
+		res              string
+		attrs            = []object.Attribute{
+			*object.NewAttribute(s.cfg.CheckpointAttribute, strconv.FormatUint(uint64(info.Index), 10)),
+			*object.NewAttribute("Timestamp", strconv.FormatInt(time.Now().Unix(), 10)),
+			*object.NewAttribute("StateRoot", info.Root.StringLE()),
+			*object.NewAttribute("Full", strconv.FormatBool(info.Full)),
+			*object.NewAttribute("PrevIndex", strconv.FormatUint(uint64(info.PrevIndex), 10)),
+		}
+	)
+	hdr.SetContainerID(s.containerID)
+	hdr.SetOwner(s.signer.UserID())
+	hdr.SetAttributes(attrs...)
+	err = s.retry(func() error {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(s.ctx, s.cfg.Timeout)
+		defer cancel()
+		writer, err := s.pool.ObjectPutInit(ctx, hdr, s.signer, prmObjectPutInit)
+		if err != nil {
+			return fmt.Errorf("failed to initiate object upload: %w", err)
+		}
+		_, err = io.Copy(writer, f)
+		if err != nil {
+			_ = writer.Close()
+			return fmt.Errorf("failed to write object data: %w", err)
+		}
+		err = writer.Close()
+		if err != nil {
+			return fmt.Errorf("failed to close object writer: %w", err)
+		}
+		res = writer.GetResult().StoredObjectID().String()
+		return nil
+	})
+	return res, err
+}
+
+// retry function with exponential backoff.
+func (s *Service) retry(action func() error) error {
+	var (
+		err     error
+		backoff = neofs.InitialBackoff
+		timer   = time.NewTimer(0)
+	)
+
+	for i := range neofs.MaxRetries {
+		if err = action(); err == nil {
+			return nil
+		}
+		if i == neofs.MaxRetries-1 {
+			break
+		}
+		timer.Reset(backoff)
+
+		select {
+		case <-timer.C:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+		backoff *= time.Duration(neofs.BackoffFactor)
+		if backoff > neofs.MaxBackoff {
+			backoff = neofs.MaxBackoff
+		}
+	}
+	return err
+}
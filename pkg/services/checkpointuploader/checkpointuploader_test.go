@@ -0,0 +1,212 @@
+package checkpointuploader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/checkpoint"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/neofs"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neofs-sdk-go/client"
+	"github.com/nspcc-dev/neofs-sdk-go/container"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	cidtest "github.com/nspcc-dev/neofs-sdk-go/container/id/test"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	"github.com/nspcc-dev/neofs-sdk-go/user"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type mockLedger struct{}
+
+func (mockLedger) GetConfig() config.Blockchain {
+	return config.Blockchain{ProtocolConfiguration: config.ProtocolConfiguration{
+		Magic: netmode.UnitTestNet,
+	}}
+}
+
+type mockPool struct {
+	lock    sync.Mutex
+	magic   string
+	putErrs int
+	objects []mockObject
+	closed  bool
+}
+
+type mockObject struct {
+	hdr  object.Object
+	data []byte
+}
+
+type mockWriter struct {
+	bytes.Buffer
+	p   *mockPool
+	hdr object.Object
+}
+
+func (w *mockWriter) Close() error {
+	w.p.lock.Lock()
+	defer w.p.lock.Unlock()
+	w.p.objects = append(w.p.objects, mockObject{hdr: w.hdr, data: w.Bytes()})
+	return nil
+}
+
+func (w *mockWriter) GetResult() client.ResObjectPut {
+	return client.ResObjectPut{}
+}
+
+func (p *mockPool) Dial(context.Context) error { return nil }
+
+func (p *mockPool) ContainerGet(context.Context, cid.ID, client.PrmContainerGet) (container.Container, error) {
+	var c container.Container
+	c.SetAttribute("Magic", p.magic)
+	return c, nil
+}
+
+func (p *mockPool) ObjectPutInit(_ context.Context, hdr object.Object, _ user.Signer, _ client.PrmObjectPutInit) (client.ObjectWriter, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.putErrs > 0 {
+		p.putErrs--
+		return nil, errors.New("put failed")
+	}
+	return &mockWriter{p: p, hdr: hdr}, nil
+}
+
+func (p *mockPool) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.closed = true
+	return nil
+}
+
+func (p *mockPool) getObjects() []mockObject {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.objects
+}
+
+func newTestConfig(t *testing.T) config.NeoFSCheckpoints {
+	const pass = "one"
+	path := filepath.Join(t.TempDir(), "wallet.json")
+	w, err := wallet.NewWallet(path)
+	require.NoError(t, err)
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	require.NoError(t, acc.Encrypt(pass, keys.NEP2ScryptParams()))
+	w.AddAccount(acc)
+	require.NoError(t, w.Save())
+	w.Close()
+
+	return config.NeoFSCheckpoints{
+		InternalService: config.InternalService{
+			Enabled:      true,
+			UnlockWallet: config.Wallet{Path: path, Password: pass},
+		},
+		ContainerID: cidtest.ID().EncodeToString(),
+		Addresses:   []string{"localhost:1"},
+	}
+}
+
+func writeCheckpoint(t *testing.T, dir string, h checkpoint.Header) checkpoint.Info {
+	w, err := checkpoint.Create(dir, &h)
+	require.NoError(t, err)
+	w.Put([]byte{1, 2, 3}, []byte{4, 5, 6})
+	require.NoError(t, w.Close())
+	return checkpoint.Info{Header: h, Path: filepath.Join(dir, checkpoint.FileName(h.Index))}
+}
+
+func getAttribute(hdr object.Object, key string) string {
+	for _, attr := range hdr.UserAttributes() {
+		if attr.Key() == key {
+			return attr.Value()
+		}
+	}
+	return ""
+}
+
+func TestNew(t *testing.T) {
+	cfg := newTestConfig(t)
+	t.Run("defaults", func(t *testing.T) {
+		s, err := New(mockLedger{}, cfg, zaptest.NewLogger(t))
+		require.NoError(t, err)
+		require.Equal(t, neofs.DefaultTimeout, s.cfg.Timeout)
+		require.Equal(t, neofs.DefaultCheckpointAttribute, s.cfg.CheckpointAttribute)
+		require.Equal(t, "checkpointuploader", s.Name())
+		s.Shutdown()
+	})
+	t.Run("invalid container", func(t *testing.T) {
+		c := cfg
+		c.ContainerID = "invalid"
+		_, err := New(mockLedger{}, c, zaptest.NewLogger(t))
+		require.ErrorContains(t, err, "invalid container ID")
+	})
+	t.Run("invalid password", func(t *testing.T) {
+		c := cfg
+		c.UnlockWallet.Password = "two"
+		_, err := New(mockLedger{}, c, zaptest.NewLogger(t))
+		require.ErrorContains(t, err, "failed to decrypt any account in the wallet")
+	})
+	t.Run("missing wallet", func(t *testing.T) {
+		c := cfg
+		c.UnlockWallet.Path = filepath.Join(t.TempDir(), "missing.json")
+		_, err := New(mockLedger{}, c, zaptest.NewLogger(t))
+		require.Error(t, err)
+	})
+}
+
+func TestUpload(t *testing.T) {
+	dir := t.TempDir()
+	s, err := New(mockLedger{}, newTestConfig(t), zaptest.NewLogger(t))
+	require.NoError(t, err)
+	p := &mockPool{magic: strconv.Itoa(int(netmode.UnitTestNet)), putErrs: 1}
+	s.pool = p
+
+	full := writeCheckpoint(t, dir, checkpoint.Header{Magic: netmode.UnitTestNet, Index: 10, Full: true, Root: util.Uint256{1}})
+	incr := writeCheckpoint(t, dir, checkpoint.Header{Magic: netmode.UnitTestNet, Index: 20, PrevIndex: 10, Root: util.Uint256{2}})
+	s.UploadCheckpoint(full) // Queued before the service is started.
+	s.Start()
+	s.UploadCheckpoint(incr)
+	require.Eventually(t, func() bool { return len(p.getObjects()) == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	for i, info := range []checkpoint.Info{full, incr} {
+		obj := p.getObjects()[i]
+		data, err := os.ReadFile(info.Path)
+		require.NoError(t, err)
+		require.Equal(t, data, obj.data)
+		require.Equal(t, strconv.Itoa(int(info.Index)), getAttribute(obj.hdr, neofs.DefaultCheckpointAttribute))
+		require.Equal(t, info.Root.StringLE(), getAttribute(obj.hdr, "StateRoot"))
+		require.Equal(t, strconv.FormatBool(info.Full), getAttribute(obj.hdr, "Full"))
+		require.Equal(t, strconv.Itoa(int(info.PrevIndex)), getAttribute(obj.hdr, "PrevIndex"))
+		require.Equal(t, s.containerID, obj.hdr.GetContainerID())
+	}
+	s.Shutdown()
+	require.True(t, p.closed)
+	s.Shutdown() // No-op.
+}
+
+func TestMagicMismatch(t *testing.T) {
+	dir := t.TempDir()
+	s, err := New(mockLedger{}, newTestConfig(t), zaptest.NewLogger(t))
+	require.NoError(t, err)
+	p := &mockPool{magic: strconv.Itoa(int(netmode.UnitTestNet) + 1)}
+	s.pool = p
+
+	s.Start()
+	<-s.done // Service stops after the container check.
+	s.UploadCheckpoint(writeCheckpoint(t, dir, checkpoint.Header{Magic: netmode.UnitTestNet, Index: 10, Full: true}))
+	s.Shutdown()
+	require.Empty(t, p.getObjects())
+	require.True(t, p.closed)
+}
@@ -20,6 +20,9 @@ const (
 	DefaultIndexFileAttribute = "Index"
 	// DefaultStateAttribute is the default attribute name for state objects.
 	DefaultStateAttribute = "State"
+	// DefaultCheckpointAttribute is the default attribute name for state
+	// checkpoint objects.
+	DefaultCheckpointAttribute = "Checkpoint"
 
 	// DefaultSearchBatchSize is a number of objects to search in a batch. We need to
 	// search with EQ filter to avoid partially-completed SEARCH responses. If EQ search
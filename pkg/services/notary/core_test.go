@@ -468,6 +468,55 @@ func TestNotary(t *testing.T) {
 
 	// OnNewRequest: multisignature request
 	r, _ = checkCompleteMultisigRequest(t, 1, 1, true)
+
+	// GetRequestStatus: mixed request
+	sigAcc, _ := wallet.NewAccount()
+	multisigAccs := make([]*wallet.Account, 3)
+	for i := range multisigAccs {
+		multisigAccs[i], _ = wallet.NewAccount()
+	}
+	mixedRequests := createMixedRequest([]requester{
+		{accounts: []*wallet.Account{sigAcc}, typ: notary.Signature},
+		{accounts: multisigAccs, m: 2, typ: notary.MultiSignature},
+	})
+	mainHash := mixedRequests[0].MainTransaction.Hash()
+	_, ok := ntr1.GetRequestStatus(mainHash)
+	require.False(t, ok)
+	ntr1.OnNewRequest(mixedRequests[1])
+	st, ok := ntr1.GetRequestStatus(mainHash)
+	require.True(t, ok)
+	require.False(t, st.Completed)
+	require.False(t, st.Sent)
+	require.Equal(t, "2 signature(s) left to collect", st.Reason)
+	require.Equal(t, mixedRequests[1].FallbackTransaction.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height, st.MinNotValidBefore)
+	require.Equal(t, mainHash, st.Main.Hash())
+	require.Len(t, st.Witnesses, 3)
+	require.Equal(t, notary.Signature, st.Witnesses[0].Type)
+	require.Equal(t, 1, st.Witnesses[0].SigsLeft)
+	require.Empty(t, st.Witnesses[0].Signed)
+	require.Len(t, st.Witnesses[0].Missing, 1)
+	require.True(t, sigAcc.PublicKey().Equal(st.Witnesses[0].Missing[0]))
+	require.Equal(t, notary.MultiSignature, st.Witnesses[1].Type)
+	require.Equal(t, 1, st.Witnesses[1].SigsLeft)
+	require.Len(t, st.Witnesses[1].Signed, 1)
+	require.True(t, multisigAccs[0].PublicKey().Equal(st.Witnesses[1].Signed[0]))
+	require.Len(t, st.Witnesses[1].Missing, 2)
+	require.Equal(t, notary.Contract, st.Witnesses[2].Type)
+	require.Equal(t, bc.GetNotaryContractScriptHash(), st.Witnesses[2].Account)
+	ntr1.OnNewRequest(mixedRequests[0])
+	ntr1.OnNewRequest(mixedRequests[2])
+	getCompletedTx(t, true, mainHash)
+	require.Eventually(t, func() bool {
+		st, _ = ntr1.GetRequestStatus(mainHash)
+		return st.Sent
+	}, time.Second*3, time.Millisecond*50)
+	require.True(t, st.Completed)
+	require.Empty(t, st.Reason)
+	for _, req := range mixedRequests {
+		ntr1.OnRequestRemoval(req)
+	}
+	_, ok = ntr1.GetRequestStatus(mainHash)
+	require.False(t, ok)
 	checkFallbackTxs(t, r, false)
 	r, _ = checkCompleteMultisigRequest(t, 1, 2, true)
 	checkFallbackTxs(t, r, false)
@@ -98,6 +98,9 @@ type (
 		fallbacks         []*transaction.Transaction
 
 		witnessInfo []witnessInfo
+		// verificationErr is the reason the main transaction can't be completed
+		// if none of the received requests passed the verification.
+		verificationErr error
 	}
 
 	// witnessInfo represents information about the signer and its witness.
@@ -132,7 +135,7 @@ func (r request) isMainCompleted() bool {
 // NewNotary returns a new Notary module.
 func NewNotary(cfg Config, net netmode.Magic, mp *mempool.Pool, onTransaction func(tx *transaction.Transaction) error) (*Notary, error) {
 	w := cfg.MainCfg.UnlockWallet
-	wall, err := wallet.NewWalletFromFile(w.Path)
+	wall, err := wallet.NewWalletFromFileWithPassword(w.Path, w.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -274,6 +277,8 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 	}
 	if r.witnessInfo == nil && validationErr == nil {
 		r.witnessInfo = newInfo
+	} else if r.witnessInfo == nil {
+		r.verificationErr = validationErr
 	}
 	// Disallow modification of a fallback transaction got from the notary
 	// request pool. Even though it has dummy Notary witness attached and its
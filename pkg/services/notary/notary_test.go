@@ -1,6 +1,7 @@
 package notary
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/fakechain"
@@ -13,6 +14,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 )
@@ -44,6 +46,20 @@ func TestWallet(t *testing.T) {
 		_, err := NewNotary(cfg, netmode.UnitTestNet, mempool.New(1, 1, true, nil), nil)
 		require.NoError(t, err)
 	})
+
+	t.Run("encrypted file", func(t *testing.T) {
+		w, err := wallet.NewWalletFromFile("./testdata/notary1.json")
+		require.NoError(t, err)
+		require.NoError(t, w.EncryptFile("one", wallet.Argon2Params{Time: 1, Memory: 64, Threads: 1}))
+		w.SetPath(filepath.Join(t.TempDir(), "notary1.json"))
+		require.NoError(t, w.Save())
+
+		cfg.MainCfg.UnlockWallet.Path = w.Path()
+		cfg.MainCfg.UnlockWallet.Password = "one"
+		ntr, err := NewNotary(cfg, netmode.UnitTestNet, mempool.New(1, 1, true, nil), nil)
+		require.NoError(t, err)
+		require.True(t, ntr.wallet.IsFileEncrypted())
+	})
 }
 
 func TestVerifyIncompleteRequest(t *testing.T) {
@@ -11,3 +11,17 @@ const (
 	// Contract represents contract witness type.
 	Contract RequestType = 0x03
 )
+
+// String implements the fmt.Stringer interface.
+func (t RequestType) String() string {
+	switch t {
+	case Signature:
+		return "signature"
+	case MultiSignature:
+		return "multisignature"
+	case Contract:
+		return "contract"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,107 @@
+package notary
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+type (
+	// RequestStatus represents the state of the notary request processed by
+	// the Notary service.
+	RequestStatus struct {
+		// Main is the main transaction with all witnesses collected so far
+		// (except the Notary one).
+		Main *transaction.Transaction
+		// MinNotValidBefore is the minimum NotValidBefore value among the
+		// fallback transactions. The main transaction can't be sent after
+		// this height.
+		MinNotValidBefore uint32
+		// Witnesses is the per-signer witness collection state. It's nil if
+		// the main transaction can't be completed.
+		Witnesses []WitnessStatus
+		// Completed denotes whether all main transaction witnesses are
+		// collected.
+		Completed bool
+		// Sent denotes whether the main transaction was sent to the network.
+		Sent bool
+		// Reason explains why the main transaction is not sent yet.
+		Reason string
+	}
+
+	// WitnessStatus represents the state of the main transaction witness
+	// collection for a single signer.
+	WitnessStatus struct {
+		Account util.Uint160
+		Type    RequestType
+		// SigsLeft is the number of signatures left to collect.
+		SigsLeft int
+		// Signed contains keys the signatures were received for.
+		Signed keys.PublicKeys
+		// Missing contains keys no signatures were received for.
+		Missing keys.PublicKeys
+	}
+)
+
+// GetRequestStatus returns the status of the notary request with the given
+// main transaction hash. The second result is false if the request is not
+// known to the service.
+func (n *Notary) GetRequestStatus(h util.Uint256) (RequestStatus, bool) {
+	n.reqMtx.RLock()
+	defer n.reqMtx.RUnlock()
+	r, ok := n.requests[h]
+	if !ok {
+		return RequestStatus{}, false
+	}
+	st := RequestStatus{
+		MinNotValidBefore: r.minNotValidBefore,
+		Completed:         r.isMainCompleted(),
+		Sent:              r.isSent,
+	}
+	// Main transaction witnesses are modified in-place, so the copy is needed
+	// anyway, updateTxSize also recalculates the cached size.
+	st.Main, _ = updateTxSize(r.main)
+	var sigsLeft int
+	if r.witnessInfo != nil {
+		st.Witnesses = make([]WitnessStatus, len(r.witnessInfo))
+		for i, wi := range r.witnessInfo {
+			ws := WitnessStatus{
+				Account:  r.main.Signers[i].Account,
+				Type:     wi.typ,
+				SigsLeft: int(wi.nSigsLeft),
+				Signed:   keys.PublicKeys{},
+				Missing:  keys.PublicKeys{},
+			}
+			for _, pub := range wi.pubs {
+				var signed bool
+				switch wi.typ {
+				case Signature:
+					signed = wi.nSigsLeft == 0
+				case MultiSignature:
+					signed = wi.sigs[pub] != nil
+				}
+				if signed {
+					ws.Signed = append(ws.Signed, pub)
+				} else {
+					ws.Missing = append(ws.Missing, pub)
+				}
+			}
+			sigsLeft += ws.SigsLeft
+			st.Witnesses[i] = ws
+		}
+	}
+	switch {
+	case r.isSent:
+	case r.witnessInfo == nil:
+		st.Reason = fmt.Sprintf("main transaction can't be completed (%s), waiting for fallbacks", r.verificationErr)
+	case r.minNotValidBefore <= n.Config.Chain.BlockHeight():
+		st.Reason = fmt.Sprintf("fallback NotValidBefore height %d is reached, main transaction can't be sent", r.minNotValidBefore)
+	case !st.Completed:
+		st.Reason = fmt.Sprintf("%d signature(s) left to collect", sigsLeft)
+	default:
+		st.Reason = "main transaction is completed, but not sent yet"
+	}
+	return st, true
+}
@@ -0,0 +1,190 @@
+/*
+Package csvpath implements column and row selectors used to filter CSV oracle
+responses.
+
+A selector is a whitespace-separated list of options:
+  - `header` treats the first record as a header, it is not included into the
+    result and column names can be used in `cols`;
+  - `cols=a,b,...` selects columns by zero-based indices or header names (all
+    columns are selected by default);
+  - `rows=a:b` selects records from a-th (inclusive) to b-th (exclusive), both
+    bounds are zero-based, can be omitted and don't include the header;
+  - `rows=n` selects the single n-th record;
+  - `delim=c` sets the field delimiter (`,` by default), `\t` can be used
+    for tabs.
+
+The result is a list of selected records, each record being a list of
+selected fields.
+*/
+package csvpath
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxRecords is the maximum number of records in the document.
+const maxRecords = 4096
+
+type selector struct {
+	header   bool
+	cols     []string
+	from, to int
+	delim    rune
+}
+
+// ErrTooManyRecords is returned when the document has too many records.
+var ErrTooManyRecords = errors.New("too many records")
+
+// Get parses the CSV document and returns records and fields selected by the
+// given selector.
+func Get(sel string, doc []byte) ([][]string, error) {
+	s, err := parse(sel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %w", err)
+	}
+	r := csv.NewReader(bytes.NewReader(doc))
+	r.Comma = s.delim
+	r.FieldsPerRecord = -1
+
+	var records [][]string
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid document: %w", err)
+		}
+		if len(records) == maxRecords {
+			return nil, ErrTooManyRecords
+		}
+		records = append(records, rec)
+	}
+
+	var header []string
+	if s.header && len(records) > 0 {
+		header, records = records[0], records[1:]
+	}
+	indices, err := s.columns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := s.from, s.to
+	if to < 0 || to > len(records) {
+		to = len(records)
+	}
+	from = min(from, to)
+	res := make([][]string, 0, to-from)
+	for _, rec := range records[from:to] {
+		if indices == nil {
+			res = append(res, rec)
+			continue
+		}
+		row := make([]string, len(indices))
+		for i, idx := range indices {
+			if idx < len(rec) {
+				row[i] = rec[idx]
+			}
+		}
+		res = append(res, row)
+	}
+	return res, nil
+}
+
+// columns converts selected column names to indices. It returns nil if all
+// columns are selected.
+func (s *selector) columns(header []string) ([]int, error) {
+	if s.cols == nil {
+		return nil, nil
+	}
+	res := make([]int, 0, len(s.cols))
+	for _, c := range s.cols {
+		if s.header {
+			if i := slices.Index(header, c); i >= 0 {
+				res = append(res, i)
+				continue
+			}
+		}
+		i, err := strconv.ParseUint(c, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("unknown column %q", c)
+		}
+		res = append(res, int(i))
+	}
+	return res, nil
+}
+
+func parse(sel string) (*selector, error) {
+	s := &selector{to: -1, delim: ','}
+	for _, opt := range strings.Fields(sel) {
+		name, value, hasValue := strings.Cut(opt, "=")
+		switch name {
+		case "header":
+			if hasValue {
+				return nil, errors.New("header can't have a value")
+			}
+			s.header = true
+		case "cols":
+			if value == "" {
+				return nil, errors.New("empty column list")
+			}
+			s.cols = strings.Split(value, ",")
+		case "rows":
+			var err error
+			if s.from, s.to, err = parseRange(value); err != nil {
+				return nil, err
+			}
+		case "delim":
+			if value == `\t` {
+				value = "\t"
+			}
+			r, size := utf8.DecodeRuneInString(value)
+			if size == 0 || size != len(value) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+				return nil, fmt.Errorf("invalid delimiter %q", value)
+			}
+			s.delim = r
+		default:
+			return nil, fmt.Errorf("unknown option %q", name)
+		}
+	}
+	return s, nil
+}
+
+func parseRange(s string) (int, int, error) {
+	parseBound := func(b string, def int) (int, error) {
+		if b == "" {
+			return def, nil
+		}
+		n, err := strconv.ParseUint(b, 10, 16)
+		if err != nil {
+			return 0, fmt.Errorf("invalid row %q", b)
+		}
+		return int(n), nil
+	}
+	left, right, isRange := strings.Cut(s, ":")
+	if !isRange {
+		if left == "" {
+			return 0, 0, errors.New("empty row range")
+		}
+		n, err := parseBound(left, 0)
+		return n, n + 1, err
+	}
+	from, err := parseBound(left, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := parseBound(right, -1)
+	if err != nil {
+		return 0, 0, err
+	}
+	return from, to, nil
+}
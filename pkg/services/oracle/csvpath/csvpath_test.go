@@ -0,0 +1,81 @@
+package csvpath
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const doc = `symbol,price,volume
+NEO,10.5,1000
+GAS,4.2,"2,500"
+FLM,0.1,30
+`
+
+func TestGet(t *testing.T) {
+	testCases := []struct {
+		sel    string
+		result [][]string
+	}{
+		{"", [][]string{{"symbol", "price", "volume"}, {"NEO", "10.5", "1000"}, {"GAS", "4.2", "2,500"}, {"FLM", "0.1", "30"}}},
+		{"header", [][]string{{"NEO", "10.5", "1000"}, {"GAS", "4.2", "2,500"}, {"FLM", "0.1", "30"}}},
+		{"header cols=price", [][]string{{"10.5"}, {"4.2"}, {"0.1"}}},
+		{"header cols=volume,0 rows=1", [][]string{{"2,500", "GAS"}}},
+		{"header rows=1:", [][]string{{"GAS", "4.2", "2,500"}, {"FLM", "0.1", "30"}}},
+		{"rows=:2 cols=2,0", [][]string{{"volume", "symbol"}, {"1000", "NEO"}}},
+		{"cols=5 rows=1:2", [][]string{{""}}},
+		{"rows=10", [][]string{}},
+		{"rows=3:1", [][]string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.sel, func(t *testing.T) {
+			res, err := Get(tc.sel, []byte(doc))
+			require.NoError(t, err)
+			require.Equal(t, tc.result, res)
+		})
+	}
+
+	t.Run("delimiter", func(t *testing.T) {
+		res, err := Get(`delim=\t cols=1`, []byte("a\tb\nc\td\n"))
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"b"}, {"d"}}, res)
+
+		res, err = Get(`delim=; header cols=b`, []byte("a;b\n1;2\n"))
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"2"}}, res)
+	})
+}
+
+func TestInvalid(t *testing.T) {
+	sels := []string{
+		"unknown",
+		"header=1",
+		"cols=",
+		"cols=price",
+		"header cols=unknown",
+		"rows=",
+		"rows=a",
+		"rows=1:b",
+		"rows=-1",
+		"delim=",
+		"delim=ab",
+		`delim="`,
+	}
+	for _, s := range sels {
+		t.Run(s, func(t *testing.T) {
+			_, err := Get(s, []byte(doc))
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("invalid document", func(t *testing.T) {
+		_, err := Get("", []byte("a,\"b\n"))
+		require.Error(t, err)
+	})
+	t.Run("too many records", func(t *testing.T) {
+		d := strings.Repeat("a\n", maxRecords+1)
+		_, err := Get("", []byte(d))
+		require.ErrorIs(t, err, ErrTooManyRecords)
+	})
+}
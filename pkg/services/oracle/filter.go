@@ -3,11 +3,27 @@ package oracle
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"mime"
+	"strings"
 	"unicode/utf8"
 
 	json "github.com/nspcc-dev/go-ordered-json"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/csvpath"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/jmespath"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/jsonpath"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/xpath"
+)
+
+// Filter language prefixes used when extended filters are enabled. Filters
+// without a prefix are always JSONPath.
+const (
+	jsonPathPrefix = "jsonpath:"
+	jmesPathPrefix = "jmespath:"
+	xPathPrefix    = "xpath:"
+	csvPrefix      = "csv:"
 )
 
 func filter(value []byte, path string) ([]byte, error) {
@@ -31,9 +47,77 @@ func filter(value []byte, path string) ([]byte, error) {
 	return json.Marshal(result)
 }
 
-func filterRequest(result []byte, req *state.OracleRequest) ([]byte, error) {
-	if req.Filter != nil {
+func filterJMESPath(value []byte, expr string) ([]byte, error) {
+	if !utf8.Valid(value) {
+		return nil, errors.New("not an UTF-8")
+	}
+
+	d := json.NewDecoder(bytes.NewReader(value))
+	d.UseOrderedObject()
+	d.UseNumber()
+
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	result, err := jmespath.Get(expr, v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func filterXPath(value []byte, path string, html bool) ([]byte, error) {
+	result, err := xpath.Get(path, value, html)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func filterCSV(value []byte, sel string) ([]byte, error) {
+	result, err := csvpath.Get(sel, value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+// filterByPrefix applies the filter to the value using the language from the
+// filter prefix with JSONPath being the default. Content type is only used
+// to choose between XML and HTML for XPath.
+func filterByPrefix(value []byte, flt string, contentType string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(flt, jsonPathPrefix):
+		return filter(value, flt[len(jsonPathPrefix):])
+	case strings.HasPrefix(flt, jmesPathPrefix):
+		return filterJMESPath(value, flt[len(jmesPathPrefix):])
+	case strings.HasPrefix(flt, xPathPrefix):
+		typ, _, _ := mime.ParseMediaType(contentType)
+		return filterXPath(value, flt[len(xPathPrefix):], typ == "text/html")
+	case strings.HasPrefix(flt, csvPrefix):
+		return filterCSV(value, flt[len(csvPrefix):])
+	default:
+		return filter(value, flt)
+	}
+}
+
+// filterRequest applies the request filter (if any) to the result. Only
+// JSONPath filters are supported unless extended filters are enabled.
+func filterRequest(result []byte, contentType string, req *state.OracleRequest, extended bool) ([]byte, error) {
+	if req.Filter == nil {
+		return result, nil
+	}
+	if !extended {
 		return filter(result, *req.Filter)
 	}
-	return result, nil
+	res, err := filterByPrefix(result, *req.Filter, contentType)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) > transaction.MaxOracleResultSize {
+		return nil, fmt.Errorf("%w: %d bytes after filtering", ErrResponseTooLarge, len(res))
+	}
+	return res, nil
 }
@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/stretchr/testify/require"
 )
 
@@ -81,3 +83,45 @@ func TestFilterOOM(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestFilterRequest(t *testing.T) {
+	newReq := func(flt string) *state.OracleRequest {
+		return &state.OracleRequest{Filter: &flt}
+	}
+	xml := []byte(`<prices><price symbol="GAS">4.2</price></prices>`)
+
+	t.Run("no filter", func(t *testing.T) {
+		actual, err := filterRequest(xml, "application/xml", &state.OracleRequest{}, false)
+		require.NoError(t, err)
+		require.Equal(t, xml, actual)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		// Prefixes are a part of JSONPath and content type is ignored.
+		_, err := filterRequest(xml, "application/xml", newReq("xpath:/prices/price"), false)
+		require.Error(t, err)
+		_, err = filterRequest(xml, "application/xml", newReq("/prices/price"), false)
+		require.Error(t, err)
+
+		actual, err := filterRequest([]byte(`{"a":1}`), "application/json", newReq("$.a"), false)
+		require.NoError(t, err)
+		require.Equal(t, `[1]`, string(actual))
+	})
+	t.Run("enabled", func(t *testing.T) {
+		actual, err := filterRequest(xml, "application/xml", newReq("xpath:/prices/price"), true)
+		require.NoError(t, err)
+		require.Equal(t, `["4.2"]`, string(actual))
+
+		// Unprefixed filters are JSONPath whatever the content type is.
+		_, err = filterRequest(xml, "application/xml", newReq("/prices/price"), true)
+		require.Error(t, err)
+
+		actual, err = filterRequest([]byte(`{"a":1}`), "application/json", newReq("$.a"), true)
+		require.NoError(t, err)
+		require.Equal(t, `[1]`, string(actual))
+	})
+	t.Run("too large", func(t *testing.T) {
+		big := []byte(`"` + strings.Repeat("a", transaction.MaxOracleResultSize) + `"`)
+		_, err := filterRequest(big, "application/json", newReq("jsonpath:$"), true)
+		require.ErrorIs(t, err, ErrResponseTooLarge)
+	})
+}
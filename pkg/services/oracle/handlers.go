@@ -0,0 +1,376 @@
+package oracle
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/neofs"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"go.uber.org/zap"
+)
+
+// Optional URL schemes that can be enabled in the configuration.
+const (
+	dataScheme = "data"
+	ipfsScheme = "ipfs"
+	wssScheme  = "wss"
+	neoScheme  = "neo"
+)
+
+type (
+	// httpsHandler fetches data with HTTPS GET requests.
+	httpsHandler struct {
+		o *Oracle
+	}
+
+	// neofsHandler fetches NeoFS objects from the configured nodes.
+	neofsHandler struct {
+		o *Oracle
+	}
+
+	// dataHandler returns the data embedded into RFC 2397 data URL.
+	dataHandler struct {
+		allowedTypes []string
+	}
+
+	// ipfsHandler fetches IPFS content via the configured HTTP gateway.
+	ipfsHandler struct {
+		gateway      *url.URL
+		client       *http.Client
+		allowedTypes []string
+	}
+
+	// wssHandler reads a single WebSocket message. WebSocket messages have no
+	// media type, so AllowedContentTypes restriction is not applied to them
+	// (the same way as for NeoFS objects).
+	wssHandler struct {
+		dialer websocket.Dialer
+	}
+
+	// neoHandler invokes a contract method via the configured NeoGo RPC node.
+	neoHandler struct {
+		endpoint string
+	}
+)
+
+// statusToCode converts HTTP response status to oracle response code.
+func statusToCode(status int) transaction.OracleResponseCode {
+	switch status {
+	case http.StatusOK:
+		return transaction.Success
+	case http.StatusForbidden:
+		return transaction.Forbidden
+	case http.StatusNotFound:
+		return transaction.NotFound
+	case http.StatusRequestTimeout:
+		return transaction.Timeout
+	default:
+		return transaction.Error
+	}
+}
+
+// httpGet performs HTTP GET request and reads its response of allowed media
+// type.
+func (o *Oracle) httpGet(ctx context.Context, client HTTPClient, u string, maxSize int) ([]byte, string, transaction.OracleResponseCode) {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		o.Log.Warn("failed to create http request", zap.String("url", u), zap.Error(err))
+		return nil, "", transaction.Error
+	}
+	httpReq.Header.Set("User-Agent", "NeoOracleService/3.0")
+	httpReq.Header.Set("Content-Type", "application/json")
+	r, err := client.Do(httpReq)
+	if err != nil {
+		code := transaction.Error
+		if errors.Is(err, ErrRestrictedRedirect) {
+			code = transaction.Forbidden
+		}
+		o.Log.Warn("oracle request failed", zap.String("url", u), zap.Error(err), zap.Stringer("code", code))
+		return nil, "", code
+	}
+	defer r.Body.Close()
+	if code := statusToCode(r.StatusCode); code != transaction.Success {
+		return nil, "", code
+	}
+	contentType := r.Header.Get("Content-Type")
+	if !checkMediaType(contentType, o.MainCfg.AllowedContentTypes) {
+		return nil, "", transaction.ContentTypeNotSupported
+	}
+	data, code := o.readResponse(r.Body, u, maxSize)
+	return data, contentType, code
+}
+
+// Fetch implements the SchemeHandler interface.
+func (h httpsHandler) Fetch(ctx context.Context, req FetchRequest) ([]byte, string, transaction.OracleResponseCode) {
+	return h.o.httpGet(ctx, h.o.Client, req.URL.String(), req.MaxSize)
+}
+
+// Fetch implements the SchemeHandler interface.
+func (h neofsHandler) Fetch(ctx context.Context, req FetchRequest) ([]byte, string, transaction.OracleResponseCode) {
+	var (
+		o = h.o
+		u = req.URL.String()
+	)
+	if len(o.MainCfg.NeoFS.Nodes) == 0 {
+		o.Log.Warn("no NeoFS nodes configured", zap.String("url", u))
+		return nil, "", transaction.Error
+	}
+	index := (int(req.ID) + req.Attempt) % len(o.MainCfg.NeoFS.Nodes)
+	rc, err := neofs.Get(ctx, req.Key, req.URL, o.MainCfg.NeoFS.Nodes[index])
+	if err != nil {
+		o.Log.Warn("failed to perform oracle request", zap.String("url", u), zap.Error(err))
+		if rc != nil {
+			rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
+		}
+		return nil, "", transaction.Error
+	}
+	data, code := o.readResponse(rc, u, req.MaxSize)
+	rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
+	return data, "", code
+}
+
+// Fetch implements the SchemeHandler interface.
+func (h dataHandler) Fetch(_ context.Context, req FetchRequest) ([]byte, string, transaction.OracleResponseCode) {
+	data, contentType, err := parseDataURL(req.URL)
+	if err != nil {
+		return nil, "", transaction.Error
+	}
+	if !checkMediaType(contentType, h.allowedTypes) {
+		return nil, "", transaction.ContentTypeNotSupported
+	}
+	if len(data) > req.MaxSize {
+		return nil, "", transaction.ResponseTooLarge
+	}
+	return data, contentType, transaction.Success
+}
+
+// parseDataURL returns the data and media type of RFC 2397 data URL.
+func parseDataURL(u *url.URL) ([]byte, string, error) {
+	raw := u.Opaque
+	if u.ForceQuery || u.RawQuery != "" {
+		raw += "?" + u.RawQuery
+	}
+	meta, payload, ok := strings.Cut(raw, ",")
+	if !ok {
+		return nil, "", errors.New("missing data separator")
+	}
+	meta, isBase64 := strings.CutSuffix(meta, ";base64")
+	if meta == "" || strings.HasPrefix(meta, ";") {
+		meta = "text/plain" + meta
+		if !strings.Contains(meta, "charset=") {
+			meta += ";charset=US-ASCII"
+		}
+	}
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, "", err
+	}
+	if !isBase64 {
+		return []byte(data), meta, nil
+	}
+	res, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, "", err
+	}
+	return res, meta, nil
+}
+
+func newIPFSHandler(mainCfg config.OracleConfiguration, cfg config.OracleSchemeConfiguration) (*ipfsHandler, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("ipfs: gateway endpoint is not set")
+	}
+	gw, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("ipfs: invalid gateway endpoint: %w", err)
+	}
+	port := gw.Port()
+	if port == "" {
+		port = "80"
+		if gw.Scheme == "https" {
+			port = "443"
+		}
+	}
+	var (
+		gwAddr     = net.JoinHostPort(gw.Hostname(), port)
+		trusted    net.Dialer
+		restricted = newDialer(mainCfg)
+	)
+	return &ipfsHandler{
+		gateway: gw,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DisableKeepAlives: true,
+				// The gateway is trusted and usually is a local one, but
+				// it can't redirect to other private hosts.
+				DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					if addr == gwAddr {
+						return trusted.DialContext(ctx, network, addr)
+					}
+					return restricted.DialContext(ctx, network, addr)
+				},
+			},
+			// AllowedHosts of ipfs scheme restrict CIDs rather than gateway
+			// hosts, so they're not applied to redirections.
+			CheckRedirect: checkRedirect(&scheme{}),
+		},
+		allowedTypes: mainCfg.AllowedContentTypes,
+	}, nil
+}
+
+// Fetch implements the SchemeHandler interface. It gets
+// "ipfs://<CID>/<path>" from "<gateway>/ipfs/<CID>/<path>".
+func (h *ipfsHandler) Fetch(ctx context.Context, req FetchRequest) ([]byte, string, transaction.OracleResponseCode) {
+	if req.URL.Host == "" {
+		return nil, "", transaction.Error
+	}
+	u := h.gateway.JoinPath("ipfs", req.URL.Host, req.URL.Path)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, "", transaction.Error
+	}
+	r, err := h.client.Do(httpReq)
+	if err != nil {
+		return nil, "", errorToCode(err)
+	}
+	defer r.Body.Close()
+	if code := statusToCode(r.StatusCode); code != transaction.Success {
+		return nil, "", code
+	}
+	contentType := r.Header.Get("Content-Type")
+	if !checkMediaType(contentType, h.allowedTypes) {
+		return nil, "", transaction.ContentTypeNotSupported
+	}
+	data, err := readLimited(r.Body, req.MaxSize)
+	if err != nil {
+		return nil, "", errorToCode(err)
+	}
+	return data, contentType, transaction.Success
+}
+
+func newWSSHandler(cfg config.OracleConfiguration) *wssHandler {
+	return &wssHandler{
+		dialer: websocket.Dialer{NetDialContext: newDialer(cfg).DialContext},
+	}
+}
+
+// Fetch implements the SchemeHandler interface.
+func (h *wssHandler) Fetch(ctx context.Context, req FetchRequest) ([]byte, string, transaction.OracleResponseCode) {
+	conn, resp, err := h.dialer.DialContext(ctx, req.URL.String(), nil)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusSwitchingProtocols {
+			return nil, "", statusToCode(resp.StatusCode)
+		}
+		return nil, "", errorToCode(err)
+	}
+	defer conn.Close()
+	conn.SetReadLimit(int64(req.MaxSize))
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetReadDeadline(deadline)
+	}
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		if errors.Is(err, websocket.ErrReadLimit) {
+			return nil, "", transaction.ResponseTooLarge
+		}
+		var netErr interface{ Timeout() bool }
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, "", transaction.Timeout
+		}
+		return nil, "", transaction.Error
+	}
+	return data, "", transaction.Success
+}
+
+func newNeoHandler(cfg config.OracleSchemeConfiguration) (*neoHandler, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("neo: RPC endpoint is not set")
+	}
+	return &neoHandler{endpoint: cfg.Endpoint}, nil
+}
+
+// Fetch implements the SchemeHandler interface. It invokes
+// "neo://<contract>/<method>?arg=<param>&height=<height>" and returns the
+// resulting stack as a JSON array of typed stack items.
+func (h *neoHandler) Fetch(ctx context.Context, req FetchRequest) ([]byte, string, transaction.OracleResponseCode) {
+	contract, method, params, height, err := parseNeoURL(req.URL)
+	if err != nil {
+		return nil, "", transaction.Error
+	}
+	var opts rpcclient.Options
+	if deadline, ok := ctx.Deadline(); ok {
+		opts.RequestTimeout = time.Until(deadline)
+	}
+	c, err := rpcclient.New(ctx, h.endpoint, opts)
+	if err != nil {
+		return nil, "", transaction.Error
+	}
+	defer c.Close()
+	res, err := c.InvokeFunctionAtHeight(height, contract, method, params, nil)
+	if err != nil {
+		return nil, "", errorToCode(err)
+	}
+	if res.State != "HALT" {
+		return nil, "", transaction.Error
+	}
+	stack := make([]json.RawMessage, 0, len(res.Stack))
+	for _, item := range res.Stack {
+		data, err := stackitem.ToJSONWithTypes(item)
+		if err != nil {
+			return nil, "", transaction.Error
+		}
+		stack = append(stack, data)
+	}
+	data, err := json.Marshal(stack)
+	if err != nil {
+		return nil, "", transaction.Error
+	}
+	if len(data) > req.MaxSize {
+		return nil, "", transaction.ResponseTooLarge
+	}
+	return data, "application/json", transaction.Success
+}
+
+func parseNeoURL(u *url.URL) (util.Uint160, string, []smartcontract.Parameter, uint32, error) {
+	contract, err := util.Uint160DecodeStringLE(strings.TrimPrefix(u.Host, "0x"))
+	if err != nil {
+		return util.Uint160{}, "", nil, 0, fmt.Errorf("invalid contract hash: %w", err)
+	}
+	method := strings.TrimPrefix(u.Path, "/")
+	if method == "" || strings.Contains(method, "/") {
+		return util.Uint160{}, "", nil, 0, fmt.Errorf("invalid method %q", method)
+	}
+	q := u.Query()
+	params := make([]smartcontract.Parameter, 0, len(q["arg"]))
+	for _, arg := range q["arg"] {
+		p, err := smartcontract.NewParameterFromString(arg)
+		if err != nil {
+			return util.Uint160{}, "", nil, 0, fmt.Errorf("invalid argument %q: %w", arg, err)
+		}
+		params = append(params, *p)
+	}
+	// Height is mandatory, otherwise oracle nodes can get different results
+	// depending on their RPC node state.
+	if !q.Has("height") {
+		return util.Uint160{}, "", nil, 0, errors.New("missing height")
+	}
+	height, err := strconv.ParseUint(q.Get("height"), 10, 32)
+	if err != nil {
+		return util.Uint160{}, "", nil, 0, fmt.Errorf("invalid height: %w", err)
+	}
+	return contract, method, params, uint32(height), nil
+}
@@ -0,0 +1,233 @@
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func fetchRequest(t *testing.T, rawURL string, maxSize int) FetchRequest {
+	u, err := url.ParseRequestURI(rawURL)
+	require.NoError(t, err)
+	return FetchRequest{URL: u, MaxSize: maxSize}
+}
+
+func TestParseDataURL(t *testing.T) {
+	testCases := []struct {
+		url, data, contentType string
+	}{
+		{"data:,A%20brief%20note", "A brief note", "text/plain;charset=US-ASCII"},
+		{"data:;charset=utf-8,%D1%8B", "ы", "text/plain;charset=utf-8"},
+		{"data:application/json;base64,eyJhIjogMX0=", `{"a": 1}`, "application/json"},
+		{"data:text/plain,what?not", "what?not", "text/plain"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			data, ct, err := parseDataURL(fetchRequest(t, tc.url, 0).URL)
+			require.NoError(t, err)
+			require.Equal(t, tc.data, string(data))
+			require.Equal(t, tc.contentType, ct)
+		})
+	}
+	for _, u := range []string{"data:text/plain", "data:;base64,!!!", "data:,%zz"} {
+		t.Run(u, func(t *testing.T) {
+			_, _, err := parseDataURL(fetchRequest(t, u, 0).URL)
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("too large", func(t *testing.T) {
+		_, _, code := dataHandler{}.Fetch(context.Background(), fetchRequest(t, "data:,12345", 4))
+		require.Equal(t, transaction.ResponseTooLarge, code)
+	})
+	t.Run("content type", func(t *testing.T) {
+		h := dataHandler{allowedTypes: []string{"application/json"}}
+		_, _, code := h.Fetch(context.Background(), fetchRequest(t, "data:,12345", 10))
+		require.Equal(t, transaction.ContentTypeNotSupported, code)
+		data, _, code := h.Fetch(context.Background(), fetchRequest(t, "data:application/json,{}", 10))
+		require.Equal(t, transaction.Success, code)
+		require.Equal(t, "{}", string(data))
+	})
+}
+
+func TestParseNeoURL(t *testing.T) {
+	h := util.Uint160{1, 2, 3}
+	contract, method, params, height, err := parseNeoURL(fetchRequest(t,
+		"neo://0x"+h.StringLE()+"/balanceOf?arg=int:5&arg=string:abc&height=10", 0).URL)
+	require.NoError(t, err)
+	require.Equal(t, h, contract)
+	require.Equal(t, "balanceOf", method)
+	require.Equal(t, []smartcontract.Parameter{
+		{Type: smartcontract.IntegerType, Value: big.NewInt(5)},
+		{Type: smartcontract.StringType, Value: "abc"},
+	}, params)
+	require.Equal(t, uint32(10), height)
+
+	for _, u := range []string{
+		"neo://abc/method",
+		"neo://" + h.StringLE() + "/",
+		"neo://" + h.StringLE() + "/a/b",
+		"neo://" + h.StringLE() + "/method?arg=unknown:1",
+		"neo://" + h.StringLE() + "/method?height=-1",
+		"neo://" + h.StringLE() + "/method?height=",
+		"neo://" + h.StringLE() + "/method?arg=int:1",
+	} {
+		t.Run(u, func(t *testing.T) {
+			_, _, _, _, err := parseNeoURL(fetchRequest(t, u, 0).URL)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestSchemeAllowedHosts(t *testing.T) {
+	s := &scheme{allowedHosts: []string{"example.com", "*.neo.org"}}
+	for u, allowed := range map[string]bool{
+		"https://example.com/path":      true,
+		"https://EXAMPLE.com:443/path":  true,
+		"https://sub.example.com/path":  false,
+		"https://api.neo.org/path":      true,
+		"https://neo.org/path":          false,
+		"https://evilneo.org/path":      false,
+		"https://api.neo.org.evil/path": false,
+	} {
+		require.Equal(t, allowed, s.isAllowed(fetchRequest(t, u, 0).URL), u)
+	}
+	require.True(t, (&scheme{}).isAllowed(fetchRequest(t, "https://any.host", 0).URL))
+}
+
+func TestInitSchemes(t *testing.T) {
+	newOracle := func(schemes map[string]config.OracleSchemeConfiguration) *Oracle {
+		return &Oracle{Config: Config{
+			Log: zaptest.NewLogger(t),
+			MainCfg: config.OracleConfiguration{
+				RequestTimeout: time.Second,
+				Schemes:        schemes,
+			},
+		}}
+	}
+
+	o := newOracle(map[string]config.OracleSchemeConfiguration{
+		"https":   {MaxResponseSize: 10, AllowedHosts: []string{"example.com"}},
+		"data":    {Enabled: true, Timeout: time.Minute, MaxResponseSize: 2 * transaction.MaxOracleResultSize},
+		"ipfs":    {Enabled: false},
+		"unknown": {Enabled: false},
+	})
+	require.NoError(t, o.initSchemes())
+	require.Len(t, o.schemes, 3)
+	require.Equal(t, 10, o.schemes["https"].maxSize)
+	require.Equal(t, time.Second, o.schemes["https"].timeout)
+	require.Equal(t, transaction.MaxOracleResultSize, o.schemes["data"].maxSize)
+	require.Equal(t, time.Minute, o.schemes["data"].timeout)
+
+	for name, cfg := range map[string]config.OracleSchemeConfiguration{
+		"unknown": {Enabled: true},
+		"ipfs":    {Enabled: true},
+		"neo":     {Enabled: true},
+		"https":   {MaxResponseSize: -1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			o := newOracle(map[string]config.OracleSchemeConfiguration{name: cfg})
+			require.Error(t, o.initSchemes())
+		})
+	}
+}
+
+func TestIPFSHandler(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ipfs/QmCID/dir/file.json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"a":1}`))
+		case "/ipfs/QmCID/big":
+			_, _ = w.Write([]byte(strings.Repeat("a", 11)))
+		case "/ipfs/QmCID/text":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("text"))
+		case "/ipfs/QmCID/redirect":
+			// Gateway itself is trusted, but redirections are not.
+			http.Redirect(w, r, strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)+"/ipfs/QmCID/dir/file.json", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	h, err := newIPFSHandler(config.OracleConfiguration{
+		AllowedContentTypes: []string{"application/json"},
+	}, config.OracleSchemeConfiguration{Endpoint: srv.URL})
+	require.NoError(t, err)
+
+	data, ct, code := h.Fetch(context.Background(), fetchRequest(t, "ipfs://QmCID/dir/file.json", 10))
+	require.Equal(t, transaction.Success, code)
+	require.Equal(t, `{"a":1}`, string(data))
+	require.Equal(t, "application/json", ct)
+
+	_, _, code = h.Fetch(context.Background(), fetchRequest(t, "ipfs://QmCID/text", 10))
+	require.Equal(t, transaction.ContentTypeNotSupported, code)
+
+	_, _, code = h.Fetch(context.Background(), fetchRequest(t, "ipfs://QmCID/redirect", 10))
+	require.Equal(t, transaction.Forbidden, code)
+
+	h.allowedTypes = nil
+	_, _, code = h.Fetch(context.Background(), fetchRequest(t, "ipfs://QmCID/big", 10))
+	require.Equal(t, transaction.ResponseTooLarge, code)
+
+	_, _, code = h.Fetch(context.Background(), fetchRequest(t, "ipfs://QmCID/unknown", 10))
+	require.Equal(t, transaction.NotFound, code)
+}
+
+func TestWSSHandler(t *testing.T) {
+	var upgrader websocket.Upgrader
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if r.URL.Path == "/silent" {
+			_, _, _ = conn.ReadMessage()
+			return
+		}
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(strings.TrimPrefix(r.URL.Path, "/")))
+	}))
+	t.Cleanup(srv.Close)
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	h := newWSSHandler(config.OracleConfiguration{AllowPrivateHost: true})
+	data, _, code := h.Fetch(context.Background(), fetchRequest(t, wsURL+"/hello", 10))
+	require.Equal(t, transaction.Success, code)
+	require.Equal(t, "hello", string(data))
+
+	_, _, code = h.Fetch(context.Background(), fetchRequest(t, wsURL+"/hello", 4))
+	require.Equal(t, transaction.ResponseTooLarge, code)
+
+	_, _, code = h.Fetch(context.Background(), fetchRequest(t, wsURL+"/forbidden", 10))
+	require.Equal(t, transaction.Forbidden, code)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, _, code = h.Fetch(ctx, fetchRequest(t, wsURL+"/silent", 10))
+	require.Equal(t, transaction.Timeout, code)
+
+	h = newWSSHandler(config.OracleConfiguration{})
+	_, _, code = h.Fetch(context.Background(), fetchRequest(t, wsURL+"/hello", 10))
+	require.Equal(t, transaction.Forbidden, code)
+}
@@ -0,0 +1,501 @@
+/*
+Package jmespath implements a deterministic subset of JMESPath used to filter
+JSON oracle responses.
+
+Supported expressions are:
+  - `name` and `"quoted name"` select object fields;
+  - `a.b` selects a field of the result of the left expression;
+  - `[n]` selects an array element, negative indices count from the end;
+  - `[*]` and `.*` project the rest of the expression onto every array element
+    or object value, null results are dropped;
+  - `@` is the current value;
+  - `a | b` evaluates b on the result of a and stops projections;
+  - `length(x)`, `keys(x)`, `sort(x)`, `sum(x)`, `min(x)` and `max(x)`
+    functions.
+
+Contrary to JSONPath the result is a single JSON value. Numbers are handled
+as exact decimals, so `sum` doesn't lose precision.
+*/
+package jmespath
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	json "github.com/nspcc-dev/go-ordered-json"
+)
+
+const (
+	// maxNestingDepth is the maximum nesting depth of the expression.
+	maxNestingDepth = 16
+	// maxExponent is the maximum absolute exponent of numbers used in
+	// arithmetic functions.
+	maxExponent = 64
+)
+
+type (
+	node interface {
+		eval(v any) (any, error)
+	}
+
+	current    struct{}
+	field      struct{ name string }
+	index      struct{ i int }
+	subexpr    struct{ left, right node }
+	pipe       struct{ left, right node }
+	projection struct {
+		left, right node
+		// values is set for object value projections.
+		values bool
+	}
+	function struct {
+		name string
+		arg  node
+	}
+
+	parser struct {
+		s     string
+		depth int
+	}
+)
+
+var functions = map[string]func(v any) (any, error){
+	"length": length,
+	"keys":   keys,
+	"sort":   sortValues,
+	"sum":    sum,
+	"min":    func(v any) (any, error) { return extremum(v, -1) },
+	"max":    func(v any) (any, error) { return extremum(v, 1) },
+}
+
+// Get evaluates the expression on the value decoded with ordered objects and
+// json.Number numbers.
+func Get(expr string, v any) (any, error) {
+	n, err := parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression: %w", err)
+	}
+	return n.eval(v)
+}
+
+func (current) eval(v any) (any, error) { return v, nil }
+
+func (f field) eval(v any) (any, error) {
+	obj, ok := v.(json.OrderedObject)
+	if !ok {
+		return nil, nil
+	}
+	for i := range obj {
+		if obj[i].Key == f.name {
+			return obj[i].Value, nil
+		}
+	}
+	return nil, nil
+}
+
+func (x index) eval(v any) (any, error) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, nil
+	}
+	i := x.i
+	if i < 0 {
+		i += len(arr)
+	}
+	if i < 0 || i >= len(arr) {
+		return nil, nil
+	}
+	return arr[i], nil
+}
+
+func (s subexpr) eval(v any) (any, error) {
+	l, err := s.left.eval(v)
+	if err != nil || l == nil {
+		return nil, err
+	}
+	return s.right.eval(l)
+}
+
+func (p pipe) eval(v any) (any, error) {
+	l, err := p.left.eval(v)
+	if err != nil {
+		return nil, err
+	}
+	return p.right.eval(l)
+}
+
+func (p projection) eval(v any) (any, error) {
+	l, err := p.left.eval(v)
+	if err != nil {
+		return nil, err
+	}
+	var elems []any
+	if p.values {
+		obj, ok := l.(json.OrderedObject)
+		if !ok {
+			return nil, nil
+		}
+		for i := range obj {
+			elems = append(elems, obj[i].Value)
+		}
+	} else {
+		arr, ok := l.([]any)
+		if !ok {
+			return nil, nil
+		}
+		elems = arr
+	}
+	res := make([]any, 0, len(elems))
+	for _, e := range elems {
+		r, err := p.right.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+func (f function) eval(v any) (any, error) {
+	arg, err := f.arg.eval(v)
+	if err != nil {
+		return nil, err
+	}
+	res, err := functions[f.name](arg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.name, err)
+	}
+	return res, nil
+}
+
+func length(v any) (any, error) {
+	switch t := v.(type) {
+	case string:
+		return json.Number(strconv.Itoa(utf8.RuneCountInString(t))), nil
+	case []any:
+		return json.Number(strconv.Itoa(len(t))), nil
+	case json.OrderedObject:
+		return json.Number(strconv.Itoa(len(t))), nil
+	}
+	return nil, errors.New("string, array or object expected")
+}
+
+func keys(v any) (any, error) {
+	obj, ok := v.(json.OrderedObject)
+	if !ok {
+		return nil, errors.New("object expected")
+	}
+	res := make([]any, 0, len(obj))
+	for i := range obj {
+		res = append(res, obj[i].Key)
+	}
+	return res, nil
+}
+
+func sortValues(v any) (any, error) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, errors.New("array expected")
+	}
+	res := slices.Clone(arr)
+	var err error
+	slices.SortStableFunc(res, func(a, b any) int {
+		c, cmpErr := compare(a, b)
+		if cmpErr != nil {
+			err = cmpErr
+		}
+		return c
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func sum(v any) (any, error) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, errors.New("array expected")
+	}
+	var s = new(big.Rat)
+	for _, e := range arr {
+		r, err := toRat(e)
+		if err != nil {
+			return nil, err
+		}
+		s.Add(s, r)
+	}
+	return ratToNumber(s), nil
+}
+
+// extremum returns the minimum (sign < 0) or maximum (sign > 0) element of
+// the array.
+func extremum(v any, sign int) (any, error) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, errors.New("array expected")
+	}
+	var res any
+	for i, e := range arr {
+		if i == 0 {
+			if _, err := compare(e, e); err != nil {
+				return nil, err
+			}
+			res = e
+			continue
+		}
+		c, err := compare(e, res)
+		if err != nil {
+			return nil, err
+		}
+		if c*sign > 0 {
+			res = e
+		}
+	}
+	return res, nil
+}
+
+// compare compares two numbers or two strings.
+func compare(a, b any) (int, error) {
+	if sa, ok := a.(string); ok {
+		sb, ok := b.(string)
+		if !ok {
+			return 0, errors.New("values of different types")
+		}
+		return strings.Compare(sa, sb), nil
+	}
+	ra, err := toRat(a)
+	if err != nil {
+		return 0, err
+	}
+	rb, err := toRat(b)
+	if err != nil {
+		return 0, err
+	}
+	return ra.Cmp(rb), nil
+}
+
+func toRat(v any) (*big.Rat, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return nil, errors.New("number expected")
+	}
+	s := string(n)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil || exp > maxExponent || exp < -maxExponent {
+			return nil, fmt.Errorf("number %s is out of range", s)
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %s", s)
+	}
+	return r, nil
+}
+
+// ratToNumber converts the finite decimal fraction to its shortest
+// representation.
+func ratToNumber(r *big.Rat) json.Number {
+	if r.IsInt() {
+		return json.Number(r.Num().String())
+	}
+	// Denominator is a product of powers of 2 and 5, so the number of
+	// fractional digits is the maximum of the powers.
+	var (
+		d    = new(big.Int).Set(r.Denom())
+		prec int
+		rem  = new(big.Int)
+	)
+	for _, p := range []int64{2, 5} {
+		var n int
+		bp := big.NewInt(p)
+		for {
+			q, m := new(big.Int).QuoRem(d, bp, rem)
+			if m.Sign() != 0 {
+				break
+			}
+			d = q
+			n++
+		}
+		prec = max(prec, n)
+	}
+	return json.Number(r.FloatString(prec))
+}
+
+func parse(expr string) (node, error) {
+	p := &parser{s: expr}
+	n, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.s != "" {
+		return nil, fmt.Errorf("unexpected %q", p.s)
+	}
+	return n, nil
+}
+
+func (p *parser) skipSpaces() {
+	p.s = strings.TrimLeft(p.s, " \t\n\r")
+}
+
+func (p *parser) consume(prefix string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.s, prefix) {
+		p.s = p.s[len(prefix):]
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseExpr() (node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxNestingDepth {
+		return nil, errors.New("expression is too deep")
+	}
+	left, err := p.parseChain()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("|") {
+		right, err := p.parseChain()
+		if err != nil {
+			return nil, err
+		}
+		left = pipe{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseChain() (node, error) {
+	var cur node
+	p.skipSpaces()
+	switch {
+	case p.consume("@"):
+		cur = current{}
+	case strings.HasPrefix(p.s, "["):
+		cur = current{}
+	case strings.HasPrefix(p.s, "*"):
+		p.s = p.s[1:]
+		rest, err := p.parseRest(current{})
+		if err != nil {
+			return nil, err
+		}
+		return projection{left: current{}, right: rest, values: true}, nil
+	default:
+		name, err := p.parseIdentifier()
+		if err != nil {
+			return nil, err
+		}
+		if p.consume("(") {
+			cur, err = p.parseFunction(name)
+		} else {
+			cur = field{name: name}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p.parseRest(cur)
+}
+
+// parseRest parses field and index accesses following cur up to the end of
+// the expression, pipe, closing parenthesis or bracket.
+func (p *parser) parseRest(cur node) (node, error) {
+	for {
+		switch {
+		case p.consume("."):
+			if p.consume("*") {
+				rest, err := p.parseRest(current{})
+				if err != nil {
+					return nil, err
+				}
+				return projection{left: cur, right: rest, values: true}, nil
+			}
+			name, err := p.parseIdentifier()
+			if err != nil {
+				return nil, err
+			}
+			cur = subexpr{left: cur, right: field{name: name}}
+		case p.consume("["):
+			if p.consume("*") {
+				if !p.consume("]") {
+					return nil, errors.New("] expected")
+				}
+				rest, err := p.parseRest(current{})
+				if err != nil {
+					return nil, err
+				}
+				return projection{left: cur, right: rest}, nil
+			}
+			p.skipSpaces()
+			end := strings.IndexByte(p.s, ']')
+			if end < 0 {
+				return nil, errors.New("] expected")
+			}
+			i, err := strconv.ParseInt(strings.TrimSpace(p.s[:end]), 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %q", p.s[:end])
+			}
+			p.s = p.s[end+1:]
+			cur = subexpr{left: cur, right: index{i: int(i)}}
+		default:
+			return cur, nil
+		}
+	}
+}
+
+func (p *parser) parseFunction(name string) (node, error) {
+	if _, ok := functions[name]; !ok {
+		return nil, fmt.Errorf("unknown function %q", name)
+	}
+	arg, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(")") {
+		return nil, errors.New(") expected")
+	}
+	return function{name: name, arg: arg}, nil
+}
+
+func (p *parser) parseIdentifier() (string, error) {
+	p.skipSpaces()
+	if strings.HasPrefix(p.s, `"`) {
+		for i := 1; i < len(p.s); i++ {
+			switch p.s[i] {
+			case '\\':
+				i++
+			case '"':
+				name, err := strconv.Unquote(p.s[:i+1])
+				if err != nil {
+					return "", fmt.Errorf("invalid identifier %s", p.s[:i+1])
+				}
+				p.s = p.s[i+1:]
+				return name, nil
+			}
+		}
+		return "", errors.New("unterminated identifier")
+	}
+	end := strings.IndexFunc(p.s, func(r rune) bool {
+		return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	if end < 0 {
+		end = len(p.s)
+	}
+	if end == 0 || p.s[0] >= '0' && p.s[0] <= '9' {
+		return "", fmt.Errorf("identifier expected at %q", p.s)
+	}
+	name := p.s[:end]
+	p.s = p.s[end:]
+	return name, nil
+}
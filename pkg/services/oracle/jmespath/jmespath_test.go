@@ -0,0 +1,117 @@
+package jmespath
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	json "github.com/nspcc-dev/go-ordered-json"
+	"github.com/stretchr/testify/require"
+)
+
+const doc = `{
+	"Stores": ["Lambton Quay", "Willis Street"],
+	"Manufacturers": [
+		{
+			"Name": "Acme Co",
+			"Products": [{"Name": "Anvil", "Price": 50}]
+		},
+		{
+			"Name": "Contoso",
+			"Products": [
+				{"Name": "Elbow Grease", "Price": 99.95},
+				{"Name": "Headlight Fluid", "Price": 4, "On sale": true}
+			]
+		}
+	],
+	"Rates": {"NEO": 0.1, "GAS": 0.2}
+}`
+
+func decode(t *testing.T, js string) any {
+	var v any
+	d := json.NewDecoder(bytes.NewBufferString(js))
+	d.UseOrderedObject()
+	d.UseNumber()
+	require.NoError(t, d.Decode(&v))
+	return v
+}
+
+func TestGet(t *testing.T) {
+	v := decode(t, doc)
+	testCases := []struct {
+		expr, result string
+	}{
+		{"Stores", `["Lambton Quay","Willis Street"]`},
+		{"Stores[-1]", `"Willis Street"`},
+		{"Stores[5]", `null`},
+		{"Unknown.Field", `null`},
+		{"Manufacturers[0].Name", `"Acme Co"`},
+		{"Manufacturers[*].Name", `["Acme Co","Contoso"]`},
+		{"Manufacturers[*].Products[*].Price", `[[50],[99.95,4]]`},
+		{`Manufacturers[1].Products[*]."On sale"`, `[true]`},
+		{"Rates.*", `[0.1,0.2]`},
+		{"*.NEO", `[0.1]`},
+		{"Manufacturers[*].Name | [0]", `"Acme Co"`},
+		{"Manufacturers[1].Products[*].Price | sum(@)", `103.95`},
+		{"sum(Rates.*)", `0.3`},
+		{"sum(Manufacturers[0].Products[*].Price)", `50`},
+		{"min(Manufacturers[1].Products[*].Price)", `4`},
+		{"max(Manufacturers[1].Products[*].Price)", `99.95`},
+		{"max(Stores)", `"Willis Street"`},
+		{"length(Manufacturers)", `2`},
+		{"length(Stores[0])", `12`},
+		{"keys(Rates)", `["NEO","GAS"]`},
+		{"sort(keys(Rates))", `["GAS","NEO"]`},
+		{"sort(Manufacturers[1].Products[*].Price)", `[4,99.95]`},
+		{"min(Manufacturers[0].Products[*].Unknown)", `null`},
+		{"@.Rates.GAS", `0.2`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			res, err := Get(tc.expr, v)
+			require.NoError(t, err)
+			data, err := json.Marshal(res)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, string(data))
+		})
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	v := decode(t, doc)
+	exprs := []string{
+		"",
+		"$.Stores",
+		"Stores[",
+		"Stores[a]",
+		"Stores[*",
+		"Stores.",
+		"Stores..Name",
+		"1Stores",
+		`"Stores`,
+		"unknown(Stores)",
+		"length(Stores",
+		"Stores Rates",
+		"length(Manufacturers[0].Products[0].Price)",
+		"keys(Stores)",
+		"sum(Stores)",
+		"min(Rates)",
+		"sort(Manufacturers)",
+		"min(Unknown[*])",
+		strings.Repeat("length(", maxNestingDepth) + "Stores" + strings.Repeat(")", maxNestingDepth),
+	}
+	for _, e := range exprs {
+		t.Run(e, func(t *testing.T) {
+			_, err := Get(e, v)
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("big exponent", func(t *testing.T) {
+		_, err := Get("sum(@)", decode(t, `[1e100000000]`))
+		require.Error(t, err)
+		res, err := Get("sum(@)", decode(t, `[1e2, 1E-2]`))
+		require.NoError(t, err)
+		require.Equal(t, json.Number("100.01"), res)
+	})
+}
@@ -45,7 +45,9 @@ func isReserved(ip net.IP) bool {
 	})
 }
 
-func getDefaultClient(cfg config.OracleConfiguration) *http.Client {
+// newDialer returns a dialer that rejects connections to private networks
+// unless they are allowed by the configuration.
+func newDialer(cfg config.OracleConfiguration) *net.Dialer {
 	d := &net.Dialer{}
 	if !cfg.AllowPrivateHost {
 		// Control is used after request URI is resolved and network connection (network
@@ -75,6 +77,11 @@ func getDefaultClient(cfg config.OracleConfiguration) *http.Client {
 			return nil
 		}
 	}
+	return d
+}
+
+func getDefaultClient(cfg config.OracleConfiguration) *http.Client {
+	d := newDialer(cfg)
 	var client http.Client
 	client.Transport = &http.Transport{
 		DisableKeepAlives: true,
@@ -84,7 +91,15 @@ func getDefaultClient(cfg config.OracleConfiguration) *http.Client {
 		DialContext: d.DialContext,
 	}
 	client.Timeout = cfg.RequestTimeout
-	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+	client.CheckRedirect = checkRedirect(&scheme{allowedHosts: cfg.Schemes["https"].AllowedHosts})
+	return &client
+}
+
+// checkRedirect returns http.Client redirection policy that limits the number
+// of redirections, forbids redirections from HTTPS to HTTP and checks every
+// redirection URL against the allowlist of the given scheme.
+func checkRedirect(s *scheme) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
 		if len(via) > maxRedirections { // from https://github.com/neo-project/neo-modules/pull/698
 			return fmt.Errorf("%w: %d redirections are reached", ErrRestrictedRedirect, maxRedirections)
 		}
@@ -92,7 +107,9 @@ func getDefaultClient(cfg config.OracleConfiguration) *http.Client {
 			lastHop := via[len(via)-1].URL
 			return fmt.Errorf("%w: redirected from secure URL %s to insecure URL %s", ErrRestrictedRedirect, lastHop, req.URL)
 		}
+		if !s.isAllowed(req.URL) {
+			return fmt.Errorf("%w: redirected to URL %s with host that is not allowed", ErrRestrictedRedirect, req.URL)
+		}
 		return nil
 	}
-	return &client
 }
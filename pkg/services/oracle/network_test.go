@@ -2,11 +2,14 @@ package oracle
 
 import (
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/stretchr/testify/require"
 )
 
@@ -45,3 +48,34 @@ func TestDefaultClient_RestrictedRedirectErr(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultClient_RedirectAllowedHosts(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/allowed":
+			http.Redirect(w, r, srv.URL+"/data", http.StatusFound)
+		case "/restricted":
+			http.Redirect(w, r, strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)+"/data", http.StatusFound)
+		default:
+			_, _ = w.Write([]byte("data"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cl := getDefaultClient(config.OracleConfiguration{
+		AllowPrivateHost: true,
+		RequestTimeout:   time.Second,
+		Schemes: map[string]config.OracleSchemeConfiguration{
+			"https": {AllowedHosts: []string{"127.0.0.1"}},
+		},
+	})
+	resp, err := cl.Get(srv.URL + "/allowed")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = cl.Get(srv.URL + "/restricted") //nolint:bodyclose // It errors out and it's a test.
+	require.ErrorIs(t, err, ErrRestrictedRedirect)
+	require.Equal(t, transaction.Forbidden, errorToCode(err))
+}
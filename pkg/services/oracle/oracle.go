@@ -69,6 +69,9 @@ type (
 		// removed contains ids of requests which won't be processed further due to expiration.
 		removed map[uint64]bool
 
+		// schemes contains URL scheme handlers with their policies.
+		schemes map[string]*scheme
+
 		wallet *wallet.Wallet
 	}
 
@@ -81,6 +84,9 @@ type (
 		Chain           Ledger
 		ResponseHandler Broadcaster
 		OnTransaction   TxCallback
+		// SchemeHandlers contains custom URL scheme handlers indexed by
+		// scheme name, they take precedence over the built-in ones.
+		SchemeHandlers map[string]SchemeHandler
 	}
 
 	// HTTPClient is an interface capable of doing oracle requests.
@@ -148,7 +154,7 @@ func NewOracle(cfg Config) (*Oracle, error) {
 
 	var err error
 	w := cfg.MainCfg.UnlockWallet
-	if o.wallet, err = wallet.NewWalletFromFile(w.Path); err != nil {
+	if o.wallet, err = wallet.NewWalletFromFileWithPassword(w.Path, w.Password); err != nil {
 		return nil, err
 	}
 
@@ -168,6 +174,9 @@ func NewOracle(cfg Config) (*Oracle, error) {
 	if o.Client == nil {
 		o.Client = getDefaultClient(o.MainCfg)
 	}
+	if err := o.initSchemes(); err != nil {
+		return nil, err
+	}
 	return o, nil
 }
 
@@ -247,6 +256,7 @@ main:
 			for id := range o.removed {
 				delete(o.responses, id)
 			}
+			updatePendingRequestsMetric(len(o.responses))
 			o.respMtx.Unlock()
 
 			for _, id := range reprocess {
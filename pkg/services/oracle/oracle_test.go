@@ -2,6 +2,7 @@ package oracle_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
@@ -54,14 +55,20 @@ func getOracleConfig(t *testing.T, bc *core.Blockchain, w, pass string, returnOr
 		Network: netmode.UnitTestNet,
 		MainCfg: config.OracleConfiguration{
 			RefreshInterval:     time.Second,
-			AllowedContentTypes: []string{"application/json"},
+			AllowedContentTypes: []string{"application/json", "application/xml", "text/html", "text/csv"},
+			ExtendedFilters:     true,
 			UnlockWallet: config.Wallet{
 				Path:     w,
 				Password: pass,
 			},
+			Schemes: map[string]config.OracleSchemeConfiguration{
+				"data":   {Enabled: true},
+				"custom": {AllowedHosts: []string{"*.allowed.com"}},
+			},
 		},
-		Chain:  bc,
-		Client: newDefaultHTTPClient(returnOracleRedirectionErrOn),
+		Chain:          bc,
+		Client:         newDefaultHTTPClient(returnOracleRedirectionErrOn),
+		SchemeHandlers: map[string]oracle.SchemeHandler{"custom": customHandler{}},
 	}
 }
 
@@ -191,6 +198,27 @@ func TestOracle(t *testing.T) {
 
 	putOracleRequest(t, cInvoker, "https://get.invalidcontent", nil, "handle", []byte{}, 10_000_000)
 
+	for _, r := range []struct{ url, flt string }{
+		{"https://get.xml", "xpath:/prices/price[@symbol='GAS']"},
+		{"https://get.html", "xpath://td[@class='price']"},
+		{"https://get.csv", "csv:header cols=price rows=1"},
+		{"https://get.filter", "jmespath:Values[1]"},
+		{"https://get.xml", "jsonpath:$.prices"},
+		{"https://get.csvbig", "csv:cols=0,0"},
+	} {
+		putOracleRequest(t, cInvoker, r.url, &r.flt, "handle", []byte{}, 10_000_000)
+	}
+
+	for _, u := range []string{
+		"data:application/json;base64,eyJhIjogMX0=",
+		"data:text/csv,a%2Cb%0A1%2C2",
+		"custom://sub.allowed.com/path",
+		"custom://example.com/path",
+		"ipfs://QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o",
+	} {
+		putOracleRequest(t, cInvoker, u, nil, "handle", []byte{}, 10_000_000)
+	}
+
 	checkResp := func(t *testing.T, id uint64, resp *transaction.OracleResponse) *state.OracleRequest {
 		// Use a hack to get request from Oracle contract, because we can't use GetRequestInternal directly.
 		requestKey := make([]byte, 9)
@@ -337,6 +365,104 @@ func TestOracle(t *testing.T) {
 			Code: transaction.ContentTypeNotSupported,
 		})
 	})
+	t.Run("FilterXPathCSV", func(t *testing.T) {
+		checkResp(t, 12, &transaction.OracleResponse{
+			ID:     12,
+			Code:   transaction.Success,
+			Result: []byte(`["4.2"]`),
+		})
+		checkResp(t, 13, &transaction.OracleResponse{
+			ID:     13,
+			Code:   transaction.Success,
+			Result: []byte(`["10.5","4.2"]`),
+		})
+		checkResp(t, 14, &transaction.OracleResponse{
+			ID:     14,
+			Code:   transaction.Success,
+			Result: []byte(`[["4.2"]]`),
+		})
+	})
+	t.Run("FilterByPrefix", func(t *testing.T) {
+		checkResp(t, 15, &transaction.OracleResponse{
+			ID:     15,
+			Code:   transaction.Success,
+			Result: []byte(`2`),
+		})
+		checkResp(t, 16, &transaction.OracleResponse{
+			ID:   16,
+			Code: transaction.Error,
+		})
+	})
+	t.Run("FilteredTooLarge", func(t *testing.T) {
+		checkResp(t, 17, &transaction.OracleResponse{
+			ID:   17,
+			Code: transaction.ResponseTooLarge,
+		})
+	})
+	t.Run("Schemes", func(t *testing.T) {
+		t.Run("data", func(t *testing.T) {
+			checkResp(t, 18, &transaction.OracleResponse{
+				ID:     18,
+				Code:   transaction.Success,
+				Result: []byte(`{"a": 1}`),
+			})
+			checkResp(t, 19, &transaction.OracleResponse{
+				ID:     19,
+				Code:   transaction.Success,
+				Result: []byte("a,b\n1,2"),
+			})
+		})
+		t.Run("custom", func(t *testing.T) {
+			checkResp(t, 20, &transaction.OracleResponse{
+				ID:     20,
+				Code:   transaction.Success,
+				Result: []byte("sub.allowed.com/path"),
+			})
+		})
+		t.Run("host not allowed", func(t *testing.T) {
+			checkResp(t, 21, &transaction.OracleResponse{
+				ID:   21,
+				Code: transaction.Forbidden,
+			})
+		})
+		t.Run("disabled", func(t *testing.T) {
+			checkResp(t, 22, &transaction.OracleResponse{
+				ID:   22,
+				Code: transaction.ProtocolNotSupported,
+			})
+		})
+	})
+	t.Run("Status", func(t *testing.T) {
+		st := orc1.GetStatus()
+		require.ElementsMatch(t, keyStrings(oracleNodes), keyStrings(st.Nodes))
+		require.Equal(t, 2, st.Required)
+		require.Len(t, st.Requests, 23)
+		for i, r := range st.Requests {
+			require.Equal(t, uint64(i), r.ID)
+		}
+
+		r := st.Requests[0]
+		require.True(t, r.Processed)
+		require.True(t, r.Sent)
+		require.Equal(t, transaction.Success, r.Code)
+		require.ElementsMatch(t, keyStrings(oracleNodes), keyStrings(r.Signatures))
+
+		r = st.Requests[3]
+		require.Equal(t, "https://get.notfound", r.URL)
+		require.True(t, r.Processed)
+		require.False(t, r.Sent)
+		require.False(t, r.Backup)
+		require.Equal(t, transaction.NotFound, r.Code)
+		require.Equal(t, 1, r.Attempts)
+		require.Len(t, r.Signatures, 1)
+		require.True(t, r.Signatures[0].Equal(acc1.PublicKey()))
+		require.Len(t, r.BackupSignatures, 1)
+		require.True(t, r.BackupSignatures[0].Equal(acc1.PublicKey()))
+		require.Equal(t, r.LastProcessed.Add(time.Second), r.NextRetry)
+		require.Equal(t, r.LastProcessed.Add(time.Hour), r.Expires)
+
+		require.Contains(t, st.Requests[16].Reason, "filter failed")
+	})
 }
 
 func TestOracle_GenesisRole(t *testing.T) {
@@ -363,6 +489,14 @@ func TestOracle_GenesisRole(t *testing.T) {
 	require.True(t, orc.IsAuthorized())
 }
 
+func keyStrings(pubs keys.PublicKeys) []string {
+	res := make([]string, len(pubs))
+	for i := range pubs {
+		res[i] = pubs[i].StringCompressed()
+	}
+	return res
+}
+
 func TestOracleFull(t *testing.T) {
 	bc, validator, committee := chain.NewMultiWithCustomConfigAndStore(t, nil, nil, false)
 	e := neotest.NewExecutor(t, bc, validator, committee)
@@ -572,6 +706,26 @@ func newDefaultHTTPClient(returnOracleRedirectionErrOn func(address string) bool
 				ct:   "application/json",
 				body: []byte{0xFF},
 			},
+			"https://get.xml": {
+				code: http.StatusOK,
+				ct:   "application/xml; charset=utf-8",
+				body: []byte(`<prices><price symbol="NEO">10.5</price><price symbol="GAS">4.2</price></prices>`),
+			},
+			"https://get.html": {
+				code: http.StatusOK,
+				ct:   "text/html",
+				body: []byte(`<html><body><table><tr><td>NEO</td><td class="price">10.5</td></tr><tr><td>GAS</td><td class="price">4.2</td></tr></table><br></body></html>`),
+			},
+			"https://get.csv": {
+				code: http.StatusOK,
+				ct:   "text/csv",
+				body: []byte("symbol,price\nNEO,10.5\nGAS,4.2\n"),
+			},
+			"https://get.csvbig": {
+				code: http.StatusOK,
+				ct:   "application/json",
+				body: bytes.Repeat([]byte{'a'}, transaction.MaxOracleResultSize*2/3),
+			},
 			"https://get.invalidcontent": {
 				code: http.StatusOK,
 				ct:   "image/gif",
@@ -581,6 +735,14 @@ func newDefaultHTTPClient(returnOracleRedirectionErrOn func(address string) bool
 	}
 }
 
+// customHandler returns the host and path of the requested URL.
+type customHandler struct{}
+
+// Fetch implements the oracle.SchemeHandler interface.
+func (customHandler) Fetch(_ context.Context, req oracle.FetchRequest) ([]byte, string, transaction.OracleResponseCode) {
+	return []byte(req.URL.Host + req.URL.Path), "text/plain", transaction.Success
+}
+
 func newResponseBody(resp []byte) gio.ReadCloser {
 	return gio.NopCloser(bytes.NewReader(resp))
 }
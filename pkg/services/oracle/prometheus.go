@@ -0,0 +1,83 @@
+package oracle
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Response transaction kinds used as metric labels.
+const (
+	sigKindMain   = "main"
+	sigKindBackup = "backup"
+)
+
+// Metrics used in monitoring service.
+var (
+	// oracleFetchTime prometheus metric.
+	oracleFetchTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Help:      "Time spent fetching oracle request data by host",
+			Name:      "oracle_fetch_time",
+			Namespace: "neogo",
+		},
+		[]string{"host"},
+	)
+	// oracleResponseCodes prometheus metric.
+	oracleResponseCodes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of processed oracle requests by response code",
+			Name:      "oracle_response_codes",
+			Namespace: "neogo",
+		},
+		[]string{"code"},
+	)
+	// oracleSignatureTime prometheus metric.
+	oracleSignatureTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Help:      "Time from the first request processing to collecting enough response signatures",
+			Name:      "oracle_signature_time",
+			Namespace: "neogo",
+		},
+		[]string{"type"},
+	)
+	// oraclePendingRequests prometheus metric.
+	oraclePendingRequests = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of oracle requests being processed",
+			Name:      "oracle_pending_requests",
+			Namespace: "neogo",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		oracleFetchTime,
+		oracleResponseCodes,
+		oracleSignatureTime,
+		oraclePendingRequests,
+	)
+}
+
+func addFetchTimeMetric(u *url.URL, d time.Duration) {
+	host := u.Host
+	if host == "" {
+		host = u.Scheme
+	}
+	oracleFetchTime.WithLabelValues(host).Observe(d.Seconds())
+}
+
+func addResponseCodeMetric(code transaction.OracleResponseCode) {
+	oracleResponseCodes.WithLabelValues(code.String()).Inc()
+}
+
+func addSignatureTimeMetric(kind string, d time.Duration) {
+	oracleSignatureTime.WithLabelValues(kind).Observe(d.Seconds())
+}
+
+func updatePendingRequestsMetric(n int) {
+	oraclePendingRequests.Set(float64(n))
+}
@@ -1,11 +1,8 @@
 package oracle
 
 import (
-	"context"
 	"errors"
 	"mime"
-	"net/http"
-	"net/url"
 	"slices"
 	"time"
 
@@ -13,7 +10,6 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
-	"github.com/nspcc-dev/neo-go/pkg/services/helpers/neofs"
 	"go.uber.org/zap"
 )
 
@@ -55,6 +51,7 @@ func (o *Oracle) RemoveRequests(ids []uint64) {
 		for _, id := range ids {
 			delete(o.responses, id)
 		}
+		updatePendingRequestsMetric(len(o.responses))
 	}
 }
 
@@ -113,82 +110,26 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 	if incTx == nil {
 		return nil
 	}
-	resp := &transaction.OracleResponse{ID: req.ID, Code: transaction.Success}
-	u, err := url.ParseRequestURI(req.Req.URL)
-	if err != nil {
-		o.Log.Warn("malformed oracle request", zap.String("url", req.Req.URL), zap.Error(err))
-		resp.Code = transaction.ProtocolNotSupported
-	} else {
-		switch u.Scheme {
-		case "https":
-			httpReq, err := http.NewRequest("GET", req.Req.URL, nil)
-			if err != nil {
-				o.Log.Warn("failed to create http request", zap.String("url", req.Req.URL), zap.Error(err))
-				resp.Code = transaction.Error
-				break
-			}
-			httpReq.Header.Set("User-Agent", "NeoOracleService/3.0")
-			httpReq.Header.Set("Content-Type", "application/json")
-			r, err := o.Client.Do(httpReq)
-			if err != nil {
-				if errors.Is(err, ErrRestrictedRedirect) {
-					resp.Code = transaction.Forbidden
-				} else {
-					resp.Code = transaction.Error
-				}
-				o.Log.Warn("oracle request failed", zap.String("url", req.Req.URL), zap.Error(err), zap.Stringer("code", resp.Code))
-				break
-			}
-			defer r.Body.Close()
-			switch r.StatusCode {
-			case http.StatusOK:
-				if !checkMediaType(r.Header.Get("Content-Type"), o.MainCfg.AllowedContentTypes) {
-					resp.Code = transaction.ContentTypeNotSupported
-					break
-				}
-
-				resp.Result, resp.Code = o.readResponse(r.Body, req.Req.URL)
-			case http.StatusForbidden:
-				resp.Code = transaction.Forbidden
-			case http.StatusNotFound:
-				resp.Code = transaction.NotFound
-			case http.StatusRequestTimeout:
-				resp.Code = transaction.Timeout
-			default:
-				resp.Code = transaction.Error
-			}
-		case neofs.URIScheme:
-			if len(o.MainCfg.NeoFS.Nodes) == 0 {
-				o.Log.Warn("no NeoFS nodes configured", zap.String("url", req.Req.URL))
-				resp.Code = transaction.Error
-				break
-			}
-			ctx, cancel := context.WithTimeout(context.Background(), o.MainCfg.NeoFS.Timeout)
-			defer cancel()
-			index := (int(req.ID) + incTx.attempts) % len(o.MainCfg.NeoFS.Nodes)
-			rc, err := neofs.Get(ctx, priv, u, o.MainCfg.NeoFS.Nodes[index])
-			if err != nil {
-				resp.Code = transaction.Error
-				o.Log.Warn("failed to perform oracle request", zap.String("url", req.Req.URL), zap.Error(err))
-				if rc != nil {
-					rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
-				}
-				break
-			}
-			resp.Result, resp.Code = o.readResponse(rc, req.Req.URL)
-			rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
-		default:
-			resp.Code = transaction.ProtocolNotSupported
-			o.Log.Warn("unknown oracle request scheme", zap.String("url", req.Req.URL))
-		}
-	}
+	resp := &transaction.OracleResponse{ID: req.ID}
+	var (
+		contentType string
+		reason      string
+	)
+	resp.Result, contentType, resp.Code = o.fetch(priv, req, incTx.attempts)
 	if resp.Code == transaction.Success {
-		resp.Result, err = filterRequest(resp.Result, req.Req)
+		var err error
+		resp.Result, err = filterRequest(resp.Result, contentType, req.Req, o.MainCfg.ExtendedFilters)
 		if err != nil {
 			o.Log.Warn("oracle filter failed", zap.Uint64("request", req.ID), zap.Error(err))
-			resp.Code = transaction.Error
+			reason = "filter failed: " + err.Error()
+			if errors.Is(err, ErrResponseTooLarge) {
+				resp.Code = transaction.ResponseTooLarge
+			} else {
+				resp.Code = transaction.Error
+			}
 		}
 	}
+	addResponseCodeMetric(resp.Code)
 	o.Log.Debug("oracle request processed", zap.String("url", req.Req.URL), zap.Int("code", int(resp.Code)), zap.String("result", string(resp.Result)))
 
 	currentHeight := o.Chain.BlockHeight()
@@ -221,6 +162,11 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 	incTx.request = req.Req
 	incTx.tx = tx
 	incTx.backupTx = backupTx
+	incTx.code = resp.Code
+	incTx.reason = reason
+	if incTx.firstProcessed.IsZero() {
+		incTx.firstProcessed = time.Now()
+	}
 	incTx.reverifyTx(o.Network)
 
 	txSig := priv.SignHashable(uint32(o.Network), tx)
@@ -231,8 +177,7 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 
 	readyTx, ready := incTx.finalize(o.getOracleNodes(), false)
 	if ready {
-		ready = !incTx.isSent
-		incTx.isSent = true
+		ready = incTx.markSent(readyTx)
 	}
 	incTx.time = time.Now()
 	incTx.attempts++
@@ -259,10 +204,11 @@ func (o *Oracle) processFailedRequest(priv *keys.PrivateKey, req request) {
 
 	// Don't process request again, fallback to backup tx.
 	incTx.Lock()
+	incTx.isBackup = true
+	incTx.reason = "not finalized in RefreshInterval, backup response is used"
 	readyTx, ready := incTx.finalize(o.getOracleNodes(), true)
 	if ready {
-		ready = !incTx.isSent
-		incTx.isSent = true
+		ready = incTx.markSent(readyTx)
 	}
 	incTx.time = time.Now()
 	incTx.attempts++
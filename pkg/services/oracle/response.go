@@ -55,8 +55,7 @@ func (o *Oracle) AddResponse(pub *keys.PublicKey, reqID uint64, txSig []byte) {
 	incTx.addResponse(pub, txSig, isBackup)
 	readyTx, ready := incTx.finalize(o.getOracleNodes(), false)
 	if ready {
-		ready = !incTx.isSent
-		incTx.isSent = true
+		ready = incTx.markSent(readyTx)
 	}
 	incTx.Unlock()
 
@@ -68,19 +67,22 @@ func (o *Oracle) AddResponse(pub *keys.PublicKey, reqID uint64, txSig []byte) {
 // ErrResponseTooLarge is returned when a response exceeds the max allowed size.
 var ErrResponseTooLarge = errors.New("too big response")
 
-func (o *Oracle) readResponse(rc gio.Reader, url string) ([]byte, transaction.OracleResponseCode) {
-	const limit = transaction.MaxOracleResultSize
+func (o *Oracle) readResponse(rc gio.Reader, url string, limit int) ([]byte, transaction.OracleResponseCode) {
+	data, err := readLimited(rc, limit)
+	return o.handleResponseError(data, err, url)
+}
+
+// readLimited reads the whole data from r if it doesn't exceed the limit.
+func readLimited(r gio.Reader, limit int) ([]byte, error) {
 	buf := make([]byte, limit+1)
-	n, err := gio.ReadFull(rc, buf)
+	n, err := gio.ReadFull(r, buf)
 	if errors.Is(err, gio.ErrUnexpectedEOF) && n <= limit {
-		res, err := checkUTF8(buf[:n])
-		return o.handleResponseError(res, err, url)
+		return buf[:n], nil
 	}
 	if err == nil || n > limit {
-		return o.handleResponseError(nil, ErrResponseTooLarge, url)
+		return nil, ErrResponseTooLarge
 	}
-
-	return o.handleResponseError(nil, err, url)
+	return nil, err
 }
 
 func (o *Oracle) handleResponseError(data []byte, err error, url string) ([]byte, transaction.OracleResponseCode) {
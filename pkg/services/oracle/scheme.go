@@ -0,0 +1,187 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/neofs"
+	"go.uber.org/zap"
+)
+
+type (
+	// SchemeHandler fetches data for oracle requests with a particular URL
+	// scheme.
+	SchemeHandler interface {
+		// Fetch returns the data located at the request URL and its media type
+		// (empty if unknown). The data is only used when the code is
+		// transaction.Success, it must not exceed req.MaxSize.
+		Fetch(ctx context.Context, req FetchRequest) ([]byte, string, transaction.OracleResponseCode)
+	}
+
+	// FetchRequest contains oracle request parameters passed to SchemeHandler.
+	FetchRequest struct {
+		// ID is the oracle request ID.
+		ID uint64
+		// URL is the parsed request URL.
+		URL *url.URL
+		// Attempt is the number of previous attempts to process the request.
+		Attempt int
+		// Key is the oracle node key.
+		Key *keys.PrivateKey
+		// MaxSize is the maximum allowed size of the fetched data.
+		MaxSize int
+	}
+
+	// scheme is a registered scheme handler with its policy.
+	scheme struct {
+		handler      SchemeHandler
+		timeout      time.Duration
+		maxSize      int
+		allowedHosts []string
+	}
+)
+
+// newSchemeHandler creates built-in handler for an optional scheme.
+func (o *Oracle) newSchemeHandler(name string, cfg config.OracleSchemeConfiguration) (SchemeHandler, error) {
+	switch name {
+	case dataScheme:
+		return dataHandler{allowedTypes: o.MainCfg.AllowedContentTypes}, nil
+	case ipfsScheme:
+		return newIPFSHandler(o.MainCfg, cfg)
+	case wssScheme:
+		return newWSSHandler(o.MainCfg), nil
+	case neoScheme:
+		return newNeoHandler(cfg)
+	default:
+		return nil, fmt.Errorf("unknown oracle URL scheme %q", name)
+	}
+}
+
+// initSchemes creates scheme registry from the default https and neofs
+// handlers, configured optional handlers and custom handlers.
+func (o *Oracle) initSchemes() error {
+	o.schemes = make(map[string]*scheme)
+	handlers := map[string]SchemeHandler{
+		"https":         httpsHandler{o},
+		neofs.URIScheme: neofsHandler{o},
+	}
+	for name, cfg := range o.MainCfg.Schemes {
+		if _, ok := handlers[name]; ok || !cfg.Enabled {
+			continue
+		}
+		if _, ok := o.SchemeHandlers[name]; ok {
+			continue
+		}
+		h, err := o.newSchemeHandler(name, cfg)
+		if err != nil {
+			return err
+		}
+		handlers[name] = h
+	}
+	for name, h := range o.SchemeHandlers {
+		handlers[name] = h
+	}
+	for name, h := range handlers {
+		var (
+			cfg = o.MainCfg.Schemes[name]
+			s   = &scheme{
+				handler:      h,
+				timeout:      cfg.Timeout,
+				maxSize:      cfg.MaxResponseSize,
+				allowedHosts: cfg.AllowedHosts,
+			}
+		)
+		if s.timeout == 0 {
+			s.timeout = o.MainCfg.RequestTimeout
+			if name == neofs.URIScheme {
+				s.timeout = o.MainCfg.NeoFS.Timeout
+			}
+		}
+		if s.maxSize < 0 {
+			return fmt.Errorf("%s: negative MaxResponseSize", name)
+		}
+		if s.maxSize == 0 || s.maxSize > transaction.MaxOracleResultSize {
+			s.maxSize = transaction.MaxOracleResultSize
+		}
+		o.schemes[name] = s
+	}
+	return nil
+}
+
+// isAllowed checks the URL host against the scheme allowlist.
+func (s *scheme) isAllowed(u *url.URL) bool {
+	if len(s.allowedHosts) == 0 {
+		return true
+	}
+	host := strings.ToLower(u.Hostname())
+	return slices.ContainsFunc(s.allowedHosts, func(allowed string) bool {
+		allowed = strings.ToLower(allowed)
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok {
+			return strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+		}
+		return host == allowed
+	})
+}
+
+// fetch gets the data for the request using the appropriate scheme handler.
+func (o *Oracle) fetch(priv *keys.PrivateKey, req request, attempt int) ([]byte, string, transaction.OracleResponseCode) {
+	u, err := url.ParseRequestURI(req.Req.URL)
+	if err != nil {
+		o.Log.Warn("malformed oracle request", zap.String("url", req.Req.URL), zap.Error(err))
+		return nil, "", transaction.ProtocolNotSupported
+	}
+	s, ok := o.schemes[u.Scheme]
+	if !ok {
+		o.Log.Warn("unknown oracle request scheme", zap.String("url", req.Req.URL))
+		return nil, "", transaction.ProtocolNotSupported
+	}
+	if !s.isAllowed(u) {
+		o.Log.Warn("oracle request host is not allowed", zap.String("url", req.Req.URL))
+		return nil, "", transaction.Forbidden
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	start := time.Now()
+	data, contentType, code := s.handler.Fetch(ctx, FetchRequest{
+		ID:      req.ID,
+		URL:     u,
+		Attempt: attempt,
+		Key:     priv,
+		MaxSize: s.maxSize,
+	})
+	addFetchTimeMetric(u, time.Since(start))
+	if code != transaction.Success {
+		return nil, "", code
+	}
+	if len(data) > s.maxSize {
+		o.Log.Warn("oracle response is too large", zap.String("url", req.Req.URL), zap.Int("size", len(data)))
+		return nil, "", transaction.ResponseTooLarge
+	}
+	if _, err := checkUTF8(data); err != nil {
+		o.Log.Warn("invalid oracle response", zap.String("url", req.Req.URL), zap.Error(err))
+		return nil, "", transaction.Error
+	}
+	return data, contentType, code
+}
+
+// errorToCode converts well-known fetch errors to response codes.
+func errorToCode(err error) transaction.OracleResponseCode {
+	switch {
+	case errors.Is(err, ErrRestrictedRedirect):
+		return transaction.Forbidden
+	case errors.Is(err, ErrResponseTooLarge):
+		return transaction.ResponseTooLarge
+	case errors.Is(err, context.DeadlineExceeded):
+		return transaction.Timeout
+	default:
+		return transaction.Error
+	}
+}
@@ -0,0 +1,112 @@
+package oracle
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+)
+
+type (
+	// Status is a snapshot of the oracle service state.
+	Status struct {
+		// Nodes is the list of designated oracle nodes.
+		Nodes keys.PublicKeys
+		// Required is the number of signatures required for response
+		// transaction.
+		Required int
+		// Requests contains requests being processed ordered by ID.
+		Requests []RequestStatus
+	}
+
+	// RequestStatus describes the state of a single oracle request.
+	RequestStatus struct {
+		ID uint64
+		// URL is the request URL, it's empty if the request wasn't processed
+		// by this node yet (but signatures from other nodes were received).
+		URL string
+		// Processed is true if the request was processed by this node.
+		Processed bool
+		// Code is the response code of the last processing.
+		Code transaction.OracleResponseCode
+		// Reason describes why the request failed or why backup response is
+		// used.
+		Reason string
+		// Attempts is the number of processing attempts.
+		Attempts int
+		// LastProcessed is the time of the last processing.
+		LastProcessed time.Time
+		// NextRetry is the time the request is to be processed again.
+		NextRetry time.Time
+		// Expires is the time the request is dropped at if it's not
+		// finalized.
+		Expires time.Time
+		// Backup is true if backup response is used for the request.
+		Backup bool
+		// Sent is true if the response transaction was sent.
+		Sent bool
+		// Signatures contains nodes that have signed the response
+		// transaction.
+		Signatures keys.PublicKeys
+		// BackupSignatures contains nodes that have signed the backup
+		// response transaction.
+		BackupSignatures keys.PublicKeys
+	}
+)
+
+// GetStatus returns the state of oracle requests being processed.
+func (o *Oracle) GetStatus() Status {
+	nodes := o.getOracleNodes()
+	st := Status{
+		Nodes:    nodes,
+		Required: smartcontract.GetDefaultHonestNodeCount(len(nodes)),
+	}
+
+	o.respMtx.RLock()
+	st.Requests = make([]RequestStatus, 0, len(o.responses))
+	for id, incTx := range o.responses {
+		incTx.RLock()
+		rs := RequestStatus{
+			ID:               id,
+			Processed:        incTx.request != nil,
+			Code:             incTx.code,
+			Reason:           incTx.reason,
+			Attempts:         incTx.attempts,
+			LastProcessed:    incTx.time,
+			Backup:           incTx.isBackup,
+			Sent:             incTx.isSent,
+			Signatures:       signedKeys(nodes, incTx.sigs),
+			BackupSignatures: signedKeys(nodes, incTx.backupSigs),
+		}
+		if incTx.request != nil {
+			rs.URL = incTx.request.URL
+		}
+		if !incTx.time.IsZero() {
+			rs.NextRetry = incTx.time.Add(o.MainCfg.RefreshInterval)
+			rs.Expires = incTx.time.Add(o.MainCfg.MaxTaskTimeout)
+		}
+		incTx.RUnlock()
+		st.Requests = append(st.Requests, rs)
+	}
+	o.respMtx.RUnlock()
+
+	slices.SortFunc(st.Requests, func(a, b RequestStatus) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return st
+}
+
+// signedKeys returns the list of keys with verified signatures in the order
+// of oracle nodes.
+func signedKeys(nodes keys.PublicKeys, sigs map[string]*txSignature) keys.PublicKeys {
+	var res keys.PublicKeys
+	for _, pub := range nodes {
+		if sig, ok := sigs[string(pub.Bytes())]; ok && sig.ok {
+			res = append(res, pub)
+		}
+	}
+	return res
+}
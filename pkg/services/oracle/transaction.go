@@ -23,6 +23,16 @@ type (
 		attempts int
 		// time is the time when the request was last processed.
 		time time.Time
+		// firstProcessed is the time when the request was processed for the
+		// first time.
+		firstProcessed time.Time
+		// code is the response code of the last request processing.
+		code transaction.OracleResponseCode
+		// reason describes why the request failed or why backup transaction
+		// was used.
+		reason string
+		// isBackup is true if the request is finalized with backup transaction.
+		isBackup bool
 		// request is an oracle request.
 		request *state.OracleRequest
 		// tx is an oracle response transaction.
@@ -81,6 +91,23 @@ func (t *incompleteTx) addResponse(pub *keys.PublicKey, sig []byte, isBackup boo
 	}
 }
 
+// markSent marks the given finalized tx as sent and returns true if it
+// hasn't been sent before.
+func (t *incompleteTx) markSent(tx *transaction.Transaction) bool {
+	if t.isSent {
+		return false
+	}
+	t.isSent = true
+	if !t.firstProcessed.IsZero() {
+		kind := sigKindMain
+		if tx == t.backupTx {
+			kind = sigKindBackup
+		}
+		addSignatureTimeMetric(kind, time.Since(t.firstProcessed))
+	}
+	return true
+}
+
 // finalize checks if either main or backup tx has sufficient number of signatures and returns
 // tx and bool value indicating if it is ready to be broadcasted.
 func (t *incompleteTx) finalize(oracleNodes keys.PublicKeys, backupOnly bool) (*transaction.Transaction, bool) {
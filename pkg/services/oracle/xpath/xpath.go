@@ -0,0 +1,422 @@
+/*
+Package xpath implements a deterministic subset of XPath 1.0 used to filter
+XML and HTML oracle responses.
+
+Supported location paths are absolute (starting with `/` or `//`) and consist
+of the following steps:
+  - `name` and `*` select child elements (namespace prefixes are ignored);
+  - `text()` selects child text nodes;
+  - `@name` and `@*` select attributes (can only be the last step);
+  - `.` selects the context node itself.
+
+Element steps can have any number of predicates:
+  - `[n]` and `[last()]` select elements by their position (starting from 1);
+  - `[@attr]` selects elements having the attribute;
+  - `[@attr='value']` and `[name='value']` compare attribute or child element
+    string values with the literal.
+
+The result is a list of string values of selected nodes in document order.
+*/
+package xpath
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxNodes is the maximum number of nodes selected by every step.
+	maxNodes = 1024
+	// maxDepth is the maximum nesting depth of the document.
+	maxDepth = 64
+)
+
+type (
+	node struct {
+		name     string
+		attrs    []xml.Attr
+		text     string
+		isText   bool
+		children []*node
+		// index is the position of the node in document order.
+		index int
+	}
+
+	step struct {
+		// descendant is set for steps preceded by `//`.
+		descendant bool
+		kind       stepKind
+		name       string
+		predicates []predicate
+	}
+
+	stepKind byte
+
+	predicate struct {
+		position int // 0 if not positional, -1 for last().
+		attr     string
+		child    string
+		value    *string
+	}
+)
+
+const (
+	stepElement stepKind = iota
+	stepText
+	stepAttr
+	stepSelf
+)
+
+// ErrTooManyNodes is returned when some step selects too many nodes.
+var ErrTooManyNodes = errors.New("too many nodes selected")
+
+// Get parses the document (as HTML if html is set) and returns string values
+// of nodes selected by the path.
+func Get(path string, doc []byte, html bool) ([]string, error) {
+	steps, err := parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	root, err := parseDocument(doc, html)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+	nodes := []*node{root}
+	for i, s := range steps {
+		if s.descendant {
+			nodes = descendants(nodes)
+		}
+		if s.kind == stepAttr {
+			if i != len(steps)-1 {
+				return nil, errors.New("attribute step must be the last one")
+			}
+			return attributes(nodes, s.name)
+		}
+		nodes, err = s.apply(nodes, html)
+		if err != nil {
+			return nil, err
+		}
+	}
+	res := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		res = append(res, n.stringValue())
+	}
+	return res, nil
+}
+
+func parseDocument(doc []byte, html bool) (*node, error) {
+	d := xml.NewDecoder(bytes.NewReader(doc))
+	if html {
+		d.Strict = false
+		d.AutoClose = xml.HTMLAutoClose
+		d.Entity = xml.HTMLEntity
+	}
+	var (
+		root  = &node{}
+		stack = []*node{root}
+		index = 1
+	)
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		cur := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(stack) > maxDepth {
+				return nil, errors.New("document is too deep")
+			}
+			n := &node{name: t.Name.Local, attrs: t.Attr, index: index}
+			if html {
+				n.name = strings.ToLower(n.name)
+				for i := range n.attrs {
+					n.attrs[i].Name.Local = strings.ToLower(n.attrs[i].Name.Local)
+				}
+			}
+			cur.children = append(cur.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			cur.children = append(cur.children, &node{text: string(t), isText: true, index: index})
+		default:
+			continue
+		}
+		index++
+	}
+	return root, nil
+}
+
+// stringValue returns the XPath string value of the node.
+func (n *node) stringValue() string {
+	if n.isText {
+		return n.text
+	}
+	var sb strings.Builder
+	n.writeText(&sb)
+	return sb.String()
+}
+
+func (n *node) writeText(sb *strings.Builder) {
+	for _, c := range n.children {
+		if c.isText {
+			sb.WriteString(c.text)
+		} else {
+			c.writeText(sb)
+		}
+	}
+}
+
+// descendants returns all given nodes and their descendant elements in
+// document order.
+func descendants(nodes []*node) []*node {
+	var (
+		res  []*node
+		seen = make(map[*node]bool)
+		walk func(n *node)
+	)
+	walk = func(n *node) {
+		if seen[n] {
+			return
+		}
+		seen[n] = true
+		res = append(res, n)
+		for _, c := range n.children {
+			if !c.isText {
+				walk(c)
+			}
+		}
+	}
+	for _, n := range nodes {
+		walk(n)
+	}
+	slices.SortFunc(res, func(a, b *node) int { return a.index - b.index })
+	return res
+}
+
+func attributes(nodes []*node, name string) ([]string, error) {
+	var res []string
+	for _, n := range nodes {
+		for _, a := range n.attrs {
+			if name == "*" || a.Name.Local == name {
+				if len(res) == maxNodes {
+					return nil, ErrTooManyNodes
+				}
+				res = append(res, a.Value)
+			}
+		}
+	}
+	if res == nil {
+		res = []string{}
+	}
+	return res, nil
+}
+
+func (s step) apply(nodes []*node, html bool) ([]*node, error) {
+	var (
+		res  []*node
+		seen = make(map[*node]bool)
+	)
+	for _, n := range nodes {
+		var selected []*node
+		switch s.kind {
+		case stepSelf:
+			selected = []*node{n}
+		case stepText:
+			for _, c := range n.children {
+				if c.isText {
+					selected = append(selected, c)
+				}
+			}
+		case stepElement:
+			for _, c := range n.children {
+				if !c.isText && (s.name == "*" || c.name == s.name || html && c.name == strings.ToLower(s.name)) {
+					selected = append(selected, c)
+				}
+			}
+		}
+		for _, p := range s.predicates {
+			selected = p.filter(selected)
+		}
+		for _, c := range selected {
+			if seen[c] {
+				continue
+			}
+			if len(res) == maxNodes {
+				return nil, ErrTooManyNodes
+			}
+			seen[c] = true
+			res = append(res, c)
+		}
+	}
+	slices.SortFunc(res, func(a, b *node) int { return a.index - b.index })
+	return res, nil
+}
+
+func (p predicate) filter(nodes []*node) []*node {
+	switch {
+	case p.position > 0:
+		if p.position > len(nodes) {
+			return nil
+		}
+		return nodes[p.position-1 : p.position]
+	case p.position < 0:
+		if len(nodes) == 0 {
+			return nil
+		}
+		return nodes[len(nodes)-1:]
+	}
+	var res []*node
+	for _, n := range nodes {
+		if p.matches(n) {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+func (p predicate) matches(n *node) bool {
+	if p.attr != "" {
+		for _, a := range n.attrs {
+			if a.Name.Local == p.attr {
+				return p.value == nil || a.Value == *p.value
+			}
+		}
+		return false
+	}
+	for _, c := range n.children {
+		if !c.isText && c.name == p.child && c.stringValue() == *p.value {
+			return true
+		}
+	}
+	return false
+}
+
+// parse parses the location path into a list of steps.
+func parse(path string) ([]step, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, errors.New("path must be absolute")
+	}
+	var steps []step
+	for len(path) > 0 {
+		var s step
+		switch {
+		case strings.HasPrefix(path, "//"):
+			s.descendant = true
+			path = path[2:]
+		case path[0] == '/':
+			path = path[1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q", path)
+		}
+		var err error
+		s, path, err = parseStep(s, path)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, s)
+	}
+	return steps, nil
+}
+
+func parseStep(s step, path string) (step, string, error) {
+	switch {
+	case strings.HasPrefix(path, "text()"):
+		s.kind = stepText
+		return s, path[len("text()"):], nil
+	case strings.HasPrefix(path, "."):
+		s.kind = stepSelf
+		return s, path[1:], nil
+	case strings.HasPrefix(path, "@"):
+		s.kind = stepAttr
+		s.name, path = parseName(path[1:])
+		if s.name == "" {
+			return s, path, errors.New("empty attribute name")
+		}
+		return s, path, nil
+	}
+	s.kind = stepElement
+	s.name, path = parseName(path)
+	if s.name == "" {
+		return s, path, errors.New("empty element name")
+	}
+	for strings.HasPrefix(path, "[") {
+		end := strings.IndexByte(path, ']')
+		if end < 0 {
+			return s, path, errors.New("unclosed predicate")
+		}
+		p, err := parsePredicate(path[1:end])
+		if err != nil {
+			return s, path, err
+		}
+		s.predicates = append(s.predicates, p)
+		path = path[end+1:]
+	}
+	return s, path, nil
+}
+
+// parseName returns the local part of the name at the beginning of the path
+// and the rest of the path.
+func parseName(path string) (string, string) {
+	if strings.HasPrefix(path, "*") {
+		return "*", path[1:]
+	}
+	end := strings.IndexAny(path, "/[]=@ ")
+	if end < 0 {
+		end = len(path)
+	}
+	name := path[:end]
+	if i := strings.LastIndexByte(name, ':'); i >= 0 {
+		name = name[i+1:]
+	}
+	return name, path[end:]
+}
+
+func parsePredicate(s string) (predicate, error) {
+	var p predicate
+	s = strings.TrimSpace(s)
+	if s == "last()" {
+		p.position = -1
+		return p, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 {
+			return p, fmt.Errorf("invalid position %d", n)
+		}
+		p.position = n
+		return p, nil
+	}
+	var name, value, hasValue = s, "", false
+	if i := strings.IndexByte(s, '='); i >= 0 {
+		name, value, hasValue = strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:]), true
+		if len(value) < 2 || value[0] != value[len(value)-1] || value[0] != '\'' && value[0] != '"' {
+			return p, fmt.Errorf("invalid literal %s", value)
+		}
+		value = value[1 : len(value)-1]
+		p.value = &value
+	}
+	if strings.HasPrefix(name, "@") {
+		p.attr = name[1:]
+		if p.attr == "" {
+			return p, errors.New("empty attribute name")
+		}
+		return p, nil
+	}
+	if !hasValue || name == "" {
+		return p, fmt.Errorf("unsupported predicate %q", s)
+	}
+	p.child = name
+	return p, nil
+}
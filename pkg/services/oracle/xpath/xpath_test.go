@@ -0,0 +1,94 @@
+package xpath
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const doc = `<?xml version="1.0"?>
+<stores>
+	<store id="1" city="Wellington">
+		<name>Lambton Quay</name>
+		<product price="50">Anvil</product>
+	</store>
+	<store id="2" city="Auckland">
+		<name>Willis Street</name>
+		<product price="99.95">Elbow Grease</product>
+		<product price="4">Headlight <b>Fluid</b></product>
+	</store>
+</stores>`
+
+func TestGet(t *testing.T) {
+	testCases := []struct {
+		path   string
+		result []string
+	}{
+		{"/stores/store/name", []string{"Lambton Quay", "Willis Street"}},
+		{"/stores/store/name/text()", []string{"Lambton Quay", "Willis Street"}},
+		{"/stores/store[2]/name", []string{"Willis Street"}},
+		{"/stores/store[last()]/@id", []string{"2"}},
+		{"/stores/store[@city='Wellington']/product", []string{"Anvil"}},
+		{"/stores/store[name=\"Willis Street\"]/product/@price", []string{"99.95", "4"}},
+		{"/stores/store[@id]/@*", []string{"1", "Wellington", "2", "Auckland"}},
+		{"//product", []string{"Anvil", "Elbow Grease", "Headlight Fluid"}},
+		{"//product[2]", []string{"Headlight Fluid"}},
+		{"//store//b", []string{"Fluid"}},
+		{"/stores/*/product[1]/.", []string{"Anvil", "Elbow Grease"}},
+		{"/stores/store[3]", []string{}},
+		{"/unknown", []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			res, err := Get(tc.path, []byte(doc), false)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, res)
+		})
+	}
+}
+
+func TestGetHTML(t *testing.T) {
+	html := `<!DOCTYPE html><html><body><P CLASS="price">1&nbsp;GAS<br></P><p class="price">2 GAS</p></body></html>`
+	res, err := Get("//p[@class='price']", []byte(html), true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1\u00a0GAS", "2 GAS"}, res)
+
+	_, err = Get("//p", []byte(html), false)
+	require.Error(t, err)
+}
+
+func TestInvalid(t *testing.T) {
+	paths := []string{
+		"",
+		"stores",
+		"/stores/",
+		"/stores/@",
+		"/stores/@id/name",
+		"/stores[",
+		"/stores[0]",
+		"/stores[name]",
+		"/stores[@]",
+		"/stores[@id=1]",
+		"/stores[position()>1]",
+	}
+	for _, p := range paths {
+		t.Run(p, func(t *testing.T) {
+			_, err := Get(p, []byte(doc), false)
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("too many nodes", func(t *testing.T) {
+		d := "<a>" + strings.Repeat("<b/>", maxNodes+1) + "</a>"
+		_, err := Get("/a/b", []byte(d), false)
+		require.ErrorIs(t, err, ErrTooManyNodes)
+		_, err = Get("/a/b[1]", []byte(d), false)
+		require.NoError(t, err)
+	})
+	t.Run("too deep", func(t *testing.T) {
+		d := strings.Repeat("<a>", maxDepth+1) + strings.Repeat("</a>", maxDepth+1)
+		_, err := Get("/a", []byte(d), false)
+		require.Error(t, err)
+	})
+}
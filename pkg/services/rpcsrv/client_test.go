@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -2442,6 +2443,69 @@ func TestClient_GetStorageHistoric(t *testing.T) {
 	require.ErrorIs(t, neorpc.ErrUnknownStorageItem, err)
 }
 
+type stateRootVerifierFunc func(r *state.MPTRoot) error
+
+func (f stateRootVerifierFunc) VerifyStateRoot(r *state.MPTRoot) error { return f(r) }
+
+func TestClient_StorageVerified(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	h, err := util.Uint160DecodeStringLE(testContractHashLE)
+	require.NoError(t, err)
+	trusted := stateRootVerifierFunc(func(r *state.MPTRoot) error {
+		local, err := chain.GetStateRoot(r.Index)
+		if err != nil {
+			return err
+		}
+		if !local.Root.Equals(r.Root) {
+			return errors.New("root mismatch")
+		}
+		return nil
+	})
+	untrusted := stateRootVerifierFunc(func(r *state.MPTRoot) error {
+		return errors.New("untrusted")
+	})
+
+	t.Run("GetStorageVerified", func(t *testing.T) {
+		actual, err := c.GetStorageVerified(trusted, 20, h, []byte("aa10"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("v2"), actual)
+
+		_, err = c.GetStorageVerified(untrusted, 20, h, []byte("aa10"))
+		require.Error(t, err)
+
+		// There's no `aa10` value in Rubles contract by the moment of block #15.
+		_, err = c.GetStorageVerified(trusted, 15, h, []byte("aa10"))
+		require.ErrorIs(t, err, neorpc.ErrUnknownStorageItem)
+	})
+
+	t.Run("FindStatesVerified", func(t *testing.T) {
+		root, err := chain.GetStateRoot(20)
+		require.NoError(t, err)
+		expected, err := c.FindStates(root.Root, h, []byte("aa"), nil, nil)
+		require.NoError(t, err)
+		require.True(t, len(expected.Results) > 2)
+
+		actual, err := c.FindStatesVerified(trusted, 20, h, []byte("aa"), nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		count := 1
+		actual, err = c.FindStatesVerified(trusted, 20, h, []byte("aa"), nil, &count)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(actual.Results))
+		require.True(t, actual.Truncated)
+
+		_, err = c.FindStatesVerified(untrusted, 20, h, []byte("aa"), nil, nil)
+		require.Error(t, err)
+	})
+}
+
 func TestClient_GetVersion_Hardforks(t *testing.T) {
 	_, _, httpSrv := initServerWithInMemoryChain(t)
 
@@ -2804,3 +2868,59 @@ func TestClient_InvokeContainedScript(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetBlockStorageDiff(t *testing.T) {
+	chain, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.SaveStorageDiffs = true
+	})
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	for _, index := range []uint32{1, chain.BlockHeight()} {
+		expected, err := chain.GetStorageDiff(index)
+		require.NoError(t, err)
+		require.NotEmpty(t, expected)
+
+		actual, err := c.GetBlockStorageDiff(index)
+		require.NoError(t, err)
+		require.Equal(t, chain.GetHeaderHash(index), actual.Hash)
+		require.Equal(t, index, actual.Index)
+		require.Equal(t, expected, actual.Changes)
+
+		actual, err = c.GetBlockStorageDiffByHash(chain.GetHeaderHash(index))
+		require.NoError(t, err)
+		require.Equal(t, index, actual.Index)
+		require.Equal(t, expected, actual.Changes)
+	}
+
+	t.Run("genesis", func(t *testing.T) {
+		actual, err := c.GetBlockStorageDiff(0)
+		require.NoError(t, err)
+		require.NotEmpty(t, actual.Changes)
+		for _, d := range actual.Changes {
+			require.Nil(t, d.Old)
+			require.NotNil(t, d.New)
+		}
+	})
+	t.Run("unknown block", func(t *testing.T) {
+		_, err := c.GetBlockStorageDiff(chain.BlockHeight() + 1)
+		require.ErrorIs(t, err, neorpc.ErrUnknownHeight)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		_, _, httpSrv := initClearServerWithInMemoryChain(t)
+		c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+		require.NoError(t, err)
+		t.Cleanup(c.Close)
+		require.NoError(t, c.Init())
+		_, err = c.GetBlockStorageDiff(0)
+		var rpcErr *neorpc.Error
+		require.ErrorAs(t, err, &rpcErr)
+		require.EqualValues(t, neorpc.InvalidRequestCode, rpcErr.Code)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -24,6 +25,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/limits"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/consensus"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
@@ -44,6 +46,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/rpcevent"
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/notary"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -91,6 +95,7 @@ type (
 		GetNextBlockValidators() ([]*keys.PublicKey, error)
 		GetNotaryContractScriptHash() util.Uint160
 		GetStateModule() core.StateRoot
+		GetStorageDiff(index uint32) ([]state.StorageDiff, error)
 		GetStorageItem(id int32, key []byte) state.StorageItem
 		GetTestHistoricVM(t trigger.Type, tx *transaction.Transaction, nextBlockHeight uint32) (*interop.Context, error)
 		GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) (*interop.Context, error)
@@ -104,11 +109,13 @@ type (
 		SubscribeForHeadersOfAddedBlocks(ch chan *block.Header)
 		SubscribeForExecutions(ch chan *state.AppExecResult)
 		SubscribeForNotifications(ch chan *state.ContainedNotificationEvent)
+		SubscribeForStorageChanges(ch chan *state.StorageChangeEvent)
 		SubscribeForTransactions(ch chan *transaction.Transaction)
 		UnsubscribeFromBlocks(ch chan *block.Block)
 		UnsubscribeFromHeadersOfAddedBlocks(ch chan *block.Header)
 		UnsubscribeFromExecutions(ch chan *state.AppExecResult)
 		UnsubscribeFromNotifications(ch chan *state.ContainedNotificationEvent)
+		UnsubscribeFromStorageChanges(ch chan *state.StorageChangeEvent)
 		UnsubscribeFromTransactions(ch chan *transaction.Transaction)
 		VerifyTx(*transaction.Transaction) error
 		VerifyWitness(util.Uint160, hash.Hashable, *transaction.Witness, int64) (int64, error)
@@ -126,6 +133,17 @@ type (
 	// OracleHandler is the interface oracle service needs to provide for the Server.
 	OracleHandler interface {
 		AddResponse(pub *keys.PublicKey, reqID uint64, txSig []byte)
+		GetStatus() oracle.Status
+	}
+
+	// ConsensusHandler is the interface consensus service needs to provide for the Server.
+	ConsensusHandler interface {
+		GetState() consensus.State
+	}
+
+	// NotaryHandler is the interface notary service needs to provide for the Server.
+	NotaryHandler interface {
+		GetRequestStatus(h util.Uint256) (notary.RequestStatus, bool)
 	}
 
 	// Server represents the JSON-RPC 2.0 server.
@@ -142,6 +160,8 @@ type (
 		stateRootEnabled bool
 		coreServer       *network.Server
 		oracle           *atomic.Value
+		consensus        atomic.Pointer[ConsensusHandler]
+		notary           atomic.Pointer[NotaryHandler]
 		log              *zap.Logger
 		shutdown         chan struct{}
 		started          atomic.Bool
@@ -160,6 +180,7 @@ type (
 		notificationSubs  int
 		transactionSubs   int
 		notaryRequestSubs int
+		storageSubs       int
 
 		blockCh           chan *block.Block
 		blockHeaderCh     chan *block.Header
@@ -167,6 +188,7 @@ type (
 		notificationCh    chan *state.ContainedNotificationEvent
 		transactionCh     chan *transaction.Transaction
 		notaryRequestCh   chan mempoolevent.Event
+		storageCh         chan *state.StorageChangeEvent
 		subEventsToExitCh chan struct{}
 	}
 
@@ -227,10 +249,12 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"getblockheader":               (*Server).getBlockHeader,
 	"getblockheadercount":          (*Server).getBlockHeaderCount,
 	"getblocknotifications":        (*Server).getBlockNotifications,
+	"getblockstoragediff":          (*Server).getBlockStorageDiff,
 	"getblocksysfee":               (*Server).getBlockSysFee,
 	"getcandidates":                (*Server).getCandidates,
 	"getcommittee":                 (*Server).getCommittee,
 	"getconnectioncount":           (*Server).getConnectionCount,
+	"getconsensusstate":            (*Server).getConsensusState,
 	"getcontractstate":             (*Server).getContractState,
 	"getnativecontracts":           (*Server).getNativeContracts,
 	"getnep11balances":             (*Server).getNEP11Balances,
@@ -252,6 +276,8 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"gettransactionheight":         (*Server).getTransactionHeight,
 	"getunclaimedgas":              (*Server).getUnclaimedGas,
 	"getnextblockvalidators":       (*Server).getNextBlockValidators,
+	"getnotaryrequeststatus":       (*Server).getNotaryRequestStatus,
+	"getoraclestatus":              (*Server).getOracleStatus,
 	"getversion":                   (*Server).getVersion,
 	"invokefunction":               (*Server).invokeFunction,
 	"invokefunctionhistoric":       (*Server).invokeFunctionHistoric,
@@ -378,6 +404,7 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 		transactionCh:     make(chan *transaction.Transaction),
 		notaryRequestCh:   make(chan mempoolevent.Event),
 		blockHeaderCh:     make(chan *block.Header),
+		storageCh:         make(chan *state.StorageChangeEvent),
 		subEventsToExitCh: make(chan struct{}),
 	}
 }
@@ -500,6 +527,18 @@ func (s *Server) SetOracleHandler(orc OracleHandler) {
 	s.oracle.Store(orc)
 }
 
+// SetConsensusHandler allows to update consensus handler used by the Server.
+// It can be nil if consensus service is disabled.
+func (s *Server) SetConsensusHandler(cons ConsensusHandler) {
+	s.consensus.Store(&cons)
+}
+
+// SetNotaryHandler allows to update notary handler used by the Server.
+// It can be nil if notary service is disabled.
+func (s *Server) SetNotaryHandler(n NotaryHandler) {
+	s.notary.Store(&n)
+}
+
 func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Request) {
 	// Restrict request body before further processing.
 	httpRequest.Body = http.MaxBytesReader(w, httpRequest.Body, int64(s.config.MaxRequestBodyBytes))
@@ -1857,6 +1896,58 @@ func (s *Server) getHistoricalContractState(root util.Uint256, csHash util.Uint1
 	return contract, nil
 }
 
+// getConsensusState returns dBFT state and round timeline of the consensus
+// service running on this node.
+func (s *Server) getConsensusState(_ params.Params) (any, *neorpc.Error) {
+	consPtr := s.consensus.Load()
+	if consPtr == nil || *consPtr == nil {
+		return nil, neorpc.ErrConsensusDisabled
+	}
+	st := (*consPtr).GetState()
+	res := &result.ConsensusState{
+		Height:     st.Height,
+		View:       st.View,
+		Primary:    st.Primary,
+		Index:      st.Index,
+		Validators: st.Validators,
+		Rounds:     make([]result.ConsensusRound, 0, len(st.Rounds)),
+	}
+	for _, r := range st.Rounds {
+		rr := result.ConsensusRound{
+			Height:           r.Height,
+			View:             r.View,
+			Primary:          r.Primary,
+			Start:            uint64(r.Start.UnixMilli()),
+			Accepted:         !r.Accepted.IsZero(),
+			ChangeViews:      make(map[string]int, len(r.ChangeViews)),
+			RecoveryRequests: r.RecoveryRequests,
+			RecoveryMessages: r.RecoveryMessages,
+			Late:             r.Late,
+			Missing:          r.Missing,
+		}
+		for reason, n := range r.ChangeViews {
+			rr.ChangeViews[reason.String()] = n
+		}
+		if !r.PrepareRequest.IsZero() {
+			rr.PrepareRequest = msBetween(r.Start, r.PrepareRequest)
+			if !r.Commit.IsZero() {
+				rr.PrepareResponse = msBetween(r.PrepareRequest, r.Commit)
+			}
+		}
+		if !r.Commit.IsZero() && rr.Accepted {
+			rr.Commit = msBetween(r.Commit, r.Accepted)
+		}
+		res.Rounds = append(res.Rounds, rr)
+	}
+	return res, nil
+}
+
+// msBetween returns the number of milliseconds between two time points.
+func msBetween(from, to time.Time) *uint64 {
+	ms := uint64(to.Sub(from).Milliseconds())
+	return &ms
+}
+
 func (s *Server) getStateHeight(_ params.Params) (any, *neorpc.Error) {
 	var height = s.chain.BlockHeight()
 	var stateHeight = s.chain.GetStateModule().CurrentValidatedHeight()
@@ -2794,6 +2885,67 @@ func getRelayResult(err error, hash util.Uint256) (any, *neorpc.Error) {
 	}
 }
 
+// getOracleStatus returns the state of oracle requests being processed by the
+// oracle service running on this node.
+func (s *Server) getOracleStatus(_ params.Params) (any, *neorpc.Error) {
+	oraclePtr := s.oracle.Load()
+	if oraclePtr == nil {
+		return nil, neorpc.ErrOracleDisabled
+	}
+	var (
+		st  = oraclePtr.(OracleHandler).GetStatus()
+		now = time.Now()
+		res = &result.OracleStatus{
+			Nodes:    st.Nodes,
+			Required: st.Required,
+			Requests: make([]result.OracleRequestStatus, 0, len(st.Requests)),
+		}
+	)
+	for _, r := range st.Requests {
+		rs := result.OracleRequestStatus{
+			ID:               r.ID,
+			URL:              r.URL,
+			Processed:        r.Processed,
+			Reason:           r.Reason,
+			Attempts:         r.Attempts,
+			Backup:           r.Backup,
+			Sent:             r.Sent,
+			Signatures:       r.Signatures,
+			BackupSignatures: r.BackupSignatures,
+		}
+		if rs.Signatures == nil {
+			rs.Signatures = keys.PublicKeys{}
+		}
+		if rs.BackupSignatures == nil {
+			rs.BackupSignatures = keys.PublicKeys{}
+		}
+		if r.Processed {
+			code := r.Code
+			rs.Code = &code
+		}
+		if !r.LastProcessed.IsZero() {
+			rs.LastProcessed = uint64(r.LastProcessed.UnixMilli())
+			rs.RetryIn = msUntil(now, r.NextRetry)
+			rs.ExpiresIn = msUntil(now, r.Expires)
+		}
+		rs.Missing = keys.PublicKeys{}
+		for _, pub := range st.Nodes {
+			if !slices.ContainsFunc(r.Signatures, pub.Equal) && !slices.ContainsFunc(r.BackupSignatures, pub.Equal) {
+				rs.Missing = append(rs.Missing, pub)
+			}
+		}
+		res.Requests = append(res.Requests, rs)
+	}
+	return res, nil
+}
+
+// msUntil returns the number of milliseconds left until the deadline or zero
+// if it has passed.
+func msUntil(now, deadline time.Time) *uint64 {
+	ms := uint64(max(deadline.Sub(now).Milliseconds(), 0))
+	return &ms
+}
+
 func (s *Server) submitOracleResponse(ps params.Params) (any, *neorpc.Error) {
 	oraclePtr := s.oracle.Load()
 	if oraclePtr == nil {
@@ -2856,6 +3008,9 @@ func (s *Server) subscribe(reqParams params.Params, sub *subscriber) (any, *neor
 	if event == neorpc.NotaryRequestEventID && !s.chain.P2PSigExtensionsEnabled() {
 		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "P2PSigExtensions are disabled")
 	}
+	if event == neorpc.StorageChangedEventID && !s.chain.GetConfig().Ledger.SaveStorageDiffs {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "storage diffs are not saved by the node")
+	}
 	// Optional filter.
 	var filter neorpc.SubscriptionFilter
 	if p := reqParams.Value(1); p != nil {
@@ -2883,6 +3038,10 @@ func (s *Server) subscribe(reqParams params.Params, sub *subscriber) (any, *neor
 			flt := new(neorpc.ExecutionFilter)
 			err = jd.Decode(flt)
 			filter = *flt
+		case neorpc.StorageChangedEventID:
+			flt := new(neorpc.StorageChangeFilter)
+			err = jd.Decode(flt)
+			filter = *flt
 		default:
 		}
 		if err != nil {
@@ -2958,6 +3117,11 @@ func (s *Server) subscribeToChannel(event neorpc.EventID) {
 			s.chain.SubscribeForHeadersOfAddedBlocks(s.blockHeaderCh)
 		}
 		s.blockHeaderSubs++
+	case neorpc.StorageChangedEventID:
+		if s.storageSubs == 0 {
+			s.chain.SubscribeForStorageChanges(s.storageCh)
+		}
+		s.storageSubs++
 	default:
 	}
 }
@@ -3019,6 +3183,11 @@ func (s *Server) unsubscribeFromChannel(event neorpc.EventID) {
 		if s.blockHeaderSubs == 0 {
 			s.chain.UnsubscribeFromHeadersOfAddedBlocks(s.blockHeaderCh)
 		}
+	case neorpc.StorageChangedEventID:
+		s.storageSubs--
+		if s.storageSubs == 0 {
+			s.chain.UnsubscribeFromStorageChanges(s.storageCh)
+		}
 	default:
 	}
 }
@@ -3072,6 +3241,9 @@ chloop:
 		case header := <-s.blockHeaderCh:
 			resp.Event = neorpc.HeaderOfAddedBlockEventID
 			resp.Payload[0] = header
+		case change := <-s.storageCh:
+			resp.Event = neorpc.StorageChangedEventID
+			resp.Payload[0] = change
 		}
 		s.subsLock.RLock()
 	subloop:
@@ -3125,6 +3297,7 @@ chloop:
 	s.chain.UnsubscribeFromNotifications(s.notificationCh)
 	s.chain.UnsubscribeFromExecutions(s.executionCh)
 	s.chain.UnsubscribeFromHeadersOfAddedBlocks(s.blockHeaderCh)
+	s.chain.UnsubscribeFromStorageChanges(s.storageCh)
 	if s.chain.P2PSigExtensionsEnabled() {
 		s.coreServer.UnsubscribeFromNotaryRequests(s.notaryRequestCh)
 	}
@@ -3138,6 +3311,7 @@ drainloop:
 		case <-s.transactionCh:
 		case <-s.notaryRequestCh:
 		case <-s.blockHeaderCh:
+		case <-s.storageCh:
 		default:
 			break drainloop
 		}
@@ -3150,6 +3324,7 @@ drainloop:
 	close(s.executionCh)
 	close(s.notaryRequestCh)
 	close(s.blockHeaderCh)
+	close(s.storageCh)
 	// notify Shutdown routine
 	close(s.subEventsToExitCh)
 }
@@ -3289,6 +3464,66 @@ func (s *Server) getRawNotaryPool(_ params.Params) (any, *neorpc.Error) {
 	return res, nil
 }
 
+// getNotaryRequestStatus returns the state of notary requests with the given
+// main transaction hash. Fallbacks are taken from the notary request pool and
+// the witness collection state is provided by the notary service if it's
+// running on this node.
+func (s *Server) getNotaryRequestStatus(reqParams params.Params) (any, *neorpc.Error) {
+	if !s.chain.P2PSigExtensionsEnabled() {
+		return nil, neorpc.NewInternalServerError("P2PSignatureExtensions are disabled")
+	}
+
+	mainHash, err := reqParams.Value(0).GetUint256()
+	if err != nil {
+		return nil, neorpc.ErrInvalidParams
+	}
+	res := &result.NotaryRequestStatus{
+		Hash:      mainHash,
+		Height:    s.chain.BlockHeight(),
+		Fallbacks: []result.NotaryFallback{},
+	}
+	s.coreServer.GetNotaryPool().IterateVerifiedTransactions(func(tx *transaction.Transaction, data any) bool {
+		if data != nil && data.(*payload.P2PNotaryRequest).MainTransaction.Hash().Equals(mainHash) {
+			res.Fallbacks = append(res.Fallbacks, result.NotaryFallback{
+				Hash:            tx.Hash(),
+				NotValidBefore:  tx.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height,
+				ValidUntilBlock: tx.ValidUntilBlock,
+			})
+		}
+		return true
+	})
+	if len(res.Fallbacks) == 0 {
+		return nil, neorpc.ErrUnknownTransaction
+	}
+
+	ntrPtr := s.notary.Load()
+	if ntrPtr == nil || *ntrPtr == nil {
+		res.Reason = "notary service is not running"
+		return res, nil
+	}
+	st, ok := (*ntrPtr).GetRequestStatus(mainHash)
+	if !ok {
+		res.Reason = "request is not tracked by notary service"
+		return res, nil
+	}
+	res.Tracked = true
+	res.NotValidBefore = st.MinNotValidBefore
+	res.Main = st.Main
+	res.Completed = st.Completed
+	res.Sent = st.Sent
+	res.Reason = st.Reason
+	for _, w := range st.Witnesses {
+		res.Witnesses = append(res.Witnesses, result.NotaryWitness{
+			Account:  w.Account,
+			Type:     w.Type.String(),
+			SigsLeft: w.SigsLeft,
+			Signed:   w.Signed,
+			Missing:  w.Missing,
+		})
+	}
+	return res, nil
+}
+
 func (s *Server) getRawNotaryTransaction(reqParams params.Params) (any, *neorpc.Error) {
 	if !s.chain.P2PSigExtensionsEnabled() {
 		return nil, neorpc.NewInternalServerError("P2PSignatureExtensions are disabled")
@@ -3377,3 +3612,30 @@ func (s *Server) getBlockNotifications(reqParams params.Params) (any, *neorpc.Er
 
 	return notifications, nil
 }
+
+// getBlockStorageDiff returns contract storage changes made by the block.
+func (s *Server) getBlockStorageDiff(reqParams params.Params) (any, *neorpc.Error) {
+	if !s.chain.GetConfig().Ledger.SaveStorageDiffs {
+		return nil, neorpc.NewInvalidRequestError("storage diffs are not saved by the node")
+	}
+	hash, respErr := s.blockHashFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	h, err := s.chain.GetHeader(hash)
+	if err != nil {
+		return nil, neorpc.ErrUnknownBlock
+	}
+	diff, err := s.chain.GetStorageDiff(h.Index)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownBlock, "no storage diff for the block")
+		}
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get storage diff: %s", err))
+	}
+	return &result.BlockStorageDiff{
+		Hash:    hash,
+		Index:   h.Index,
+		Changes: diff,
+	}, nil
+}
@@ -23,10 +23,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/dbft"
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/internal/testchain"
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/consensus"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
@@ -44,6 +46,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/notary"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
 	rpc2 "github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -1228,6 +1232,30 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getconsensusstate": {
+		{
+			name:    "disabled",
+			params:  "[]",
+			fail:    true,
+			errCode: neorpc.ErrConsensusDisabledCode,
+		},
+	},
+	"getblockstoragediff": {
+		{
+			name:    "disabled",
+			params:  "[1]",
+			fail:    true,
+			errCode: neorpc.InvalidRequestCode,
+		},
+	},
+	"getoraclestatus": {
+		{
+			name:    "disabled",
+			params:  "[]",
+			fail:    true,
+			errCode: neorpc.ErrOracleDisabledCode,
+		},
+	},
 	"getconnectioncount": {
 		{
 			params: "[]",
@@ -2367,6 +2395,158 @@ func TestRPC(t *testing.T) {
 	})
 }
 
+type fakeConsensusHandler struct {
+	state consensus.State
+}
+
+func (f *fakeConsensusHandler) GetState() consensus.State { return f.state }
+
+func TestGetConsensusState(t *testing.T) {
+	_, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getconsensusstate", "params": []}`
+
+	rpcSrv.SetConsensusHandler(nil)
+	body := doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+	checkErrGetResult(t, body, true, neorpc.ErrConsensusDisabledCode)
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+	start := time.UnixMilli(1_000_000)
+	rpcSrv.SetConsensusHandler(&fakeConsensusHandler{state: consensus.State{
+		Height:     7,
+		View:       1,
+		Primary:    1,
+		Index:      0,
+		Validators: []*keys.PublicKey{pub},
+		Rounds: []consensus.Round{{
+			Height:           7,
+			Start:            start,
+			PrepareRequest:   start.Add(100 * time.Millisecond),
+			ChangeViews:      map[dbft.ChangeViewReason]int{dbft.CVTimeout: 2},
+			RecoveryMessages: 1,
+			Missing:          []*keys.PublicKey{pub},
+		}, {
+			Height:         7,
+			View:           1,
+			Primary:        1,
+			Start:          start.Add(time.Second),
+			PrepareRequest: start.Add(1100 * time.Millisecond),
+			Commit:         start.Add(1300 * time.Millisecond),
+			Accepted:       start.Add(1600 * time.Millisecond),
+		}},
+	}})
+	body = doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+	res := checkErrGetResult(t, body, false, 0)
+	var actual result.ConsensusState
+	require.NoError(t, json.Unmarshal(res, &actual))
+
+	ms := func(v uint64) *uint64 { return &v }
+	require.Equal(t, result.ConsensusState{
+		Height:     7,
+		View:       1,
+		Primary:    1,
+		Index:      0,
+		Validators: keys.PublicKeys{pub},
+		Rounds: []result.ConsensusRound{{
+			Height:           7,
+			Start:            1_000_000,
+			PrepareRequest:   ms(100),
+			ChangeViews:      map[string]int{"Timeout": 2},
+			RecoveryMessages: 1,
+			Missing:          keys.PublicKeys{pub},
+		}, {
+			Height:          7,
+			View:            1,
+			Primary:         1,
+			Start:           1_001_000,
+			PrepareRequest:  ms(100),
+			PrepareResponse: ms(200),
+			Commit:          ms(300),
+			Accepted:        true,
+			ChangeViews:     map[string]int{},
+		}},
+	}, actual)
+}
+
+type fakeOracleHandler struct {
+	status oracle.Status
+}
+
+func (f *fakeOracleHandler) AddResponse(*keys.PublicKey, uint64, []byte) {}
+func (f *fakeOracleHandler) GetStatus() oracle.Status                    { return f.status }
+
+func TestGetOracleStatus(t *testing.T) {
+	_, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getoraclestatus", "params": []}`
+
+	body := doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+	checkErrGetResult(t, body, true, neorpc.ErrOracleDisabledCode)
+
+	var pubs keys.PublicKeys
+	for range 3 {
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		pubs = append(pubs, priv.PublicKey())
+	}
+	last := time.Now().Add(-time.Minute)
+	rpcSrv.SetOracleHandler(&fakeOracleHandler{status: oracle.Status{
+		Nodes:    pubs,
+		Required: 2,
+		Requests: []oracle.RequestStatus{{
+			ID: 1,
+		}, {
+			ID:               2,
+			URL:              "https://example.com",
+			Processed:        true,
+			Code:             transaction.Forbidden,
+			Reason:           "not finalized",
+			Attempts:         2,
+			LastProcessed:    last,
+			NextRetry:        last.Add(time.Second),
+			Expires:          last.Add(time.Hour),
+			Backup:           true,
+			Signatures:       keys.PublicKeys{pubs[0]},
+			BackupSignatures: keys.PublicKeys{pubs[0], pubs[1]},
+		}},
+	}})
+	body = doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+	res := checkErrGetResult(t, body, false, 0)
+	var actual result.OracleStatus
+	require.NoError(t, json.Unmarshal(res, &actual))
+
+	require.Equal(t, pubs, actual.Nodes)
+	require.Equal(t, 2, actual.Required)
+	require.Len(t, actual.Requests, 2)
+	require.Equal(t, result.OracleRequestStatus{
+		ID:               1,
+		Signatures:       keys.PublicKeys{},
+		BackupSignatures: keys.PublicKeys{},
+		Missing:          pubs,
+	}, actual.Requests[0])
+
+	r := actual.Requests[1]
+	require.NotNil(t, r.RetryIn)
+	require.Equal(t, uint64(0), *r.RetryIn)
+	require.NotNil(t, r.ExpiresIn)
+	require.InDelta(t, uint64(59*time.Minute/time.Millisecond), *r.ExpiresIn, float64(time.Minute/time.Millisecond))
+	code := transaction.Forbidden
+	r.RetryIn, r.ExpiresIn = nil, nil
+	require.Equal(t, result.OracleRequestStatus{
+		ID:               2,
+		URL:              "https://example.com",
+		Processed:        true,
+		Code:             &code,
+		Reason:           "not finalized",
+		Attempts:         2,
+		LastProcessed:    uint64(last.UnixMilli()),
+		Backup:           true,
+		Signatures:       keys.PublicKeys{pubs[0]},
+		BackupSignatures: keys.PublicKeys{pubs[0], pubs[1]},
+		Missing:          keys.PublicKeys{pubs[2]},
+	}, r)
+}
+
 func TestSubmitOracle(t *testing.T) {
 	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "submitoracleresponse", "params": %s}`
 
@@ -2413,6 +2593,7 @@ func TestNotaryRequestRPC(t *testing.T) {
 	rpcSubmit := `{"jsonrpc": "2.0", "id": 1, "method": "submitnotaryrequest", "params": %s}`
 	rpcPool := `{"jsonrpc": "2.0", "id": 1, "method": "getrawnotarypool", "params": []}`
 	rpcTx := `{"jsonrpc": "2.0", "id": 1, "method": "getrawnotarytransaction", "params": ["%s", %d]}`
+	rpcStatus := `{"jsonrpc": "2.0", "id": 1, "method": "getnotaryrequeststatus", "params": ["%s"]}`
 
 	t.Run("disabled P2PSigExtensions", func(t *testing.T) {
 		_, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
@@ -2430,9 +2611,13 @@ func TestNotaryRequestRPC(t *testing.T) {
 			body := doRPCCallOverHTTP(fmt.Sprintf(rpcTx, " ", 1), httpSrv.URL, t)
 			checkErrGetResult(t, body, true, neorpc.InternalServerErrorCode)
 		})
+		t.Run("getnotaryrequeststatus", func(t *testing.T) {
+			body := doRPCCallOverHTTP(fmt.Sprintf(rpcStatus, " "), httpSrv.URL, t)
+			checkErrGetResult(t, body, true, neorpc.InternalServerErrorCode)
+		})
 	})
 
-	chain, _, httpSrv := initServerWithInMemoryChainAndServices(t, false, true, false)
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChainAndServices(t, false, true, false)
 
 	submitNotaryRequest := func(t *testing.T, fail bool, errCode int64, params ...string) func(t *testing.T) {
 		return func(t *testing.T) {
@@ -2597,6 +2782,87 @@ func TestNotaryRequestRPC(t *testing.T) {
 			checkGetTxBytes(t, notaryRequest2.FallbackTransaction)
 		})
 	})
+
+	t.Run("getnotaryrequeststatus", func(t *testing.T) {
+		getStatus := func(t *testing.T, h util.Uint256) *result.NotaryRequestStatus {
+			body := doRPCCallOverHTTP(fmt.Sprintf(rpcStatus, h.StringLE()), httpSrv.URL, t)
+			res := checkErrGetResult(t, body, false, 0)
+			actual := new(result.NotaryRequestStatus)
+			require.NoError(t, json.Unmarshal(res, actual))
+			return actual
+		}
+		mainHash := notaryRequest1.MainTransaction.Hash()
+		fallback := notaryRequest1.FallbackTransaction
+
+		t.Run("invalid param", func(t *testing.T) {
+			body := doRPCCallOverHTTP(fmt.Sprintf(rpcStatus, "invalid"), httpSrv.URL, t)
+			checkErrGetResult(t, body, true, neorpc.InvalidParamsCode)
+		})
+		t.Run("unknown transaction", func(t *testing.T) {
+			body := doRPCCallOverHTTP(fmt.Sprintf(rpcStatus, fallback.Hash().StringLE()), httpSrv.URL, t)
+			checkErrGetResult(t, body, true, neorpc.ErrUnknownTransactionCode)
+		})
+		t.Run("service disabled", func(t *testing.T) {
+			actual := getStatus(t, mainHash)
+			require.Equal(t, &result.NotaryRequestStatus{
+				Hash:   mainHash,
+				Height: chain.BlockHeight(),
+				Fallbacks: []result.NotaryFallback{{
+					Hash:            fallback.Hash(),
+					NotValidBefore:  fallback.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height,
+					ValidUntilBlock: fallback.ValidUntilBlock,
+				}},
+				Reason: "notary service is not running",
+			}, actual)
+		})
+		t.Run("not tracked", func(t *testing.T) {
+			rpcSrv.SetNotaryHandler(&fakeNotaryHandler{})
+			actual := getStatus(t, mainHash)
+			require.False(t, actual.Tracked)
+			require.Equal(t, "request is not tracked by notary service", actual.Reason)
+		})
+		t.Run("tracked", func(t *testing.T) {
+			sender := testchain.PrivateKeyByID(0)
+			rpcSrv.SetNotaryHandler(&fakeNotaryHandler{statuses: map[util.Uint256]notary.RequestStatus{
+				mainHash: {
+					Main:              notaryRequest1.MainTransaction,
+					MinNotValidBefore: 123,
+					Witnesses: []notary.WitnessStatus{{
+						Account:  sender.GetScriptHash(),
+						Type:     notary.Signature,
+						SigsLeft: 1,
+						Signed:   keys.PublicKeys{},
+						Missing:  keys.PublicKeys{sender.PublicKey()},
+					}},
+					Reason: "1 signature(s) left to collect",
+				},
+			}})
+			t.Cleanup(func() { rpcSrv.SetNotaryHandler(nil) })
+			actual := getStatus(t, mainHash)
+			require.True(t, actual.Tracked)
+			require.False(t, actual.Completed)
+			require.False(t, actual.Sent)
+			require.Equal(t, uint32(123), actual.NotValidBefore)
+			require.Equal(t, mainHash, actual.Main.Hash())
+			require.Equal(t, "1 signature(s) left to collect", actual.Reason)
+			require.Len(t, actual.Witnesses, 1)
+			require.Equal(t, sender.GetScriptHash(), actual.Witnesses[0].Account)
+			require.Equal(t, "signature", actual.Witnesses[0].Type)
+			require.Equal(t, 1, actual.Witnesses[0].SigsLeft)
+			require.Empty(t, actual.Witnesses[0].Signed)
+			require.Equal(t, 1, len(actual.Witnesses[0].Missing))
+			require.True(t, sender.PublicKey().Equal(actual.Witnesses[0].Missing[0]))
+		})
+	})
+}
+
+type fakeNotaryHandler struct {
+	statuses map[util.Uint256]notary.RequestStatus
+}
+
+func (f *fakeNotaryHandler) GetRequestStatus(h util.Uint256) (notary.RequestStatus, bool) {
+	st, ok := f.statuses[h]
+	return st, ok
 }
 
 // createValidNotaryRequest creates and signs P2PNotaryRequest payload which can
@@ -582,6 +582,45 @@ func TestHeaderOfAddedBlockSubscriptions(t *testing.T) {
 	callUnsubscribe(t, c, respMsgs, headerSubID)
 }
 
+func TestStorageChangeSubscriptions(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.SaveStorageDiffs = true
+	})
+	c, respMsgs := initWSClient(t, httpSrv, rpcSrv)
+	gasHash := chain.UtilityTokenHash()
+
+	stSubID := callSubscribe(t, c, respMsgs, fmt.Sprintf(`["storage_changed", {"contract":"%s", "prefix":"FA=="}]`, gasHash.StringLE()))
+	blockSubID := callSubscribe(t, c, respMsgs, `["block_added"]`)
+
+	b := testchain.NewBlock(t, chain, 1, 0)
+	require.NoError(t, chain.AddBlock(b))
+
+	var changes int
+	for {
+		var resp = new(neorpc.Notification)
+		select {
+		case body := <-respMsgs:
+			require.NoError(t, json.Unmarshal(body, resp))
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+		if resp.Event == neorpc.BlockEventID {
+			break
+		}
+		require.Equal(t, neorpc.StorageChangedEventID, resp.Event)
+		rmap := resp.Payload[0].(map[string]any)
+		require.Equal(t, "0x"+gasHash.StringLE(), rmap["contract"])
+		require.Equal(t, b.Hash().StringLE(), strings.TrimPrefix(rmap["blockhash"].(string), "0x"))
+		key, err := base64.StdEncoding.DecodeString(rmap["key"].(string))
+		require.NoError(t, err)
+		require.Equal(t, byte(20), key[0])
+		changes++
+	}
+	require.NotZero(t, changes)
+	callUnsubscribe(t, c, respMsgs, stSubID)
+	callUnsubscribe(t, c, respMsgs, blockSubID)
+}
+
 func testMaxSubscriptions(t *testing.T, f func(*config.Config), maxFeeds int) {
 	var subIDs = make([]string, 0)
 	_, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, f)
@@ -630,6 +669,7 @@ func TestBadSubUnsub(t *testing.T) {
 		"notification filter 2":  `{"jsonrpc": "2.0", "method": "subscribe", "params": ["notification_from_execution", "name"], "id": 1}`,
 		"execution filter 1":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", "FAULT"], "id": 1}`,
 		"execution filter 2":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", {"state": "STOP"}], "id": 1}`,
+		"storage diffs disabled": `{"jsonrpc": "2.0", "method": "subscribe", "params": ["storage_changed"], "id": 1}`,
 	}
 	var unsubCases = map[string]string{
 		"no params":         `{"jsonrpc": "2.0", "method": "unsubscribe", "params": [], "id": 1}`,
@@ -0,0 +1,679 @@
+/*
+Package statefetcher implements a service that fetches contract storage
+snapshot for the state synchronisation point from NeoFS (or from a local
+directory) and passes it to the state sync module.
+
+Snapshot format is the one used by `util upload-state` command: a single byte
+version, uint32 LE network magic, uint32 LE height, 32-byte state root
+followed by a sequence of var-bytes-encoded MPT key/value pairs. Version 1
+snapshots also contain the state root signed by state validators (serialized
+state.MPTRoot) right after the header, it's required for networks without
+StateRootInHeader.
+*/
+package statefetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	gio "github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/services/helpers/neofs"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neofs-sdk-go/client"
+	"github.com/nspcc-dev/neofs-sdk-go/container"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	"github.com/nspcc-dev/neofs-sdk-go/object"
+	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
+	"github.com/nspcc-dev/neofs-sdk-go/pool"
+	"github.com/nspcc-dev/neofs-sdk-go/user"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultDownloaderWorkersCount is the default number of workers
+	// downloading snapshot ranges.
+	DefaultDownloaderWorkersCount = 16
+	// DefaultRangeSize is the default size of a single snapshot range
+	// downloaded by a worker.
+	DefaultRangeSize = 4 * 1024 * 1024
+	// DefaultKeyValueBatchSize is the default number of contract storage items
+	// passed to the state sync module at once.
+	DefaultKeyValueBatchSize = 10000
+
+	// snapshotVersion is the snapshot format version without signed state
+	// root.
+	snapshotVersion = 0
+	// signedSnapshotVersion is the snapshot format version with signed state
+	// root.
+	signedSnapshotVersion = 1
+	// headerSize is the size of snapshot header (version, magic, height and
+	// state root).
+	headerSize = 1 + 4 + 4 + util.Uint256Size
+)
+
+// Ledger is an interface to the state sync module sufficient for Service.
+type Ledger interface {
+	GetConfig() config.Blockchain
+	GetStateSyncPoint() (uint32, util.Uint256)
+	AddContractStorageItems(kvs []storage.KeyValue) error
+	FinalizeContractStorage(sr *state.MPTRoot) error
+}
+
+// snapshot is a source of state snapshot data.
+type snapshot interface {
+	// Size returns the size of the snapshot in bytes.
+	Size() uint64
+	// ReadRange returns length bytes of the snapshot starting from offset.
+	ReadRange(ctx context.Context, offset, length uint64) ([]byte, error)
+	// Close releases snapshot resources.
+	Close() error
+}
+
+// Service is a service that fetches contract storage snapshot from NeoFS.
+type Service struct {
+	// isActive denotes whether the service is working or in the process of shutdown.
+	isActive   atomic.Bool
+	isShutdown atomic.Bool
+	log        *zap.Logger
+	cfg        config.NeoFSStateFetcher
+
+	chain   Ledger
+	pool    neofs.PoolWrapper
+	account *wallet.Account
+
+	// Global context for download operations cancellation.
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	// A set of routines managing graceful Service shutdown.
+	quit             chan bool
+	quitOnce         sync.Once
+	exiterToShutdown chan struct{}
+	fetcherToExiter  chan struct{}
+
+	shutdownCallback func()
+}
+
+// New creates a new StateFetcher Service.
+func New(chain Ledger, cfg config.NeoFSStateFetcher, logger *zap.Logger, shutdownCallback func()) (*Service, error) {
+	if !cfg.Enabled {
+		return &Service{}, nil
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = neofs.DefaultTimeout
+	}
+	if cfg.DownloaderWorkersCount <= 0 {
+		cfg.DownloaderWorkersCount = DefaultDownloaderWorkersCount
+	}
+	if cfg.RangeSize == 0 {
+		cfg.RangeSize = DefaultRangeSize
+	}
+	if cfg.KeyValueBatchSize <= 0 {
+		cfg.KeyValueBatchSize = DefaultKeyValueBatchSize
+	}
+	if cfg.StateAttribute == "" {
+		cfg.StateAttribute = neofs.DefaultStateAttribute
+	}
+	s := &Service{
+		chain:            chain,
+		log:              logger,
+		cfg:              cfg,
+		shutdownCallback: shutdownCallback,
+
+		quit:             make(chan bool),
+		exiterToShutdown: make(chan struct{}),
+		fetcherToExiter:  make(chan struct{}),
+	}
+	if cfg.Path != "" {
+		return s, nil
+	}
+
+	var err error
+	if cfg.UnlockWallet.Path != "" {
+		walletFromFile, err := wallet.NewWalletFromFileWithPassword(cfg.UnlockWallet.Path, cfg.UnlockWallet.Password)
+		if err != nil {
+			return nil, err
+		}
+		for _, acc := range walletFromFile.Accounts {
+			if err := acc.Decrypt(cfg.UnlockWallet.Password, walletFromFile.Scrypt); err == nil {
+				s.account = acc
+				break
+			}
+		}
+		if s.account == nil {
+			return nil, errors.New("failed to decrypt any account in the wallet")
+		}
+	} else {
+		s.account, err = wallet.NewAccount()
+		if err != nil {
+			return nil, err
+		}
+	}
+	params := pool.DefaultOptions()
+	params.SetHealthcheckTimeout(neofs.DefaultHealthcheckTimeout)
+	params.SetNodeDialTimeout(neofs.DefaultDialTimeout)
+	params.SetNodeStreamTimeout(neofs.DefaultStreamTimeout)
+	p, err := pool.New(pool.NewFlatNodeParams(cfg.Addresses), user.NewAutoIDSignerRFC6979(s.account.PrivateKey().PrivateKey), params)
+	if err != nil {
+		return nil, err
+	}
+	s.pool = neofs.PoolWrapper{Pool: p}
+	return s, nil
+}
+
+// Start runs the NeoFS StateFetcher service.
+func (s *Service) Start() error {
+	if s.IsShutdown() {
+		return errors.New("service is already shut down")
+	}
+	if !s.isActive.CompareAndSwap(false, true) {
+		return nil
+	}
+	s.log.Info("starting NeoFS StateFetcher service")
+	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
+	if s.cfg.Path == "" {
+		if err := s.checkContainer(); err != nil {
+			s.isActive.CompareAndSwap(true, false)
+			return err
+		}
+	}
+
+	// Start routine that manages Service shutdown process.
+	go s.exiter()
+
+	// Start snapshot fetching routine.
+	go s.fetcher()
+	return nil
+}
+
+// checkContainer dials NeoFS pool and checks that the configured container
+// belongs to the current network.
+func (s *Service) checkContainer() error {
+	var (
+		containerID  cid.ID
+		containerObj container.Container
+		err          error
+	)
+	if err = s.pool.Dial(context.Background()); err != nil {
+		return fmt.Errorf("failed to dial NeoFS pool: %w", err)
+	}
+	err = containerID.DecodeString(s.cfg.ContainerID)
+	if err != nil {
+		return fmt.Errorf("failed to decode container ID: %w", err)
+	}
+	err = s.retry(func() error {
+		containerObj, err = s.pool.ContainerGet(s.ctx, containerID, client.PrmContainerGet{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get container: %w", err)
+	}
+	containerMagic := containerObj.Attribute("Magic")
+	if containerMagic != strconv.Itoa(int(s.chain.GetConfig().Magic)) {
+		return fmt.Errorf("container magic mismatch: expected %d, got %s", s.chain.GetConfig().Magic, containerMagic)
+	}
+	return nil
+}
+
+// fetcher downloads the snapshot for the current state sync point and passes
+// its contents to the state sync module.
+func (s *Service) fetcher() {
+	defer close(s.fetcherToExiter)
+
+	var force bool
+	if err := s.fetch(); err != nil {
+		if !isContextCanceledErr(err) {
+			s.log.Error("NeoFS StateFetcher service: state fetching routine failed", zap.Error(err))
+		}
+		force = true
+	}
+	// Stop the service since there's nothing to do anymore.
+	s.stopService(force)
+}
+
+func (s *Service) fetch() error {
+	height, root := s.chain.GetStateSyncPoint()
+	start := time.Now()
+	snap, err := s.openSnapshot(height)
+	if err != nil {
+		return fmt.Errorf("failed to find state snapshot for height %d: %w", height, err)
+	}
+	defer snap.Close()
+	if snap.Size() < headerSize {
+		return fmt.Errorf("state snapshot is too short: %d bytes", snap.Size())
+	}
+	hdr, err := snap.ReadRange(s.ctx, 0, headerSize)
+	if err != nil {
+		return fmt.Errorf("failed to read state snapshot header: %w", err)
+	}
+	version, root, err := s.checkHeader(hdr, height, root)
+	if err != nil {
+		return err
+	}
+	s.log.Info("NeoFS StateFetcher service: downloading state snapshot",
+		zap.Uint32("height", height),
+		zap.String("state root", root.StringBE()),
+		zap.Uint64("size", snap.Size()))
+
+	var (
+		r     = gio.NewBinReaderFromIO(s.newRangeReader(snap, headerSize))
+		sr    *state.MPTRoot
+		batch = make([]storage.KeyValue, 0, s.cfg.KeyValueBatchSize)
+		count int
+	)
+	if version == signedSnapshotVersion {
+		sr = new(state.MPTRoot)
+		sr.DecodeBinary(r)
+		if r.Err != nil {
+			return fmt.Errorf("failed to decode signed state root: %w", r.Err)
+		}
+		if sr.Index != height || !sr.Root.Equals(root) {
+			return fmt.Errorf("signed state root mismatch: expected %s at %d, got %s at %d",
+				root.StringBE(), height, sr.Root.StringBE(), sr.Index)
+		}
+	}
+	for {
+		k := r.ReadVarBytes()
+		if errors.Is(r.Err, io.EOF) {
+			break
+		}
+		v := r.ReadVarBytes()
+		if r.Err != nil {
+			return fmt.Errorf("failed to decode contract storage item: %w", r.Err)
+		}
+		batch = append(batch, storage.KeyValue{Key: k, Value: v})
+		if len(batch) == s.cfg.KeyValueBatchSize {
+			if err = s.chain.AddContractStorageItems(batch); err != nil {
+				return err
+			}
+			count += len(batch)
+			batch = batch[:0]
+		}
+	}
+	if r.Err != nil && !errors.Is(r.Err, io.EOF) {
+		return fmt.Errorf("failed to decode contract storage item: %w", r.Err)
+	}
+	if len(batch) != 0 {
+		if err = s.chain.AddContractStorageItems(batch); err != nil {
+			return err
+		}
+		count += len(batch)
+	}
+	if err = s.chain.FinalizeContractStorage(sr); err != nil {
+		return err
+	}
+	s.log.Info("NeoFS StateFetcher service: state snapshot is processed",
+		zap.Uint32("height", height),
+		zap.Int("items", count),
+		zap.Duration("time spent", time.Since(start)))
+	return nil
+}
+
+// checkHeader checks snapshot header against the expected network magic,
+// state sync point and state root and returns snapshot version and state root.
+// The state root is only known in advance if StateRootInHeader is enabled,
+// otherwise the snapshot must contain the signed one.
+func (s *Service) checkHeader(hdr []byte, height uint32, root util.Uint256) (byte, util.Uint256, error) {
+	r := gio.NewBinReaderFromBuf(hdr)
+	version := r.ReadB()
+	magic := r.ReadU32LE()
+	h := r.ReadU32LE()
+	var sr util.Uint256
+	r.ReadBytes(sr[:])
+	if r.Err != nil {
+		return 0, sr, fmt.Errorf("failed to decode state snapshot header: %w", r.Err)
+	}
+	if version != snapshotVersion && version != signedSnapshotVersion {
+		return 0, sr, fmt.Errorf("unsupported state snapshot version %d", version)
+	}
+	if magic != uint32(s.chain.GetConfig().Magic) {
+		return 0, sr, fmt.Errorf("state snapshot magic mismatch: expected %d, got %d", s.chain.GetConfig().Magic, magic)
+	}
+	if h != height {
+		return 0, sr, fmt.Errorf("state snapshot height mismatch: expected %d, got %d", height, h)
+	}
+	if !s.chain.GetConfig().StateRootInHeader {
+		if version != signedSnapshotVersion {
+			return 0, sr, errors.New("state snapshot has no signed state root required without StateRootInHeader")
+		}
+	} else if !sr.Equals(root) {
+		return 0, sr, fmt.Errorf("state snapshot root mismatch: expected %s, got %s", root.StringBE(), sr.StringBE())
+	}
+	return version, sr, nil
+}
+
+// openSnapshot returns the snapshot for the given height either from the
+// local directory or from NeoFS.
+func (s *Service) openSnapshot(height uint32) (snapshot, error) {
+	if s.cfg.Path != "" {
+		return s.openFileSnapshot(height)
+	}
+	prm := client.PrmObjectSearch{}
+	filters := object.NewSearchFilters()
+	filters.AddFilter(s.cfg.StateAttribute, strconv.FormatUint(uint64(height), 10), object.MatchStringEqual)
+	prm.SetFilters(filters)
+
+	var oids []oid.ID
+	ctx, cancel := context.WithTimeout(s.ctx, s.cfg.Timeout)
+	defer cancel()
+	err := s.retry(func() error {
+		var err error
+		oids, err = neofs.ObjectSearch(ctx, s.pool, s.account.PrivateKey(), s.cfg.ContainerID, prm)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(oids) == 0 {
+		return nil, fmt.Errorf("no '%s' object found", s.cfg.StateAttribute)
+	}
+	if len(oids) > 1 {
+		s.log.Warn("NeoFS StateFetcher service: duplicated state objects found, using the first one",
+			zap.Uint32("height", height),
+			zap.Stringers("oids", oids))
+	}
+	var (
+		containerID cid.ID
+		hdr         *object.Object
+	)
+	if err = containerID.DecodeString(s.cfg.ContainerID); err != nil {
+		return nil, err
+	}
+	err = s.retry(func() error {
+		hdr, err = s.pool.ObjectHead(ctx, containerID, oids[0], user.NewAutoIDSignerRFC6979(s.account.PrivateKey().PrivateKey), client.PrmObjectHead{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state object header: %w", err)
+	}
+	return &neofsSnapshot{s: s, oid: oids[0].String(), size: hdr.PayloadSize()}, nil
+}
+
+// openFileSnapshot searches for the snapshot file with the given height in
+// the configured directory.
+func (s *Service) openFileSnapshot(height uint32) (snapshot, error) {
+	entries, err := os.ReadDir(s.cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	hdr := make([]byte, headerSize)
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		f, err := os.Open(filepath.Join(s.cfg.Path, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.ReadFull(f, hdr)
+		if err == nil {
+			r := gio.NewBinReaderFromBuf(hdr[1+4 : 1+4+4])
+			if r.ReadU32LE() == height {
+				st, err := f.Stat()
+				if err != nil {
+					_ = f.Close()
+					return nil, err
+				}
+				return &fileSnapshot{f: f, size: uint64(st.Size())}, nil
+			}
+		}
+		_ = f.Close()
+	}
+	return nil, fmt.Errorf("no state snapshot file found in %s", s.cfg.Path)
+}
+
+// newRangeReader returns a reader for the snapshot contents starting from
+// the given offset. Snapshot ranges are downloaded in parallel by
+// DownloaderWorkersCount routines.
+func (s *Service) newRangeReader(snap snapshot, offset uint64) io.Reader {
+	var (
+		size     = snap.Size() - offset
+		n        = int((size + s.cfg.RangeSize - 1) / s.cfg.RangeSize)
+		ranges   = make(chan int)
+		results  = make([]chan []byte, n)
+		inFlight = make(chan struct{}, 2*s.cfg.DownloaderWorkersCount)
+	)
+	for i := range results {
+		results[i] = make(chan []byte, 1)
+	}
+	go func() {
+		defer close(ranges)
+		for i := range n {
+			select {
+			case inFlight <- struct{}{}:
+			case <-s.ctx.Done():
+				return
+			}
+			select {
+			case ranges <- i:
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+	for range min(s.cfg.DownloaderWorkersCount, n) {
+		go func() {
+			for i := range ranges {
+				off := offset + uint64(i)*s.cfg.RangeSize
+				ctx, cancel := context.WithTimeout(s.ctx, s.cfg.Timeout)
+				data, err := snap.ReadRange(ctx, off, min(s.cfg.RangeSize, snap.Size()-off))
+				cancel()
+				if err != nil {
+					if !isContextCanceledErr(err) {
+						s.log.Error("NeoFS StateFetcher service: failed to download state snapshot range",
+							zap.Uint64("offset", off), zap.Error(err))
+					}
+					s.ctxCancel()
+					return
+				}
+				results[i] <- data
+			}
+		}()
+	}
+	return &rangeReader{s: s, results: results, inFlight: inFlight}
+}
+
+// rangeReader reads downloaded snapshot ranges in order.
+type rangeReader struct {
+	s        *Service
+	results  []chan []byte
+	inFlight chan struct{}
+	cur      []byte
+	next     int
+}
+
+// Read implements io.Reader interface.
+func (r *rangeReader) Read(p []byte) (int, error) {
+	for len(r.cur) == 0 {
+		if r.next == len(r.results) {
+			return 0, io.EOF
+		}
+		select {
+		case r.cur = <-r.results[r.next]:
+		case <-r.s.ctx.Done():
+			return 0, r.s.ctx.Err()
+		}
+		r.next++
+		<-r.inFlight
+	}
+	n := copy(p, r.cur)
+	r.cur = r.cur[n:]
+	return n, nil
+}
+
+// neofsSnapshot is a state snapshot stored in NeoFS.
+type neofsSnapshot struct {
+	s    *Service
+	oid  string
+	size uint64
+}
+
+// Size implements snapshot interface.
+func (n *neofsSnapshot) Size() uint64 {
+	return n.size
+}
+
+// ReadRange implements snapshot interface.
+func (n *neofsSnapshot) ReadRange(ctx context.Context, offset, length uint64) ([]byte, error) {
+	u, err := url.Parse(fmt.Sprintf("%s:%s/%s/range/%d|%d", neofs.URIScheme, n.s.cfg.ContainerID, n.oid, offset, length))
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	err = n.s.retry(func() error {
+		rc, err := neofs.GetWithClient(ctx, n.s.pool, n.s.account.PrivateKey(), u, false)
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		data, err = io.ReadAll(rc)
+		return err
+	})
+	if err == nil && uint64(len(data)) != length {
+		err = fmt.Errorf("unexpected range length: expected %d, got %d", length, len(data))
+	}
+	return data, err
+}
+
+// Close implements snapshot interface.
+func (n *neofsSnapshot) Close() error {
+	return nil
+}
+
+// fileSnapshot is a state snapshot stored in a local file.
+type fileSnapshot struct {
+	f    *os.File
+	size uint64
+}
+
+// Size implements snapshot interface.
+func (f *fileSnapshot) Size() uint64 {
+	return f.size
+}
+
+// ReadRange implements snapshot interface.
+func (f *fileSnapshot) ReadRange(_ context.Context, offset, length uint64) ([]byte, error) {
+	data := make([]byte, length)
+	_, err := f.f.ReadAt(data, int64(offset))
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Close implements snapshot interface.
+func (f *fileSnapshot) Close() error {
+	return f.f.Close()
+}
+
+// Shutdown stops the NeoFS StateFetcher service. It cancels all in-progress
+// downloading operations and waits until all service routines finish their
+// work.
+func (s *Service) Shutdown() {
+	if !s.IsActive() || s.IsShutdown() {
+		return
+	}
+	s.stopService(true)
+	<-s.exiterToShutdown
+}
+
+// stopService close quitting goroutine once. It's the only entrypoint to shutdown
+// procedure.
+func (s *Service) stopService(force bool) {
+	s.quitOnce.Do(func() {
+		s.quit <- force
+		close(s.quit)
+	})
+}
+
+// exiter is a routine that is listening to a quitting signal and manages graceful
+// Service shutdown process.
+func (s *Service) exiter() {
+	if !s.isActive.Load() {
+		return
+	}
+	// Closing signal may come from anyone, but only once.
+	force := <-s.quit
+	s.log.Info("shutting down NeoFS StateFetcher service",
+		zap.Bool("force", force),
+	)
+
+	s.isActive.CompareAndSwap(true, false)
+	s.isShutdown.CompareAndSwap(false, true)
+	// Cancel all pending downloads, they're not needed anymore.
+	s.ctxCancel()
+	<-s.fetcherToExiter
+
+	// Everything is done, release resources, turn off the activity marker and let
+	// the server know about it.
+	if s.pool.Pool != nil {
+		_ = s.pool.Close()
+	}
+	_ = s.log.Sync()
+	s.shutdownCallback()
+
+	// Notify Shutdown routine in case if it's user-triggered shutdown.
+	close(s.exiterToShutdown)
+}
+
+// IsShutdown returns true if the NeoFS StateFetcher service is completely shutdown.
+// The service can not be started again.
+func (s *Service) IsShutdown() bool {
+	return s.isShutdown.Load()
+}
+
+// IsActive returns true if the NeoFS StateFetcher service is running.
+func (s *Service) IsActive() bool {
+	return s.isActive.Load()
+}
+
+// retry function with exponential backoff.
+func (s *Service) retry(action func() error) error {
+	var (
+		err     error
+		backoff = neofs.InitialBackoff
+		timer   = time.NewTimer(0)
+	)
+
+	for i := range neofs.MaxRetries {
+		if err = action(); err == nil {
+			return nil
+		}
+		if i == neofs.MaxRetries-1 {
+			break
+		}
+		timer.Reset(backoff)
+
+		select {
+		case <-timer.C:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+		backoff *= time.Duration(neofs.BackoffFactor)
+		if backoff > neofs.MaxBackoff {
+			backoff = neofs.MaxBackoff
+		}
+	}
+	return err
+}
+
+// isContextCanceledErr returns whether error is a wrapped [context.Canceled].
+// Ref. https://github.com/nspcc-dev/neofs-sdk-go/issues/624.
+func isContextCanceledErr(err error) bool {
+	return errors.Is(err, context.Canceled) ||
+		strings.Contains(err.Error(), "context canceled")
+}
@@ -0,0 +1,236 @@
+package statefetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	gio "github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type mockLedger struct {
+	lock              sync.Mutex
+	height            uint32
+	root              util.Uint256
+	stateRootInHeader bool
+	items             []storage.KeyValue
+	batches           int
+	signed            *state.MPTRoot
+	finalized         bool
+}
+
+func (m *mockLedger) GetConfig() config.Blockchain {
+	return config.Blockchain{ProtocolConfiguration: config.ProtocolConfiguration{
+		Magic:             netmode.UnitTestNet,
+		StateRootInHeader: m.stateRootInHeader,
+	}}
+}
+
+func (m *mockLedger) GetStateSyncPoint() (uint32, util.Uint256) {
+	return m.height, m.root
+}
+
+func (m *mockLedger) AddContractStorageItems(kvs []storage.KeyValue) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.items = append(m.items, kvs...)
+	m.batches++
+	return nil
+}
+
+func (m *mockLedger) FinalizeContractStorage(sr *state.MPTRoot) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.signed = sr
+	m.finalized = true
+	return nil
+}
+
+func writeSnapshot(t *testing.T, path string, magic netmode.Magic, height uint32, root util.Uint256, sr *state.MPTRoot, items []storage.KeyValue) {
+	w := gio.NewBufBinWriter()
+	if sr != nil {
+		w.WriteB(signedSnapshotVersion)
+	} else {
+		w.WriteB(snapshotVersion)
+	}
+	w.WriteU32LE(uint32(magic))
+	w.WriteU32LE(height)
+	w.WriteBytes(root[:])
+	if sr != nil {
+		sr.EncodeBinary(w.BinWriter)
+	}
+	for _, kv := range items {
+		w.WriteVarBytes(kv.Key)
+		w.WriteVarBytes(kv.Value)
+	}
+	require.NoError(t, w.Err)
+	require.NoError(t, os.WriteFile(path, w.Bytes(), 0o644))
+}
+
+func TestServiceConstructor(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		s, err := New(&mockLedger{}, config.NeoFSStateFetcher{}, zaptest.NewLogger(t), func() {})
+		require.NoError(t, err)
+		require.False(t, s.IsActive())
+		require.False(t, s.IsShutdown())
+		s.Shutdown()
+	})
+
+	t.Run("local directory", func(t *testing.T) {
+		s, err := New(&mockLedger{}, config.NeoFSStateFetcher{
+			InternalService: config.InternalService{Enabled: true},
+			Path:            t.TempDir(),
+		}, zaptest.NewLogger(t), func() {})
+		require.NoError(t, err)
+		require.Equal(t, DefaultDownloaderWorkersCount, s.cfg.DownloaderWorkersCount)
+		require.Equal(t, uint64(DefaultRangeSize), s.cfg.RangeSize)
+		require.Equal(t, DefaultKeyValueBatchSize, s.cfg.KeyValueBatchSize)
+	})
+
+	t.Run("no addresses", func(t *testing.T) {
+		_, err := New(&mockLedger{}, config.NeoFSStateFetcher{
+			InternalService: config.InternalService{Enabled: true},
+		}, zaptest.NewLogger(t), func() {})
+		require.Error(t, err)
+	})
+}
+
+func TestServiceLocal(t *testing.T) {
+	const height = 20
+	var (
+		root  = util.Uint256{1, 2, 3}
+		items = make([]storage.KeyValue, 100)
+	)
+	for i := range items {
+		items[i] = storage.KeyValue{
+			Key:   []byte(fmt.Sprintf("key%03d", i)),
+			Value: []byte(fmt.Sprintf("value%d", i)),
+		}
+	}
+
+	run := func(t *testing.T, dir string, ledger *mockLedger) bool {
+		done := make(chan struct{})
+		s, err := New(ledger, config.NeoFSStateFetcher{
+			InternalService:        config.InternalService{Enabled: true},
+			Path:                   dir,
+			RangeSize:              7,
+			DownloaderWorkersCount: 3,
+			KeyValueBatchSize:      30,
+		}, zaptest.NewLogger(t), func() { close(done) })
+		require.NoError(t, err)
+		require.NoError(t, s.Start())
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("service is not stopped")
+		}
+		require.True(t, s.IsShutdown())
+		require.False(t, s.IsActive())
+		require.Error(t, s.Start())
+		return ledger.finalized
+	}
+
+	t.Run("good", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSnapshot(t, filepath.Join(dir, "state-10"), netmode.UnitTestNet, 10, util.Uint256{}, nil, items[:1])
+		writeSnapshot(t, filepath.Join(dir, "state-20"), netmode.UnitTestNet, height, root, nil, items)
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0o755))
+
+		ledger := &mockLedger{height: height, root: root, stateRootInHeader: true}
+		require.True(t, run(t, dir, ledger))
+		require.Equal(t, items, ledger.items)
+		require.Equal(t, 4, ledger.batches)
+		require.Nil(t, ledger.signed)
+	})
+
+	t.Run("signed", func(t *testing.T) {
+		dir := t.TempDir()
+		sr := &state.MPTRoot{Index: height, Root: root, Witness: []transaction.Witness{{
+			InvocationScript:   []byte{1, 2, 3},
+			VerificationScript: []byte{3, 2, 1},
+		}}}
+		writeSnapshot(t, filepath.Join(dir, "state-20"), netmode.UnitTestNet, height, root, sr, items)
+
+		ledger := &mockLedger{height: height}
+		require.True(t, run(t, dir, ledger))
+		require.Equal(t, items, ledger.items)
+		require.Equal(t, sr, ledger.signed)
+	})
+
+	for name, tc := range map[string]struct {
+		noStateRootInHeader bool
+		f                   func(dir string)
+	}{
+		"no snapshot": {f: func(dir string) {
+			writeSnapshot(t, filepath.Join(dir, "state"), netmode.UnitTestNet, 10, root, nil, items)
+		}},
+		"magic mismatch": {f: func(dir string) {
+			writeSnapshot(t, filepath.Join(dir, "state"), netmode.TestNet, height, root, nil, items)
+		}},
+		"root mismatch": {f: func(dir string) {
+			writeSnapshot(t, filepath.Join(dir, "state"), netmode.UnitTestNet, height, util.Uint256{3, 2, 1}, nil, items)
+		}},
+		"truncated": {f: func(dir string) {
+			p := filepath.Join(dir, "state")
+			writeSnapshot(t, p, netmode.UnitTestNet, height, root, nil, items)
+			require.NoError(t, os.Truncate(p, headerSize+10))
+		}},
+		"no signed root": {noStateRootInHeader: true, f: func(dir string) {
+			writeSnapshot(t, filepath.Join(dir, "state"), netmode.UnitTestNet, height, root, nil, items)
+		}},
+		"signed root mismatch": {noStateRootInHeader: true, f: func(dir string) {
+			sr := &state.MPTRoot{Index: height, Root: util.Uint256{3, 2, 1}}
+			writeSnapshot(t, filepath.Join(dir, "state"), netmode.UnitTestNet, height, root, sr, items)
+		}},
+		"signed root height mismatch": {noStateRootInHeader: true, f: func(dir string) {
+			sr := &state.MPTRoot{Index: height - 1, Root: root}
+			writeSnapshot(t, filepath.Join(dir, "state"), netmode.UnitTestNet, height, root, sr, items)
+		}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			tc.f(dir)
+			ledger := &mockLedger{height: height, root: root, stateRootInHeader: !tc.noStateRootInHeader}
+			require.False(t, run(t, dir, ledger))
+		})
+	}
+}
+
+func TestRangeReaderError(t *testing.T) {
+	s, err := New(&mockLedger{}, config.NeoFSStateFetcher{
+		InternalService:        config.InternalService{Enabled: true},
+		Path:                   t.TempDir(),
+		RangeSize:              2,
+		DownloaderWorkersCount: 2,
+	}, zaptest.NewLogger(t), func() {})
+	require.NoError(t, err)
+	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
+	r := s.newRangeReader(&failingSnapshot{size: 10}, 0)
+	_, err = r.Read(make([]byte, 10))
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+type failingSnapshot struct {
+	size uint64
+}
+
+func (f *failingSnapshot) Size() uint64 { return f.size }
+
+func (f *failingSnapshot) ReadRange(_ context.Context, _, _ uint64) ([]byte, error) {
+	return nil, errors.New("read failed")
+}
+
+func (f *failingSnapshot) Close() error { return nil }
@@ -109,7 +109,7 @@ func New(cfg config.StateRoot, sm *stateroot.Module, log *zap.Logger, bc Ledger,
 		}
 		var err error
 		w := cfg.UnlockWallet
-		if s.wallet, err = wallet.NewWalletFromFile(w.Path); err != nil {
+		if s.wallet, err = wallet.NewWalletFromFileWithPassword(w.Path, w.Password); err != nil {
 			return nil, err
 		}
 
@@ -12,6 +12,7 @@ import (
 	"text/template"
 	"unicode"
 
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -97,21 +98,33 @@ type (
 		// - `methodName` for method return value;
 		// - `mathodName.paramName` for method's parameter value.
 		// - `eventName.paramName` for event's parameter value.
-		Types  map[string]ExtendedType `yaml:"types,omitempty"`
-		Output io.Writer               `yaml:"-"`
+		Types map[string]ExtendedType `yaml:"types,omitempty"`
+		// Gas contains static execution cost estimations of contract methods
+		// to be mentioned in the method comments, the map key is the method
+		// name.
+		Gas    map[string]MethodGas `yaml:"gas,omitempty"`
+		Output io.Writer            `yaml:"-"`
+	}
+
+	// MethodGas is a static execution cost estimation of a contract method.
+	MethodGas struct {
+		// Total is the estimated cost in datoshi.
+		Total int64 `yaml:"total"`
+		// Unbounded is set if the actual cost can exceed the estimation.
+		Unbounded bool `yaml:"unbounded,omitempty"`
 	}
 
 	ExtendedType struct {
-		Base      smartcontract.ParamType `yaml:"base"`
-		Name      string                  `yaml:"name,omitempty"`      // Structure name, omitted for arrays, interfaces and maps.
-		Interface string                  `yaml:"interface,omitempty"` // Interface type name, "iterator" only for now.
-		Key       smartcontract.ParamType `yaml:"key,omitempty"`       // Key type (only simple types can be used for keys) for maps.
-		Value     *ExtendedType           `yaml:"value,omitempty"`     // Value type for iterators, arrays and maps.
-		Fields    []FieldExtendedType     `yaml:"fields,omitempty"`    // Ordered type data for structure fields.
+		Base      smartcontract.ParamType `yaml:"base" json:"base"`
+		Name      string                  `yaml:"name,omitempty" json:"name,omitempty"`           // Structure name, omitted for arrays, interfaces and maps.
+		Interface string                  `yaml:"interface,omitempty" json:"interface,omitempty"` // Interface type name, "iterator" only for now.
+		Key       smartcontract.ParamType `yaml:"key,omitempty" json:"key,omitempty"`             // Key type (only simple types can be used for keys) for maps.
+		Value     *ExtendedType           `yaml:"value,omitempty" json:"value,omitempty"`         // Value type for iterators, arrays and maps.
+		Fields    []FieldExtendedType     `yaml:"fields,omitempty" json:"fields,omitempty"`       // Ordered type data for structure fields.
 	}
 
 	FieldExtendedType struct {
-		Field        string `yaml:"field"`
+		Field        string `yaml:"field" json:"field"`
 		ExtendedType `yaml:",inline"`
 	}
 
@@ -189,6 +202,20 @@ func FExecute(tmplt *template.Template, out io.Writer, data any) error {
 	return nil
 }
 
+// GasComment returns an additional doc comment line with the execution cost
+// estimation of the given method if it's present in the configuration.
+func GasComment(cfg *Config, method string) string {
+	g, ok := cfg.Gas[method]
+	if !ok {
+		return ""
+	}
+	var bound = "is"
+	if g.Unbounded {
+		bound = "is at least"
+	}
+	return fmt.Sprintf("\n// Its static execution cost estimation %s %s GAS.", bound, fixedn.Fixed8(g.Total))
+}
+
 func scTypeToGo(name string, typ smartcontract.ParamType, cfg *Config) (string, string) {
 	if over, ok := cfg.Overrides[name]; ok {
 		return over.TypeName, over.Package
@@ -277,7 +304,7 @@ func TemplateFromManifest(cfg Config, scTypeConverter func(string, smartcontract
 			Name:     upperFirst(name),
 			NameABI:  m.Name,
 			CallFlag: callflag.All.String(),
-			Comment:  fmt.Sprintf("invokes `%s` method of contract.", m.Name),
+			Comment:  fmt.Sprintf("invokes `%s` method of contract.", m.Name) + GasComment(&cfg, m.Name),
 		}
 		if f, ok := cfg.CallFlags[m.Name]; ok {
 			mtd.CallFlag = f.String()
@@ -0,0 +1,128 @@
+package binding
+
+import (
+	"slices"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+)
+
+// nep24RoyaltyRecipient is a named type for NEP-24 royaltyInfo method results.
+const nep24RoyaltyRecipient = "nep24.RoyaltyRecipient"
+
+// AddStandardTypes adds extended type information for methods of NEP-11 and
+// NEP-24 standards implemented by the contract from cfg.Manifest and restores
+// standard parameter names of NEP-17, NEP-26 and NEP-27 methods and events.
+// It's useful when there is no bindings configuration file generated by the
+// compiler, e.g. for contracts that are already deployed. Types that are
+// already present in the configuration are not changed, cfg.Manifest is
+// replaced with a modified copy if needed.
+func AddStandardTypes(cfg *Config) {
+	if cfg.Types == nil {
+		cfg.Types = make(map[string]ExtendedType)
+	}
+	if cfg.NamedTypes == nil {
+		cfg.NamedTypes = make(map[string]ExtendedType)
+	}
+	var (
+		add = func(name string, et ExtendedType) {
+			if _, ok := cfg.Types[name]; !ok {
+				cfg.Types[name] = et
+			}
+		}
+		bytesIterator = ExtendedType{
+			Base:      smartcontract.InteropInterfaceType,
+			Interface: "iterator",
+			Value:     &ExtendedType{Base: smartcontract.ByteArrayType},
+		}
+		nep11  = standard.ComplyABI(cfg.Manifest, standard.Nep11NonDivisible) == nil
+		nep11d = standard.ComplyABI(cfg.Manifest, standard.Nep11Divisible) == nil
+	)
+	for _, std := range cfg.Manifest.SupportedStandards {
+		switch std {
+		case manifest.NEP11StandardName:
+			if !nep11 && !nep11d {
+				continue
+			}
+			add("tokensOf", bytesIterator)
+			if cfg.Manifest.ABI.GetMethod("tokens", 0) != nil {
+				add("tokens", bytesIterator)
+			}
+			if cfg.Manifest.ABI.GetMethod("properties", 1) != nil {
+				add("properties", ExtendedType{
+					Base:  smartcontract.MapType,
+					Key:   smartcontract.StringType,
+					Value: &ExtendedType{Base: smartcontract.AnyType},
+				})
+			}
+			if nep11d {
+				add("ownerOf", ExtendedType{
+					Base:      smartcontract.InteropInterfaceType,
+					Interface: "iterator",
+					Value:     &ExtendedType{Base: smartcontract.Hash160Type},
+				})
+			}
+		case manifest.NEP17StandardName:
+			setStandardNames(cfg, standard.Nep17)
+		case manifest.NEP26StandardName:
+			setStandardNames(cfg, standard.Nep26)
+		case manifest.NEP27StandardName:
+			setStandardNames(cfg, standard.Nep27)
+		case manifest.NEP24StandardName:
+			if standard.ComplyABI(cfg.Manifest, standard.Nep24) != nil {
+				continue
+			}
+			if _, ok := cfg.Types[standard.MethodRoyaltyInfo]; ok {
+				continue
+			}
+			cfg.Types[standard.MethodRoyaltyInfo] = ExtendedType{
+				Base:  smartcontract.ArrayType,
+				Value: &ExtendedType{Base: smartcontract.ArrayType, Name: nep24RoyaltyRecipient},
+			}
+			if _, ok := cfg.NamedTypes[nep24RoyaltyRecipient]; !ok {
+				cfg.NamedTypes[nep24RoyaltyRecipient] = ExtendedType{
+					Base: smartcontract.ArrayType,
+					Name: nep24RoyaltyRecipient,
+					Fields: []FieldExtendedType{
+						{Field: "Address", ExtendedType: ExtendedType{Base: smartcontract.Hash160Type}},
+						{Field: "Amount", ExtendedType: ExtendedType{Base: smartcontract.IntegerType}},
+					},
+				}
+			}
+		}
+	}
+}
+
+// setStandardNames renames parameters of cfg.Manifest methods and events
+// defined by the given standard (and its base standards) to the ones used by
+// the standard if the contract complies with it. Parameter types are the same
+// for compliant contracts, so standard parameters are used as is.
+func setStandardNames(cfg *Config, st *standard.Standard) {
+	if standard.ComplyABI(cfg.Manifest, st) != nil {
+		return
+	}
+	// Avoid changing the original manifest.
+	mfst := *cfg.Manifest
+	mfst.ABI.Methods = slices.Clone(mfst.ABI.Methods)
+	mfst.ABI.Events = slices.Clone(mfst.ABI.Events)
+	cfg.Manifest = &mfst
+	for ; st != nil; st = st.Base {
+		for _, stm := range st.ABI.Methods {
+			for i := range mfst.ABI.Methods {
+				m := &mfst.ABI.Methods[i]
+				if m.Name == stm.Name && len(m.Parameters) == len(stm.Parameters) {
+					m.Parameters = slices.Clone(stm.Parameters)
+				}
+			}
+		}
+		for _, ste := range st.ABI.Events {
+			for i := range mfst.ABI.Events {
+				e := &mfst.ABI.Events[i]
+				if e.Name == ste.Name && len(e.Parameters) == len(ste.Parameters) {
+					e.Parameters = slices.Clone(ste.Parameters)
+				}
+			}
+		}
+	}
+}
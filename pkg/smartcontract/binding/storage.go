@@ -0,0 +1,326 @@
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"slices"
+	"text/template"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+)
+
+// StorageKind is a kind of contract storage item declared in the storage
+// layout.
+type StorageKind string
+
+// Supported storage item kinds.
+const (
+	// StorageMap is a set of values of the same type stored under
+	// prefix+key keys.
+	StorageMap StorageKind = "map"
+	// StorageCounter is an integer value stored under the prefix key.
+	StorageCounter StorageKind = "counter"
+	// StorageSingleton is a single value stored under the prefix key.
+	StorageSingleton StorageKind = "singleton"
+)
+
+// StorageExtraField is the name of manifest `extra` field containing contract
+// storage layout.
+const StorageExtraField = "storage"
+
+// StorageItem describes a typed contract storage item. Keys are formed as a
+// single prefix byte followed by the item key (for maps). Integer keys are
+// encoded as little-endian two's complement numbers, strings as UTF-8 bytes.
+// Values of Boolean, Integer, ByteArray, String, Hash160, Hash256 and
+// PublicKey types are stored as is, Any, Array and Map values are serialized
+// with StdLib serialization.
+type StorageItem struct {
+	Name   string                  `yaml:"name" json:"name"`
+	Kind   StorageKind             `yaml:"kind" json:"kind"`
+	Prefix byte                    `yaml:"prefix" json:"prefix"`
+	Key    smartcontract.ParamType `yaml:"key,omitempty" json:"key,omitempty"`
+	Value  *ExtendedType           `yaml:"value,omitempty" json:"value,omitempty"`
+}
+
+type (
+	storageTmpl struct {
+		Package string
+		Imports []string
+		Items   []storageItemTmpl
+	}
+
+	storageItemTmpl struct {
+		StorageItem
+
+		// Name is the item name used in the Go code.
+		Name      string
+		KeyType   string
+		KeyBytes  string
+		ValueType string
+		Zero      string
+		Decode    string
+		Encode    string
+		// Serialized is true for values stored with StdLib serialization.
+		Serialized bool
+	}
+)
+
+const storageTmplSrc = `// Code generated by neo-go contract generate-storage --config <file.yml> --out <file.go>; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{range $m := .Imports}}	"{{ $m }}"
+{{end}})
+
+// Storage prefixes declared in the contract storage layout.
+const (
+{{- range .Items}}
+	Prefix{{.Name}} = {{printf "0x%02x" .Prefix}}
+{{- end}}
+)
+{{range .Items}}{{if eq .Kind "map"}}
+// Get{{.Name}} returns {{.Name}} value for the given key, zero value is
+// returned if there is no such key.
+func Get{{.Name}}(ctx storage.Context, key {{.KeyType}}) {{.ValueType}} {
+	v := storage.Get(ctx, append([]byte{Prefix{{.Name}}}, {{.KeyBytes}}...))
+	if v == nil {
+		return {{.Zero}}
+	}
+	return {{.Decode}}
+}
+
+// Put{{.Name}} stores {{.Name}} value for the given key.
+func Put{{.Name}}(ctx storage.Context, key {{.KeyType}}, value {{.ValueType}}) {
+	storage.Put(ctx, append([]byte{Prefix{{.Name}}}, {{.KeyBytes}}...), {{.Encode}})
+}
+
+// Delete{{.Name}} removes {{.Name}} value for the given key.
+func Delete{{.Name}}(ctx storage.Context, key {{.KeyType}}) {
+	storage.Delete(ctx, append([]byte{Prefix{{.Name}}}, {{.KeyBytes}}...))
+}
+
+// Find{{.Name}} returns iterator over all {{.Name}} items, keys are returned
+// without prefix{{if .Serialized}}, values are deserialized{{end}}.
+func Find{{.Name}}(ctx storage.Context) iterator.Iterator {
+	return storage.Find(ctx, []byte{Prefix{{.Name}}}, storage.RemovePrefix{{if .Serialized}}|storage.DeserializeValues{{end}})
+}
+{{else if eq .Kind "counter"}}
+// Get{{.Name}} returns current {{.Name}} counter value.
+func Get{{.Name}}(ctx storage.Context) int {
+	v := storage.Get(ctx, []byte{Prefix{{.Name}}})
+	if v == nil {
+		return 0
+	}
+	return v.(int)
+}
+
+// Increment{{.Name}} increments {{.Name}} counter and returns its new value.
+func Increment{{.Name}}(ctx storage.Context) int {
+	v := Get{{.Name}}(ctx) + 1
+	storage.Put(ctx, []byte{Prefix{{.Name}}}, v)
+	return v
+}
+{{else}}
+// Get{{.Name}} returns {{.Name}} value, zero value is returned if it's not
+// stored.
+func Get{{.Name}}(ctx storage.Context) {{.ValueType}} {
+	v := storage.Get(ctx, []byte{Prefix{{.Name}}})
+	if v == nil {
+		return {{.Zero}}
+	}
+	return {{.Decode}}
+}
+
+// Put{{.Name}} stores {{.Name}} value.
+func Put{{.Name}}(ctx storage.Context, value {{.ValueType}}) {
+	storage.Put(ctx, []byte{Prefix{{.Name}}}, {{.Encode}})
+}
+
+// Delete{{.Name}} removes {{.Name}} value.
+func Delete{{.Name}}(ctx storage.Context) {
+	storage.Delete(ctx, []byte{Prefix{{.Name}}})
+}
+{{end}}{{end -}}
+`
+
+var storageTemplate = template.Must(template.New("storage").Parse(storageTmplSrc))
+
+// ValidateStorage checks the given storage layout for correctness: names
+// and prefixes must be unique, key and value types must be supported by
+// the item kind.
+func ValidateStorage(items []StorageItem) error {
+	var (
+		names    = make(map[string]bool, len(items))
+		prefixes = make(map[byte]string, len(items))
+	)
+	for _, it := range items {
+		if !token.IsIdentifier(it.Name) || it.Name == "_" {
+			return fmt.Errorf("invalid storage item name: %q", it.Name)
+		}
+		name := upperFirst(it.Name)
+		if names[name] {
+			return fmt.Errorf("duplicate storage item name: %s", it.Name)
+		}
+		names[name] = true
+		if other, ok := prefixes[it.Prefix]; ok {
+			return fmt.Errorf("storage items %s and %s have the same prefix 0x%02x", other, it.Name, it.Prefix)
+		}
+		prefixes[it.Prefix] = it.Name
+
+		switch it.Kind {
+		case StorageMap:
+			if !isStorageKeyType(it.Key) {
+				return fmt.Errorf("storage item %s: unsupported key type %s", it.Name, it.Key)
+			}
+			if it.Value == nil {
+				return fmt.Errorf("storage item %s: value type is not specified", it.Name)
+			}
+		case StorageCounter:
+			if it.Value != nil && it.Value.Base != smartcontract.IntegerType {
+				return fmt.Errorf("storage item %s: counter value should be Integer", it.Name)
+			}
+			continue
+		case StorageSingleton:
+			if it.Value == nil {
+				return fmt.Errorf("storage item %s: value type is not specified", it.Name)
+			}
+		default:
+			return fmt.Errorf("storage item %s: unknown kind %q", it.Name, it.Kind)
+		}
+		switch it.Value.Base {
+		case smartcontract.InteropInterfaceType, smartcontract.VoidType, smartcontract.SignatureType:
+			return fmt.Errorf("storage item %s: unsupported value type %s", it.Name, it.Value.Base)
+		}
+	}
+	return nil
+}
+
+func isStorageKeyType(t smartcontract.ParamType) bool {
+	switch t {
+	case smartcontract.IntegerType, smartcontract.ByteArrayType, smartcontract.StringType,
+		smartcontract.Hash160Type, smartcontract.Hash256Type, smartcontract.PublicKeyType:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSerializedStorageValue returns true if values of the given type are
+// stored with StdLib serialization.
+func IsSerializedStorageValue(et ExtendedType) bool {
+	switch et.Base {
+	case smartcontract.AnyType, smartcontract.ArrayType, smartcontract.MapType:
+		return true
+	default:
+		return false
+	}
+}
+
+// StorageToExtra returns manifest `extra` field value with the given storage
+// layout.
+func StorageToExtra(items []StorageItem) (json.RawMessage, error) {
+	return json.Marshal(map[string][]StorageItem{StorageExtraField: items})
+}
+
+// StorageFromManifest returns storage layout from the manifest `extra` field,
+// nil is returned if there is none.
+func StorageFromManifest(m *manifest.Manifest) ([]StorageItem, error) {
+	if len(m.Extra) == 0 || string(m.Extra) == "null" {
+		return nil, nil
+	}
+	var extra map[string]json.RawMessage
+	if json.Unmarshal(m.Extra, &extra) != nil {
+		// Not an object, so no layout.
+		return nil, nil
+	}
+	data, ok := extra[StorageExtraField]
+	if !ok {
+		return nil, nil
+	}
+	var items []StorageItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("invalid storage layout: %w", err)
+	}
+	if err := ValidateStorage(items); err != nil {
+		return nil, fmt.Errorf("invalid storage layout: %w", err)
+	}
+	return items, nil
+}
+
+// GenerateStorage writes Go file with typed accessors for the given storage
+// layout to be used in the contract itself.
+func GenerateStorage(pkg string, items []StorageItem, out io.Writer) error {
+	if len(items) == 0 {
+		return errors.New("empty storage layout")
+	}
+	if err := ValidateStorage(items); err != nil {
+		return err
+	}
+	var (
+		ctr     = storageTmpl{Package: pkg}
+		imports = map[string]bool{"github.com/nspcc-dev/neo-go/pkg/interop/storage": true}
+		cfg     = NewConfig()
+		goType  = func(t smartcontract.ParamType) string {
+			s, p := scTypeToGo("", t, &cfg)
+			if p != "" {
+				imports[p] = true
+			}
+			return s
+		}
+	)
+	for _, it := range items {
+		var itm = storageItemTmpl{StorageItem: it, Name: upperFirst(it.Name)}
+		if it.Kind != StorageCounter {
+			itm.ValueType = goType(it.Value.Base)
+			itm.Serialized = IsSerializedStorageValue(*it.Value)
+			switch it.Value.Base {
+			case smartcontract.BoolType:
+				itm.Zero = "false"
+			case smartcontract.IntegerType:
+				itm.Zero = "0"
+			case smartcontract.StringType:
+				itm.Zero = `""`
+			default:
+				itm.Zero = "nil"
+			}
+			switch {
+			case itm.Serialized && it.Value.Base == smartcontract.AnyType:
+				itm.Decode = "std.Deserialize(v.([]byte))"
+			case itm.Serialized:
+				itm.Decode = "std.Deserialize(v.([]byte)).(" + itm.ValueType + ")"
+			default:
+				itm.Decode = "v.(" + itm.ValueType + ")"
+			}
+			if itm.Serialized {
+				itm.Encode = "std.Serialize(value)"
+				imports["github.com/nspcc-dev/neo-go/pkg/interop/native/std"] = true
+			} else {
+				itm.Encode = "value"
+			}
+		}
+		if it.Kind == StorageMap {
+			itm.KeyType = goType(it.Key)
+			switch it.Key {
+			case smartcontract.IntegerType:
+				itm.KeyBytes = "convert.ToBytes(key)"
+				imports["github.com/nspcc-dev/neo-go/pkg/interop/convert"] = true
+			case smartcontract.StringType:
+				itm.KeyBytes = "[]byte(key)"
+			default:
+				itm.KeyBytes = "key"
+			}
+			imports["github.com/nspcc-dev/neo-go/pkg/interop/iterator"] = true
+		}
+		ctr.Items = append(ctr.Items, itm)
+	}
+	for imp := range imports {
+		ctr.Imports = append(ctr.Imports, imp)
+	}
+	slices.Sort(ctr.Imports)
+	return FExecute(storageTemplate, out, ctr)
+}
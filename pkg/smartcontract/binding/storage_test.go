@@ -0,0 +1,93 @@
+package binding
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStorage(t *testing.T) {
+	var (
+		integer = &ExtendedType{Base: smartcontract.IntegerType}
+		good    = []StorageItem{
+			{Name: "balances", Kind: StorageMap, Prefix: 1, Key: smartcontract.Hash160Type, Value: integer},
+			{Name: "lastID", Kind: StorageCounter, Prefix: 2},
+			{Name: "owner", Kind: StorageSingleton, Prefix: 3, Value: &ExtendedType{Base: smartcontract.Hash160Type}},
+			{Name: "config", Kind: StorageSingleton, Prefix: 4, Value: &ExtendedType{Base: smartcontract.MapType, Key: smartcontract.StringType}},
+		}
+	)
+	require.NoError(t, ValidateStorage(good))
+	require.NoError(t, ValidateStorage(nil))
+
+	testCases := map[string]struct {
+		items []StorageItem
+		err   string
+	}{
+		"bad name": {
+			items: []StorageItem{{Name: "1st", Kind: StorageCounter}},
+			err:   "invalid storage item name",
+		},
+		"duplicate name": {
+			items: []StorageItem{{Name: "count", Kind: StorageCounter}, {Name: "Count", Kind: StorageCounter, Prefix: 1}},
+			err:   "duplicate storage item name: Count",
+		},
+		"duplicate prefix": {
+			items: []StorageItem{{Name: "a", Kind: StorageCounter}, {Name: "b", Kind: StorageCounter}},
+			err:   "storage items a and b have the same prefix 0x00",
+		},
+		"unknown kind": {
+			items: []StorageItem{{Name: "a", Kind: "list", Value: integer}},
+			err:   `unknown kind "list"`,
+		},
+		"bad key": {
+			items: []StorageItem{{Name: "a", Kind: StorageMap, Key: smartcontract.BoolType, Value: integer}},
+			err:   "unsupported key type Boolean",
+		},
+		"no value": {
+			items: []StorageItem{{Name: "a", Kind: StorageSingleton}},
+			err:   "value type is not specified",
+		},
+		"bad counter": {
+			items: []StorageItem{{Name: "a", Kind: StorageCounter, Value: &ExtendedType{Base: smartcontract.StringType}}},
+			err:   "counter value should be Integer",
+		},
+		"bad value": {
+			items: []StorageItem{{Name: "a", Kind: StorageSingleton, Value: &ExtendedType{Base: smartcontract.InteropInterfaceType}}},
+			err:   "unsupported value type InteropInterface",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorContains(t, ValidateStorage(tc.items), tc.err)
+		})
+	}
+}
+
+func TestStorageFromManifest(t *testing.T) {
+	items := []StorageItem{
+		{Name: "balances", Kind: StorageMap, Prefix: 1, Key: smartcontract.Hash160Type, Value: &ExtendedType{Base: smartcontract.IntegerType}},
+		{Name: "lastID", Kind: StorageCounter, Prefix: 2},
+	}
+	m := manifest.NewManifest("Test")
+	res, err := StorageFromManifest(m)
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	m.Extra, err = StorageToExtra(items)
+	require.NoError(t, err)
+	res, err = StorageFromManifest(m)
+	require.NoError(t, err)
+	require.Equal(t, items, res)
+
+	m.Extra = json.RawMessage(`"some string"`)
+	res, err = StorageFromManifest(m)
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	m.Extra = json.RawMessage(`{"storage": [{"name": "a", "kind": "counter"}, {"name": "b", "kind": "counter"}]}`)
+	_, err = StorageFromManifest(m)
+	require.ErrorContains(t, err, "invalid storage layout")
+}
@@ -393,10 +393,12 @@ func (e *{{$e.Name}}) FromStackItem(item *stackitem.Array) error {
 {{- end}}
 	return nil
 }
-{{end -}}`
+{{end -}}
+{{- if .Storage}}{{template "STORAGE" .}}{{end -}}`
 
 	srcTmpl = bindingDefinition +
 		eventDefinition +
+		storageDefinition +
 		safemethodDefinition +
 		methodDefinition
 )
@@ -408,6 +410,7 @@ type (
 		SafeMethods  []SafeMethodTmpl
 		CustomEvents []CustomEventTemplate
 		NamedTypes   []binding.ExtendedType
+		Storage      []StorageTmpl
 
 		IsNep11D       bool
 		IsNep11ND      bool
@@ -520,6 +523,12 @@ func Generate(cfg binding.Config) error {
 		mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, standard.Nep27)
 	}
 
+	storage, err := binding.StorageFromManifest(cfg.Manifest)
+	if err != nil {
+		return err
+	}
+	ctr.Storage = storageToTmpl(storage, cfg.NamedTypes, imports)
+
 	ctr.ContractTmpl = binding.TemplateFromManifest(cfg, scTypeToGo)
 	ctr = scTemplateToRPC(cfg, ctr, imports, scTypeToGo)
 	ctr.NamedTypes = make([]binding.ExtendedType, 0, len(cfg.NamedTypes))
@@ -999,7 +1008,8 @@ func scTemplateToRPC(cfg binding.Config, ctr ContractTmpl, imports map[string]st
 			ctr.Methods = slices.Delete(ctr.Methods, i, i+1)
 			i--
 		} else {
-			ctr.Methods[i].Comment = fmt.Sprintf("creates a transaction invoking `%s` method of the contract.", ctr.Methods[i].NameABI)
+			ctr.Methods[i].Comment = fmt.Sprintf("creates a transaction invoking `%s` method of the contract.", ctr.Methods[i].NameABI) +
+				binding.GasComment(&cfg, ctr.Methods[i].NameABI)
 			if ctr.Methods[i].ReturnType == "bool" {
 				imports["github.com/nspcc-dev/neo-go/pkg/smartcontract"] = struct{}{}
 			}
@@ -0,0 +1,213 @@
+package rpcbinding
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+type (
+	// foreignTmpl is a language-independent contract description used by
+	// non-Go binding generators. All names are already converted to the
+	// target language conventions.
+	foreignTmpl struct {
+		ContractName string
+		// Hash is "0x"-prefixed contract hash in the form used by RPC
+		// (big-endian), it's empty for dynamic hash bindings.
+		Hash        string
+		SafeMethods []foreignMethod
+		Methods     []foreignMethod
+		Events      []foreignStruct
+		NamedTypes  []foreignStruct
+	}
+
+	foreignMethod struct {
+		Name    string
+		NameABI string
+		Params  []foreignField
+		Return  binding.ExtendedType
+	}
+
+	foreignField struct {
+		Name string
+		Type binding.ExtendedType
+	}
+
+	foreignStruct struct {
+		// Name is the type name in the resulting binding.
+		Name string
+		// ManifestName is the event name declared in the contract manifest,
+		// it's empty for named types.
+		ManifestName string
+		Fields       []foreignField
+	}
+
+	// foreignNaming defines target language identifier conventions.
+	foreignNaming struct {
+		// method converts PascalCase method name to the target language form.
+		method func(string) string
+		// field converts PascalCase parameter or field name to the target
+		// language form.
+		field func(string) string
+		// reserved contains words that can't be used as identifiers.
+		reserved map[string]bool
+	}
+)
+
+// foreignTemplate creates language-independent contract template from the
+// given configuration. Go-specific Overrides are ignored.
+func foreignTemplate(cfg binding.Config, naming foreignNaming) (foreignTmpl, error) {
+	// Avoid changing *cfg.Manifest.
+	mfst := *cfg.Manifest
+	mfst.ABI.Methods = slices.Clone(mfst.ABI.Methods)
+	cfg.Manifest = &mfst
+
+	// OnNepXXPayment handlers normally can't be called directly.
+	if standard.ComplyABI(cfg.Manifest, standard.Nep26) == nil {
+		mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, standard.Nep26)
+	}
+	if standard.ComplyABI(cfg.Manifest, standard.Nep27) == nil {
+		mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, standard.Nep27)
+	}
+
+	var ctr = foreignTmpl{ContractName: cfg.Manifest.Name}
+	if !cfg.Hash.Equals(util.Uint160{}) {
+		ctr.Hash = "0x" + cfg.Hash.StringLE()
+	}
+
+	// Reuse Go generator method naming to get the same overload suffixes.
+	noTypes := func(string, smartcontract.ParamType, *binding.Config) (string, string) { return "", "" }
+	for _, m := range binding.TemplateFromManifest(cfg, noTypes).Methods {
+		abim := cfg.Manifest.ABI.GetMethod(m.NameABI, len(m.Arguments))
+		mtd := foreignMethod{
+			Name:    naming.ident(naming.method(toPascalCase(m.Name)), nil),
+			NameABI: m.NameABI,
+			Return:  binding.ExtendedType{Base: abim.ReturnType},
+		}
+		if et, ok := cfg.Types[abim.Name]; ok {
+			mtd.Return = et
+		}
+		var used = make(map[string]bool)
+		for i, p := range abim.Parameters {
+			et, ok := cfg.Types[abim.Name+"."+p.Name]
+			if !ok {
+				et = binding.ExtendedType{Base: p.Type}
+			}
+			name := naming.field(toPascalCase(p.Name))
+			if name == "" {
+				name = naming.field("Arg" + strconv.Itoa(i))
+			}
+			mtd.Params = append(mtd.Params, foreignField{
+				Name: naming.ident(name, used),
+				Type: et,
+			})
+		}
+		if abim.Safe {
+			ctr.SafeMethods = append(ctr.SafeMethods, mtd)
+		} else {
+			ctr.Methods = append(ctr.Methods, mtd)
+		}
+	}
+
+	for _, e := range cfg.Manifest.ABI.Events {
+		eBindingName := ToEventBindingName(e.Name)
+		ev := foreignStruct{
+			Name:         eBindingName,
+			ManifestName: e.Name,
+		}
+		var used = make(map[string]bool)
+		for _, p := range e.Parameters {
+			pBindingName := ToParameterBindingName(p.Name)
+			et, ok := cfg.Types[eBindingName+"."+pBindingName]
+			if !ok {
+				et = binding.ExtendedType{Base: p.Type}
+			}
+			name := naming.field(pBindingName)
+			if used[name] {
+				return ctr, fmt.Errorf("event `%s` has two fields with identical resulting binding name `%s`", e.Name, name)
+			}
+			ev.Fields = append(ev.Fields, foreignField{Name: naming.ident(name, used), Type: et})
+		}
+		ctr.Events = append(ctr.Events, ev)
+	}
+
+	for _, t := range cfg.NamedTypes {
+		nt := foreignStruct{Name: toTypeName(t.Name)}
+		var used = make(map[string]bool)
+		for _, f := range t.Fields {
+			name := naming.field(toPascalCase(f.Field))
+			if used[name] {
+				return ctr, fmt.Errorf("named type `%s` has two fields with identical resulting binding name `%s`", t.Name, name)
+			}
+			nt.Fields = append(nt.Fields, foreignField{Name: naming.ident(name, used), Type: f.ExtendedType})
+		}
+		ctr.NamedTypes = append(ctr.NamedTypes, nt)
+	}
+	slices.SortFunc(ctr.NamedTypes, func(a, b foreignStruct) int { return cmp.Compare(a.Name, b.Name) })
+	return ctr, nil
+}
+
+// ident makes a valid identifier from the given name avoiding reserved words
+// and names that are already used (if used map is provided).
+func (n foreignNaming) ident(name string, used map[string]bool) string {
+	for n.reserved[name] || used[name] {
+		name += "_"
+	}
+	if used != nil {
+		used[name] = true
+	}
+	return name
+}
+
+// isIterator returns true if the given type is an iterator interface.
+func isIterator(et binding.ExtendedType) bool {
+	return et.Base == smartcontract.InteropInterfaceType && et.Interface == "iterator"
+}
+
+// isVoid returns true if the given type is a method return type for methods
+// returning nothing.
+func isVoid(et binding.ExtendedType) bool {
+	return et.Base == smartcontract.VoidType
+}
+
+// quote returns string literal valid for both TypeScript and Python.
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[0:1]) + s[1:]
+}
+
+// toSnakeCase converts PascalCase or camelCase string to snake_case keeping
+// abbreviations together (e.g. "NEP17Transfer" becomes "nep17_transfer").
+func toSnakeCase(s string) string {
+	var (
+		rs  = []rune(s)
+		res strings.Builder
+	)
+	for i, r := range rs {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := rs[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				unicode.IsUpper(prev) && i+1 < len(rs) && unicode.IsLower(rs[i+1]) {
+				res.WriteByte('_')
+			}
+		}
+		res.WriteRune(unicode.ToLower(r))
+	}
+	return res.String()
+}
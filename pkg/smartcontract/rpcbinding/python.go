@@ -0,0 +1,542 @@
+package rpcbinding
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+)
+
+const pyTmpl = `
+{{- define "ARGS" -}}
+self{{range .Params}}, {{.Name}}: {{pyParamType .Type}}{{end}}
+{{- end -}}
+{{- define "STRUCT" -}}
+{{- range .Fields}}
+    {{.Name}}: {{pyType .Type}}
+{{- end}}
+{{- end -}}
+{{- define "FROMLIST"}}
+        arr = _to_list(item)
+        if len(arr) != {{len .Fields}}:
+            raise ValueError("wrong number of structure elements")
+        return {{.Name}}(
+{{- range $index, $f := .Fields}}
+            {{.Name}}={{pyFromItem .Type (printf "arr[%d]" $index)}},
+{{- end}}
+        )
+{{- end -}}
+# Code generated by neo-go contract generate-rpcwrapper --lang python --manifest <file.json> --out <file.py> [--hash <hash>] [--config <config>]; DO NOT EDIT.
+
+"""RPC wrappers for {{.ContractName}} contract."""
+
+from __future__ import annotations
+
+import base64
+import json
+import urllib.request
+from dataclasses import dataclass
+from typing import Any, Optional, Protocol
+{{if .Hash}}
+HASH = "{{.Hash}}"
+"""Contract hash."""
+{{end}}
+StackItem = dict[str, Any]
+"""JSON representation of VM stack item returned by RPC."""
+
+ContractParam = dict[str, Any]
+"""JSON representation of contract method parameter."""
+
+Signer = dict[str, Any]
+"""JSON representation of transaction signer."""
+
+InvokeResult = dict[str, Any]
+"""Result of test invocation returned by RPC."""
+
+ApplicationLog = dict[str, Any]
+"""Result of getapplicationlog RPC call."""
+
+
+@dataclass
+class Invocation:
+    """Contract method call description.
+
+    It can be passed to wallets (dAPI invoke) to create, sign and send a
+    transaction.
+    """
+
+    script_hash: str
+    operation: str
+    args: list[ContractParam]
+
+
+class Invoker(Protocol):
+    """Invoker is used by ContractReader and Contract to perform test invocations."""
+
+    def invoke_function(
+        self, contract_hash: str, method: str, params: list[ContractParam], signers: Optional[list[Signer]] = None
+    ) -> InvokeResult: ...
+
+    def traverse_iterator(self, session: str, iterator: str, count: int) -> list[StackItem]: ...
+
+    def terminate_session(self, session: str) -> bool: ...
+
+
+class RPCInvoker:
+    """RPCInvoker implements Invoker using JSON-RPC node API."""
+
+    def __init__(self, endpoint: str, timeout: float = 30.0) -> None:
+        self.endpoint = endpoint
+        self.timeout = timeout
+
+    def invoke_function(
+        self, contract_hash: str, method: str, params: list[ContractParam], signers: Optional[list[Signer]] = None
+    ) -> InvokeResult:
+        args: list[Any] = [contract_hash, method, params]
+        if signers:
+            args.append(signers)
+        return self._request("invokefunction", args)
+
+    def traverse_iterator(self, session: str, iterator: str, count: int) -> list[StackItem]:
+        return self._request("traverseiterator", [session, iterator, count])
+
+    def terminate_session(self, session: str) -> bool:
+        return self._request("terminatesession", [session])
+
+    def _request(self, method: str, params: list[Any]) -> Any:
+        data = json.dumps({"jsonrpc": "2.0", "id": 1, "method": method, "params": params}).encode()
+        req = urllib.request.Request(self.endpoint, data=data, headers={"Content-Type": "application/json"})
+        with urllib.request.urlopen(req, timeout=self.timeout) as resp:
+            body = json.load(resp)
+        if "error" in body:
+            raise RuntimeError(f"RPC error {body['error']['code']}: {body['error']['message']}")
+        return body["result"]
+{{range $typ := .NamedTypes}}
+
+@dataclass
+class {{.Name}}:
+    """{{.Name}} is a contract-specific type used by its methods."""
+{{template "STRUCT" $typ}}
+
+    @staticmethod
+    def from_stack_item(item: StackItem) -> Optional[{{.Name}}]:
+        """Converts stack item into {{.Name}}, NULL item is returned as None."""
+        if item["type"] == "Any":
+            return None
+{{- template "FROMLIST" $typ}}
+
+    def to_param(self) -> ContractParam:
+        """Converts {{.Name}} into contract parameter."""
+        return {"type": "Array", "value": [
+{{- range $index, $f := .Fields}}{{if ne $index 0}}, {{end}}{{pyToParam .Type (printf "self.%s" .Name)}}{{end -}}
+        ]}
+{{end}}
+{{- range $e := .Events}}
+
+@dataclass
+class {{.Name}}:
+    """{{.Name}} represents {{quote .ManifestName}} event emitted by the contract."""
+{{template "STRUCT" $e}}
+
+    @staticmethod
+    def from_stack_item(item: StackItem) -> {{.Name}}:
+        """Converts event state into {{.Name}}."""
+{{- template "FROMLIST" $e}}
+
+
+def {{snake .Name}}s_from_application_log(log: ApplicationLog) -> list[{{.Name}}]:
+    """Retrieves all emitted events with {{quote .ManifestName}} name from the provided application log."""
+    res = []
+    for ex in log["executions"]:
+        for e in ex["notifications"]:
+            if e["eventname"] == {{quote .ManifestName}}:
+                res.append({{.Name}}.from_stack_item(e["state"]))
+    return res
+{{end}}
+
+class ContractReader:
+    """ContractReader implements safe contract methods."""
+
+    def __init__(self, invoker: Invoker, contract_hash: str{{if .Hash}} = HASH{{end}}) -> None:
+        self._invoker = invoker
+        self.contract_hash = contract_hash
+{{range $m := .SafeMethods}}
+{{- if isIterator .Return}}
+    def {{.Name}}({{template "ARGS" $m}}, max_items: int = 100) -> list[{{pyIteratorType .Return}}]:
+        """Invokes ` + "`{{.NameABI}}`" + ` method of contract and retrieves up to max_items iterator values."""
+        return [{{pyIteratorItem .Return "e"}} for e in self._iterate({{quote .NameABI}}, {{params $m}}, max_items)]
+{{- else if isVoid .Return}}
+    def {{.Name}}({{template "ARGS" $m}}) -> None:
+        """Invokes ` + "`{{.NameABI}}`" + ` method of contract."""
+        self._call({{quote .NameABI}}, {{params $m}})
+{{- else}}
+    def {{.Name}}({{template "ARGS" $m}}) -> {{pyType .Return}}:
+        """Invokes ` + "`{{.NameABI}}`" + ` method of contract."""
+        return {{pyFromItem .Return (printf "self._call(%s, %s)" (quote .NameABI) (params $m))}}
+{{- end}}
+{{end}}
+    def _call(self, method: str, params: list[ContractParam]) -> StackItem:
+        """Performs test invocation and returns the only resulting stack item."""
+        res = self._invoker.invoke_function(self.contract_hash, method, params)
+        if res["state"] != "HALT":
+            raise RuntimeError(f"invocation failed: {res.get('exception')}")
+        if len(res["stack"]) != 1:
+            raise RuntimeError(f"unexpected stack length: {len(res['stack'])}")
+        return res["stack"][0]
+
+    def _iterate(self, method: str, params: list[ContractParam], max_items: int) -> list[StackItem]:
+        """Performs test invocation and retrieves up to max_items iterator values."""
+        res = self._invoker.invoke_function(self.contract_hash, method, params)
+        if res["state"] != "HALT":
+            raise RuntimeError(f"invocation failed: {res.get('exception')}")
+        if len(res["stack"]) != 1 or res["stack"][0]["type"] != "InteropInterface":
+            raise RuntimeError("iterator expected")
+        it = res["stack"][0]
+        if "value" in it:
+            # Sessions are disabled, iterator is expanded by the node.
+            return it["value"][:max_items]
+        if "session" not in res or "id" not in it:
+            raise RuntimeError("no iterator session")
+        try:
+            return self._invoker.traverse_iterator(res["session"], it["id"], max_items)
+        finally:
+            self._invoker.terminate_session(res["session"])
+{{if .Methods}}
+
+class Contract(ContractReader):
+    """Contract implements all contract methods.
+
+    State-changing methods return Invocation to be passed to wallet, their
+    _test variants perform test invocation with the Contract signers.
+    """
+
+    def __init__(
+        self, invoker: Invoker, contract_hash: str{{if .Hash}} = HASH{{end}}, signers: Optional[list[Signer]] = None
+    ) -> None:
+        super().__init__(invoker, contract_hash)
+        self.signers = signers or []
+{{range $m := .Methods}}
+    def {{.Name}}({{template "ARGS" $m}}) -> Invocation:
+        """Creates an invocation of ` + "`{{.NameABI}}`" + ` method of contract."""
+        return Invocation(self.contract_hash, {{quote .NameABI}}, {{params $m}})
+
+    def {{.Name}}_test({{template "ARGS" $m}}) -> InvokeResult:
+        """Performs test invocation of ` + "`{{.NameABI}}`" + ` method of contract."""
+        return self._invoker.invoke_function(self.contract_hash, {{quote .NameABI}}, {{params $m}}, self.signers)
+{{end -}}
+{{end}}
+
+def _to_base64(b: bytes) -> str:
+    return base64.b64encode(b).decode()
+
+
+def _to_bool(item: StackItem) -> bool:
+    if item["type"] == "Boolean":
+        return bool(item["value"])
+    if item["type"] == "Integer":
+        return int(item["value"]) != 0
+    if item["type"] in ("ByteString", "Buffer"):
+        return any(_to_bytes(item))
+    raise ValueError(f"can't convert {item['type']} to boolean")
+
+
+def _to_int(item: StackItem) -> int:
+    if item["type"] == "Integer":
+        return int(item["value"])
+    if item["type"] == "Boolean":
+        return 1 if item["value"] else 0
+    if item["type"] in ("ByteString", "Buffer"):
+        return int.from_bytes(_to_bytes(item), "little", signed=True)
+    raise ValueError(f"can't convert {item['type']} to integer")
+
+
+def _to_bytes(item: StackItem) -> bytes:
+    if item["type"] not in ("ByteString", "Buffer"):
+        raise ValueError(f"can't convert {item['type']} to bytes")
+    return base64.b64decode(item["value"])
+
+
+def _to_str(item: StackItem) -> str:
+    return _to_bytes(item).decode("utf-8")
+
+
+def _to_fixed_bytes(item: StackItem, n: int) -> bytes:
+    b = _to_bytes(item)
+    if len(b) != n:
+        raise ValueError(f"wrong length: expected {n}, got {len(b)}")
+    return b
+
+
+def _to_hash160(item: StackItem) -> str:
+    return "0x" + _to_fixed_bytes(item, 20)[::-1].hex()
+
+
+def _to_hash256(item: StackItem) -> str:
+    return "0x" + _to_fixed_bytes(item, 32)[::-1].hex()
+
+
+def _to_public_key(item: StackItem) -> str:
+    return _to_fixed_bytes(item, 33).hex()
+
+
+def _to_list(item: StackItem) -> list[StackItem]:
+    if item["type"] not in ("Array", "Struct"):
+        raise ValueError(f"can't convert {item['type']} to list")
+    return item["value"]
+
+
+def _to_map(item: StackItem) -> list[tuple[StackItem, StackItem]]:
+    if item["type"] != "Map":
+        raise ValueError(f"can't convert {item['type']} to map")
+    return [(e["key"], e["value"]) for e in item["value"]]
+
+
+def _to_key(item: StackItem) -> Any:
+    if item["type"] == "Boolean":
+        return _to_bool(item)
+    if item["type"] == "Integer":
+        return _to_int(item)
+    return _to_bytes(item)
+
+
+def _struct_to_param(v: Any) -> ContractParam:
+    if v is None:
+        return {"type": "Any"}
+    return v.to_param()
+
+
+def any_to_param(v: Any) -> ContractParam:
+    """Converts Python value into contract parameter.
+
+    Dictionaries with "type" key are treated as StackItem or ContractParam
+    and converted as is.
+    """
+    if v is None:
+        return {"type": "Any"}
+    if isinstance(v, bool):
+        return {"type": "Boolean", "value": v}
+    if isinstance(v, int):
+        return {"type": "Integer", "value": str(v)}
+    if isinstance(v, str):
+        return {"type": "String", "value": v}
+    if isinstance(v, (bytes, bytearray)):
+        return {"type": "ByteArray", "value": _to_base64(v)}
+    if isinstance(v, (list, tuple)):
+        return {"type": "Array", "value": [any_to_param(e) for e in v]}
+    if isinstance(v, dict):
+        if "type" in v:
+            return _item_to_param(v)
+        return {"type": "Map", "value": [{"key": any_to_param(k), "value": any_to_param(e)} for k, e in v.items()]}
+    if hasattr(v, "to_param"):
+        return v.to_param()
+    raise TypeError(f"can't convert {type(v).__name__} to contract parameter")
+
+
+def _item_to_param(item: StackItem) -> ContractParam:
+    if item["type"] in ("ByteString", "Buffer"):
+        return {"type": "ByteArray", "value": item["value"]}
+    if item["type"] in ("Array", "Struct"):
+        return {"type": "Array", "value": [_item_to_param(e) for e in item["value"]]}
+    if item["type"] == "Map":
+        return {
+            "type": "Map",
+            "value": [{"key": _item_to_param(e["key"]), "value": _item_to_param(e["value"])} for e in item["value"]],
+        }
+    return {"type": item["type"], "value": item.get("value")}
+`
+
+// pyNaming contains Python identifier conventions.
+var pyNaming = foreignNaming{
+	method: toSnakeCase,
+	field:  toSnakeCase,
+	reserved: map[string]bool{
+		"False": true, "None": true, "True": true, "and": true, "as": true,
+		"assert": true, "async": true, "await": true, "break": true, "class": true,
+		"continue": true, "def": true, "del": true, "elif": true, "else": true,
+		"except": true, "finally": true, "for": true, "from": true, "global": true,
+		"if": true, "import": true, "in": true, "is": true, "lambda": true,
+		"nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+		"return": true, "try": true, "while": true, "with": true, "yield": true,
+		// Names used by the binding itself.
+		"self": true, "max_items": true, "signers": true, "contract_hash": true,
+		"from_stack_item": true, "to_param": true,
+	},
+}
+
+// GeneratePython writes Python module containing smartcontract bindings to the
+// `cfg.Output`. The module requires Python 3.9+, has no dependencies and uses
+// JSON-RPC node API via urllib (or any other Invoker implementation).
+// Go-specific type overrides are ignored. It doesn't check manifest from
+// Config for validity, incorrect manifest can lead to unexpected results.
+func GeneratePython(cfg binding.Config) error {
+	ctr, err := foreignTemplate(cfg, pyNaming)
+	if err != nil {
+		return err
+	}
+	var pyType, pyParamType func(et binding.ExtendedType) string
+	pyType = func(et binding.ExtendedType) string {
+		switch et.Base {
+		case smartcontract.BoolType:
+			return "bool"
+		case smartcontract.IntegerType:
+			return "int"
+		case smartcontract.ByteArrayType, smartcontract.SignatureType:
+			return "bytes"
+		case smartcontract.StringType, smartcontract.Hash160Type, smartcontract.Hash256Type, smartcontract.PublicKeyType:
+			return "str"
+		case smartcontract.ArrayType:
+			if len(et.Name) > 0 {
+				return "Optional[" + toTypeName(et.Name) + "]"
+			} else if et.Value != nil {
+				return "list[" + pyType(*et.Value) + "]"
+			}
+			return "list[StackItem]"
+		case smartcontract.MapType:
+			var vt = "StackItem"
+			if et.Value != nil {
+				vt = pyType(*et.Value)
+			}
+			return "dict[" + pyKeyType(et.Key) + ", " + vt + "]"
+		default:
+			return "StackItem"
+		}
+	}
+	pyParamType = func(et binding.ExtendedType) string {
+		switch et.Base {
+		case smartcontract.AnyType, smartcontract.InteropInterfaceType:
+			return "Any"
+		case smartcontract.ArrayType:
+			if len(et.Name) == 0 {
+				if et.Value != nil {
+					return "list[" + pyParamType(*et.Value) + "]"
+				}
+				return "list[Any]"
+			}
+		case smartcontract.MapType:
+			var vt = "Any"
+			if et.Value != nil {
+				vt = pyParamType(*et.Value)
+			}
+			return "dict[" + pyKeyType(et.Key) + ", " + vt + "]"
+		}
+		return pyType(et)
+	}
+	funcs := template.FuncMap{
+		"isIterator": isIterator,
+		"isVoid":     isVoid,
+		"quote":      quote,
+		"snake":      toSnakeCase,
+		"params": func(m foreignMethod) string {
+			var ps = make([]string, 0, len(m.Params))
+			for _, p := range m.Params {
+				ps = append(ps, pyToParam(p.Type, p.Name))
+			}
+			return "[" + strings.Join(ps, ", ") + "]"
+		},
+		"pyType":      pyType,
+		"pyParamType": pyParamType,
+		"pyFromItem":  pyFromItem,
+		"pyToParam":   pyToParam,
+		"pyIteratorType": func(et binding.ExtendedType) string {
+			if et.Value == nil {
+				return "StackItem"
+			}
+			return pyType(*et.Value)
+		},
+		"pyIteratorItem": func(et binding.ExtendedType, v string) string {
+			if et.Value == nil {
+				return v
+			}
+			return pyFromItem(*et.Value, v)
+		},
+	}
+	return executeForeign(template.Must(template.New("generate").Funcs(funcs).Parse(pyTmpl)), cfg, ctr)
+}
+
+func pyKeyType(key smartcontract.ParamType) string {
+	switch key {
+	case smartcontract.BoolType:
+		return "bool"
+	case smartcontract.IntegerType:
+		return "int"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "bytes"
+	case smartcontract.StringType, smartcontract.Hash160Type, smartcontract.Hash256Type, smartcontract.PublicKeyType:
+		return "str"
+	default:
+		return "Any"
+	}
+}
+
+func pyFromItem(et binding.ExtendedType, v string) string {
+	switch et.Base {
+	case smartcontract.BoolType:
+		return "_to_bool(" + v + ")"
+	case smartcontract.IntegerType:
+		return "_to_int(" + v + ")"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "_to_bytes(" + v + ")"
+	case smartcontract.StringType:
+		return "_to_str(" + v + ")"
+	case smartcontract.Hash160Type:
+		return "_to_hash160(" + v + ")"
+	case smartcontract.Hash256Type:
+		return "_to_hash256(" + v + ")"
+	case smartcontract.PublicKeyType:
+		return "_to_public_key(" + v + ")"
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return toTypeName(et.Name) + ".from_stack_item(" + v + ")"
+		} else if et.Value != nil {
+			return "[" + pyFromItem(*et.Value, "e") + " for e in _to_list(" + v + ")]"
+		}
+		return "_to_list(" + v + ")"
+	case smartcontract.MapType:
+		var key = "_to_key(k)"
+		if et.Key != smartcontract.AnyType {
+			key = pyFromItem(binding.ExtendedType{Base: et.Key}, "k")
+		}
+		var val = "e"
+		if et.Value != nil {
+			val = pyFromItem(*et.Value, "e")
+		}
+		return "{" + key + ": " + val + " for k, e in _to_map(" + v + ")}"
+	default:
+		return v
+	}
+}
+
+func pyToParam(et binding.ExtendedType, v string) string {
+	switch et.Base {
+	case smartcontract.BoolType:
+		return `{"type": "Boolean", "value": ` + v + `}`
+	case smartcontract.IntegerType:
+		return `{"type": "Integer", "value": str(` + v + `)}`
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return `{"type": "` + et.Base.String() + `", "value": _to_base64(` + v + `)}`
+	case smartcontract.StringType, smartcontract.Hash160Type, smartcontract.Hash256Type, smartcontract.PublicKeyType:
+		return `{"type": "` + et.Base.String() + `", "value": ` + v + `}`
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return "_struct_to_param(" + v + ")"
+		}
+		var elem = "any_to_param(e)"
+		if et.Value != nil {
+			elem = pyToParam(*et.Value, "e")
+		}
+		return `{"type": "Array", "value": [` + elem + ` for e in ` + v + `]}`
+	case smartcontract.MapType:
+		var key = "any_to_param(k)"
+		if et.Key != smartcontract.AnyType {
+			key = pyToParam(binding.ExtendedType{Base: et.Key}, "k")
+		}
+		var val = "any_to_param(e)"
+		if et.Value != nil {
+			val = pyToParam(*et.Value, "e")
+		}
+		return `{"type": "Map", "value": [{"key": ` + key + `, "value": ` + val + `} for k, e in ` + v + `.items()]}`
+	default:
+		return "any_to_param(" + v + ")"
+	}
+}
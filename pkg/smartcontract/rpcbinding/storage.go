@@ -0,0 +1,208 @@
+package rpcbinding
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+)
+
+// StorageTmpl is a template for typed contract storage item accessors.
+type StorageTmpl struct {
+	binding.StorageItem
+
+	// Name is the item name used in the resulting binding.
+	Name string
+	// Prefix is a hex representation of the item prefix.
+	Prefix      string
+	KeyType     string
+	KeyBytes    string
+	KeyDecode   string
+	ValueType   string
+	ValueDecode string
+	Zero        string
+	// Missing is the value returned for missing storage items, it's the
+	// same as the one returned by the contract-side getter.
+	Missing    string
+	Serialized bool
+}
+
+const storageDefinition = `{{ define "STORAGE" }}
+// StorageGetter is used by StorageReader to get contract storage items.
+type StorageGetter interface {
+	GetStorageByHash(hash util.Uint160, key []byte) ([]byte, error)
+	FindStorageByHash(contractHash util.Uint160, prefix []byte, start *int) (result.FindStorage, error)
+}
+
+// StorageReader implements typed access to the contract storage items
+// declared in the contract storage layout.
+type StorageReader struct {
+	getter StorageGetter
+	hash   util.Uint160
+}
+{{range .Storage}}{{if eq .Kind "map"}}
+// {{.Name}}Entry is a key-value pair of {{.Name}} storage map.
+type {{.Name}}Entry struct {
+	Key   {{.KeyType}}
+	Value {{.ValueType}}
+}
+{{end}}{{end}}
+// NewStorageReader creates an instance of StorageReader using {{if len .Hash -}}Hash{{- else -}}provided contract hash{{- end}} and the given StorageGetter.
+func NewStorageReader(getter StorageGetter{{- if not (len .Hash) -}}, hash util.Uint160{{- end -}}) *StorageReader {
+	{{if len .Hash -}}
+	var hash = Hash
+	{{end -}}
+	return &StorageReader{getter, hash}
+}
+{{range .Storage}}{{if eq .Kind "map"}}
+// {{.Name}} returns {{.Name}} storage map value for the given key, zero value
+// is returned if there is no such key.
+func (s *StorageReader) {{.Name}}(key {{.KeyType}}) ({{.ValueType}}, error) {
+	b, err := s.getter.GetStorageByHash(s.hash, append([]byte{ {{- .Prefix -}} }, {{.KeyBytes}}...))
+	if err != nil {
+		if errors.Is(err, neorpc.ErrUnknownStorageItem) {
+			return {{.Missing}}, nil
+		}
+		return {{.Zero}}, err
+	}
+	return storageTo{{.Name}}(b)
+}
+
+// Find{{.Name}} returns all {{.Name}} storage map items.
+func (s *StorageReader) Find{{.Name}}() ([]{{.Name}}Entry, error) {
+	var (
+		res   []{{.Name}}Entry
+		start int
+	)
+	for {
+		page, err := s.getter.FindStorageByHash(s.hash, []byte{ {{- .Prefix -}} }, &start)
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range page.Results {
+			k, err := {{.KeyDecode}}
+			if err != nil {
+				return nil, fmt.Errorf("key %x: %w", kv.Key, err)
+			}
+			v, err := storageTo{{.Name}}(kv.Value)
+			if err != nil {
+				return nil, fmt.Errorf("value of %x: %w", kv.Key, err)
+			}
+			res = append(res, {{.Name}}Entry{Key: k, Value: v})
+		}
+		if !page.Truncated {
+			return res, nil
+		}
+		start = page.Next
+	}
+}
+{{else}}
+// {{.Name}} returns {{.Name}} storage {{.Kind}} value, zero value is returned
+// if it's not stored.
+func (s *StorageReader) {{.Name}}() ({{.ValueType}}, error) {
+	b, err := s.getter.GetStorageByHash(s.hash, []byte{ {{- .Prefix -}} })
+	if err != nil {
+		if errors.Is(err, neorpc.ErrUnknownStorageItem) {
+			return {{.Missing}}, nil
+		}
+		return {{.Zero}}, err
+	}
+	return storageTo{{.Name}}(b)
+}
+{{end}}
+// storageTo{{.Name}} decodes {{.Name}} storage value.
+func storageTo{{.Name}}(b []byte) ({{.ValueType}}, error) {
+	{{- if .Serialized}}
+	item, err := stackitem.Deserialize(b)
+	if err != nil {
+		return {{.Zero}}, err
+	}
+	{{- end}}
+	return {{addIndent .ValueDecode "\t"}}
+}
+{{end}}{{end}}`
+
+// storageToTmpl converts storage layout to the template data adding imports
+// used by the resulting code.
+func storageToTmpl(items []binding.StorageItem, named map[string]binding.ExtendedType, imports map[string]struct{}) []StorageTmpl {
+	if len(items) == 0 {
+		return nil
+	}
+	imports["errors"] = struct{}{}
+	imports["github.com/nspcc-dev/neo-go/pkg/neorpc"] = struct{}{}
+	imports["github.com/nspcc-dev/neo-go/pkg/neorpc/result"] = struct{}{}
+	imports["github.com/nspcc-dev/neo-go/pkg/vm/stackitem"] = struct{}{}
+
+	var res = make([]StorageTmpl, 0, len(items))
+	for _, it := range items {
+		var (
+			st = StorageTmpl{
+				StorageItem: it,
+				Name:        upperFirst(it.Name),
+				Prefix:      fmt.Sprintf("0x%02x", it.Prefix),
+			}
+			et = binding.ExtendedType{Base: smartcontract.IntegerType}
+		)
+		if it.Value != nil {
+			et = *it.Value
+		}
+		if _, ok := named[et.Name]; !ok {
+			// Unknown structures are decoded as arrays.
+			et.Name = ""
+		}
+		st.Serialized = binding.IsSerializedStorageValue(et)
+		st.ValueType, _ = extendedTypeToGo(et, named)
+		if st.Serialized {
+			st.ValueDecode = etTypeConverter(et, "item")
+		} else {
+			st.ValueDecode = etTypeConverter(et, "stackitem.NewByteArray(b)")
+		}
+		addETImports(et, named, imports)
+		switch et.Base {
+		case smartcontract.BoolType:
+			st.Zero = "false"
+		case smartcontract.StringType:
+			st.Zero = `""`
+		case smartcontract.Hash160Type, smartcontract.Hash256Type:
+			st.Zero = st.ValueType + "{}"
+		default:
+			st.Zero = "nil"
+		}
+		st.Missing = st.Zero
+		if et.Base == smartcontract.IntegerType {
+			st.Missing = "big.NewInt(0)"
+		}
+		if it.Kind == binding.StorageMap {
+			imports["fmt"] = struct{}{}
+			var kt = binding.ExtendedType{Base: it.Key}
+			st.KeyType, _ = extendedTypeToGo(kt, named)
+			const key = "kv.Key[1:]"
+			switch it.Key {
+			case smartcontract.IntegerType:
+				imports["math/big"] = struct{}{}
+				imports["github.com/nspcc-dev/neo-go/pkg/encoding/bigint"] = struct{}{}
+				st.KeyBytes = "bigint.ToBytes(key)"
+				st.KeyDecode = "bigint.FromBytes(" + key + "), error(nil)"
+			case smartcontract.StringType:
+				st.KeyBytes = "[]byte(key)"
+				st.KeyDecode = "string(" + key + "), error(nil)"
+			case smartcontract.Hash160Type:
+				st.KeyBytes = "key.BytesBE()"
+				st.KeyDecode = "util.Uint160DecodeBytesBE(" + key + ")"
+			case smartcontract.Hash256Type:
+				st.KeyBytes = "key.BytesBE()"
+				st.KeyDecode = "util.Uint256DecodeBytesBE(" + key + ")"
+			case smartcontract.PublicKeyType:
+				imports["crypto/elliptic"] = struct{}{}
+				imports["github.com/nspcc-dev/neo-go/pkg/crypto/keys"] = struct{}{}
+				st.KeyBytes = "key.Bytes()"
+				st.KeyDecode = "keys.NewPublicKeyFromBytes(" + key + ", elliptic.P256())"
+			default:
+				st.KeyBytes = "key"
+				st.KeyDecode = key + ", error(nil)"
+			}
+		}
+		res = append(res, st)
+	}
+	return res
+}
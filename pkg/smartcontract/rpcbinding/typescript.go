@@ -0,0 +1,653 @@
+package rpcbinding
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+)
+
+const tsTmpl = `
+{{- define "ARGS" -}}
+{{- range $index, $arg := .Params}}{{if ne $index 0}}, {{end}}{{.Name}}: {{tsParamType .Type}}{{end -}}
+{{- end -}}
+{{- define "STRUCT" -}}
+{{- range .Fields}}
+  {{.Name}}: {{tsType .Type}};
+{{- end}}
+{{- end -}}
+{{- define "FROMARRAY"}}
+  const arr = toArray(item);
+  if (arr.length !== {{len .Fields}}) {
+    throw new Error("wrong number of structure elements");
+  }
+  return {
+{{- range $index, $f := .Fields}}
+    {{.Name}}: {{tsFromItem .Type (printf "arr[%d]" $index)}},
+{{- end}}
+  };
+{{- end -}}
+// Code generated by neo-go contract generate-rpcwrapper --lang ts --manifest <file.json> --out <file.ts> [--hash <hash>] [--config <config>]; DO NOT EDIT.
+
+// This module contains RPC wrappers for {{.ContractName}} contract.
+{{if .Hash}}
+/** Hash contains contract hash. */
+export const Hash = "{{.Hash}}";
+{{end}}
+/** StackItem is a JSON representation of VM stack item returned by RPC. */
+export interface StackItem {
+  type: string;
+  value?: any;
+  interface?: string;
+  id?: string;
+  truncated?: boolean;
+}
+
+/** ContractParam is a JSON representation of contract method parameter. */
+export interface ContractParam {
+  type: string;
+  value?: any;
+}
+
+/** Signer is a JSON representation of transaction signer. */
+export interface Signer {
+  account: string;
+  scopes: string;
+  allowedcontracts?: string[];
+  allowedgroups?: string[];
+}
+
+/** InvokeResult is a result of test invocation returned by RPC. */
+export interface InvokeResult {
+  state: string;
+  gasconsumed: string;
+  script: string;
+  stack: StackItem[];
+  exception?: string | null;
+  session?: string;
+}
+
+/** Notification is a JSON representation of contract event. */
+export interface Notification {
+  contract: string;
+  eventname: string;
+  state: StackItem;
+}
+
+/** ApplicationLog is a result of getapplicationlog RPC call. */
+export interface ApplicationLog {
+  executions: {
+    trigger: string;
+    vmstate: string;
+    notifications: Notification[];
+  }[];
+}
+
+/**
+ * Invocation describes contract method call, it can be passed to wallets
+ * (dAPI invoke) to create, sign and send a transaction.
+ */
+export interface Invocation {
+  scriptHash: string;
+  operation: string;
+  args: ContractParam[];
+}
+
+/** Invoker is used by ContractReader and Contract to perform test invocations. */
+export interface Invoker {
+  invokeFunction(hash: string, method: string, params: ContractParam[], signers?: Signer[]): Promise<InvokeResult>;
+  traverseIterator(session: string, iterator: string, count: number): Promise<StackItem[]>;
+  terminateSession(session: string): Promise<boolean>;
+}
+
+/** RPCInvoker implements Invoker using JSON-RPC node API. */
+export class RPCInvoker implements Invoker {
+  constructor(readonly endpoint: string) {}
+
+  async invokeFunction(hash: string, method: string, params: ContractParam[], signers?: Signer[]): Promise<InvokeResult> {
+    const args: unknown[] = [hash, method, params];
+    if (signers !== undefined && signers.length > 0) {
+      args.push(signers);
+    }
+    return this.request("invokefunction", args);
+  }
+
+  async traverseIterator(session: string, iterator: string, count: number): Promise<StackItem[]> {
+    return this.request("traverseiterator", [session, iterator, count]);
+  }
+
+  async terminateSession(session: string): Promise<boolean> {
+    return this.request("terminatesession", [session]);
+  }
+
+  private async request(method: string, params: unknown[]): Promise<any> {
+    const resp = await fetch(this.endpoint, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ jsonrpc: "2.0", id: 1, method, params }),
+    });
+    const body = await resp.json();
+    if (body.error) {
+      throw new Error(` + "`RPC error ${body.error.code}: ${body.error.message}`" + `);
+    }
+    return body.result;
+  }
+}
+{{range $typ := .NamedTypes}}
+/** {{.Name}} is a contract-specific type used by its methods. */
+export interface {{.Name}} {
+{{- template "STRUCT" $typ}}
+}
+
+/** itemTo{{.Name}} converts stack item into {{.Name}}, NULL item is returned as null. */
+export function itemTo{{.Name}}(item: StackItem): {{.Name}} | null {
+  if (item.type === "Any") {
+    return null;
+  }
+{{- template "FROMARRAY" $typ}}
+}
+
+/** {{lowerFirst .Name}}ToParam converts {{.Name}} into contract parameter. */
+export function {{lowerFirst .Name}}ToParam(v: {{.Name}} | null): ContractParam {
+  if (v === null) {
+    return { type: "Any" };
+  }
+  return { type: "Array", value: [
+{{- range $index, $f := .Fields}}{{if ne $index 0}}, {{end}}{{tsToParam .Type (printf "v.%s" .Name)}}{{end -}}
+  ] };
+}
+{{end -}}
+{{range $e := .Events}}
+/** {{.Name}} represents "{{.ManifestName}}" event emitted by the contract. */
+export interface {{.Name}} {
+{{- template "STRUCT" $e}}
+}
+
+/**
+ * {{lowerFirst .Name}}sFromApplicationLog retrieves a set of all emitted events
+ * with "{{.ManifestName}}" name from the provided application log.
+ */
+export function {{lowerFirst .Name}}sFromApplicationLog(log: ApplicationLog): {{.Name}}[] {
+  const res: {{.Name}}[] = [];
+  for (const ex of log.executions) {
+    for (const e of ex.notifications) {
+      if (e.eventname === {{quote .ManifestName}}) {
+        res.push(itemTo{{.Name}}(e.state));
+      }
+    }
+  }
+  return res;
+}
+
+/** itemTo{{.Name}} converts event state into {{.Name}}. */
+export function itemTo{{.Name}}(item: StackItem): {{.Name}} {
+{{- template "FROMARRAY" $e}}
+}
+{{end}}
+/** ContractReader implements safe contract methods. */
+export class ContractReader {
+  constructor(protected readonly invoker: Invoker, readonly contractHash: string{{if .Hash}} = Hash{{end}}) {}
+{{range $m := .SafeMethods}}
+  /** {{.Name}} invokes ` + "`{{.NameABI}}`" + ` method of contract. */
+{{- if isIterator .Return}}
+  async {{.Name}}({{template "ARGS" $m}}{{if .Params}}, {{end}}maxItems: number = 100): Promise<Array<{{tsIteratorType .Return}}>> {
+    return (await this.iterate({{quote .NameABI}}, {{params $m}}, maxItems)).map((e) => {{tsIteratorItem .Return "e"}});
+  }
+{{- else if isVoid .Return}}
+  async {{.Name}}({{template "ARGS" $m}}): Promise<void> {
+    await this.call({{quote .NameABI}}, {{params $m}});
+  }
+{{- else}}
+  async {{.Name}}({{template "ARGS" $m}}): Promise<{{tsType .Return}}> {
+    return {{tsFromItem .Return (printf "await this.call(%s, %s)" (quote .NameABI) (params $m))}};
+  }
+{{- end}}
+{{end}}
+  /** call performs test invocation and returns the only resulting stack item. */
+  protected async call(method: string, params: ContractParam[], signers?: Signer[]): Promise<StackItem> {
+    const res = await this.invoker.invokeFunction(this.contractHash, method, params, signers);
+    if (res.state !== "HALT") {
+      throw new Error(` + "`invocation failed: ${res.exception}`" + `);
+    }
+    if (res.stack.length !== 1) {
+      throw new Error(` + "`unexpected stack length: ${res.stack.length}`" + `);
+    }
+    return res.stack[0];
+  }
+
+  /** iterate performs test invocation and retrieves up to maxItems iterator values. */
+  protected async iterate(method: string, params: ContractParam[], maxItems: number): Promise<StackItem[]> {
+    const res = await this.invoker.invokeFunction(this.contractHash, method, params);
+    if (res.state !== "HALT") {
+      throw new Error(` + "`invocation failed: ${res.exception}`" + `);
+    }
+    if (res.stack.length !== 1 || res.stack[0].type !== "InteropInterface") {
+      throw new Error("iterator expected");
+    }
+    const iter = res.stack[0];
+    if (iter.value !== undefined) {
+      // Sessions are disabled, iterator is expanded by the node.
+      return (iter.value as StackItem[]).slice(0, maxItems);
+    }
+    if (res.session === undefined || iter.id === undefined) {
+      throw new Error("no iterator session");
+    }
+    try {
+      return await this.invoker.traverseIterator(res.session, iter.id, maxItems);
+    } finally {
+      await this.invoker.terminateSession(res.session);
+    }
+  }
+}
+{{if .Methods}}
+/**
+ * Contract implements all contract methods. State-changing methods return
+ * Invocation to be passed to wallet, their Test variants perform test
+ * invocation with the Contract signers.
+ */
+export class Contract extends ContractReader {
+  constructor(invoker: Invoker, contractHash: string{{if .Hash}} = Hash{{end}}, readonly signers: Signer[] = []) {
+    super(invoker, contractHash);
+  }
+{{range $m := .Methods}}
+  /** {{.Name}} creates an invocation of ` + "`{{.NameABI}}`" + ` method of contract. */
+  {{.Name}}({{template "ARGS" $m}}): Invocation {
+    return { scriptHash: this.contractHash, operation: {{quote .NameABI}}, args: {{params $m}} };
+  }
+
+  /** {{.Name}}Test performs test invocation of ` + "`{{.NameABI}}`" + ` method of contract. */
+  async {{.Name}}Test({{template "ARGS" $m}}): Promise<InvokeResult> {
+    return this.invoker.invokeFunction(this.contractHash, {{quote .NameABI}}, {{params $m}}, this.signers);
+  }
+{{end -}}
+}
+{{end}}
+function fromBase64(s: string): Uint8Array {
+  return Uint8Array.from(atob(s), (c) => c.charCodeAt(0));
+}
+
+function toBase64(b: Uint8Array): string {
+  let s = "";
+  for (const c of b) {
+    s += String.fromCharCode(c);
+  }
+  return btoa(s);
+}
+
+function toHex(b: Uint8Array): string {
+  return Array.from(b, (c) => c.toString(16).padStart(2, "0")).join("");
+}
+
+function toBool(item: StackItem): boolean {
+  switch (item.type) {
+    case "Boolean":
+      return item.value as boolean;
+    case "Integer":
+      return BigInt(item.value) !== 0n;
+    case "ByteString":
+    case "Buffer":
+      return toBytes(item).some((c) => c !== 0);
+    default:
+      throw new Error(` + "`can't convert ${item.type} to boolean`" + `);
+  }
+}
+
+function toBigInt(item: StackItem): bigint {
+  switch (item.type) {
+    case "Integer":
+      return BigInt(item.value);
+    case "Boolean":
+      return item.value ? 1n : 0n;
+    case "ByteString":
+    case "Buffer": {
+      const b = toBytes(item);
+      let res = 0n;
+      for (let i = b.length - 1; i >= 0; i--) {
+        res = (res << 8n) | BigInt(b[i]);
+      }
+      if (b.length > 0 && (b[b.length - 1] & 0x80) !== 0) {
+        res -= 1n << BigInt(b.length * 8);
+      }
+      return res;
+    }
+    default:
+      throw new Error(` + "`can't convert ${item.type} to integer`" + `);
+  }
+}
+
+function toBytes(item: StackItem): Uint8Array {
+  if (item.type !== "ByteString" && item.type !== "Buffer") {
+    throw new Error(` + "`can't convert ${item.type} to bytes`" + `);
+  }
+  return fromBase64(item.value as string);
+}
+
+function toUTF8(item: StackItem): string {
+  return new TextDecoder("utf-8", { fatal: true }).decode(toBytes(item));
+}
+
+function toFixedBytes(item: StackItem, n: number): Uint8Array {
+  const b = toBytes(item);
+  if (b.length !== n) {
+    throw new Error(` + "`wrong length: expected ${n}, got ${b.length}`" + `);
+  }
+  return b;
+}
+
+function toHash160(item: StackItem): string {
+  return "0x" + toHex(toFixedBytes(item, 20).reverse());
+}
+
+function toHash256(item: StackItem): string {
+  return "0x" + toHex(toFixedBytes(item, 32).reverse());
+}
+
+function toPublicKey(item: StackItem): string {
+  return toHex(toFixedBytes(item, 33));
+}
+
+function toArray(item: StackItem): StackItem[] {
+  if (item.type !== "Array" && item.type !== "Struct") {
+    throw new Error(` + "`can't convert ${item.type} to array`" + `);
+  }
+  return item.value as StackItem[];
+}
+
+function toMap<K, V>(item: StackItem, key: (k: StackItem) => K, value: (v: StackItem) => V): Map<K, V> {
+  if (item.type !== "Map") {
+    throw new Error(` + "`can't convert ${item.type} to map`" + `);
+  }
+  return new Map((item.value as { key: StackItem; value: StackItem }[]).map((e) => [key(e.key), value(e.value)] as [K, V]));
+}
+
+function toKey(item: StackItem): unknown {
+  switch (item.type) {
+    case "Boolean":
+      return toBool(item);
+    case "Integer":
+      return toBigInt(item);
+    default:
+      return toBytes(item);
+  }
+}
+
+/**
+ * anyToParam converts JavaScript value into contract parameter. StackItem and
+ * ContractParam values are converted as is.
+ */
+export function anyToParam(v: unknown): ContractParam {
+  if (v === null || v === undefined) {
+    return { type: "Any" };
+  }
+  if (typeof v === "boolean") {
+    return { type: "Boolean", value: v };
+  }
+  if (typeof v === "bigint" || typeof v === "number") {
+    return { type: "Integer", value: v.toString() };
+  }
+  if (typeof v === "string") {
+    return { type: "String", value: v };
+  }
+  if (v instanceof Uint8Array) {
+    return { type: "ByteArray", value: toBase64(v) };
+  }
+  if (Array.isArray(v)) {
+    return { type: "Array", value: v.map((e) => anyToParam(e)) };
+  }
+  if (v instanceof Map) {
+    return { type: "Map", value: Array.from(v, ([k, e]) => ({ key: anyToParam(k), value: anyToParam(e) })) };
+  }
+  if (typeof v === "object" && "type" in v) {
+    return itemToParam(v as StackItem);
+  }
+  throw new Error(` + "`can't convert ${typeof v} to contract parameter`" + `);
+}
+
+function itemToParam(item: StackItem): ContractParam {
+  switch (item.type) {
+    case "ByteString":
+    case "Buffer":
+      return { type: "ByteArray", value: item.value };
+    case "Array":
+    case "Struct":
+      return { type: "Array", value: (item.value as StackItem[]).map((e) => itemToParam(e)) };
+    case "Map":
+      return {
+        type: "Map",
+        value: (item.value as { key: StackItem; value: StackItem }[]).map((e) => ({
+          key: itemToParam(e.key),
+          value: itemToParam(e.value),
+        })),
+      };
+    default:
+      return { type: item.type, value: item.value };
+  }
+}
+`
+
+// tsNaming contains TypeScript identifier conventions.
+var tsNaming = foreignNaming{
+	method: lowerFirst,
+	field:  lowerFirst,
+	reserved: map[string]bool{
+		"arguments": true, "await": true, "break": true, "case": true, "catch": true,
+		"class": true, "const": true, "continue": true, "debugger": true, "default": true,
+		"delete": true, "do": true, "else": true, "enum": true, "eval": true,
+		"export": true, "extends": true, "false": true, "finally": true, "for": true,
+		"function": true, "if": true, "implements": true, "import": true, "in": true,
+		"instanceof": true, "interface": true, "let": true, "new": true, "null": true,
+		"package": true, "private": true, "protected": true, "public": true, "return": true,
+		"static": true, "super": true, "switch": true, "this": true, "throw": true,
+		"true": true, "try": true, "typeof": true, "var": true, "void": true,
+		"while": true, "with": true, "yield": true,
+		// Names used by the binding itself.
+		"call": true, "iterate": true, "constructor": true, "maxItems": true,
+	},
+}
+
+// GenerateTS writes TypeScript module containing smartcontract bindings to the
+// `cfg.Output`. The module has no dependencies and uses JSON-RPC node API via
+// fetch (or any other Invoker implementation). Go-specific type overrides are
+// ignored. It doesn't check manifest from Config for validity, incorrect
+// manifest can lead to unexpected results.
+func GenerateTS(cfg binding.Config) error {
+	ctr, err := foreignTemplate(cfg, tsNaming)
+	if err != nil {
+		return err
+	}
+	named := func(et binding.ExtendedType) string {
+		return toTypeName(et.Name)
+	}
+	var tsType, tsParamType func(et binding.ExtendedType) string
+	tsType = func(et binding.ExtendedType) string {
+		switch et.Base {
+		case smartcontract.BoolType:
+			return "boolean"
+		case smartcontract.IntegerType:
+			return "bigint"
+		case smartcontract.ByteArrayType, smartcontract.SignatureType:
+			return "Uint8Array"
+		case smartcontract.StringType, smartcontract.Hash160Type, smartcontract.Hash256Type, smartcontract.PublicKeyType:
+			return "string"
+		case smartcontract.ArrayType:
+			if len(et.Name) > 0 {
+				return named(et) + " | null"
+			} else if et.Value != nil {
+				return "Array<" + tsType(*et.Value) + ">"
+			}
+			return "StackItem[]"
+		case smartcontract.MapType:
+			var vt = "StackItem"
+			if et.Value != nil {
+				vt = tsType(*et.Value)
+			}
+			return "Map<" + tsKeyType(et.Key) + ", " + vt + ">"
+		default:
+			return "StackItem"
+		}
+	}
+	tsParamType = func(et binding.ExtendedType) string {
+		switch et.Base {
+		case smartcontract.AnyType, smartcontract.InteropInterfaceType:
+			return "unknown"
+		case smartcontract.IntegerType:
+			return "bigint | number"
+		case smartcontract.ArrayType:
+			if len(et.Name) == 0 {
+				if et.Value != nil {
+					return "Array<" + tsParamType(*et.Value) + ">"
+				}
+				return "unknown[]"
+			}
+		case smartcontract.MapType:
+			var vt = "unknown"
+			if et.Value != nil {
+				vt = tsParamType(*et.Value)
+			}
+			return "Map<" + tsKeyType(et.Key) + ", " + vt + ">"
+		}
+		return tsType(et)
+	}
+	funcs := template.FuncMap{
+		"isIterator": isIterator,
+		"isVoid":     isVoid,
+		"lowerFirst": lowerFirst,
+		"quote":      quote,
+		"params": func(m foreignMethod) string {
+			var ps = make([]string, 0, len(m.Params))
+			for _, p := range m.Params {
+				ps = append(ps, tsToParam(p.Type, p.Name, named))
+			}
+			return "[" + strings.Join(ps, ", ") + "]"
+		},
+		"tsType":      tsType,
+		"tsParamType": tsParamType,
+		"tsFromItem": func(et binding.ExtendedType, v string) string {
+			return tsFromItem(et, v, named)
+		},
+		"tsToParam": func(et binding.ExtendedType, v string) string {
+			return tsToParam(et, v, named)
+		},
+		"tsIteratorType": func(et binding.ExtendedType) string {
+			if et.Value == nil {
+				return "StackItem"
+			}
+			return tsType(*et.Value)
+		},
+		"tsIteratorItem": func(et binding.ExtendedType, v string) string {
+			if et.Value == nil {
+				return v
+			}
+			return tsFromItem(*et.Value, v, named)
+		},
+	}
+	return executeForeign(template.Must(template.New("generate").Funcs(funcs).Parse(tsTmpl)), cfg, ctr)
+}
+
+func tsKeyType(key smartcontract.ParamType) string {
+	switch key {
+	case smartcontract.BoolType:
+		return "boolean"
+	case smartcontract.IntegerType:
+		return "bigint"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "Uint8Array"
+	case smartcontract.StringType, smartcontract.Hash160Type, smartcontract.Hash256Type, smartcontract.PublicKeyType:
+		return "string"
+	default:
+		return "unknown"
+	}
+}
+
+func tsFromItem(et binding.ExtendedType, v string, named func(binding.ExtendedType) string) string {
+	switch et.Base {
+	case smartcontract.BoolType:
+		return "toBool(" + v + ")"
+	case smartcontract.IntegerType:
+		return "toBigInt(" + v + ")"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "toBytes(" + v + ")"
+	case smartcontract.StringType:
+		return "toUTF8(" + v + ")"
+	case smartcontract.Hash160Type:
+		return "toHash160(" + v + ")"
+	case smartcontract.Hash256Type:
+		return "toHash256(" + v + ")"
+	case smartcontract.PublicKeyType:
+		return "toPublicKey(" + v + ")"
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return "itemTo" + named(et) + "(" + v + ")"
+		} else if et.Value != nil {
+			return "toArray(" + v + ").map((e) => " + tsFromItem(*et.Value, "e", named) + ")"
+		}
+		return "toArray(" + v + ")"
+	case smartcontract.MapType:
+		var key = "toKey(k)"
+		if et.Key != smartcontract.AnyType {
+			key = tsFromItem(binding.ExtendedType{Base: et.Key}, "k", named)
+		}
+		var val = "e"
+		if et.Value != nil {
+			val = tsFromItem(*et.Value, "e", named)
+		}
+		return "toMap(" + v + ", (k) => " + key + ", (e) => " + val + ")"
+	default:
+		return v
+	}
+}
+
+func tsToParam(et binding.ExtendedType, v string, named func(binding.ExtendedType) string) string {
+	switch et.Base {
+	case smartcontract.BoolType:
+		return `{ type: "Boolean", value: ` + v + ` }`
+	case smartcontract.IntegerType:
+		return `{ type: "Integer", value: ` + v + `.toString() }`
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return fmt.Sprintf(`{ type: %q, value: toBase64(%s) }`, et.Base, v)
+	case smartcontract.StringType, smartcontract.Hash160Type, smartcontract.Hash256Type, smartcontract.PublicKeyType:
+		return fmt.Sprintf(`{ type: %q, value: %s }`, et.Base, v)
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return lowerFirst(named(et)) + "ToParam(" + v + ")"
+		}
+		var elem = "anyToParam(e)"
+		if et.Value != nil {
+			elem = tsToParam(*et.Value, "e", named)
+			if strings.HasPrefix(elem, "{") {
+				elem = "(" + elem + ")" // Object literal has to be wrapped.
+			}
+		}
+		return `{ type: "Array", value: ` + v + `.map((e) => ` + elem + `) }`
+	case smartcontract.MapType:
+		var key = "anyToParam(k)"
+		if et.Key != smartcontract.AnyType {
+			key = tsToParam(binding.ExtendedType{Base: et.Key}, "k", named)
+		}
+		var val = "anyToParam(e)"
+		if et.Value != nil {
+			val = tsToParam(*et.Value, "e", named)
+		}
+		return `{ type: "Map", value: Array.from(` + v + `, ([k, e]) => ({ key: ` + key + `, value: ` + val + ` })) }`
+	default:
+		return "anyToParam(" + v + ")"
+	}
+}
+
+// executeForeign executes template for non-Go binding and writes the result
+// to cfg.Output.
+func executeForeign(tmplt *template.Template, cfg binding.Config, ctr foreignTmpl) error {
+	var buf bytes.Buffer
+	err := tmplt.Execute(&buf, ctr)
+	if err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	_, err = cfg.Output.Write(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to write the resulting binding: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// encryptedWalletVersion is the version of whole-file encrypted wallet
+	// format.
+	encryptedWalletVersion = "neo-go-encrypted-1.0"
+	// encryptedWalletKDF is the only supported key derivation function.
+	encryptedWalletKDF = "argon2id"
+	// encryptedWalletCipher is the only supported AEAD.
+	encryptedWalletCipher = "xchacha20-poly1305"
+	// encryptedWalletSaltLen is the length of random KDF salt.
+	encryptedWalletSaltLen = 32
+
+	// maxArgon2Time is the maximum allowed number of Argon2 passes.
+	maxArgon2Time = 16
+	// maxArgon2Memory is the maximum allowed amount of Argon2 memory in KiB
+	// (4 GiB).
+	maxArgon2Memory = 4 * 1024 * 1024
+)
+
+var (
+	// ErrWalletEncrypted is returned from [NewWalletFromFile] and
+	// [NewWalletFromBytes] when the data given is a whole-file encrypted wallet,
+	// use [NewWalletFromFileWithPassword] to open it.
+	ErrWalletEncrypted = errors.New("wallet file is encrypted")
+	// ErrInvalidWalletPassword is returned when the wallet file can't be
+	// decrypted with the password given.
+	ErrInvalidWalletPassword = errors.New("invalid wallet password")
+)
+
+// Argon2Params are Argon2id key derivation parameters used for whole-file
+// wallet encryption.
+type Argon2Params struct {
+	// Time is the number of passes over the memory.
+	Time uint32 `json:"time"`
+	// Memory is the amount of memory used in KiB.
+	Memory uint32 `json:"memory"`
+	// Threads is the number of threads used.
+	Threads uint8 `json:"threads"`
+}
+
+// encryptedWallet is a JSON container for the encrypted NEP-6 wallet data.
+type encryptedWallet struct {
+	Version string        `json:"version"`
+	KDF     *encryptedKDF `json:"kdf"`
+	Cipher  string        `json:"cipher"`
+	Nonce   []byte        `json:"nonce"`
+	Data    []byte        `json:"data"`
+}
+
+// encryptedKDF describes the key derivation function used for encrypted wallet.
+type encryptedKDF struct {
+	Name string `json:"name"`
+	Salt []byte `json:"salt"`
+	Argon2Params
+}
+
+// fileEncryption is a state of whole-file wallet encryption.
+type fileEncryption struct {
+	kdf encryptedKDF
+	key []byte
+}
+
+// DefaultArgon2Params returns the default Argon2id parameters for whole-file
+// wallet encryption (as recommended by RFC 9106 for memory-constrained
+// environments).
+func DefaultArgon2Params() Argon2Params {
+	return Argon2Params{
+		Time:    3,
+		Memory:  64 * 1024,
+		Threads: 4,
+	}
+}
+
+// NewWalletFromFileWithPassword creates a Wallet from the given wallet file
+// path decrypting it with the given password if it's a whole-file encrypted
+// wallet (see [Wallet.EncryptFile]). Encryption settings are retained, so
+// subsequent [Wallet.Save] calls will keep the file encrypted. Regular NEP-6
+// wallets are opened the same way [NewWalletFromFile] does.
+func NewWalletFromFileWithPassword(path string, password string) (*Wallet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("open wallet: %w", err)
+	}
+	wall, err := newWalletFromEncryptedBytes(data, password)
+	if err != nil {
+		return nil, err
+	}
+	wall.path = path
+	return wall, nil
+}
+
+func newWalletFromEncryptedBytes(data []byte, password string) (*Wallet, error) {
+	ew, err := decodeEncryptedWallet(data)
+	if err != nil {
+		return nil, err
+	}
+	if ew == nil {
+		return NewWalletFromBytes(data)
+	}
+	if ew.Version != encryptedWalletVersion || ew.KDF.Name != encryptedWalletKDF || ew.Cipher != encryptedWalletCipher {
+		return nil, fmt.Errorf("unsupported encrypted wallet format: %s, %s, %s", ew.Version, ew.KDF.Name, ew.Cipher)
+	}
+	if err := ew.KDF.validate(); err != nil {
+		return nil, err
+	}
+	enc := &fileEncryption{kdf: *ew.KDF}
+	enc.deriveKey(password)
+	aead, err := chacha20poly1305.NewX(enc.key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ew.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length %d", len(ew.Nonce))
+	}
+	plain, err := aead.Open(nil, ew.Nonce, ew.Data, []byte(ew.Version))
+	if err != nil {
+		return nil, ErrInvalidWalletPassword
+	}
+	wall, err := NewWalletFromBytes(plain)
+	if err != nil {
+		return nil, err
+	}
+	wall.fileEnc = enc
+	return wall, nil
+}
+
+// decodeEncryptedWallet returns encrypted wallet container if the data given
+// is an encrypted wallet and nil if it's not.
+func decodeEncryptedWallet(data []byte) (*encryptedWallet, error) {
+	ew := new(encryptedWallet)
+	if err := json.Unmarshal(data, ew); err != nil {
+		return nil, fmt.Errorf("unmarshal wallet: %w", err)
+	}
+	if ew.KDF == nil {
+		return nil, nil
+	}
+	return ew, nil
+}
+
+// EncryptFile makes subsequent [Wallet.Save] and [Wallet.SavePretty] calls
+// encrypt the whole wallet file (including labels, contracts and tokens, not
+// just private keys) with a key derived from the given password via Argon2id.
+// XChaCha20-Poly1305 is used for encryption. Account keys are still NEP-2
+// encrypted inside. Such wallets can only be opened with
+// [NewWalletFromFileWithPassword].
+func (w *Wallet) EncryptFile(password string, params Argon2Params) error {
+	if err := params.validate(); err != nil {
+		return err
+	}
+	salt := make([]byte, encryptedWalletSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	enc := &fileEncryption{
+		kdf: encryptedKDF{
+			Name:         encryptedWalletKDF,
+			Salt:         salt,
+			Argon2Params: params,
+		},
+	}
+	enc.deriveKey(password)
+	w.fileEnc = enc
+	return nil
+}
+
+// DecryptFile makes subsequent [Wallet.Save] and [Wallet.SavePretty] calls
+// store the wallet as a regular NEP-6 file.
+func (w *Wallet) DecryptFile() {
+	w.fileEnc = nil
+}
+
+// IsFileEncrypted returns true if the wallet is stored as a whole-file
+// encrypted one.
+func (w *Wallet) IsFileEncrypted() bool {
+	return w.fileEnc != nil
+}
+
+// validate checks Argon2 parameters, they can come from untrusted wallet file,
+// so excessive values are rejected as well.
+func (p Argon2Params) validate() error {
+	if p.Time == 0 || p.Time > maxArgon2Time || p.Threads == 0 ||
+		p.Memory < 8*uint32(p.Threads) || p.Memory > maxArgon2Memory {
+		return fmt.Errorf("invalid Argon2 parameters: time %d, memory %d, threads %d", p.Time, p.Memory, p.Threads)
+	}
+	return nil
+}
+
+func (e *fileEncryption) deriveKey(password string) {
+	e.key = argon2.IDKey([]byte(password), e.kdf.Salt, e.kdf.Time, e.kdf.Memory, e.kdf.Threads, chacha20poly1305.KeySize)
+}
+
+// encrypt returns encrypted wallet container for the given NEP-6 data.
+func (e *fileEncryption) encrypt(data []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(e.key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	kdf := e.kdf
+	return json.Marshal(encryptedWallet{
+		Version: encryptedWalletVersion,
+		KDF:     &kdf,
+		Cipher:  encryptedWalletCipher,
+		Nonce:   nonce,
+		Data:    aead.Seal(nil, nonce, data, []byte(encryptedWalletVersion)),
+	})
+}
@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+)
+
+var testArgon2Params = Argon2Params{Time: 1, Memory: 64, Threads: 1}
+
+func TestEncryptedWallet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallet.json")
+	w, err := NewWallet(path)
+	require.NoError(t, err)
+	w.Scrypt = keys.ScryptParams{N: 2, R: 1, P: 1}
+	require.NoError(t, w.CreateAccount("secret label", "pass"))
+	addr := w.Accounts[0].Address
+
+	require.NoError(t, w.EncryptFile("filepass", testArgon2Params))
+	require.True(t, w.IsFileEncrypted())
+	require.NoError(t, w.Save())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.False(t, strings.Contains(string(raw), "secret label"))
+	require.False(t, strings.Contains(string(raw), addr))
+
+	_, err = NewWalletFromFile(path)
+	require.ErrorIs(t, err, ErrWalletEncrypted)
+
+	_, err = NewWalletFromFileWithPassword(path, "wrong")
+	require.ErrorIs(t, err, ErrInvalidWalletPassword)
+
+	w2, err := NewWalletFromFileWithPassword(path, "filepass")
+	require.NoError(t, err)
+	require.True(t, w2.IsFileEncrypted())
+	require.Equal(t, path, w2.Path())
+	require.Equal(t, 1, len(w2.Accounts))
+	require.Equal(t, "secret label", w2.Accounts[0].Label)
+	require.NoError(t, w2.Accounts[0].Decrypt("pass", w2.Scrypt))
+
+	// Encryption is retained on save.
+	w2.Accounts[0].Label = "new label"
+	require.NoError(t, w2.Save())
+	w3, err := NewWalletFromFileWithPassword(path, "filepass")
+	require.NoError(t, err)
+	require.Equal(t, "new label", w3.Accounts[0].Label)
+
+	// And can be disabled.
+	w3.DecryptFile()
+	require.False(t, w3.IsFileEncrypted())
+	require.NoError(t, w3.Save())
+	w4, err := NewWalletFromFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "new label", w4.Accounts[0].Label)
+
+	// Plain wallets can be opened with password too.
+	w5, err := NewWalletFromFileWithPassword(path, "any")
+	require.NoError(t, err)
+	require.False(t, w5.IsFileEncrypted())
+}
+
+func TestEncryptFileInvalidParams(t *testing.T) {
+	w := NewInMemoryWallet()
+	require.Error(t, w.EncryptFile("pass", Argon2Params{}))
+	require.Error(t, w.EncryptFile("pass", Argon2Params{Time: 1, Memory: 1, Threads: 1}))
+	require.Error(t, w.EncryptFile("pass", Argon2Params{Time: maxArgon2Time + 1, Memory: 64, Threads: 1}))
+	require.Error(t, w.EncryptFile("pass", Argon2Params{Time: 1, Memory: maxArgon2Memory + 1, Threads: 1}))
+	require.False(t, w.IsFileEncrypted())
+}
+
+func TestEncryptedWalletExcessiveParams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallet.json")
+	w, err := NewWallet(path)
+	require.NoError(t, err)
+	require.NoError(t, w.EncryptFile("pass", testArgon2Params))
+	require.NoError(t, w.Save())
+
+	for _, p := range []Argon2Params{
+		{Time: 1, Memory: 0xffffffff, Threads: 1},
+		{Time: 0xffffffff, Memory: 64, Threads: 1},
+	} {
+		// Parameters must be rejected before key derivation.
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var ew encryptedWallet
+		require.NoError(t, json.Unmarshal(raw, &ew))
+		ew.KDF.Argon2Params = p
+		raw, err = json.Marshal(ew)
+		require.NoError(t, err)
+		bad := filepath.Join(t.TempDir(), "bad.json")
+		require.NoError(t, os.WriteFile(bad, raw, 0644))
+
+		_, err = NewWalletFromFileWithPassword(bad, "pass")
+		require.ErrorContains(t, err, "invalid Argon2 parameters")
+	}
+}
@@ -43,6 +43,9 @@ type Wallet struct {
 
 	// Path where the wallet file is located..
 	path string
+
+	// fileEnc is set for whole-file encrypted wallets.
+	fileEnc *fileEncryption
 }
 
 // Extra stores imported token contracts.
@@ -67,20 +70,19 @@ func NewInMemoryWallet() *Wallet {
 	return newWallet(nil)
 }
 
-// NewWalletFromFile creates a Wallet from the given wallet file path.
+// NewWalletFromFile creates a Wallet from the given wallet file path. It
+// returns [ErrWalletEncrypted] for whole-file encrypted wallets, use
+// [NewWalletFromFileWithPassword] to open them.
 func NewWalletFromFile(path string) (*Wallet, error) {
-	file, err := os.Open(path)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("open wallet: %w", err)
 	}
-	defer file.Close()
-
-	wall := &Wallet{
-		path: file.Name(),
-	}
-	if err := json.NewDecoder(file).Decode(wall); err != nil {
-		return nil, fmt.Errorf("unmarshal wallet: %w", err)
+	wall, err := NewWalletFromBytes(data)
+	if err != nil {
+		return nil, err
 	}
+	wall.path = path
 	return wall, nil
 }
 
@@ -90,6 +92,11 @@ func NewWalletFromFile(path string) (*Wallet, error) {
 // NewWalletFromBytes constructor doesn't set wallet's path. If you want to save the wallet to file system,
 // use [Wallet.SetPath].
 func NewWalletFromBytes(wallet []byte) (*Wallet, error) {
+	if ew, err := decodeEncryptedWallet(wallet); err != nil {
+		return nil, err
+	} else if ew != nil {
+		return nil, ErrWalletEncrypted
+	}
 	wall := &Wallet{}
 	if err := json.NewDecoder(bytes.NewReader(wallet)).Decode(wall); err != nil {
 		return nil, fmt.Errorf("unmarshal wallet: %w", err)
@@ -200,6 +207,12 @@ func (w *Wallet) writeRaw(data []byte) error {
 	if w.path == "" {
 		return ErrPathIsEmpty
 	}
+	if w.fileEnc != nil {
+		var err error
+		if data, err = w.fileEnc.encrypt(data); err != nil {
+			return fmt.Errorf("failed to encrypt wallet: %w", err)
+		}
+	}
 
 	return os.WriteFile(w.path, data, 0644)
 }